@@ -12,14 +12,19 @@
 package client
 
 import (
-	"crypto/tls"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
-const defaultTimeout = 10
+const (
+	//DefaultTimeout is connection timeout in seconds used unless profile sets timeout
+	DefaultTimeout = 10
+	//DefaultMaxRetry is maximum number of retries used unless profile sets max retry
+	DefaultMaxRetry = 4
+)
 
 //Client is an Abstraction for actual client
 type Client struct {
@@ -31,19 +36,29 @@ func NewDefaultClient(tripper http.RoundTripper) (*Client, error) {
 
 	client := retryablehttp.NewClient()
 	client.HTTPClient.Transport = tripper
-	client.HTTPClient.Timeout = defaultTimeout * time.Second
+	client.HTTPClient.Timeout = DefaultTimeout * time.Second
+	client.RetryMax = DefaultMaxRetry
+	client.ErrorHandler = returnLastResponse
 	client.Logger = nil
 	return &Client{
 		HTTPClient: client,
 	}, nil
 }
 
-//New takes transport and uses accordingly
+//returnLastResponse returns last response once retries are exhausted, so that its status code is reported to
+//caller instead of being discarded
+func returnLastResponse(resp *http.Response, err error, attempts int) (*http.Response, error) {
+	if resp != nil {
+		return resp, nil
+	}
+	return nil, fmt.Errorf("giving up after %d attempt(s): %w", attempts, err)
+}
+
+//New takes transport and uses accordingly, if transport is nil, default transport which uses proxy from environment
+//and verifies certificates is used. Gateway configures TLS of transport for profile
 func New(tripper http.RoundTripper) (*Client, error) {
 	if tripper == nil {
-		tripper = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
+		tripper = http.DefaultTransport.(*http.Transport).Clone()
 	}
 	return NewDefaultClient(tripper)
 }
@@ -12,6 +12,7 @@
 package mocks
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"opensearch-cli/client"
@@ -26,6 +27,11 @@ func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return f(req), nil
 }
 
+// WithTLSConfig returns same function since requests are not sent over network
+func (f RoundTripFunc) WithTLSConfig(*tls.Config) http.RoundTripper {
+	return f
+}
+
 //NewTestClient returns *http.Client with Transport replaced to avoid making real calls
 func NewTestClient(fn RoundTripFunc) *client.Client {
 	c, err := client.New(fn)
@@ -15,6 +15,7 @@ import (
 	"opensearch-cli/client"
 	adctrl "opensearch-cli/controller/ad"
 	ctrl "opensearch-cli/controller/platform"
+	"opensearch-cli/entity"
 	adgateway "opensearch-cli/gateway/ad"
 	gateway "opensearch-cli/gateway/platform"
 	handler "opensearch-cli/handler/ad"
@@ -47,11 +48,16 @@ func GetADCommand() *cobra.Command {
 
 //GetADHandler returns handler by wiring the dependency manually
 func GetADHandler() (*handler.Handler, error) {
-	c, err := client.New(nil)
+	profile, err := GetProfile()
 	if err != nil {
 		return nil, err
 	}
-	profile, err := GetProfile()
+	return getADHandlerForProfile(profile)
+}
+
+//getADHandlerForProfile returns handler whose requests are sent to cluster of profile
+func getADHandlerForProfile(profile *entity.Profile) (*handler.Handler, error) {
+	c, err := client.New(nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,126 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	entity "opensearch-cli/entity/ad"
+	handler "opensearch-cli/handler/ad"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	applyCommandName          = "apply"
+	applyFileFlagName         = "filename"
+	applyPruneFlagName        = "prune"
+	applyPrunePatternFlagName = "prune-pattern"
+	applyDryRunFlagName       = "dry-run"
+	applyYesFlagName          = "yes"
+)
+
+//planSymbols are prefix of every action displayed in plan
+var planSymbols = map[string]string{
+	entity.PlanActionCreate:    "+",
+	entity.PlanActionUpdate:    "~",
+	entity.PlanActionDelete:    "-",
+	entity.PlanActionUnchanged: "=",
+}
+
+//applyCmd reconciles live detectors with detectors configuration from directory
+var applyCmd = &cobra.Command{
+	Use:   applyCommandName + " -f directory-path" + " [flags] ",
+	Short: "Create, update or delete detectors to match JSON files from a directory",
+	Long: "Compare every detector JSON file from a directory, in the format accepted by `opensearch-cli ad create`, with live detector of the same name, " +
+		"then create missing detectors and update changed detectors. Running detectors are stopped before update and started afterwards.\n" +
+		"Use the `--prune` flag to delete live detectors matched by `--prune-pattern` which don't have a file.\n" +
+		"Use the `--dry-run` flag to display the field level difference without applying it.\n" +
+		"The command asks for confirmation before applying changes, use the `--yes` flag to apply them without asking, e.g. in CI pipelines.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := applyDetectors(cmd)
+		if err == nil {
+			return
+		}
+		DisplayError(err, applyCommandName)
+		os.Exit(1)
+	},
+}
+
+func init() {
+	GetADCommand().AddCommand(applyCmd)
+	applyCmd.Flags().StringP(applyFileFlagName, "f", "", "Directory of detector JSON files, or a single detector JSON file")
+	applyCmd.Flags().BoolP(applyPruneFlagName, "", false, "Delete live detectors matched by --prune-pattern which don't have a file")
+	applyCmd.Flags().StringP(applyPrunePatternFlagName, "", "", "Name or name regex pattern of detectors managed by the directory, required by --prune")
+	applyCmd.Flags().BoolP(applyDryRunFlagName, "", false, "Display changes without applying them")
+	applyCmd.Flags().BoolP(applyYesFlagName, "y", false, "Apply changes without asking for confirmation")
+	applyCmd.Flags().BoolP("help", "h", false, "Help for "+applyCommandName)
+	_ = applyCmd.MarkFlagRequired(applyFileFlagName)
+}
+
+//applyDetectors displays plan to reconcile detectors and applies it unless --dry-run is passed
+func applyDetectors(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString(applyFileFlagName)
+	prune, _ := cmd.Flags().GetBool(applyPruneFlagName)
+	prunePattern, _ := cmd.Flags().GetString(applyPrunePatternFlagName)
+	dryRun, _ := cmd.Flags().GetBool(applyDryRunFlagName)
+	yes, _ := cmd.Flags().GetBool(applyYesFlagName)
+	if prune && len(prunePattern) < 1 {
+		return fmt.Errorf("--%s requires --%s to select detectors managed by %s", applyPruneFlagName, applyPrunePatternFlagName, path)
+	}
+	if !prune {
+		prunePattern = ""
+	}
+	commandHandler, err := GetADHandler()
+	if err != nil {
+		return err
+	}
+	plans, err := handler.PlanAnomalyDetectors(commandHandler, path, prunePattern)
+	if err != nil {
+		return err
+	}
+	if plans == nil {
+		plans = []*entity.DetectorPlan{}
+	}
+	err = printOutput(plans, func() error {
+		return displayDetectorPlans(os.Stdout, plans)
+	})
+	if err != nil || dryRun {
+		return err
+	}
+	return handler.ApplyAnomalyDetectors(commandHandler, plans, !yes)
+}
+
+//displayDetectorPlans prints every planned action with field level difference and summary
+func displayDetectorPlans(w io.Writer, plans []*entity.DetectorPlan) error {
+	count := map[string]int{}
+	for _, plan := range plans {
+		count[plan.Action]++
+		target := plan.File
+		if len(plan.ID) > 0 {
+			target = plan.ID
+		}
+		if _, err := fmt.Fprintf(w, "%s %s %s (%s)\n", planSymbols[plan.Action], plan.Action, plan.Name, target); err != nil {
+			return err
+		}
+		for _, change := range plan.Changes {
+			if _, err := fmt.Fprintf(w, "    %s: %q => %q\n", change.Field, change.Live, change.Desired); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to delete, %d unchanged\n",
+		count[entity.PlanActionCreate], count[entity.PlanActionUpdate], count[entity.PlanActionDelete], count[entity.PlanActionUnchanged])
+	return err
+}
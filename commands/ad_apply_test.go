@@ -0,0 +1,37 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"bytes"
+	entity "opensearch-cli/entity/ad"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayDetectorPlans(t *testing.T) {
+	var out bytes.Buffer
+	err := displayDetectorPlans(&out, []*entity.DetectorPlan{
+		{Action: entity.PlanActionCreate, Name: "new-detector", File: "detectors/new.json"},
+		{Action: entity.PlanActionUpdate, Name: "detector", ID: "detectorID", File: "detectors/detector.json", Changes: []entity.FieldDiff{
+			{Field: "detection_interval", Live: "5m", Desired: "10m"},
+		}},
+		{Action: entity.PlanActionDelete, Name: "old-detector", ID: "oldID"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "+ create new-detector (detectors/new.json)\n"+
+		"~ update detector (detectorID)\n"+
+		"    detection_interval: \"5m\" => \"10m\"\n"+
+		"- delete old-detector (oldID)\n"+
+		"Plan: 1 to create, 1 to update, 1 to delete, 0 unchanged\n", out.String())
+}
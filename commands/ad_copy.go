@@ -0,0 +1,144 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"opensearch-cli/entity"
+	adentity "opensearch-cli/entity/ad"
+	"opensearch-cli/environment"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/ad"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	copyCommandName          = "copy"
+	copyFromProfileFlagName  = "from-profile"
+	copyToProfileFlagName    = "to-profile"
+	copyRewriteIndexFlagName = "rewrite-index"
+)
+
+//copyCmd creates detectors matched by name or name regex pattern from one profile on another profile
+var copyCmd = &cobra.Command{
+	Use:   copyCommandName + " detector_name" + " [flags] ",
+	Short: "Copy detectors from one profile to another based on name or name regex pattern",
+	Long: "Copy detectors matched by name or name regex pattern from cluster of `--from-profile` to cluster of `--to-profile`.\n" +
+		"Use `--rewrite-index old=new` to create copies on index with different name, the flag can be repeated.\n" +
+		"Detectors whose name already exists on target are not modified and are reported as conflict.\n" +
+		"Copy is refused if both profiles resolve to same endpoint, for example when `OPENSEARCH_ENDPOINT` overrides endpoint of both.\n" +
+		"Wrap regex pattern in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
+		"Only features with average, count, max, min or sum aggregation on single field can be copied.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := copyDetectors(cmd, args[0])
+		DisplayError(err, copyCommandName)
+	},
+}
+
+func init() {
+	GetADCommand().AddCommand(copyCmd)
+	copyCmd.Flags().StringP(copyFromProfileFlagName, "", "", "Profile of cluster to copy detectors from")
+	_ = copyCmd.MarkFlagRequired(copyFromProfileFlagName)
+	copyCmd.Flags().StringP(copyToProfileFlagName, "", "", "Profile of cluster to copy detectors to")
+	_ = copyCmd.MarkFlagRequired(copyToProfileFlagName)
+	copyCmd.Flags().StringToStringP(copyRewriteIndexFlagName, "", nil, "Replace index name of copied detectors, in the format old=new")
+	copyCmd.Flags().BoolP("help", "h", false, "Help for "+copyCommandName)
+}
+
+//getProfileByName returns profile with given name for execution
+func getProfileByName(name string) (*entity.Profile, error) {
+	p, err := GetProfileController()
+	if err != nil {
+		return nil, err
+	}
+	profile, _, err := p.GetProfileForExecution(name)
+	if err != nil {
+		return nil, err
+	}
+	if err = applyInsecureFlag(&profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+//checkDifferentEndpoints fails if source and target resolve to same cluster, which happens
+//when environment variables override endpoint of both profiles
+func checkDifferentEndpoints(source *entity.Profile, target *entity.Profile) error {
+	normalize := func(endpoint string) string {
+		return strings.TrimRight(strings.ToLower(endpoint), "/")
+	}
+	if normalize(source.Endpoint) != normalize(target.Endpoint) {
+		return nil
+	}
+	return fmt.Errorf("profiles %s and %s resolve to same endpoint %s, check whether %s is set",
+		source.Name, target.Name, source.Endpoint, environment.OPENSEARCH_ENDPOINT)
+}
+
+//copyDetectors copies detectors matched by pattern and prints outcome of every detector
+func copyDetectors(cmd *cobra.Command, pattern string) error {
+	from, _ := cmd.Flags().GetString(copyFromProfileFlagName)
+	to, _ := cmd.Flags().GetString(copyToProfileFlagName)
+	indexRewrites, _ := cmd.Flags().GetStringToString(copyRewriteIndexFlagName)
+	if from == to {
+		return fmt.Errorf("--%s and --%s must be different", copyFromProfileFlagName, copyToProfileFlagName)
+	}
+	sourceProfile, err := getProfileByName(from)
+	if err != nil {
+		return err
+	}
+	targetProfile, err := getProfileByName(to)
+	if err != nil {
+		return err
+	}
+	if err = checkDifferentEndpoints(sourceProfile, targetProfile); err != nil {
+		return err
+	}
+	source, err := getADHandlerForProfile(sourceProfile)
+	if err != nil {
+		return err
+	}
+	target, err := getADHandlerForProfile(targetProfile)
+	if err != nil {
+		return err
+	}
+	results, copyErr := handler.CopyAnomalyDetectors(source, target, pattern, indexRewrites)
+	if results == nil && copyErr != nil {
+		return copyErr
+	}
+	if results == nil {
+		results = []*adentity.CopyResult{}
+	}
+	if err = printOutput(results, func() error {
+		return displayCopyResults(os.Stdout, results)
+	}); err != nil {
+		return err
+	}
+	return copyErr
+}
+
+//displayCopyResults prints outcome of every copied detector as table
+func displayCopyResults(w io.Writer, results []*adentity.CopyResult) error {
+	if len(results) < 1 {
+		_, err := fmt.Fprintln(w, "no detectors copied")
+		return err
+	}
+	f, err := formatter.New(formatter.Table)
+	if err != nil {
+		return err
+	}
+	return f.Format(w, results)
+}
@@ -0,0 +1,33 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"opensearch-cli/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDifferentEndpoints(t *testing.T) {
+	t.Run("different endpoints", func(t *testing.T) {
+		source := &entity.Profile{Name: "dev", Endpoint: "https://dev:9200"}
+		target := &entity.Profile{Name: "prod", Endpoint: "https://prod:9200"}
+		assert.NoError(t, checkDifferentEndpoints(source, target))
+	})
+	t.Run("same endpoint", func(t *testing.T) {
+		source := &entity.Profile{Name: "dev", Endpoint: "https://localhost:9200"}
+		target := &entity.Profile{Name: "prod", Endpoint: "https://LOCALHOST:9200/"}
+		assert.EqualError(t, checkDifferentEndpoints(source, target),
+			"profiles dev and prod resolve to same endpoint https://localhost:9200, check whether OPENSEARCH_ENDPOINT is set")
+	})
+}
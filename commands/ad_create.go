@@ -21,6 +21,7 @@ import (
 const (
 	createDetectorsCommandName = "create"
 	generate                   = "generate-template"
+	fanOut                     = "fan-out"
 )
 
 //createCmd creates detectors with configuration from input file, if interactive mode is on,
@@ -29,7 +30,8 @@ var createCmd = &cobra.Command{
 	Use:   createDetectorsCommandName + " json-file-path ...",
 	Short: "Create detectors based on JSON files",
 	Long: "Create detectors based on a local JSON file\n" +
-		"To begin, use `opensearch-cli ad create --generate-template` to generate a sample configuration. Save this template locally and update it for your use case. Then use `opensearch-cli ad create file-path` to create detector.",
+		"To begin, use `opensearch-cli ad create --generate-template` to generate a sample configuration. Save this template locally and update it for your use case. Then use `opensearch-cli ad create file-path` to create detector.\n" +
+		"Detectors with `category_field` are created as a single high cardinality detector. Use the `--fan-out` flag to create one detector per value of the partition field instead.",
 	Run: func(cmd *cobra.Command, args []string) {
 		generate, _ := cmd.Flags().GetBool(generate)
 		if generate {
@@ -41,7 +43,8 @@ var createCmd = &cobra.Command{
 			fmt.Println(cmd.Usage())
 			return
 		}
-		err := createDetectors(args)
+		fanOut, _ := cmd.Flags().GetBool(fanOut)
+		err := createDetectors(args, fanOut)
 		DisplayError(err, createDetectorsCommandName)
 	},
 }
@@ -55,19 +58,20 @@ func generateTemplate() {
 func init() {
 	GetADCommand().AddCommand(createCmd)
 	createCmd.Flags().BoolP(generate, "g", false, "Output sample detector configuration")
+	createCmd.Flags().BoolP(fanOut, "", false, "Create one detector per value of partition field instead of a high cardinality detector")
 	createCmd.Flags().BoolP("help", "h", false, "Help for "+createDetectorsCommandName)
 
 }
 
 //createDetectors create detectors based on configurations from fileNames
-func createDetectors(fileNames []string) error {
+func createDetectors(fileNames []string, fanOut bool) error {
 
 	commandHandler, err := GetADHandler()
 	if err != nil {
 		return err
 	}
 	for _, name := range fileNames {
-		err = handler.CreateAnomalyDetector(commandHandler, name)
+		err = handler.CreateAnomalyDetector(commandHandler, name, fanOut)
 		if err != nil {
 			return err
 		}
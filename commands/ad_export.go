@@ -0,0 +1,71 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	handler "opensearch-cli/handler/ad"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	exportCommandName       = "export"
+	exportDirFlagName       = "dir"
+	exportOverwriteFlagName = "overwrite"
+)
+
+//exportCmd writes detectors matched by name or name regex pattern to files accepted by create command
+var exportCmd = &cobra.Command{
+	Use:   exportCommandName + " detector_name ..." + " [flags] ",
+	Short: "Export detectors to JSON files based on a list of names or name regex patterns",
+	Long: "Export detectors based on a list of names or name regex patterns into the directory given by `--dir`, one file per detector.\n" +
+		"Files are in the format accepted by `opensearch-cli ad create` and `opensearch-cli ad apply`, so detectors can be created again on another cluster or profile.\n" +
+		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
+		"Only features with average, count, max, min or sum aggregation on single field can be exported.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := exportDetectors(cmd, args)
+		DisplayError(err, exportCommandName)
+	},
+}
+
+func init() {
+	GetADCommand().AddCommand(exportCmd)
+	exportCmd.Flags().StringP(exportDirFlagName, "d", ".", "Directory to write detector files")
+	exportCmd.Flags().BoolP(exportOverwriteFlagName, "", false, "Overwrite existing detector files")
+	exportCmd.Flags().BoolP("help", "h", false, "Help for "+exportCommandName)
+}
+
+//exportDetectors writes detectors matched by patterns to files
+func exportDetectors(cmd *cobra.Command, patterns []string) error {
+	dir, _ := cmd.Flags().GetString(exportDirFlagName)
+	overwrite, _ := cmd.Flags().GetBool(exportOverwriteFlagName)
+	commandHandler, err := GetADHandler()
+	if err != nil {
+		return err
+	}
+	for _, pattern := range patterns {
+		fileNames, skipped, err := handler.ExportAnomalyDetectors(commandHandler, pattern, dir, overwrite)
+		for _, fileName := range fileNames {
+			fmt.Println("exported", fileName)
+		}
+		for _, detector := range skipped {
+			fmt.Fprintf(os.Stderr, "skipped %s: %s\n", detector.Name, detector.Reason)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
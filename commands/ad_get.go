@@ -61,7 +61,12 @@ func printDetectors(display Display, cmd *cobra.Command, detectors []string) err
 	if err != nil {
 		return err
 	}
-	return fprint(cmd, display, results)
+	if results == nil {
+		results = []*entity.DetectorOutput{}
+	}
+	return printOutput(results, func() error {
+		return fprint(cmd, display, results)
+	})
 }
 
 //getDetectors fetch detector from controller
@@ -0,0 +1,231 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/ad"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/ad"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	historicalCommandName         = "historical"
+	historicalStartCommandName    = "start"
+	historicalStopCommandName     = "stop"
+	historicalStatusCommandName   = "status"
+	historicalIDFlagName          = "id"
+	historicalFromFlagName        = "from"
+	historicalToFlagName          = "to"
+	historicalNoWaitFlagName      = "no-wait"
+	historicalWaitFlagName        = "wait"
+	historicalIntervalFlagName    = "interval"
+	historicalTimeoutFlagName     = "timeout"
+	defaultHistoricalPollInterval = 5 * time.Second
+	defaultHistoricalTimeout      = time.Hour
+	historicalTaskFailedState     = "FAILED"
+)
+
+//historicalCmd is base command for historical analysis of detectors
+var historicalCmd = &cobra.Command{
+	Use:   historicalCommandName,
+	Short: "Manage historical analysis of detectors",
+	Long:  "Use the historical commands to run detectors over past data, stop them and display their progress.",
+}
+
+//historicalStartCmd starts historical analysis of detectors based on id, name or name regex pattern.
+//default input is name pattern, one can change this format to be id by passing --id flag
+var historicalStartCmd = &cobra.Command{
+	Use:   historicalStartCommandName + " detector_name ..." + " [flags] ",
+	Short: "Start historical analysis of detectors based on a list of IDs, names, or name regex patterns",
+	Long: "Start historical analysis of detectors over the time range given by `--from` and `--to` based on a list of IDs, names, or name regex patterns.\n" +
+		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
+		"The default input is detector name. Use the `--id` flag if input is detector ID instead of name.\n" +
+		"The command displays a progress bar until every analysis is completed or `--timeout` is reached, use the `--no-wait` flag to return as soon as analysis is started.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := startHistoricalAnalysis(cmd, args)
+		DisplayError(err, historicalStartCommandName)
+	},
+}
+
+//historicalStopCmd stops historical analysis of detectors based on id, name or name regex pattern.
+//default input is name pattern, one can change this format to be id by passing --id flag
+var historicalStopCmd = &cobra.Command{
+	Use:   historicalStopCommandName + " detector_name ..." + " [flags] ",
+	Short: "Stop historical analysis of detectors based on a list of IDs, names, or name regex patterns",
+	Long: "Stop historical analysis of detectors based on a list of IDs, names, or name regex patterns.\n" +
+		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
+		"The default input is detector name. Use the `--id` flag if input is detector ID instead of name",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		idStatus, _ := cmd.Flags().GetBool(historicalIDFlagName)
+		action := handler.StopHistoricalAnalysisByNamePattern
+		if idStatus {
+			action = handler.StopHistoricalAnalysisByID
+		}
+		err := execute(action, args)
+		DisplayError(err, historicalStopCommandName)
+	},
+}
+
+//historicalStatusCmd prints status of latest historical analysis of detectors based on id, name or name regex pattern.
+//default input is name pattern, one can change this format to be id by passing --id flag
+var historicalStatusCmd = &cobra.Command{
+	Use:   historicalStatusCommandName + " detector_name ..." + " [flags] ",
+	Short: "Display state and progress of historical analysis of detectors based on a list of IDs, names, or name regex patterns",
+	Long: "Display state, progress, time range and error of latest historical analysis of detectors based on a list of IDs, names, or name regex patterns.\n" +
+		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
+		"The default input is detector name. Use the `--id` flag if input is detector ID instead of name.\n" +
+		"Use the `--wait` flag to display a progress bar until every analysis is completed or `--timeout` is reached.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		wait, _ := cmd.Flags().GetBool(historicalWaitFlagName)
+		err := printHistoricalAnalysisStatus(cmd, args, wait)
+		DisplayError(err, historicalStatusCommandName)
+	},
+}
+
+func init() {
+	GetADCommand().AddCommand(historicalCmd)
+	historicalCmd.Flags().BoolP("help", "h", false, "Help for "+historicalCommandName)
+
+	historicalStartCmd.Flags().BoolP(historicalIDFlagName, "", false, "Input is detector ID")
+	historicalStartCmd.Flags().StringP(historicalFromFlagName, "", "", "Start of time range as duration relative to now like 720h, RFC3339 time, date like 2006-01-02 or epoch milliseconds")
+	historicalStartCmd.Flags().StringP(historicalToFlagName, "", "", "End of time range as duration relative to now like 1h, RFC3339 time, date like 2006-01-02 or epoch milliseconds, default is now")
+	historicalStartCmd.Flags().BoolP(historicalNoWaitFlagName, "", false, "Do not wait for historical analysis to complete")
+	historicalStartCmd.Flags().DurationP(historicalIntervalFlagName, "i", defaultHistoricalPollInterval, "Interval between progress updates")
+	historicalStartCmd.Flags().DurationP(historicalTimeoutFlagName, "t", defaultHistoricalTimeout, "Maximum time to wait for historical analysis to complete, 0 waits without limit")
+	historicalStartCmd.Flags().BoolP("help", "h", false, "Help for "+historicalStartCommandName)
+	_ = historicalStartCmd.MarkFlagRequired(historicalFromFlagName)
+	historicalCmd.AddCommand(historicalStartCmd)
+
+	historicalStopCmd.Flags().BoolP(historicalIDFlagName, "", false, "Input is detector ID")
+	historicalStopCmd.Flags().BoolP("help", "h", false, "Help for "+historicalStopCommandName)
+	historicalCmd.AddCommand(historicalStopCmd)
+
+	historicalStatusCmd.Flags().BoolP(historicalIDFlagName, "", false, "Input is detector ID")
+	historicalStatusCmd.Flags().BoolP(historicalWaitFlagName, "w", false, "Display progress bar until historical analysis is completed")
+	historicalStatusCmd.Flags().DurationP(historicalIntervalFlagName, "i", defaultHistoricalPollInterval, "Interval between progress updates")
+	historicalStatusCmd.Flags().DurationP(historicalTimeoutFlagName, "t", defaultHistoricalTimeout, "Maximum time to wait for historical analysis to complete with --wait, 0 waits without limit")
+	historicalStatusCmd.Flags().BoolP("help", "h", false, "Help for "+historicalStatusCommandName)
+	historicalCmd.AddCommand(historicalStatusCmd)
+}
+
+//startHistoricalAnalysis starts historical analysis of detectors and waits for them unless --no-wait is passed
+func startHistoricalAnalysis(cmd *cobra.Command, detectors []string) error {
+	idStatus, _ := cmd.Flags().GetBool(historicalIDFlagName)
+	from, _ := cmd.Flags().GetString(historicalFromFlagName)
+	to, _ := cmd.Flags().GetString(historicalToFlagName)
+	noWait, _ := cmd.Flags().GetBool(historicalNoWaitFlagName)
+	commandHandler, err := GetADHandler()
+	if err != nil {
+		return err
+	}
+	// default is name
+	action := handler.StartHistoricalAnalysisByNamePattern
+	if idStatus {
+		action = handler.StartHistoricalAnalysisByID
+	}
+	var started []string
+	for _, detector := range detectors {
+		IDs, err := action(commandHandler, detector, from, to)
+		if err != nil {
+			return err
+		}
+		started = append(started, IDs...)
+	}
+	//nothing to wait for if user declined to proceed or no detector matched
+	if noWait || len(started) < 1 {
+		return nil
+	}
+	return waitForHistoricalAnalysis(cmd, commandHandler, started)
+}
+
+//printHistoricalAnalysisStatus prints status of historical analysis of detectors, if wait is true,
+// progress bar is displayed until every analysis is completed
+func printHistoricalAnalysisStatus(cmd *cobra.Command, detectors []string, wait bool) error {
+	idStatus, _ := cmd.Flags().GetBool(historicalIDFlagName)
+	commandHandler, err := GetADHandler()
+	if err != nil {
+		return err
+	}
+	// default is name
+	action := handler.GetHistoricalAnalysisStatusByNamePattern
+	if idStatus {
+		action = handler.GetHistoricalAnalysisStatusByID
+	}
+	var results []*entity.HistoricalAnalysisStatus
+	for _, detector := range detectors {
+		output, err := action(commandHandler, detector)
+		if err != nil {
+			return err
+		}
+		results = append(results, output...)
+	}
+	if wait && len(results) > 0 {
+		var IDs []string
+		for _, status := range results {
+			IDs = append(IDs, status.ID)
+		}
+		return waitForHistoricalAnalysis(cmd, commandHandler, IDs)
+	}
+	return displayHistoricalAnalysisStatus(results)
+}
+
+//waitForHistoricalAnalysis displays progress bar until historical analysis of every detector is completed or timeout
+// is reached, prints their status and returns error if any analysis failed
+func waitForHistoricalAnalysis(cmd *cobra.Command, commandHandler *handler.Handler, IDs []string) error {
+	interval, _ := cmd.Flags().GetDuration(historicalIntervalFlagName)
+	timeout, _ := cmd.Flags().GetDuration(historicalTimeoutFlagName)
+	results, err := handler.WaitForHistoricalAnalysis(commandHandler, IDs, interval, timeout)
+	if err != nil {
+		return err
+	}
+	if err = displayHistoricalAnalysisStatus(results); err != nil {
+		return err
+	}
+	return getHistoricalAnalysisError(results)
+}
+
+//displayHistoricalAnalysisStatus prints status of historical analysis, default format is table
+func displayHistoricalAnalysisStatus(results []*entity.HistoricalAnalysisStatus) error {
+	if results == nil {
+		results = []*entity.HistoricalAnalysisStatus{}
+	}
+	return printOutput(results, func() error {
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, results)
+	})
+}
+
+//getHistoricalAnalysisError returns error if any historical analysis failed
+func getHistoricalAnalysisError(results []*entity.HistoricalAnalysisStatus) error {
+	var failed []string
+	for _, status := range results {
+		if strings.EqualFold(status.State, historicalTaskFailedState) {
+			failed = append(failed, fmt.Sprintf("%s: %s", status.Name, status.Error))
+		}
+	}
+	if len(failed) < 1 {
+		return nil
+	}
+	return fmt.Errorf("historical analysis failed for %d detector(s)\n%s", len(failed), strings.Join(failed, "\n"))
+}
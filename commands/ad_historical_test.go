@@ -0,0 +1,35 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	entity "opensearch-cli/entity/ad"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHistoricalAnalysisError(t *testing.T) {
+	t.Run("all tasks finished", func(t *testing.T) {
+		assert.NoError(t, getHistoricalAnalysisError([]*entity.HistoricalAnalysisStatus{
+			{Name: "d1", State: "FINISHED"},
+			{Name: "d2", State: "STOPPED"},
+		}))
+	})
+	t.Run("task failed", func(t *testing.T) {
+		err := getHistoricalAnalysisError([]*entity.HistoricalAnalysisStatus{
+			{Name: "d1", State: "FINISHED"},
+			{Name: "d2", State: "FAILED", Error: "No data in the index"},
+		})
+		assert.EqualError(t, err, "historical analysis failed for 1 detector(s)\nd2: No data in the index")
+	})
+}
@@ -0,0 +1,94 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/ad"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/ad"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	previewCommandName      = "preview"
+	previewIDFlagName       = "id"
+	previewNameFlagName     = "name"
+	previewFromFlagName     = "from"
+	previewToFlagName       = "to"
+	defaultPreviewTimeRange = "168h"
+)
+
+//previewCmd prints sample anomaly results of detectors from configuration file, or of existing detectors
+//based on id, name or name regex pattern if --id or --name flag is passed
+var previewCmd = &cobra.Command{
+	Use:   previewCommandName + " json-file-path ..." + " [flags] ",
+	Short: "Preview sample anomaly results and feature values of detectors without creating them",
+	Long: "Preview sample anomaly results and feature values of detectors over the time range given by `--from` and `--to`.\n" +
+		"The default input is a local JSON file in the format accepted by `opensearch-cli ad create`, the detector is not created.\n" +
+		"Use the `--name` flag if input is name or name regex pattern of existing detectors, or the `--id` flag if input is detector ID.\n" +
+		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := printPreview(cmd, args)
+		DisplayError(err, previewCommandName)
+	},
+}
+
+func init() {
+	GetADCommand().AddCommand(previewCmd)
+	previewCmd.Flags().BoolP(previewIDFlagName, "", false, "Input is detector ID")
+	previewCmd.Flags().BoolP(previewNameFlagName, "", false, "Input is detector name or name regex pattern")
+	previewCmd.Flags().StringP(previewFromFlagName, "", defaultPreviewTimeRange, "Start of time range as duration relative to now like 168h, RFC3339 time, date like 2006-01-02 or epoch milliseconds")
+	previewCmd.Flags().StringP(previewToFlagName, "", "", "End of time range as duration relative to now like 1h, RFC3339 time, date like 2006-01-02 or epoch milliseconds, default is now")
+	previewCmd.Flags().BoolP("help", "h", false, "Help for "+previewCommandName)
+}
+
+//printPreview prints sample anomaly results of detectors, default format is table
+func printPreview(cmd *cobra.Command, args []string) error {
+	idStatus, _ := cmd.Flags().GetBool(previewIDFlagName)
+	nameStatus, _ := cmd.Flags().GetBool(previewNameFlagName)
+	from, _ := cmd.Flags().GetString(previewFromFlagName)
+	to, _ := cmd.Flags().GetString(previewToFlagName)
+	if idStatus && nameStatus {
+		return fmt.Errorf("flags --%s and --%s cannot be used together", previewIDFlagName, previewNameFlagName)
+	}
+	commandHandler, err := GetADHandler()
+	if err != nil {
+		return err
+	}
+	// default is file
+	action := handler.PreviewAnomalyDetector
+	if idStatus {
+		action = handler.PreviewAnomalyDetectorByID
+	}
+	if nameStatus {
+		action = handler.PreviewAnomalyDetectorByNamePattern
+	}
+	results := []*entity.AnomalyResultOutput{}
+	for _, arg := range args {
+		output, err := action(commandHandler, arg, from, to)
+		if err != nil {
+			return err
+		}
+		results = append(results, output...)
+	}
+	return printOutput(results, func() error {
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, results)
+	})
+}
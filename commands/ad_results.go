@@ -0,0 +1,106 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	entity "opensearch-cli/entity/ad"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/ad"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	resultsCommandName           = "results"
+	resultsIDFlagName            = "id"
+	resultsFromFlagName          = "from"
+	resultsToFlagName            = "to"
+	resultsMinGradeFlagName      = "min-grade"
+	resultsMinConfidenceFlagName = "min-confidence"
+	resultsEntityFlagName        = "entity"
+	resultsLimitFlagName         = "limit"
+	defaultResultsLimit          = 100
+)
+
+//resultsCmd prints anomaly results of detectors based on id, name or name regex pattern.
+//default input is name pattern, one can change this format to be id by passing --id flag
+var resultsCmd = &cobra.Command{
+	Use:   resultsCommandName + " detector_name ..." + " [flags] ",
+	Short: "Search anomalies found by detectors based on a list of IDs, names, or name regex patterns",
+	Long: "Search anomalies found by detectors based on a list of IDs, names, or name regex patterns.\n" +
+		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
+		"The default input is detector name. Use the `--id` flag if input is detector ID instead of name.\n" +
+		"Only results with anomaly grade above zero are returned, latest first. Results are displayed as table by default, " +
+		"use the global `--output` flag to stream them as jsonl or csv.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := printAnomalyResults(cmd, args)
+		if err != nil {
+			DisplayError(err, resultsCommandName)
+		}
+	},
+}
+
+func init() {
+	GetADCommand().AddCommand(resultsCmd)
+	resultsCmd.Flags().BoolP(resultsIDFlagName, "", false, "Input is detector ID")
+	resultsCmd.Flags().StringP(resultsFromFlagName, "", "", "Start of time range as duration relative to now like 24h, RFC3339 time, date like 2006-01-02 or epoch milliseconds")
+	resultsCmd.Flags().StringP(resultsToFlagName, "", "", "End of time range as duration relative to now like 1h, RFC3339 time, date like 2006-01-02 or epoch milliseconds")
+	resultsCmd.Flags().Float64P(resultsMinGradeFlagName, "", 0, "Minimum anomaly grade between 0 and 1")
+	resultsCmd.Flags().Float64P(resultsMinConfidenceFlagName, "", 0, "Minimum confidence between 0 and 1")
+	resultsCmd.Flags().StringP(resultsEntityFlagName, "e", "", "Entity value of high cardinality detector, use name=value to match category field name as well")
+	resultsCmd.Flags().IntP(resultsLimitFlagName, "l", defaultResultsLimit, "Maximum number of results, 0 returns all results")
+	resultsCmd.Flags().BoolP("help", "h", false, "Help for "+resultsCommandName)
+}
+
+//getAnomalyResultFilter builds filter from command flags
+func getAnomalyResultFilter(cmd *cobra.Command) entity.AnomalyResultFilter {
+	from, _ := cmd.Flags().GetString(resultsFromFlagName)
+	to, _ := cmd.Flags().GetString(resultsToFlagName)
+	minGrade, _ := cmd.Flags().GetFloat64(resultsMinGradeFlagName)
+	minConfidence, _ := cmd.Flags().GetFloat64(resultsMinConfidenceFlagName)
+	entityValue, _ := cmd.Flags().GetString(resultsEntityFlagName)
+	limit, _ := cmd.Flags().GetInt(resultsLimitFlagName)
+	return entity.AnomalyResultFilter{
+		From:            from,
+		To:              to,
+		MinAnomalyGrade: minGrade,
+		MinConfidence:   minConfidence,
+		Entity:          entityValue,
+		Limit:           limit,
+	}
+}
+
+//printAnomalyResults streams anomaly results page by page, default format is table
+func printAnomalyResults(cmd *cobra.Command, detectors []string) error {
+	idStatus, _ := cmd.Flags().GetBool(resultsIDFlagName)
+	commandHandler, err := GetADHandler()
+	if err != nil {
+		return err
+	}
+	writer, err := newStreamWriter(formatter.Table)
+	if err != nil {
+		return err
+	}
+	// default is name
+	action := handler.SearchAnomalyResultsByNamePattern
+	if idStatus {
+		action = handler.SearchAnomalyResultsByID
+	}
+	err = action(commandHandler, detectors, getAnomalyResultFilter(cmd), func(page []*entity.AnomalyResultOutput) error {
+		return writer.Write(page)
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Flush()
+}
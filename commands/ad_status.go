@@ -0,0 +1,175 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/ad"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/ad"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	statusDetectorsCommandName = "status"
+	statusIDFlagName           = "id"
+	statusWatchFlagName        = "watch"
+	statusStateFlagName        = "state"
+	statusIntervalFlagName     = "interval"
+	statusTimeoutFlagName      = "timeout"
+	defaultWatchState          = "RUNNING"
+)
+
+//detectorStates are states of detector which can be waited for in watch mode
+var detectorStates = []string{"DISABLED", "INIT", "RUNNING"}
+
+//statusDetectorsCmd prints detectors lifecycle status based on id, name or name regex pattern.
+//default input is name pattern, one can change this format to be id by passing --id flag
+var statusDetectorsCmd = &cobra.Command{
+	Use:   statusDetectorsCommandName + " detector_name ..." + " [flags] ",
+	Short: "Display state, initialization progress and errors of detectors based on a list of IDs, names, or name regex patterns",
+	Long: "Display state (DISABLED, INIT or RUNNING), initialization progress, error and nodes of detectors based on a list of IDs, names, or name regex patterns.\n" +
+		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
+		"The default input is detector name. Use the `--id` flag if input is detector ID instead of name.\n" +
+		"Use the `--watch` flag to wait until every detector reaches the state given by `--state`, command exits with non zero code if " +
+		"any detector reports an error or the timeout is reached.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		watch, _ := cmd.Flags().GetBool(statusWatchFlagName)
+		err := printDetectorsStatus(cmd, args)
+		if err == nil {
+			return
+		}
+		DisplayError(err, statusDetectorsCommandName)
+		if watch {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	GetADCommand().AddCommand(statusDetectorsCmd)
+	statusDetectorsCmd.Flags().BoolP(statusIDFlagName, "", false, "Input is detector ID")
+	statusDetectorsCmd.Flags().BoolP(statusWatchFlagName, "w", false, "Poll status until every detector reaches the state given by --state")
+	statusDetectorsCmd.Flags().StringP(statusStateFlagName, "s", defaultWatchState, "State to wait for in watch mode. Options are DISABLED, INIT and RUNNING")
+	statusDetectorsCmd.Flags().DurationP(statusIntervalFlagName, "i", 10*time.Second, "Interval between polls in watch mode")
+	statusDetectorsCmd.Flags().DurationP(statusTimeoutFlagName, "t", 10*time.Minute, "Maximum time to wait in watch mode")
+	statusDetectorsCmd.Flags().BoolP("help", "h", false, "Help for "+statusDetectorsCommandName)
+}
+
+//printDetectorsStatus prints detectors status once or until detectors reach expected state if watch is enabled
+func printDetectorsStatus(cmd *cobra.Command, detectors []string) error {
+	idStatus, _ := cmd.Flags().GetBool(statusIDFlagName)
+	watch, _ := cmd.Flags().GetBool(statusWatchFlagName)
+	state, _ := cmd.Flags().GetString(statusStateFlagName)
+	interval, _ := cmd.Flags().GetDuration(statusIntervalFlagName)
+	timeout, _ := cmd.Flags().GetDuration(statusTimeoutFlagName)
+	if err := validateState(state); err != nil {
+		return err
+	}
+	commandHandler, err := GetADHandler()
+	if err != nil {
+		return err
+	}
+	// default is name
+	action := handler.GetAnomalyDetectorStatusByNamePattern
+	if idStatus {
+		action = handler.GetAnomalyDetectorStatusByID
+	}
+	fetch := func() ([]*entity.DetectorStatus, error) {
+		var results []*entity.DetectorStatus
+		for _, detector := range detectors {
+			output, err := action(commandHandler, detector)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, output...)
+		}
+		return results, nil
+	}
+	if !watch {
+		results, err := fetch()
+		if err != nil {
+			return err
+		}
+		return displayDetectorsStatus(results)
+	}
+	return watchDetectorsStatus(fetch, state, interval, timeout)
+}
+
+//watchDetectorsStatus polls detectors status until every detector reaches state or timeout is reached
+func watchDetectorsStatus(fetch func() ([]*entity.DetectorStatus, error), state string, interval time.Duration, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		results, err := fetch()
+		if err != nil {
+			return err
+		}
+		if err = displayDetectorsStatus(results); err != nil {
+			return err
+		}
+		done, err := hasReachedState(results, state)
+		if err != nil || done {
+			return err
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for detectors to reach state %s", timeout, state)
+		}
+		time.Sleep(interval)
+	}
+}
+
+//validateState checks that state is one of detector states, so that watch mode does not poll for state which is never reached
+func validateState(state string) error {
+	for _, valid := range detectorStates {
+		if strings.EqualFold(valid, state) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid state %s. Options are %s", state, strings.Join(detectorStates, ", "))
+}
+
+//hasReachedState checks whether every detector reached the state, returns error if any detector failed
+func hasReachedState(results []*entity.DetectorStatus, state string) (bool, error) {
+	if len(results) < 1 {
+		return false, fmt.Errorf("no detectors found")
+	}
+	done := true
+	for _, status := range results {
+		if strings.EqualFold(status.State, state) {
+			continue
+		}
+		if len(status.Error) > 0 {
+			return false, fmt.Errorf("detector %s is in state %s due to: %s", status.Name, status.State, status.Error)
+		}
+		done = false
+	}
+	return done, nil
+}
+
+//displayDetectorsStatus prints detectors status, default format is table
+func displayDetectorsStatus(results []*entity.DetectorStatus) error {
+	if results == nil {
+		results = []*entity.DetectorStatus{}
+	}
+	return printOutput(results, func() error {
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, results)
+	})
+}
@@ -0,0 +1,69 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	entity "opensearch-cli/entity/ad"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateState(t *testing.T) {
+	t.Run("valid state", func(t *testing.T) {
+		assert.NoError(t, validateState("init"))
+	})
+	t.Run("invalid state", func(t *testing.T) {
+		assert.EqualError(t, validateState("RUNING"), "invalid state RUNING. Options are DISABLED, INIT, RUNNING")
+	})
+}
+
+func TestHasReachedState(t *testing.T) {
+	t.Run("no detectors", func(t *testing.T) {
+		_, err := hasReachedState(nil, "RUNNING")
+		assert.EqualError(t, err, "no detectors found")
+	})
+	t.Run("all detectors reached state", func(t *testing.T) {
+		done, err := hasReachedState([]*entity.DetectorStatus{{State: "RUNNING"}, {State: "running"}}, "RUNNING")
+		assert.NoError(t, err)
+		assert.True(t, done)
+	})
+	t.Run("detector is initializing", func(t *testing.T) {
+		done, err := hasReachedState([]*entity.DetectorStatus{{State: "RUNNING"}, {State: "INIT"}}, "RUNNING")
+		assert.NoError(t, err)
+		assert.False(t, done)
+	})
+	t.Run("detector failed", func(t *testing.T) {
+		_, err := hasReachedState([]*entity.DetectorStatus{{Name: "d1", State: "DISABLED", Error: "no data"}}, "RUNNING")
+		assert.EqualError(t, err, "detector d1 is in state DISABLED due to: no data")
+	})
+}
+
+func TestWatchDetectorsStatus(t *testing.T) {
+	t.Run("wait until running", func(t *testing.T) {
+		states := []string{"INIT", "INIT", "RUNNING"}
+		polls := 0
+		err := watchDetectorsStatus(func() ([]*entity.DetectorStatus, error) {
+			polls++
+			return []*entity.DetectorStatus{{Name: "d1", State: states[polls-1]}}, nil
+		}, "RUNNING", time.Millisecond, time.Second)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 3, polls)
+	})
+	t.Run("timed out", func(t *testing.T) {
+		err := watchDetectorsStatus(func() ([]*entity.DetectorStatus, error) {
+			return []*entity.DetectorStatus{{Name: "d1", State: "INIT"}}, nil
+		}, "RUNNING", time.Second, time.Millisecond)
+		assert.EqualError(t, err, "timed out after 1ms waiting for detectors to reach state RUNNING")
+	})
+}
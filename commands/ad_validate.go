@@ -0,0 +1,99 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/ad"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/ad"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	validateCommandName = "validate"
+)
+
+//validateCmd validates detectors configuration from input files against the cluster without creating them
+var validateCmd = &cobra.Command{
+	Use:   validateCommandName + " json-file-path ..." + " [flags] ",
+	Short: "Validate detectors configuration from JSON files without creating them",
+	Long: "Validate detectors configuration from local JSON files in the format accepted by `opensearch-cli ad create` without creating them.\n" +
+		"The command checks mandatory fields, that indices exist, that `time_field` is a date field, that every feature field is aggregatable " +
+		"and calls the validate API of the Anomaly Detection plugin. Every problem found is reported, command exits with non zero code if any problem is found.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := validateDetectors(args)
+		if err == nil {
+			return
+		}
+		DisplayError(err, validateCommandName)
+		os.Exit(1)
+	},
+}
+
+func init() {
+	GetADCommand().AddCommand(validateCmd)
+	validateCmd.Flags().BoolP("help", "h", false, "Help for "+validateCommandName)
+}
+
+//validateDetectors validates configuration of every file and prints problems found
+func validateDetectors(fileNames []string) error {
+	commandHandler, err := GetADHandler()
+	if err != nil {
+		return err
+	}
+	total := 0
+	results := []entity.ValidationResult{}
+	for _, name := range fileNames {
+		problems, err := handler.ValidateAnomalyDetector(commandHandler, name)
+		if err != nil {
+			return err
+		}
+		if problems == nil {
+			problems = []*entity.ValidationProblem{}
+		}
+		total += len(problems)
+		results = append(results, entity.ValidationResult{File: name, Valid: len(problems) < 1, Problems: problems})
+	}
+	if err = printValidationResults(results); err != nil {
+		return err
+	}
+	if total > 0 {
+		return fmt.Errorf("found %d problem(s) in detector configuration", total)
+	}
+	return nil
+}
+
+//printValidationResults prints problems of detector configuration of every file once, so that output requested
+//by --output flag is single document. Default format is table of problems per file
+func printValidationResults(results []entity.ValidationResult) error {
+	return printOutput(results, func() error {
+		for _, result := range results {
+			if result.Valid {
+				fmt.Printf("%s: detector configuration is valid\n", result.File)
+				continue
+			}
+			fmt.Printf("%s: found %d problem(s)\n", result.File, len(result.Problems))
+			f, err := formatter.New(formatter.Table)
+			if err != nil {
+				return err
+			}
+			if err = f.Format(os.Stdout, result.Problems); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
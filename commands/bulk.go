@@ -0,0 +1,136 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/platform"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/platform"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	bulkCommandName         = "bulk"
+	bulkIndexFlagName       = "index"
+	bulkFormatFlagName      = "format"
+	bulkActionFlagName      = "action"
+	bulkIDFieldFlagName     = "id-field"
+	bulkBatchSizeFlagName   = "batch-size"
+	bulkBatchBytesFlagName  = "batch-bytes"
+	bulkConcurrencyFlagName = "concurrency"
+	bulkRetriesFlagName     = "retries"
+	bulkDeadLetterFlagName  = "dead-letter"
+	defaultBulkBatchSize    = 1000
+	defaultBulkBatchBytes   = 5 * 1024 * 1024
+	defaultBulkConcurrency  = 2
+	defaultBulkRetries      = 3
+)
+
+//bulkCommand loads documents from files or stdin using bulk API
+var bulkCommand = &cobra.Command{
+	Use:   bulkCommandName + " [file-path...]" + " [flags] ",
+	Short: "Load documents from NDJSON, JSON array or CSV files into an index",
+	Long: "Load documents from NDJSON, JSON array or CSV files using the bulk API, standard input is read if no file or \"-\" is given.\n" +
+		"NDJSON files contain one JSON document per line, JSON files contain either an array of documents or NDJSON, " +
+		"CSV files must have a header and every column is indexed as a string field. " +
+		"Bulk API bodies with action and source lines, like files written by `index export`, are detected from the first line " +
+		"and sent as they are, use `--format bulk` to skip detection. Files ending with .gz are decompressed.\n" +
+		"Documents are sent in chunks limited by `--batch-size` and `--batch-bytes`. Items rejected due to throttling are retried, " +
+		"items which still failed are summarized by error type and written to `--dead-letter` file in bulk format, " +
+		"which can be loaded again with `--format bulk`.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := loadBulk(cmd, args)
+		DisplayError(err, bulkCommandName)
+	},
+}
+
+func init() {
+	bulkCommand.Flags().StringP(bulkIndexFlagName, "i", "", "Name of the index, required unless format is bulk")
+	bulkCommand.Flags().StringP(bulkFormatFlagName, "", entity.BulkFormatAuto, "Format of input: auto, ndjson, json, csv or bulk, auto detects format from file extension and content")
+	bulkCommand.Flags().StringP(bulkActionFlagName, "", entity.BulkActionIndex, "Action used for documents: index or create")
+	bulkCommand.Flags().StringP(bulkIDFieldFlagName, "", "", "Field or column used as document ID")
+	bulkCommand.Flags().IntP(bulkBatchSizeFlagName, "b", defaultBulkBatchSize, "Maximum number of documents per bulk request")
+	bulkCommand.Flags().IntP(bulkBatchBytesFlagName, "", defaultBulkBatchBytes, "Maximum size of bulk request body in bytes")
+	bulkCommand.Flags().IntP(bulkConcurrencyFlagName, "", defaultBulkConcurrency, "Number of bulk requests sent in parallel")
+	bulkCommand.Flags().IntP(bulkRetriesFlagName, "", defaultBulkRetries, "Number of times throttled items are retried")
+	bulkCommand.Flags().StringP(bulkDeadLetterFlagName, "", "", "File to write failed documents to in bulk format")
+	bulkCommand.Flags().BoolP("help", "h", false, "Help for "+bulkCommandName)
+	GetRoot().AddCommand(bulkCommand)
+}
+
+//GetBulkCommand returns bulk command
+func GetBulkCommand() *cobra.Command {
+	return bulkCommand
+}
+
+//getBulkLoadRequest reads bulk load request from flags
+func getBulkLoadRequest(cmd *cobra.Command) entity.BulkLoadRequest {
+	request := entity.BulkLoadRequest{}
+	request.Index, _ = cmd.Flags().GetString(bulkIndexFlagName)
+	request.Format, _ = cmd.Flags().GetString(bulkFormatFlagName)
+	request.Action, _ = cmd.Flags().GetString(bulkActionFlagName)
+	request.IDField, _ = cmd.Flags().GetString(bulkIDFieldFlagName)
+	request.BatchSize, _ = cmd.Flags().GetInt(bulkBatchSizeFlagName)
+	request.BatchBytes, _ = cmd.Flags().GetInt(bulkBatchBytesFlagName)
+	request.Concurrency, _ = cmd.Flags().GetInt(bulkConcurrencyFlagName)
+	request.Retries, _ = cmd.Flags().GetInt(bulkRetriesFlagName)
+	return request
+}
+
+//loadBulk loads documents from files and prints number of loaded documents with summary of failures
+func loadBulk(cmd *cobra.Command, fileNames []string) error {
+	if len(fileNames) < 1 {
+		fileNames = []string{handler.StdinFileName}
+	}
+	request := getBulkLoadRequest(cmd)
+	deadLetter, _ := cmd.Flags().GetString(bulkDeadLetterFlagName)
+	h, err := getCurlHandler()
+	if err != nil {
+		return err
+	}
+	result, loadErr := handler.LoadBulk(h, fileNames, request, deadLetter)
+	if result == nil {
+		return loadErr
+	}
+	if err = printBulkLoadResult(result, deadLetter); err != nil {
+		return err
+	}
+	if loadErr != nil {
+		return loadErr
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("failed to load %d document(s)", result.Failed)
+	}
+	return nil
+}
+
+func printBulkLoadResult(result *entity.BulkLoadResult, deadLetter string) error {
+	return printOutput(result, func() error {
+		fmt.Printf("loaded %d document(s)\n", result.Loaded)
+		if result.Failed < 1 {
+			return nil
+		}
+		fmt.Printf("failed to load %d document(s)\n", result.Failed)
+		if len(deadLetter) > 0 {
+			fmt.Printf("failed documents were written to %s\n", deadLetter)
+		}
+		fmt.Println("failures by type:")
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, result.Failures)
+	})
+}
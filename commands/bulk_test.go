@@ -0,0 +1,40 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	entity "opensearch-cli/entity/platform"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkCommand(t *testing.T) {
+	t.Run("help does not conflict with global flags", func(t *testing.T) {
+		_, err := executeCommand(GetRoot(), bulkCommandName, "--help")
+		assert.NoError(t, err)
+	})
+	t.Run("bulk load request from flags", func(t *testing.T) {
+		cmd := GetBulkCommand()
+		assert.NoError(t, cmd.ParseFlags([]string{"-i", "docs", "--format", "csv", "--id-field", "id", "--concurrency", "4", "--batch-bytes", "100"}))
+		assert.EqualValues(t, entity.BulkLoadRequest{
+			Index:       "docs",
+			Format:      entity.BulkFormatCSV,
+			Action:      entity.BulkActionIndex,
+			IDField:     "id",
+			BatchSize:   defaultBulkBatchSize,
+			BatchBytes:  100,
+			Concurrency: 4,
+			Retries:     defaultBulkRetries,
+		}, getBulkLoadRequest(cmd))
+	})
+}
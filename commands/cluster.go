@@ -0,0 +1,197 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"opensearch-cli/client"
+	controller "opensearch-cli/controller/cluster"
+	entity "opensearch-cli/entity/cluster"
+	"opensearch-cli/formatter"
+	gateway "opensearch-cli/gateway/cluster"
+	handler "opensearch-cli/handler/cluster"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	clusterCommandName           = "cluster"
+	clusterHealthCommandName     = "health"
+	clusterNodesCommandName      = "nodes"
+	clusterWaitForStatusFlagName = "wait-for-status"
+	clusterIntervalFlagName      = "interval"
+	clusterTimeoutFlagName       = "timeout"
+	defaultClusterHealthTimeout  = time.Minute
+	defaultClusterPollInterval   = 5 * time.Second
+)
+
+//exit codes of cluster health command by status
+const (
+	healthExitCodeGreen = iota
+	healthExitCodeYellow
+	healthExitCodeRed
+	healthExitCodeUnknown
+	healthExitCodeTimeout
+)
+
+//clusterCommand is base command for cluster operations
+var clusterCommand = &cobra.Command{
+	Use:   clusterCommandName,
+	Short: "Inspect cluster health, nodes, shard allocation and settings",
+	Long:  "Use the cluster commands to inspect health, nodes and shard allocation of the cluster and to get or set cluster settings.",
+}
+
+//clusterHealthCommand prints health of cluster and exits with code based on status
+var clusterHealthCommand = &cobra.Command{
+	Use:   clusterHealthCommandName + " [flags] ",
+	Short: "Display health of the cluster",
+	Long: "Display status, nodes and shard counts of the cluster. Use `--wait-for-status` to poll health every `--interval` " +
+		"until status is at least as good as the given status or `--timeout` is reached.\n" +
+		"Command exits with code 0 if status is green, 1 if yellow, 2 if red and 3 if health could not be retrieved, " +
+		"so that scripts can branch on the status.\n" +
+		"With `--wait-for-status`, command exits with code 0 once the status is reached, 4 if `--timeout` is reached first " +
+		"and 3 if health could not be retrieved.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		health, err := printClusterHealth(cmd)
+		if err != nil {
+			DisplayError(err, clusterHealthCommandName)
+			os.Exit(healthExitCodeUnknown)
+		}
+		status, _ := cmd.Flags().GetString(clusterWaitForStatusFlagName)
+		if code := getHealthExitCode(health, status); code != healthExitCodeGreen {
+			os.Exit(code)
+		}
+	},
+}
+
+//clusterNodesCommand prints nodes of cluster
+var clusterNodesCommand = &cobra.Command{
+	Use:   clusterNodesCommandName + " [flags] ",
+	Short: "List nodes of the cluster",
+	Long:  "List nodes of the cluster with their roles, elected cluster manager, heap, memory and CPU usage and load.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := listNodes()
+		DisplayError(err, clusterNodesCommandName)
+	},
+}
+
+func init() {
+	clusterCommand.Flags().BoolP("help", "h", false, "Help for "+clusterCommandName)
+	GetRoot().AddCommand(clusterCommand)
+	clusterHealthCommand.Flags().StringP(clusterWaitForStatusFlagName, "w", "", "Wait until status is at least green, yellow or red")
+	clusterHealthCommand.Flags().DurationP(clusterIntervalFlagName, "", defaultClusterPollInterval, "Interval between polls while waiting for status")
+	clusterHealthCommand.Flags().DurationP(clusterTimeoutFlagName, "", defaultClusterHealthTimeout, "Maximum time to wait for status")
+	clusterHealthCommand.Flags().BoolP("help", "h", false, "Help for "+clusterHealthCommandName)
+	clusterCommand.AddCommand(clusterHealthCommand)
+	clusterNodesCommand.Flags().BoolP("help", "h", false, "Help for "+clusterNodesCommandName)
+	clusterCommand.AddCommand(clusterNodesCommand)
+}
+
+//GetClusterCommand returns cluster base command
+func GetClusterCommand() *cobra.Command {
+	return clusterCommand
+}
+
+//getClusterHandler returns handler by wiring the dependency manually
+func getClusterHandler() (*handler.Handler, error) {
+	c, err := client.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := GetProfile()
+	if err != nil {
+		return nil, err
+	}
+	g, err := gateway.New(c, profile)
+	if err != nil {
+		return nil, err
+	}
+	return handler.New(controller.New(g)), nil
+}
+
+//getHealthExitCode returns exit code of cluster health command for status of health, if command waited
+//for status, exit code tells whether status was reached instead
+func getHealthExitCode(health *entity.Health, waitForStatus string) int {
+	if len(waitForStatus) > 0 {
+		if health.TimedOut {
+			return healthExitCodeTimeout
+		}
+		return healthExitCodeGreen
+	}
+	switch strings.ToLower(health.Status) {
+	case entity.HealthStatusGreen:
+		return healthExitCodeGreen
+	case entity.HealthStatusYellow:
+		return healthExitCodeYellow
+	case entity.HealthStatusRed:
+		return healthExitCodeRed
+	}
+	return healthExitCodeUnknown
+}
+
+//printClusterHealth prints health of cluster, optionally after waiting for status, default format is table
+func printClusterHealth(cmd *cobra.Command) (*entity.Health, error) {
+	status, _ := cmd.Flags().GetString(clusterWaitForStatusFlagName)
+	interval, _ := cmd.Flags().GetDuration(clusterIntervalFlagName)
+	timeout, _ := cmd.Flags().GetDuration(clusterTimeoutFlagName)
+	h, err := getClusterHandler()
+	if err != nil {
+		return nil, err
+	}
+	var health *entity.Health
+	if len(status) > 0 {
+		health, err = handler.WaitForHealth(h, status, interval, timeout)
+	} else {
+		health, err = handler.GetHealth(h)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = printOutput(health, func() error {
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		if err = f.Format(os.Stdout, []*entity.Health{health}); err != nil {
+			return err
+		}
+		if health.TimedOut && len(status) > 0 {
+			fmt.Fprintf(os.Stderr, "timed out after %s waiting for status %s\n", timeout, status)
+		}
+		return nil
+	})
+	return health, err
+}
+
+//listNodes prints nodes of cluster, default format is table
+func listNodes() error {
+	h, err := getClusterHandler()
+	if err != nil {
+		return err
+	}
+	nodes, err := handler.ListNodes(h)
+	if err != nil {
+		return err
+	}
+	return printOutput(nodes, func() error {
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, nodes)
+	})
+}
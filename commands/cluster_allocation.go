@@ -0,0 +1,103 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/cluster"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/cluster"
+	mapper "opensearch-cli/mapper/cluster"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	clusterAllocationExplainCommandName = "allocation-explain"
+	allocationIndexFlagName             = "index"
+	allocationShardFlagName             = "shard"
+	allocationPrimaryFlagName           = "primary"
+)
+
+//clusterAllocationExplainCommand explains allocation of shard
+var clusterAllocationExplainCommand = &cobra.Command{
+	Use:   clusterAllocationExplainCommandName + " [flags] ",
+	Short: "Explain why a shard is unassigned or why it remains on its node",
+	Long: "Explain allocation of the shard given by `--index`, `--shard` and `--primary`, or of the first unassigned shard if no index is given. " +
+		"Decisions of allocation deciders are displayed for every node.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := explainAllocation(getAllocationExplainRequest(cmd))
+		DisplayError(err, clusterAllocationExplainCommandName)
+	},
+}
+
+func init() {
+	clusterAllocationExplainCommand.Flags().StringP(allocationIndexFlagName, "i", "", "Index of the shard")
+	clusterAllocationExplainCommand.Flags().IntP(allocationShardFlagName, "s", 0, "Number of the shard")
+	clusterAllocationExplainCommand.Flags().BoolP(allocationPrimaryFlagName, "", false, "Explain primary shard instead of replica")
+	clusterAllocationExplainCommand.Flags().BoolP("help", "h", false, "Help for "+clusterAllocationExplainCommandName)
+	GetClusterCommand().AddCommand(clusterAllocationExplainCommand)
+}
+
+//getAllocationExplainRequest reads shard to explain from flags
+func getAllocationExplainRequest(cmd *cobra.Command) entity.AllocationExplainRequest {
+	request := entity.AllocationExplainRequest{}
+	request.Index, _ = cmd.Flags().GetString(allocationIndexFlagName)
+	request.Shard, _ = cmd.Flags().GetInt(allocationShardFlagName)
+	request.Primary, _ = cmd.Flags().GetBool(allocationPrimaryFlagName)
+	return request
+}
+
+//explainAllocation prints explanation of shard allocation with decisions of every node
+func explainAllocation(request entity.AllocationExplainRequest) error {
+	h, err := getClusterHandler()
+	if err != nil {
+		return err
+	}
+	explanation, err := handler.ExplainAllocation(h, request)
+	if err != nil {
+		return err
+	}
+	return printOutput(explanation, func() error {
+		shardType := "replica"
+		if explanation.Primary {
+			shardType = "primary"
+		}
+		fmt.Printf("shard %d (%s) of index %s is %s\n", explanation.Shard, shardType, explanation.Index, explanation.CurrentState)
+		if explanation.CurrentNode != nil {
+			fmt.Printf("current node: %s\n", explanation.CurrentNode.Name)
+		}
+		if explanation.UnassignedInfo != nil {
+			fmt.Printf("unassigned reason: %s at %s\n", explanation.UnassignedInfo.Reason, explanation.UnassignedInfo.At)
+			if len(explanation.UnassignedInfo.Details) > 0 {
+				fmt.Printf("details: %s\n", explanation.UnassignedInfo.Details)
+			}
+		}
+		if len(explanation.AllocateExplanation) > 0 {
+			fmt.Printf("%s: %s\n", explanation.CanAllocate, explanation.AllocateExplanation)
+		}
+		if len(explanation.RebalanceExplanation) > 0 {
+			fmt.Printf("%s: %s\n", explanation.CanRebalanceCluster, explanation.RebalanceExplanation)
+		}
+		decisions := mapper.MapToDeciderOutput(*explanation)
+		if len(decisions) < 1 {
+			return nil
+		}
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, decisions)
+	})
+}
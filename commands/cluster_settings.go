@@ -0,0 +1,119 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/cluster"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/cluster"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	clusterSettingsCommandName    = "settings"
+	clusterSettingsGetCommandName = "get"
+	clusterSettingsSetCommandName = "set"
+	includeDefaultsFlagName       = "include-defaults"
+	transientFlagName             = "transient"
+)
+
+//clusterSettingsCommand is base command for cluster settings
+var clusterSettingsCommand = &cobra.Command{
+	Use:   clusterSettingsCommandName,
+	Short: "Get or set cluster settings",
+	Long:  "Use the settings commands to get or set persistent and transient cluster settings.",
+}
+
+//clusterSettingsGetCommand prints cluster settings
+var clusterSettingsGetCommand = &cobra.Command{
+	Use:   clusterSettingsGetCommandName + " [name...]" + " [flags] ",
+	Short: "Get cluster settings",
+	Long: "Get transient and persistent cluster settings, or only settings with given names or name prefixes like `cluster.routing`. " +
+		"Use `--include-defaults` to include default values of settings which are not set.",
+	Run: func(cmd *cobra.Command, args []string) {
+		includeDefaults, _ := cmd.Flags().GetBool(includeDefaultsFlagName)
+		err := getClusterSettings(entity.SettingsRequest{
+			Names:           args,
+			IncludeDefaults: includeDefaults,
+		})
+		DisplayError(err, clusterSettingsGetCommandName)
+	},
+}
+
+//clusterSettingsSetCommand updates cluster settings
+var clusterSettingsSetCommand = &cobra.Command{
+	Use:   clusterSettingsSetCommandName + " name=value ..." + " [flags] ",
+	Short: "Set cluster settings",
+	Long: "Set cluster settings given as name=value pairs, e.g. `cluster.routing.allocation.enable=primaries`. " +
+		"Use null as value to reset a setting to its default. Settings are persistent unless `--transient` is given.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		transient, _ := cmd.Flags().GetBool(transientFlagName)
+		err := setClusterSettings(args, transient)
+		DisplayError(err, clusterSettingsSetCommandName)
+	},
+}
+
+func init() {
+	clusterSettingsCommand.Flags().BoolP("help", "h", false, "Help for "+clusterSettingsCommandName)
+	GetClusterCommand().AddCommand(clusterSettingsCommand)
+	clusterSettingsGetCommand.Flags().BoolP(includeDefaultsFlagName, "", false, "Include default values of settings")
+	clusterSettingsGetCommand.Flags().BoolP("help", "h", false, "Help for "+clusterSettingsGetCommandName)
+	clusterSettingsCommand.AddCommand(clusterSettingsGetCommand)
+	clusterSettingsSetCommand.Flags().BoolP(transientFlagName, "", false, "Set transient settings, which are lost on full cluster restart")
+	clusterSettingsSetCommand.Flags().BoolP("help", "h", false, "Help for "+clusterSettingsSetCommandName)
+	clusterSettingsCommand.AddCommand(clusterSettingsSetCommand)
+}
+
+//printSettings prints settings, default format is table
+func printSettings(settings []entity.SettingOutput) error {
+	return printOutput(settings, func() error {
+		if len(settings) < 1 {
+			fmt.Println("no settings found")
+			return nil
+		}
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, settings)
+	})
+}
+
+//getClusterSettings prints cluster settings
+func getClusterSettings(request entity.SettingsRequest) error {
+	h, err := getClusterHandler()
+	if err != nil {
+		return err
+	}
+	settings, err := handler.GetSettings(h, request)
+	if err != nil {
+		return err
+	}
+	return printSettings(settings)
+}
+
+//setClusterSettings updates cluster settings and prints settings which were set
+func setClusterSettings(values []string, transient bool) error {
+	h, err := getClusterHandler()
+	if err != nil {
+		return err
+	}
+	settings, err := handler.PutSettings(h, values, transient)
+	if err != nil {
+		return err
+	}
+	return printSettings(settings)
+}
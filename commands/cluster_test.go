@@ -0,0 +1,48 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	entity "opensearch-cli/entity/cluster"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHealthExitCode(t *testing.T) {
+	assert.EqualValues(t, 0, getHealthExitCode(&entity.Health{Status: "green"}, ""))
+	assert.EqualValues(t, 1, getHealthExitCode(&entity.Health{Status: "YELLOW"}, ""))
+	assert.EqualValues(t, 2, getHealthExitCode(&entity.Health{Status: "red"}, ""))
+	assert.EqualValues(t, 3, getHealthExitCode(&entity.Health{}, ""))
+	assert.EqualValues(t, 0, getHealthExitCode(&entity.Health{Status: "yellow"}, "yellow"))
+	assert.EqualValues(t, 4, getHealthExitCode(&entity.Health{Status: "red", TimedOut: true}, "yellow"))
+}
+
+func TestClusterCommands(t *testing.T) {
+	t.Run("allocation explain request from flags", func(t *testing.T) {
+		cmd := clusterAllocationExplainCommand
+		assert.NoError(t, cmd.ParseFlags([]string{"-i", "logs-1", "-s", "2", "--primary"}))
+		assert.EqualValues(t, entity.AllocationExplainRequest{
+			Index:   "logs-1",
+			Shard:   2,
+			Primary: true,
+		}, getAllocationExplainRequest(cmd))
+	})
+	t.Run("settings are required", func(t *testing.T) {
+		_, err := executeCommand(GetRoot(), clusterCommandName, clusterSettingsCommandName, clusterSettingsSetCommandName)
+		assert.Error(t, err)
+	})
+	t.Run("health takes no arguments", func(t *testing.T) {
+		_, err := executeCommand(GetRoot(), clusterCommandName, clusterHealthCommandName, "green")
+		assert.Error(t, err)
+	})
+}
@@ -84,8 +84,10 @@ func CurlActionExecute(input entity.CurlCommandRequest) error {
 	}
 	response, err := handler.Curl(commandHandler, input)
 	if err == nil {
-		fmt.Println(string(response))
-		return nil
+		return printOutput(response, func() error {
+			fmt.Println(string(response))
+			return nil
+		})
 	}
 	if requestError, ok := err.(*entity.RequestError); ok {
 		fmt.Println(requestError.GetResponse())
@@ -0,0 +1,61 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"opensearch-cli/client"
+	controller "opensearch-cli/controller/index"
+	gateway "opensearch-cli/gateway/index"
+	handler "opensearch-cli/handler/index"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	indexCommandName = "index"
+)
+
+//indexCommand is base command for index operations
+var indexCommand = &cobra.Command{
+	Use:   indexCommandName,
+	Short: "Manage indices",
+	Long:  "Use the index commands to list, create, delete, open and close indices, inspect mappings, update settings, manage aliases and export documents.",
+}
+
+func init() {
+	indexCommand.Flags().BoolP("help", "h", false, "Help for "+indexCommandName)
+	GetRoot().AddCommand(indexCommand)
+}
+
+//GetIndexCommand returns index base command
+func GetIndexCommand() *cobra.Command {
+	return indexCommand
+}
+
+//getIndexHandler returns handler by wiring the dependency manually
+func getIndexHandler() (*handler.Handler, error) {
+	c, err := client.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := GetProfile()
+	if err != nil {
+		return nil, err
+	}
+	g, err := gateway.New(c, profile)
+	if err != nil {
+		return nil, err
+	}
+	ctr := controller.New(os.Stdin, g)
+	return handler.New(ctr), nil
+}
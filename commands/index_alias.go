@@ -0,0 +1,138 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/index"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	indexAliasCommandName       = "alias"
+	indexAliasListCommandName   = "list"
+	indexAliasAddCommandName    = "add"
+	indexAliasRemoveCommandName = "remove"
+	indexWriteIndexFlagName     = "write-index"
+)
+
+//indexAliasCommand is base command for alias operations
+var indexAliasCommand = &cobra.Command{
+	Use:   indexAliasCommandName,
+	Short: "Manage aliases of indices",
+	Long:  "Use the alias commands to list aliases and to add or remove an alias of an index.",
+}
+
+//indexAliasListCommand lists aliases of indices
+var indexAliasListCommand = &cobra.Command{
+	Use:   indexAliasListCommandName + " [index]" + " [flags] ",
+	Short: "List aliases of indices",
+	Long:  "List aliases of indices matched by index name or pattern, or aliases of every index if no index is given.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		index := ""
+		if len(args) > 0 {
+			index = args[0]
+		}
+		err := listAliases(index)
+		DisplayError(err, indexAliasListCommandName)
+	},
+}
+
+//indexAliasAddCommand adds alias to index
+var indexAliasAddCommand = &cobra.Command{
+	Use:   indexAliasAddCommandName + " index alias" + " [flags] ",
+	Short: "Add an alias to an index",
+	Long:  "Add an alias to an index. Use `--write-index` to make the index the write index of the alias.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		isWriteIndex, _ := cmd.Flags().GetBool(indexWriteIndexFlagName)
+		err := addAlias(args[0], args[1], isWriteIndex)
+		DisplayError(err, indexAliasAddCommandName)
+	},
+}
+
+//indexAliasRemoveCommand removes alias from index
+var indexAliasRemoveCommand = &cobra.Command{
+	Use:   indexAliasRemoveCommandName + " index alias" + " [flags] ",
+	Short: "Remove an alias from an index",
+	Long:  "Remove an alias from an index, the index itself is not affected.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := removeAlias(args[0], args[1])
+		DisplayError(err, indexAliasRemoveCommandName)
+	},
+}
+
+func init() {
+	indexAliasCommand.Flags().BoolP("help", "h", false, "Help for "+indexAliasCommandName)
+	GetIndexCommand().AddCommand(indexAliasCommand)
+	indexAliasListCommand.Flags().BoolP("help", "h", false, "Help for "+indexAliasListCommandName)
+	indexAliasCommand.AddCommand(indexAliasListCommand)
+	indexAliasAddCommand.Flags().BoolP(indexWriteIndexFlagName, "", false, "Make the index the write index of the alias")
+	indexAliasAddCommand.Flags().BoolP("help", "h", false, "Help for "+indexAliasAddCommandName)
+	indexAliasCommand.AddCommand(indexAliasAddCommand)
+	indexAliasRemoveCommand.Flags().BoolP("help", "h", false, "Help for "+indexAliasRemoveCommandName)
+	indexAliasCommand.AddCommand(indexAliasRemoveCommand)
+}
+
+//listAliases prints aliases of indices, default format is table
+func listAliases(index string) error {
+	h, err := getIndexHandler()
+	if err != nil {
+		return err
+	}
+	aliases, err := handler.ListAliases(h, index)
+	if err != nil {
+		return err
+	}
+	return printOutput(aliases, func() error {
+		if len(aliases) < 1 {
+			fmt.Println("no aliases found")
+			return nil
+		}
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, aliases)
+	})
+}
+
+//addAlias adds alias to index
+func addAlias(index string, alias string, isWriteIndex bool) error {
+	h, err := getIndexHandler()
+	if err != nil {
+		return err
+	}
+	if err = handler.AddAlias(h, index, alias, isWriteIndex); err != nil {
+		return err
+	}
+	fmt.Printf("successfully added alias %s to index %s\n", alias, index)
+	return nil
+}
+
+//removeAlias removes alias from index
+func removeAlias(index string, alias string) error {
+	h, err := getIndexHandler()
+	if err != nil {
+		return err
+	}
+	if err = handler.RemoveAlias(h, index, alias); err != nil {
+		return err
+	}
+	fmt.Printf("successfully removed alias %s from index %s\n", alias, index)
+	return nil
+}
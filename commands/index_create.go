@@ -0,0 +1,90 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	handler "opensearch-cli/handler/index"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	indexCreateCommandName = "create"
+	indexDeleteCommandName = "delete"
+	indexFileFlagName      = "file"
+	indexYesFlagName       = "yes"
+)
+
+//indexCreateCommand creates index from settings and mappings file
+var indexCreateCommand = &cobra.Command{
+	Use:   indexCreateCommandName + " index" + " [flags] ",
+	Short: "Create an index from a settings and mappings file",
+	Long: "Create an index with settings, mappings and aliases from a JSON file given by `--file`, " +
+		"which has the same format as the body of the create index API. The index is created with default settings if no file is given.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		fileName, _ := cmd.Flags().GetString(indexFileFlagName)
+		err := createIndex(args[0], fileName)
+		DisplayError(err, indexCreateCommandName)
+	},
+}
+
+//indexDeleteCommand deletes indices after user confirmed deletion
+var indexDeleteCommand = &cobra.Command{
+	Use:   indexDeleteCommandName + " index ..." + " [flags] ",
+	Short: "Delete indices",
+	Long: "Delete indices after confirmation. Wrap patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
+		"Indices matched by every pattern are listed before confirmation, use `--yes` to delete indices without confirmation.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool(indexYesFlagName)
+		err := deleteIndices(args, !yes)
+		DisplayError(err, indexDeleteCommandName)
+	},
+}
+
+func init() {
+	indexCreateCommand.Flags().StringP(indexFileFlagName, "f", "", "JSON file with settings, mappings and aliases of the index")
+	indexCreateCommand.Flags().BoolP("help", "h", false, "Help for "+indexCreateCommandName)
+	GetIndexCommand().AddCommand(indexCreateCommand)
+	indexDeleteCommand.Flags().BoolP(indexYesFlagName, "y", false, "Delete indices without confirmation")
+	indexDeleteCommand.Flags().BoolP("help", "h", false, "Help for "+indexDeleteCommandName)
+	GetIndexCommand().AddCommand(indexDeleteCommand)
+}
+
+//createIndex creates index from file
+func createIndex(index string, fileName string) error {
+	h, err := getIndexHandler()
+	if err != nil {
+		return err
+	}
+	if err = handler.CreateIndex(h, index, fileName); err != nil {
+		return err
+	}
+	fmt.Printf("successfully created index %s\n", index)
+	return nil
+}
+
+//deleteIndices deletes every index, if interactive is enabled, deletion of every index is confirmed by user
+func deleteIndices(indices []string, interactive bool) error {
+	h, err := getIndexHandler()
+	if err != nil {
+		return err
+	}
+	for _, index := range indices {
+		if err = handler.DeleteIndex(h, index, interactive); err != nil {
+			return err
+		}
+	}
+	return nil
+}
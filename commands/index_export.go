@@ -0,0 +1,111 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/platform"
+	handler "opensearch-cli/handler/platform"
+	mapper "opensearch-cli/mapper/platform"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	indexExportCommandName   = "export"
+	exportQueryFlagName      = "query"
+	exportOutFlagName        = "out"
+	exportSlicesFlagName     = "slices"
+	exportSizeFlagName       = "size"
+	exportRoutingFlagName    = "routing"
+	exportCheckpointFlagName = "checkpoint"
+	exportTiebreakerFlagName = "tiebreaker"
+	defaultExportSlices      = 1
+	defaultExportPageSize    = 1000
+)
+
+//indexExportCommand exports documents of index into file
+var indexExportCommand = &cobra.Command{
+	Use:   indexExportCommandName + " index" + " [flags] ",
+	Short: "Export documents of an index into a file in bulk format",
+	Long: "Export every document of an index, or documents matched by `--query`, into a file using point in time with search_after. " +
+		"Output contains bulk API action and source lines with _id and optionally routing, but without index name, " +
+		"so that it can be loaded into any index with `opensearch-cli bulk --index <index> <file>`. Output is compressed if file name ends with .gz.\n" +
+		"Use `--slices` to export slices of the index in parallel. Use `--checkpoint` to save progress after every page, " +
+		"export is resumed from the checkpoint file if it exists and the file is deleted once export is completed.\n" +
+		"Resumed export continues in the point in time of the interrupted export while it is alive, which is 10 minutes after its last page. " +
+		"Otherwise export sorted by `_shard_doc` cannot be resumed, and export sorted by `--tiebreaker` field continues in a new point in time " +
+		"without snapshot guarantee: documents indexed, updated or deleted in between may be exported twice or not at all, a warning is printed then. " +
+		"Tiebreaker must be a field with doc values which is unique for every document.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := exportIndex(cmd, args[0])
+		DisplayError(err, indexExportCommandName)
+	},
+}
+
+func init() {
+	indexExportCommand.Flags().StringP(exportQueryFlagName, "q", "", "Query as JSON or file name with prefix '@', either search body or query clause")
+	indexExportCommand.Flags().StringP(exportOutFlagName, "", "", "Output file, compressed with gzip if name ends with .gz")
+	_ = indexExportCommand.MarkFlagRequired(exportOutFlagName)
+	indexExportCommand.Flags().IntP(exportSlicesFlagName, "", defaultExportSlices, "Number of slices exported in parallel")
+	indexExportCommand.Flags().IntP(exportSizeFlagName, "", defaultExportPageSize, "Number of documents per page")
+	indexExportCommand.Flags().BoolP(exportRoutingFlagName, "", false, "Include custom routing of documents")
+	indexExportCommand.Flags().StringP(exportCheckpointFlagName, "", "", "File to save progress to and resume export from")
+	indexExportCommand.Flags().StringP(exportTiebreakerFlagName, "", mapper.TiebreakerSortField, "Unique field with doc values to sort documents by, after sort of query")
+	indexExportCommand.Flags().BoolP("help", "h", false, "Help for "+indexExportCommandName)
+	GetIndexCommand().AddCommand(indexExportCommand)
+}
+
+//getExportRequest reads export request from flags
+func getExportRequest(cmd *cobra.Command, index string) entity.ExportRequest {
+	request := entity.ExportRequest{
+		Index: index,
+	}
+	request.Query, _ = cmd.Flags().GetString(exportQueryFlagName)
+	request.Slices, _ = cmd.Flags().GetInt(exportSlicesFlagName)
+	request.PageSize, _ = cmd.Flags().GetInt(exportSizeFlagName)
+	request.Routing, _ = cmd.Flags().GetBool(exportRoutingFlagName)
+	request.Tiebreaker, _ = cmd.Flags().GetString(exportTiebreakerFlagName)
+	return request
+}
+
+//exportIndex exports documents of index and prints number of exported documents
+func exportIndex(cmd *cobra.Command, index string) error {
+	outFile, _ := cmd.Flags().GetString(exportOutFlagName)
+	checkpointFile, _ := cmd.Flags().GetString(exportCheckpointFlagName)
+	h, err := getCurlHandler()
+	if err != nil {
+		return err
+	}
+	result, exportErr := handler.ExportIndex(h, getExportRequest(cmd, index), outFile, checkpointFile)
+	if result == nil {
+		return exportErr
+	}
+	if !result.Consistent {
+		//warning is printed on stderr so that it does not corrupt output requested by --output flag
+		fmt.Fprintf(os.Stderr, "warning: point in time of interrupted export expired, export was resumed in new point in time "+
+			"and documents changed in between may be exported twice or missing\n")
+	}
+	err = printOutput(result, func() error {
+		if result.Resumed {
+			fmt.Printf("resumed export from %s\n", checkpointFile)
+		}
+		fmt.Printf("exported %d document(s) from %s to %s\n", result.Exported, index, outFile)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return exportErr
+}
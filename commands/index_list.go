@@ -0,0 +1,87 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/index"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/index"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	indexListCommandName = "list"
+	indexHealthFlagName  = "health"
+	indexStatusFlagName  = "status"
+	indexSortFlagName    = "sort"
+)
+
+//indexListCommand lists indices using cat indices API
+var indexListCommand = &cobra.Command{
+	Use:   indexListCommandName + " [pattern]" + " [flags] ",
+	Short: "List indices with health, status, document count and size",
+	Long: "List indices matched by pattern, or every index if no pattern is given, using the cat indices API. Sizes are in bytes.\n" +
+		"Use `--health` and `--status` to filter indices and `--sort` to sort them by column, e.g. `--sort store.size:desc`. " +
+		"Sizes and counts are sorted as numbers, indices are sorted by name by default.",
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		request := getIndexListRequest(cmd, args)
+		err := listIndices(request)
+		DisplayError(err, indexListCommandName)
+	},
+}
+
+func init() {
+	indexListCommand.Flags().StringP(indexHealthFlagName, "", "", "Show only indices with health: green, yellow or red")
+	indexListCommand.Flags().StringP(indexStatusFlagName, "", "", "Show only indices with status: open or close")
+	indexListCommand.Flags().StringP(indexSortFlagName, "", "", "Column to sort by, optionally followed by :asc or :desc")
+	indexListCommand.Flags().BoolP("help", "h", false, "Help for "+indexListCommandName)
+	GetIndexCommand().AddCommand(indexListCommand)
+}
+
+//getIndexListRequest reads list request from pattern and flags
+func getIndexListRequest(cmd *cobra.Command, args []string) entity.ListRequest {
+	request := entity.ListRequest{}
+	if len(args) > 0 {
+		request.Pattern = args[0]
+	}
+	request.Health, _ = cmd.Flags().GetString(indexHealthFlagName)
+	request.Status, _ = cmd.Flags().GetString(indexStatusFlagName)
+	request.Sort, _ = cmd.Flags().GetString(indexSortFlagName)
+	return request
+}
+
+//listIndices lists indices and prints them, default format is table
+func listIndices(request entity.ListRequest) error {
+	h, err := getIndexHandler()
+	if err != nil {
+		return err
+	}
+	indices, err := handler.ListIndices(h, request)
+	if err != nil {
+		return err
+	}
+	return printOutput(indices, func() error {
+		if len(indices) < 1 {
+			fmt.Println("no indices found")
+			return nil
+		}
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, indices)
+	})
+}
@@ -0,0 +1,70 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	handler "opensearch-cli/handler/index"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	indexOpenCommandName  = "open"
+	indexCloseCommandName = "close"
+)
+
+//indexOpenCommand opens closed indices
+var indexOpenCommand = &cobra.Command{
+	Use:   indexOpenCommandName + " index ..." + " [flags] ",
+	Short: "Open closed indices",
+	Long:  "Open closed indices so that they can be searched and written again.",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := changeIndexState(handler.OpenIndex, args, "opened")
+		DisplayError(err, indexOpenCommandName)
+	},
+}
+
+//indexCloseCommand closes indices
+var indexCloseCommand = &cobra.Command{
+	Use:   indexCloseCommandName + " index ..." + " [flags] ",
+	Short: "Close indices",
+	Long:  "Close indices to release their resources, closed indices cannot be searched or written until they are opened.",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := changeIndexState(handler.CloseIndex, args, "closed")
+		DisplayError(err, indexCloseCommandName)
+	},
+}
+
+func init() {
+	indexOpenCommand.Flags().BoolP("help", "h", false, "Help for "+indexOpenCommandName)
+	GetIndexCommand().AddCommand(indexOpenCommand)
+	indexCloseCommand.Flags().BoolP("help", "h", false, "Help for "+indexCloseCommandName)
+	GetIndexCommand().AddCommand(indexCloseCommand)
+}
+
+//changeIndexState opens or closes every index by calling method provided
+func changeIndexState(f func(*handler.Handler, string) error, indices []string, state string) error {
+	h, err := getIndexHandler()
+	if err != nil {
+		return err
+	}
+	for _, index := range indices {
+		if err = f(h, index); err != nil {
+			return err
+		}
+		fmt.Printf("successfully %s index %s\n", state, index)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	handler "opensearch-cli/handler/index"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	indexGetMappingCommandName  = "get-mapping"
+	indexPutSettingsCommandName = "put-settings"
+	indexSettingsFlagName       = "settings"
+)
+
+//indexGetMappingCommand prints mappings of indices
+var indexGetMappingCommand = &cobra.Command{
+	Use:   indexGetMappingCommandName + " index" + " [flags] ",
+	Short: "Get mappings of indices",
+	Long:  "Get mappings of indices matched by index name or pattern, mappings are printed as returned by the get mapping API.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getMapping(args[0])
+		DisplayError(err, indexGetMappingCommandName)
+	},
+}
+
+//indexPutSettingsCommand updates dynamic settings of indices
+var indexPutSettingsCommand = &cobra.Command{
+	Use:   indexPutSettingsCommandName + " index" + " [flags] ",
+	Short: "Update dynamic settings of indices",
+	Long: "Update dynamic settings of indices matched by index name or pattern. " +
+		"Settings are given by `--settings` either as JSON, e.g. '{\"index\":{\"number_of_replicas\":2}}', or as file name with prefix '@'.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, _ := cmd.Flags().GetString(indexSettingsFlagName)
+		err := putSettings(args[0], settings)
+		DisplayError(err, indexPutSettingsCommandName)
+	},
+}
+
+func init() {
+	indexGetMappingCommand.Flags().BoolP("help", "h", false, "Help for "+indexGetMappingCommandName)
+	GetIndexCommand().AddCommand(indexGetMappingCommand)
+	indexPutSettingsCommand.Flags().StringP(indexSettingsFlagName, "s", "", "Settings as JSON or file name with prefix '@'")
+	_ = indexPutSettingsCommand.MarkFlagRequired(indexSettingsFlagName)
+	indexPutSettingsCommand.Flags().BoolP("help", "h", false, "Help for "+indexPutSettingsCommandName)
+	GetIndexCommand().AddCommand(indexPutSettingsCommand)
+}
+
+//getMapping prints mappings of indices
+func getMapping(index string) error {
+	h, err := getIndexHandler()
+	if err != nil {
+		return err
+	}
+	mapping, err := handler.GetMapping(h, index)
+	if err != nil {
+		return err
+	}
+	return printOutput(mapping, func() error {
+		formattedOutput, err := json.MarshalIndent(mapping, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(formattedOutput))
+		return nil
+	})
+}
+
+//putSettings updates settings of indices
+func putSettings(index string, settings string) error {
+	h, err := getIndexHandler()
+	if err != nil {
+		return err
+	}
+	if err = handler.PutSettings(h, index, settings); err != nil {
+		return err
+	}
+	fmt.Printf("successfully updated settings of %s\n", index)
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	indexentity "opensearch-cli/entity/index"
+	entity "opensearch-cli/entity/platform"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexExportCommand(t *testing.T) {
+	t.Run("out is required", func(t *testing.T) {
+		_, err := executeCommand(GetRoot(), indexCommandName, indexExportCommandName, "index1")
+		assert.Error(t, err)
+	})
+	t.Run("export request from flags", func(t *testing.T) {
+		cmd := indexExportCommand
+		assert.NoError(t, cmd.ParseFlags([]string{"-q", "@q.json", "--slices", "4", "--routing"}))
+		assert.EqualValues(t, entity.ExportRequest{
+			Index:      "index1",
+			Query:      "@q.json",
+			Slices:     4,
+			PageSize:   defaultExportPageSize,
+			Routing:    true,
+			Tiebreaker: "_shard_doc",
+		}, getExportRequest(cmd, "index1"))
+	})
+}
+
+func TestIndexListCommand(t *testing.T) {
+	t.Run("list request from flags", func(t *testing.T) {
+		cmd := indexListCommand
+		assert.NoError(t, cmd.ParseFlags([]string{"--health", "green", "--sort", "docs.count:desc"}))
+		assert.EqualValues(t, indexentity.ListRequest{
+			Pattern: "logs-*",
+			Health:  "green",
+			Sort:    "docs.count:desc",
+		}, getIndexListRequest(cmd, []string{"logs-*"}))
+	})
+	t.Run("at most one pattern", func(t *testing.T) {
+		_, err := executeCommand(GetRoot(), indexCommandName, indexListCommandName, "logs-*", "metrics-*")
+		assert.Error(t, err)
+	})
+}
+
+func TestIndexAliasCommand(t *testing.T) {
+	t.Run("alias and index are required", func(t *testing.T) {
+		_, err := executeCommand(GetRoot(), indexCommandName, indexAliasCommandName, indexAliasAddCommandName, "logs-1")
+		assert.Error(t, err)
+	})
+	t.Run("settings are required", func(t *testing.T) {
+		_, err := executeCommand(GetRoot(), indexCommandName, indexPutSettingsCommandName, "logs-1")
+		assert.Error(t, err)
+	})
+}
@@ -15,32 +15,43 @@ import (
 	"fmt"
 	"opensearch-cli/client"
 	ctrl "opensearch-cli/controller/knn"
+	platformctrl "opensearch-cli/controller/platform"
+	entity "opensearch-cli/entity/knn"
+	"opensearch-cli/formatter"
 	gateway "opensearch-cli/gateway/knn"
+	platformgateway "opensearch-cli/gateway/platform"
 	handler "opensearch-cli/handler/knn"
+	mapper "opensearch-cli/mapper/knn"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 const (
-	knnCommandName        = "knn"
-	knnStatsCommandName   = "stats"
-	knnWarmupCommandName  = "warmup"
-	knnStatsNodesFlagName = "nodes"
-	knnStatsNamesFlagName = "stat-names"
+	knnCommandName           = "knn"
+	knnStatsCommandName      = "stats"
+	knnWarmupCommandName     = "warmup"
+	knnClearCacheCommandName = "clear-cache"
+	knnStatsNodesFlagName    = "nodes"
+	knnStatsNamesFlagName    = "stat-names"
+	knnStatsWatchFlagName    = "watch"
 )
 
 //knnCommand is base command for k-NN plugin.
 var knnCommand = &cobra.Command{
 	Use:   knnCommandName,
 	Short: "Manage the k-NN plugin",
-	Long:  "Use the k-NN commands to perform operations like stats, warmup.",
+	Long:  "Use the k-NN commands to perform operations like stats, warmup, clear cache, model training.",
 }
 
 //knnStatsCommandName provide stats command for k-NN plugin.
 var knnStatsCommand = &cobra.Command{
 	Use:   knnStatsCommandName,
 	Short: "Display current status of the k-NN Plugin",
-	Long:  "Display current status of the k-NN Plugin.",
+	Long: "Display current status of the k-NN Plugin as one row per node.\n" +
+		"Use the `--watch` flag to poll stats at given interval and display difference of counters like hit, miss and " +
+		"eviction count between polls, until interrupted.",
 	Run: func(cmd *cobra.Command, args []string) {
 		h, err := GetKNNHandler()
 		if err != nil {
@@ -57,7 +68,19 @@ var knnStatsCommand = &cobra.Command{
 			DisplayError(err, knnStatsCommandName)
 			return
 		}
-		err = getStatistics(h, nodes, names)
+		watch, err := cmd.Flags().GetDuration(knnStatsWatchFlagName)
+		if err != nil {
+			DisplayError(err, knnStatsCommandName)
+			return
+		}
+		fetch := func() (*entity.Stats, error) {
+			return handler.GetStatistics(h, nodes, names)
+		}
+		if watch > 0 {
+			err = watchStatistics(fetch, watch, 0)
+		} else {
+			err = getStatistics(fetch)
+		}
 		DisplayError(err, knnStatsCommandName)
 	},
 }
@@ -82,6 +105,24 @@ var knnWarmupCommand = &cobra.Command{
 	},
 }
 
+//knnClearCacheCommand clears cache of indices
+var knnClearCacheCommand = &cobra.Command{
+	Use:   knnClearCacheCommandName + " index ..." + " [flags] ",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Clear cache for given indices",
+	Long: "Clear cache command evicts all graphs for all of the shards (primaries and replicas) " +
+		"for given indices from native memory.\nUse `opensearch-cli knn stats` command to verify whether indices are successfully evicted from memory.",
+	Run: func(cmd *cobra.Command, args []string) {
+		h, err := GetKNNHandler()
+		if err != nil {
+			DisplayError(err, knnClearCacheCommandName)
+			return
+		}
+		err = clearCache(h, args)
+		DisplayError(err, knnClearCacheCommandName)
+	},
+}
+
 func GetKNNCommand() *cobra.Command {
 	return knnCommand
 }
@@ -94,6 +135,10 @@ func GetKNNWarmupCommand() *cobra.Command {
 	return knnWarmupCommand
 }
 
+func GetKNNClearCacheCommand() *cobra.Command {
+	return knnClearCacheCommand
+}
+
 func init() {
 	//knn base command
 	knnCommand.Flags().BoolP("help", "h", false, "Help for k-NN plugin")
@@ -102,21 +147,63 @@ func init() {
 	knnStatsCommand.Flags().BoolP("help", "h", false, "Help for k-NN plugin stats command")
 	knnStatsCommand.Flags().StringP(knnStatsNodesFlagName, "n", "", "Input is list of node Ids, separated by ','")
 	knnStatsCommand.Flags().StringP(knnStatsNamesFlagName, "s", "", "Input is list of stats names, separated by ','")
+	knnStatsCommand.Flags().DurationP(knnStatsWatchFlagName, "w", 0, "Poll stats at given interval, e.g. 10s, and display deltas between polls")
 	knnCommand.AddCommand(knnStatsCommand)
 	//knn warmup command
 	knnWarmupCommand.Flags().BoolP("help", "h", false, "Help for k-NN plugin warmup command")
 	knnCommand.AddCommand(knnWarmupCommand)
+	//knn clear cache command
+	knnClearCacheCommand.Flags().BoolP("help", "h", false, "Help for k-NN plugin clear cache command")
+	knnCommand.AddCommand(knnClearCacheCommand)
 }
 
-func getStatistics(h *handler.Handler, nodes string, names string) error {
-	stats, err := handler.GetStatistics(h, nodes, names)
+func getStatistics(fetch func() (*entity.Stats, error)) error {
+	stats, err := fetch()
 	if err != nil {
 		return err
 	}
-	fmt.Println(string(stats))
+	return printOutput(stats, func() error {
+		return printNodeStats(stats, mapper.MapToNodeStatsOutput(*stats))
+	})
+}
+
+//watchStatistics polls stats every interval and prints counters as difference from previous poll,
+//polls limits number of polls, 0 polls until interrupted
+func watchStatistics(fetch func() (*entity.Stats, error), interval time.Duration, polls int) error {
+	var previous *entity.Stats
+	for i := 0; polls < 1 || i < polls; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		stats, err := fetch()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", time.Now().Format(time.RFC3339))
+		if err = printNodeStats(stats, mapper.MapToNodeStatsDelta(previous, *stats)); err != nil {
+			return err
+		}
+		fmt.Println()
+		previous = stats
+	}
 	return nil
 }
 
+func printNodeStats(stats *entity.Stats, rows []*entity.NodeStatsOutput) error {
+	if stats.CircuitBreakerTriggered != nil {
+		fmt.Printf("cluster: %s, circuit breaker triggered: %t\n", stats.ClusterName, *stats.CircuitBreakerTriggered)
+	}
+	if len(rows) < 1 {
+		fmt.Println("no nodes found")
+		return nil
+	}
+	f, err := formatter.New(formatter.Table)
+	if err != nil {
+		return err
+	}
+	return f.Format(os.Stdout, rows)
+}
+
 func warmupIndices(h *handler.Handler, index []string) error {
 	shards, err := handler.WarmupIndices(h, index)
 	if err != nil {
@@ -129,6 +216,22 @@ func warmupIndices(h *handler.Handler, index []string) error {
 	return nil
 }
 
+func clearCache(h *handler.Handler, index []string) error {
+	failed := 0
+	for _, i := range index {
+		shards, err := handler.ClearCache(h, []string{i})
+		if err != nil {
+			return err
+		}
+		failed += shards.Failed
+		fmt.Printf("%s: cleared cache of %d/%d shards\n", i, shards.Successful, shards.Total)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d shards were failed to clear cache", failed)
+	}
+	return nil
+}
+
 //GetKNNHandler returns handler by wiring the dependency manually
 func GetKNNHandler() (*handler.Handler, error) {
 	c, err := client.New(nil)
@@ -143,6 +246,11 @@ func GetKNNHandler() (*handler.Handler, error) {
 	if err != nil {
 		return nil, err
 	}
-	ctr := ctrl.New(g)
+	esg, err := platformgateway.New(c, profile)
+	if err != nil {
+		return nil, err
+	}
+	esc := platformctrl.New(esg)
+	ctr := ctrl.New(esc, g)
 	return handler.New(ctr), nil
 }
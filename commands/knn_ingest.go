@@ -0,0 +1,110 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/knn"
+	handler "opensearch-cli/handler/knn"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	knnIngestCommandName          = "ingest"
+	knnIngestIndexFlagName        = "index"
+	knnIngestFieldFlagName        = "field"
+	knnIngestFormatFlagName       = "format"
+	knnIngestVectorColumnFlagName = "vector-column"
+	knnIngestIDColumnFlagName     = "id-column"
+	knnIngestBatchSizeFlagName    = "batch-size"
+	knnIngestConcurrencyFlagName  = "concurrency"
+	knnIngestRetriesFlagName      = "retries"
+	knnIngestCreateIndexFlagName  = "create-index"
+	defaultIngestBatchSize        = 500
+	defaultIngestConcurrency      = 2
+	defaultIngestRetries          = 3
+)
+
+//knnIngestCommand indexes vectors from file
+var knnIngestCommand = &cobra.Command{
+	Use:   knnIngestCommandName + " file-path" + " [flags] ",
+	Args:  cobra.ExactArgs(1),
+	Short: "Index vectors from CSV, JSONL, npy or fvecs file",
+	Long: "Index vectors and optional metadata from file into vector field of an index using the bulk API.\n" +
+		"CSV files must have a header, vector column contains a JSON array or comma separated values, every other column is indexed as metadata. " +
+		"JSONL files have one JSON object per line, vector key contains an array and every other key is indexed as metadata. " +
+		"Files with .npy extension must contain two dimensional float32 or float64 array, .fvecs files contain vectors only.\n" +
+		"Items rejected due to throttling are retried. Use `--create-index` to create the index with knn_vector mapping if it doesn't exist.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := ingestVectors(cmd, args[0])
+		DisplayError(err, knnIngestCommandName)
+	},
+}
+
+func init() {
+	knnIngestCommand.Flags().StringP(knnIngestIndexFlagName, "i", "", "Name of the index")
+	_ = knnIngestCommand.MarkFlagRequired(knnIngestIndexFlagName)
+	knnIngestCommand.Flags().StringP(knnIngestFieldFlagName, "f", "", "Name of the knn_vector field")
+	_ = knnIngestCommand.MarkFlagRequired(knnIngestFieldFlagName)
+	knnIngestCommand.Flags().StringP(knnIngestFormatFlagName, "", entity.FileFormatAuto, "Format of file: auto, csv, jsonl, npy or fvecs, auto detects format from file extension")
+	knnIngestCommand.Flags().StringP(knnIngestVectorColumnFlagName, "", "", "Column or key of vector in CSV and JSONL files, default is name of the field")
+	knnIngestCommand.Flags().StringP(knnIngestIDColumnFlagName, "", "", "Column or key used as document ID in CSV and JSONL files")
+	knnIngestCommand.Flags().IntP(knnIngestBatchSizeFlagName, "b", defaultIngestBatchSize, "Number of documents per bulk request")
+	knnIngestCommand.Flags().IntP(knnIngestConcurrencyFlagName, "", defaultIngestConcurrency, "Number of bulk requests sent in parallel")
+	knnIngestCommand.Flags().IntP(knnIngestRetriesFlagName, "", defaultIngestRetries, "Number of times failed items are retried")
+	knnIngestCommand.Flags().BoolP(knnIngestCreateIndexFlagName, "", false, "Create index with knn_vector mapping if it doesn't exist")
+	knnIngestCommand.Flags().BoolP("help", "h", false, "Help for k-NN plugin ingest command")
+	GetKNNCommand().AddCommand(knnIngestCommand)
+}
+
+//ingestVectors indexes vectors from file and prints number of indexed and failed documents
+func ingestVectors(cmd *cobra.Command, fileName string) error {
+	request := entity.IngestRequest{}
+	request.Index, _ = cmd.Flags().GetString(knnIngestIndexFlagName)
+	request.Field, _ = cmd.Flags().GetString(knnIngestFieldFlagName)
+	request.Format, _ = cmd.Flags().GetString(knnIngestFormatFlagName)
+	request.VectorColumn, _ = cmd.Flags().GetString(knnIngestVectorColumnFlagName)
+	request.IDColumn, _ = cmd.Flags().GetString(knnIngestIDColumnFlagName)
+	request.BatchSize, _ = cmd.Flags().GetInt(knnIngestBatchSizeFlagName)
+	request.Concurrency, _ = cmd.Flags().GetInt(knnIngestConcurrencyFlagName)
+	request.Retries, _ = cmd.Flags().GetInt(knnIngestRetriesFlagName)
+	request.CreateIndex, _ = cmd.Flags().GetBool(knnIngestCreateIndexFlagName)
+	h, err := GetKNNHandler()
+	if err != nil {
+		return err
+	}
+	result, ingestErr := handler.IngestVectors(h, fileName, request)
+	if result == nil {
+		return ingestErr
+	}
+	err = printOutput(result, func() error {
+		fmt.Printf("indexed %d document(s) into %s\n", result.Indexed, request.Index)
+		if result.Failed > 0 {
+			fmt.Printf("failed to index %d document(s)\n", result.Failed)
+			for _, reason := range result.Errors {
+				fmt.Println(reason)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if ingestErr != nil {
+		return ingestErr
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("failed to index %d document(s)", result.Failed)
+	}
+	return nil
+}
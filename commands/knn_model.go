@@ -0,0 +1,202 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	entity "opensearch-cli/entity/knn"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/knn"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	knnModelCommandName       = "model"
+	knnModelTrainCommandName  = "train"
+	knnModelGetCommandName    = "get"
+	knnModelListCommandName   = "list"
+	knnModelDeleteCommandName = "delete"
+	knnModelIDFlagName        = "model-id"
+	knnModelWaitFlagName      = "wait"
+	knnModelIntervalFlagName  = "interval"
+	knnModelTimeoutFlagName   = "timeout"
+	defaultModelPollInterval  = 5 * time.Second
+	defaultModelWaitTimeout   = time.Hour
+)
+
+//knnModelCommand is base command for k-NN models
+var knnModelCommand = &cobra.Command{
+	Use:   knnModelCommandName,
+	Short: "Manage k-NN models",
+	Long:  "Use the model commands to train, get, list and delete models used by methods like IVF and PQ.",
+}
+
+//knnModelTrainCommand trains model based on request from file
+var knnModelTrainCommand = &cobra.Command{
+	Use:   knnModelTrainCommandName + " json-file-path" + " [flags] ",
+	Args:  cobra.ExactArgs(1),
+	Short: "Train a model based on JSON file",
+	Long: "Train a model based on JSON file with fields `training_index`, `training_field`, `dimension`, `method` and optional `description`, " +
+		"`max_training_vector_count` and `search_size` as accepted by train API of the k-NN plugin.\n" +
+		"Training is an asynchronous operation, use the `--wait` flag to wait until model is created or failed or `--timeout` is reached.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := trainModel(cmd, args[0])
+		DisplayError(err, knnModelTrainCommandName)
+	},
+}
+
+//knnModelGetCommand prints metadata of models
+var knnModelGetCommand = &cobra.Command{
+	Use:   knnModelGetCommandName + " model_id ..." + " [flags] ",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Get models based on a list of IDs",
+	Long:  "Get metadata of models based on a list of IDs.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getModels(args)
+		DisplayError(err, knnModelGetCommandName)
+	},
+}
+
+//knnModelListCommand prints metadata of every model
+var knnModelListCommand = &cobra.Command{
+	Use:   knnModelListCommandName + " [flags] ",
+	Args:  cobra.NoArgs,
+	Short: "List every model",
+	Long:  "List metadata of every model in the cluster.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := listModels()
+		DisplayError(err, knnModelListCommandName)
+	},
+}
+
+//knnModelDeleteCommand deletes models
+var knnModelDeleteCommand = &cobra.Command{
+	Use:   knnModelDeleteCommandName + " model_id ..." + " [flags] ",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Delete models based on a list of IDs",
+	Long:  "Delete models based on a list of IDs. Models in use by an index or still in training cannot be deleted.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := deleteModels(args)
+		DisplayError(err, knnModelDeleteCommandName)
+	},
+}
+
+func init() {
+	knnModelCommand.Flags().BoolP("help", "h", false, "Help for k-NN models")
+	GetKNNCommand().AddCommand(knnModelCommand)
+	knnModelTrainCommand.Flags().StringP(knnModelIDFlagName, "", "", "ID of model, generated by the plugin if not provided")
+	knnModelTrainCommand.Flags().BoolP(knnModelWaitFlagName, "w", false, "Wait until model is created or failed")
+	knnModelTrainCommand.Flags().DurationP(knnModelIntervalFlagName, "i", defaultModelPollInterval, "Interval between model state checks")
+	knnModelTrainCommand.Flags().DurationP(knnModelTimeoutFlagName, "t", defaultModelWaitTimeout, "Maximum time to wait for model with --wait, 0 waits without limit")
+	knnModelTrainCommand.Flags().BoolP("help", "h", false, "Help for "+knnModelTrainCommandName)
+	knnModelCommand.AddCommand(knnModelTrainCommand)
+	knnModelGetCommand.Flags().BoolP("help", "h", false, "Help for "+knnModelGetCommandName)
+	knnModelCommand.AddCommand(knnModelGetCommand)
+	knnModelListCommand.Flags().BoolP("help", "h", false, "Help for "+knnModelListCommandName)
+	knnModelCommand.AddCommand(knnModelListCommand)
+	knnModelDeleteCommand.Flags().BoolP("help", "h", false, "Help for "+knnModelDeleteCommandName)
+	knnModelCommand.AddCommand(knnModelDeleteCommand)
+}
+
+//trainModel starts training and optionally waits until training is finished
+func trainModel(cmd *cobra.Command, fileName string) error {
+	modelID, _ := cmd.Flags().GetString(knnModelIDFlagName)
+	wait, _ := cmd.Flags().GetBool(knnModelWaitFlagName)
+	interval, _ := cmd.Flags().GetDuration(knnModelIntervalFlagName)
+	timeout, _ := cmd.Flags().GetDuration(knnModelTimeoutFlagName)
+	h, err := GetKNNHandler()
+	if err != nil {
+		return err
+	}
+	modelID, err = handler.TrainModel(h, fileName, modelID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("started training of model %s\n", modelID)
+	if !wait {
+		return nil
+	}
+	model, err := handler.WaitForModel(h, modelID, interval, timeout)
+	if err != nil {
+		return err
+	}
+	if err = printModels([]*entity.Model{model}); err != nil {
+		return err
+	}
+	if model.State == entity.ModelStateFailed {
+		return fmt.Errorf("training of model %s failed due to %s", modelID, model.Error)
+	}
+	return nil
+}
+
+//getModels prints metadata of models by id
+func getModels(modelIDs []string) error {
+	h, err := GetKNNHandler()
+	if err != nil {
+		return err
+	}
+	models := []*entity.Model{}
+	for _, modelID := range modelIDs {
+		model, err := handler.GetModel(h, modelID)
+		if err != nil {
+			return err
+		}
+		models = append(models, model)
+	}
+	return printModels(models)
+}
+
+//listModels prints metadata of every model
+func listModels() error {
+	h, err := GetKNNHandler()
+	if err != nil {
+		return err
+	}
+	models, err := handler.ListModels(h)
+	if err != nil {
+		return err
+	}
+	return printModels(models)
+}
+
+//deleteModels deletes models by id
+func deleteModels(modelIDs []string) error {
+	h, err := GetKNNHandler()
+	if err != nil {
+		return err
+	}
+	for _, modelID := range modelIDs {
+		if err = handler.DeleteModel(h, modelID); err != nil {
+			return err
+		}
+		fmt.Printf("successfully deleted model %s\n", modelID)
+	}
+	return nil
+}
+
+//printModels prints models metadata, default format is table
+func printModels(models []*entity.Model) error {
+	return printOutput(models, func() error {
+		if len(models) < 1 {
+			fmt.Println("no models found")
+			return nil
+		}
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, models)
+	})
+}
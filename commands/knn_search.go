@@ -0,0 +1,120 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	entity "opensearch-cli/entity/knn"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/knn"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	knnSearchCommandName          = "search"
+	knnSearchIndexFlagName        = "index"
+	knnSearchFieldFlagName        = "field"
+	knnSearchKFlagName            = "k"
+	knnSearchVectorFlagName       = "vector"
+	knnSearchVectorFileFlagName   = "vector-file"
+	knnSearchVectorFormatFlagName = "vector-format"
+	knnSearchFilterFlagName       = "filter"
+	defaultKNNSearchK             = 10
+	stdinFileName                 = "-"
+)
+
+//knnSearchCommand searches k nearest neighbors of query vector
+var knnSearchCommand = &cobra.Command{
+	Use:   knnSearchCommandName + " [flags] ",
+	Args:  cobra.NoArgs,
+	Short: "Search k nearest neighbors of a vector",
+	Long: "Search k nearest neighbors of a query vector in the vector field of an index.\n" +
+		"The query vector is read from `--vector`, from the file given by `--vector-file` or from stdin if neither is provided. " +
+		"It can be a JSON array, a CSV row or raw float32 little-endian bytes, the format is detected unless `--vector-format` is provided.\n" +
+		"Use `--filter` to restrict neighbors by a query in JSON, prefix with @ to read the query from file.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := searchVector(cmd)
+		DisplayError(err, knnSearchCommandName)
+	},
+}
+
+func init() {
+	knnSearchCommand.Flags().StringP(knnSearchIndexFlagName, "i", "", "Name of the index to search")
+	_ = knnSearchCommand.MarkFlagRequired(knnSearchIndexFlagName)
+	knnSearchCommand.Flags().StringP(knnSearchFieldFlagName, "f", "", "Name of the knn_vector field")
+	_ = knnSearchCommand.MarkFlagRequired(knnSearchFieldFlagName)
+	knnSearchCommand.Flags().IntP(knnSearchKFlagName, "k", defaultKNNSearchK, "Number of nearest neighbors to return")
+	knnSearchCommand.Flags().StringP(knnSearchVectorFlagName, "", "", "Query vector as JSON array or comma separated values")
+	knnSearchCommand.Flags().StringP(knnSearchVectorFileFlagName, "", "", "File to read query vector from, use - for stdin")
+	knnSearchCommand.Flags().StringP(knnSearchVectorFormatFlagName, "", entity.VectorFormatAuto, "Format of query vector: auto, json, csv or binary")
+	knnSearchCommand.Flags().StringP(knnSearchFilterFlagName, "", "", "Filter query in JSON, or file name prefixed by @")
+	knnSearchCommand.Flags().BoolP("help", "h", false, "Help for k-NN plugin search command")
+	GetKNNCommand().AddCommand(knnSearchCommand)
+}
+
+//readVector reads query vector from flag, file or stdin
+func readVector(vector string, fileName string, stdin io.Reader) ([]byte, error) {
+	if len(vector) > 0 && len(fileName) > 0 {
+		return nil, fmt.Errorf("only one of --%s and --%s can be provided", knnSearchVectorFlagName, knnSearchVectorFileFlagName)
+	}
+	if len(vector) > 0 {
+		return []byte(vector), nil
+	}
+	if len(fileName) > 0 && fileName != stdinFileName {
+		return os.ReadFile(fileName)
+	}
+	return io.ReadAll(stdin)
+}
+
+//searchVector searches nearest neighbors and prints hits with scores
+func searchVector(cmd *cobra.Command) error {
+	index, _ := cmd.Flags().GetString(knnSearchIndexFlagName)
+	field, _ := cmd.Flags().GetString(knnSearchFieldFlagName)
+	k, _ := cmd.Flags().GetInt(knnSearchKFlagName)
+	vector, _ := cmd.Flags().GetString(knnSearchVectorFlagName)
+	fileName, _ := cmd.Flags().GetString(knnSearchVectorFileFlagName)
+	format, _ := cmd.Flags().GetString(knnSearchVectorFormatFlagName)
+	filter, _ := cmd.Flags().GetString(knnSearchFilterFlagName)
+	data, err := readVector(vector, fileName, os.Stdin)
+	if err != nil {
+		return err
+	}
+	h, err := GetKNNHandler()
+	if err != nil {
+		return err
+	}
+	results, err := handler.SearchVector(h, entity.SearchCommandRequest{
+		Index:        index,
+		Field:        field,
+		K:            k,
+		Vector:       data,
+		VectorFormat: format,
+		Filter:       filter,
+	})
+	if err != nil {
+		return err
+	}
+	return printOutput(results, func() error {
+		if len(results) < 1 {
+			fmt.Println("no hits found")
+			return nil
+		}
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, results)
+	})
+}
@@ -13,7 +13,12 @@ package commands
 
 import (
 	"bytes"
+	"fmt"
+	entity "opensearch-cli/entity/knn"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -51,6 +56,26 @@ func TestGetStatistics(t *testing.T) {
 		assert.NoError(t, err)
 		assert.EqualValues(t, "node1,node2", nodeNames)
 	})
+	t.Run("test watch statistics", func(t *testing.T) {
+		hits := int64(0)
+		polls := 0
+		fetch := func() (*entity.Stats, error) {
+			polls++
+			hits += 10
+			count := hits
+			return &entity.Stats{
+				NodeStats: map[string]entity.NodeStats{"node1": {HitCount: &count}},
+			}, nil
+		}
+		assert.NoError(t, watchStatistics(fetch, time.Millisecond, 3))
+		assert.EqualValues(t, 3, polls)
+	})
+	t.Run("test watch statistics failed", func(t *testing.T) {
+		fetch := func() (*entity.Stats, error) {
+			return nil, fmt.Errorf("failed to fetch stats")
+		}
+		assert.EqualError(t, watchStatistics(fetch, time.Millisecond, 0), "failed to fetch stats")
+	})
 }
 
 func TestWarmupIndices(t *testing.T) {
@@ -74,3 +99,39 @@ func TestWarmupIndices(t *testing.T) {
 		assert.Empty(t, result)
 	})
 }
+
+func TestClearCache(t *testing.T) {
+	t.Run("test clear cache command failed", func(t *testing.T) {
+		rootCmd := GetRoot()
+		knnCommand := GetKNNCommand()
+		knnClearCacheCmd := GetKNNClearCacheCommand()
+		knnCommand.AddCommand(knnClearCacheCmd)
+		rootCmd.AddCommand(knnCommand)
+		_, err := executeCommand(rootCmd, knnCommandName, knnClearCacheCommandName)
+		assert.Error(t, err)
+	})
+}
+
+func TestReadVector(t *testing.T) {
+	t.Run("read vector from flag", func(t *testing.T) {
+		data, err := readVector("[1,2]", "", bytes.NewBufferString("[3,4]"))
+		assert.NoError(t, err)
+		assert.EqualValues(t, "[1,2]", string(data))
+	})
+	t.Run("read vector from stdin", func(t *testing.T) {
+		data, err := readVector("", stdinFileName, bytes.NewBufferString("[3,4]"))
+		assert.NoError(t, err)
+		assert.EqualValues(t, "[3,4]", string(data))
+	})
+	t.Run("read vector from file", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "vector.csv")
+		assert.NoError(t, os.WriteFile(fileName, []byte("5,6"), 0644))
+		data, err := readVector("", fileName, bytes.NewBufferString("[3,4]"))
+		assert.NoError(t, err)
+		assert.EqualValues(t, "5,6", string(data))
+	})
+	t.Run("vector from flag and file", func(t *testing.T) {
+		_, err := readVector("[1,2]", "vector.csv", nil)
+		assert.EqualError(t, err, "only one of --vector and --vector-file can be provided")
+	})
+}
@@ -14,14 +14,20 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"opensearch-cli/client"
 	"opensearch-cli/environment"
+	"strconv"
 
 	"golang.org/x/term"
 
 	"opensearch-cli/controller/config"
+	"opensearch-cli/controller/credential"
 	"opensearch-cli/controller/profile"
 	"opensearch-cli/entity"
+	"opensearch-cli/formatter"
+	"opensearch-cli/gateway"
 	"os"
+	"path/filepath"
 	"strings"
 	"text/tabwriter"
 
@@ -29,19 +35,32 @@ import (
 )
 
 const (
-	CreateNewProfileCommandName = "create"
-	DeleteProfilesCommandName   = "delete"
-	FlagProfileVerbose          = "verbose"
-	ListProfilesCommandName     = "list"
-	ProfileCommandName          = "profile"
-	padding                     = 3
-	alignLeft                   = 0
-	FlagProfileCreateName       = "name"
-	FlagProfileCreateEndpoint   = "endpoint"
-	FlagProfileCreateAuthType   = "auth-type"
-	FlagProfileMaxRetry         = "max-retry"
-	FlagProfileTimeout          = "timeout"
-	FlagProfileHelp             = "help"
+	CreateNewProfileCommandName  = "create"
+	DeleteProfilesCommandName    = "delete"
+	FlagProfileVerbose           = "verbose"
+	ListProfilesCommandName      = "list"
+	ProfileCommandName           = "profile"
+	padding                      = 3
+	alignLeft                    = 0
+	FlagProfileCreateName        = "name"
+	FlagProfileCreateEndpoint    = "endpoint"
+	FlagProfileCreateAuthType    = "auth-type"
+	FlagProfileMaxRetry          = "max-retry"
+	FlagProfileTimeout           = "timeout"
+	FlagProfileHelp              = "help"
+	ShowProfileCommandName       = "show"
+	FlagProfileEffective         = "effective"
+	maskedPassword               = "********"
+	FlagProfileCredentialBackend = "credential-backend"
+	FlagProfileCredentialProcess = "credential-process"
+	credentialsFileName          = "credentials"
+	FlagProfileTest              = "test"
+	FlagProfileTLSVerify         = "tls-verify"
+	FlagProfileTLSServerName     = "tls-server-name"
+	FlagProfileTLSMinVersion     = "tls-min-version"
+	FlagProfileTLSSystemCA       = "tls-system-ca"
+	FlagProfileTLSCABundle       = "tls-ca-bundle"
+	FlagProfileTLSPin            = "tls-pin"
 )
 
 //GetProfileController gets controller based on config file
@@ -61,7 +80,11 @@ var profileCommand = &cobra.Command{
 		"When you specify a profile for a command (e.g. `opensearch-cli <command> --profile <profile_name>`), opensearch-cli uses " +
 		"the profile's settings and credentials to run the given command.\n" +
 		"To configure a default profile for commands, either specify the default profile name in an environment " +
-		"variable (`" + environment.OPENSEARCH_PROFILE + "`) or create a profile named `default`.",
+		"variable (`" + environment.OPENSEARCH_PROFILE + "`) or create a profile named `default`.\n" +
+		"Environment variables `" + environment.OPENSEARCH_ENDPOINT + "`, `" + environment.OPENSEARCH_USER + "`, `" +
+		environment.OPENSEARCH_PASSWORD + "`, `" + environment.OPENSEARCH_MAX_RETRY + "` and `" + environment.OPENSEARCH_TIMEOUT +
+		"` override fields of the profile. If no profile is found and `" + environment.OPENSEARCH_ENDPOINT +
+		"` is set, commands are executed with a profile built from environment variables.",
 }
 
 //createProfileCmd creates profile interactively by prompting for name (distinct), user, endpoint, password.
@@ -89,17 +112,12 @@ var createProfileCmd = &cobra.Command{
 			MaxRetry: &maxAttempt,
 			Timeout:  &timeout,
 		}
-		switch authType, _ := cmd.Flags().GetString(FlagProfileCreateAuthType); authType {
-		case "disabled":
-			break
-		case "basic":
-			getBasicAuthDetails(&newProfile)
-		case "aws-iam":
-			getAWSIAMAuthDetails(&newProfile)
-		case "cert":
-			getCertificateAuthDetails(&newProfile)
-		default:
-			DisplayError(errors.New("invalid value for auth-type. Use --help -h command to see permitted values"), CreateNewProfileCommandName)
+		if err = setTLSSettings(cmd, &newProfile); err != nil {
+			DisplayError(err, CreateNewProfileCommandName)
+			return
+		}
+		if err = setAuthDetails(cmd, &newProfile); err != nil {
+			DisplayError(err, CreateNewProfileCommandName)
 			return
 		}
 		err = CreateProfile(profileController, newProfile)
@@ -108,9 +126,96 @@ var createProfileCmd = &cobra.Command{
 			return
 		}
 		fmt.Println("Profile created successfully.")
+		offerProfileTest(cmd, name)
 	},
 }
 
+//setAuthDetails sets authentication of profile based on auth-type flag by prompting user for details
+func setAuthDetails(cmd *cobra.Command, newProfile *entity.Profile) (err error) {
+	authType, _ := cmd.Flags().GetString(FlagProfileCreateAuthType)
+	if authType != "basic" && (cmd.Flags().Changed(FlagProfileCredentialBackend) || cmd.Flags().Changed(FlagProfileCredentialProcess)) {
+		return errors.New("credential backend can only be used with auth-type 'basic'")
+	}
+	switch authType {
+	case "disabled":
+		break
+	case "basic":
+		if newProfile.Credential, err = getCredentialBackend(cmd); err != nil {
+			return err
+		}
+		//user and password are supplied by credential process on every execution
+		if newProfile.Credential == nil || newProfile.Credential.Backend != entity.CredentialBackendProcess {
+			getBasicAuthDetails(newProfile)
+		}
+	case "aws-iam":
+		getAWSIAMAuthDetails(newProfile)
+	case "cert":
+		getCertificateAuthDetails(newProfile)
+	default:
+		return errors.New("invalid value for auth-type. Use --help -h command to see permitted values")
+	}
+	return nil
+}
+
+//setTLSSettings sets TLS settings of profile from tls flags which are given, settings are removed from profile
+//if none is left. Settings are validated so that profile which cannot connect is not saved
+func setTLSSettings(cmd *cobra.Command, p *entity.Profile) error {
+	settings := entity.TLS{}
+	if p.TLS != nil {
+		settings = *p.TLS
+	}
+	flags := cmd.Flags()
+	if flags.Changed(FlagProfileTLSVerify) {
+		verify, _ := flags.GetBool(FlagProfileTLSVerify)
+		settings.Verify = &verify
+	}
+	if flags.Changed(FlagProfileTLSServerName) {
+		settings.ServerName, _ = flags.GetString(FlagProfileTLSServerName)
+	}
+	if flags.Changed(FlagProfileTLSMinVersion) {
+		settings.MinVersion, _ = flags.GetString(FlagProfileTLSMinVersion)
+	}
+	if flags.Changed(FlagProfileTLSSystemCA) {
+		systemCA, _ := flags.GetBool(FlagProfileTLSSystemCA)
+		settings.SystemCA = &systemCA
+	}
+	if flags.Changed(FlagProfileTLSCABundle) {
+		settings.CABundles, _ = flags.GetStringSlice(FlagProfileTLSCABundle)
+	}
+	if flags.Changed(FlagProfileTLSPin) {
+		settings.Pins, _ = flags.GetStringSlice(FlagProfileTLSPin)
+	}
+	p.TLS = &settings
+	if settings.Verify == nil && settings.ServerName == "" && settings.MinVersion == "" && settings.SystemCA == nil &&
+		len(settings.CABundles) == 0 && len(settings.Pins) == 0 {
+		p.TLS = nil
+	}
+	_, err := gateway.GetTLSConfig(&entity.Profile{TLS: p.TLS})
+	return err
+}
+
+//getCredentialBackend gets backend where credentials are saved, nil is returned if they are saved in config file
+func getCredentialBackend(cmd *cobra.Command) (*entity.Credential, error) {
+	backend, _ := cmd.Flags().GetString(FlagProfileCredentialBackend)
+	process, _ := cmd.Flags().GetString(FlagProfileCredentialProcess)
+	if backend == entity.CredentialBackendProcess {
+		if len(process) == 0 {
+			return nil, fmt.Errorf("%s is required for %s '%s'", FlagProfileCredentialProcess, FlagProfileCredentialBackend, backend)
+		}
+		return &entity.Credential{Backend: backend, Process: process}, nil
+	}
+	if len(process) > 0 {
+		return nil, fmt.Errorf("%s can only be used with %s '%s'", FlagProfileCredentialProcess, FlagProfileCredentialBackend, entity.CredentialBackendProcess)
+	}
+	switch backend {
+	case entity.CredentialBackendConfig:
+		return nil, nil
+	case entity.CredentialBackendKeyring, entity.CredentialBackendFile:
+		return &entity.Credential{Backend: backend}, nil
+	}
+	return nil, fmt.Errorf("invalid value for %s, allowed values are %s", FlagProfileCredentialBackend, strings.Join(entity.CredentialBackends, ", "))
+}
+
 func getProfileName(cmd *cobra.Command, controller profile.Controller) (string, error) {
 	name, _ := cmd.Flags().GetString(FlagProfileCreateName)
 	if err := validateProfileName(name, controller); err != nil {
@@ -147,6 +252,23 @@ var listProfileCmd = &cobra.Command{
 	},
 }
 
+//showProfileCmd shows fields of profile
+var showProfileCmd = &cobra.Command{
+	Use:   ShowProfileCommandName + " [profile_name]",
+	Short: "Show fields of a profile",
+	Long: "Show fields of a profile from the config file, password is masked.\n" +
+		"Use `--effective` to show the profile which is used for execution after environment variables are applied, " +
+		"along with where every field came from: flag, env, file or default. " +
+		"If no profile name is given, profile is selected the same way as for any other command.",
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showProfile(cmd, args); err != nil {
+			DisplayError(err, ShowProfileCommandName)
+			return
+		}
+	},
+}
+
 //deleteProfiles deletes profiles based on names
 func deleteProfiles(profiles []string) error {
 	profileController, err := GetProfileController()
@@ -161,6 +283,7 @@ func init() {
 	profileCommand.AddCommand(createProfileCmd)
 	profileCommand.AddCommand(deleteProfilesCmd)
 	profileCommand.AddCommand(listProfileCmd)
+	profileCommand.AddCommand(showProfileCmd)
 
 	//profile flags
 	profileCommand.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+ProfileCommandName)
@@ -172,25 +295,49 @@ func init() {
 	//profile create flags
 	createProfileCmd.Flags().StringP(FlagProfileCreateName, "n", "", "Create profile with this name")
 	_ = createProfileCmd.MarkFlagRequired(FlagProfileCreateName)
-	createProfileCmd.Flags().StringP(FlagProfileCreateEndpoint, "e", "", "Create profile with this endpoint or host")
+	addProfileSettingsFlags(createProfileCmd)
 	_ = createProfileCmd.MarkFlagRequired(FlagProfileCreateEndpoint)
-	createProfileCmd.Flags().StringP(FlagProfileCreateAuthType, "a", "", "Authentication type. Options are disabled, basic, cert and aws-iam."+
-		"\nIf security is disabled, provide --auth-type='disabled'.\nIf security uses HTTP basic authentication, provide --auth-type='basic'.\n"+
-		"If security uses client certificate authentication, provide --auth-type='cert'.\n"+
-		"If security uses AWS IAM ARNs as users, provide --auth-type='aws-iam'.\nopensearch-cli asks for additional information based on your choice of authentication type.")
 	_ = createProfileCmd.MarkFlagRequired(FlagProfileCreateAuthType)
-	createProfileCmd.Flags().IntP(FlagProfileMaxRetry, "m", 3, "Maximum retry attempts allowed if transient problems occur.\n"+
-		"You can override this value by using the "+environment.OPENSEARCH_MAX_RETRY+" environment variable.")
-	createProfileCmd.Flags().Int64P(FlagProfileTimeout, "t", 10, "Maximum time allowed for connection in seconds.\n"+
-		"You can override this value by using the "+environment.OPENSEARCH_TIMEOUT+" environment variable.")
+	createProfileCmd.Flags().BoolP(FlagProfileTest, "", false, "Test connection of profile after it is created without asking")
 	createProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+CreateNewProfileCommandName)
 
+	//profile show flags
+	showProfileCmd.Flags().BoolP(FlagProfileEffective, "", false, "Show profile used for execution with source of every field")
+	showProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+ShowProfileCommandName)
+
 	//profile delete flags
 	deleteProfilesCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+DeleteProfilesCommandName)
 
 	GetRoot().AddCommand(profileCommand)
 }
 
+//addProfileSettingsFlags adds flags for settings of profile which are shared by create and update commands
+func addProfileSettingsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(FlagProfileCreateEndpoint, "e", "", "Endpoint or host of the cluster")
+	cmd.Flags().StringP(FlagProfileCreateAuthType, "a", "", "Authentication type. Options are disabled, basic, cert and aws-iam."+
+		"\nIf security is disabled, provide --auth-type='disabled'.\nIf security uses HTTP basic authentication, provide --auth-type='basic'.\n"+
+		"If security uses client certificate authentication, provide --auth-type='cert'.\n"+
+		"If security uses AWS IAM ARNs as users, provide --auth-type='aws-iam'.\nopensearch-cli asks for additional information based on your choice of authentication type.")
+	cmd.Flags().IntP(FlagProfileMaxRetry, "m", 3, "Maximum retry attempts allowed if transient problems occur.\n"+
+		"You can override this value by using the "+environment.OPENSEARCH_MAX_RETRY+" environment variable.")
+	cmd.Flags().Int64P(FlagProfileTimeout, "t", 10, "Maximum time allowed for connection in seconds.\n"+
+		"You can override this value by using the "+environment.OPENSEARCH_TIMEOUT+" environment variable.")
+	cmd.Flags().StringP(FlagProfileCredentialBackend, "", entity.CredentialBackendConfig, "Where user and password of basic authentication are saved. Options are config, keyring, file and process.\n"+
+		"config saves them in the config file.\nkeyring saves them in the Secret Service keyring using secret-tool from libsecret.\n"+
+		"file saves them in the file '"+credentialsFileName+"' next to the config file, encrypted with a passphrase. "+
+		"The passphrase is asked when needed or read from the "+environment.OPENSEARCH_CREDENTIALS_PASSPHRASE+" environment variable.\n"+
+		"process runs the command given by --"+FlagProfileCredentialProcess+" whenever credentials are needed.")
+	cmd.Flags().StringP(FlagProfileCredentialProcess, "", "", "Command which prints credentials as json, e.g. {\"user\": \"admin\", \"password\": \"secret\"}")
+	cmd.Flags().BoolP(FlagProfileTLSVerify, "", true, "Verify certificate of the cluster. Use --"+FlagProfileTLSVerify+"=false only for clusters with self-signed certificates, "+
+		"or use --"+flagInsecure+" to skip verification for a single execution")
+	cmd.Flags().StringP(FlagProfileTLSServerName, "", "", "Server name used to verify certificate of the cluster, default is host of the endpoint")
+	cmd.Flags().StringP(FlagProfileTLSMinVersion, "", "", "Minimum TLS version. Options are 1.0, 1.1, 1.2 and 1.3, default is 1.2")
+	cmd.Flags().BoolP(FlagProfileTLSSystemCA, "", true, "Trust CA certificates of the system in addition to CA bundles")
+	cmd.Flags().StringSliceP(FlagProfileTLSCABundle, "", nil, "PEM file with CA certificates to trust, can be repeated")
+	cmd.Flags().StringSliceP(FlagProfileTLSPin, "", nil, "Base64 encoded SHA-256 hash of public key which certificate of the cluster must have, "+
+		"optionally prefixed with sha256//, can be repeated.\nGet it with: openssl x509 -in cert.pem -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64")
+}
+
 //getProfileController gets profile controller by wiring config controller with config file
 func getProfileController(cfgFlagValue string) (profile.Controller, error) {
 	configFilePath, err := GetConfigFilePath(cfgFlagValue)
@@ -201,14 +348,48 @@ func getProfileController(cfgFlagValue string) (profile.Controller, error) {
 		return nil, err
 	}
 	configController := config.New(configFilePath)
-	profileController := profile.New(configController)
+	stores := credential.NewFactory(credential.Options{
+		FilePath:   filepath.Join(filepath.Dir(configFilePath), credentialsFileName),
+		Passphrase: getCredentialsPassphrase,
+	})
+	profileController := profile.New(configController, stores)
 	return profileController, nil
 }
 
+//getCredentialsPassphrase reads passphrase of credentials file from environment variable or asks user for it,
+//prompts are written to stderr so that output of command is not affected
+func getCredentialsPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(environment.OPENSEARCH_CREDENTIALS_PASSPHRASE); len(passphrase) > 0 {
+		return passphrase, nil
+	}
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(os.Stderr, prompt)
+		value, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase, set %s if terminal is not available: %w",
+				environment.OPENSEARCH_CREDENTIALS_PASSPHRASE, err)
+		}
+		return string(value), nil
+	}
+	passphrase, err := read("Passphrase for credentials file: ")
+	if err != nil || !confirm {
+		return passphrase, err
+	}
+	confirmation, err := read("Confirm passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if confirmation != passphrase {
+		return "", errors.New("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
 // CreateProfile creates a new named profile
 func CreateProfile(profileController profile.Controller, newProfile entity.Profile) error {
 	if err := profileController.CreateProfile(newProfile); err != nil {
-		return fmt.Errorf("failed to create profile %v due to: %w", newProfile, err)
+		return fmt.Errorf("failed to create profile %s due to: %w", newProfile.Name, err)
 	}
 	return nil
 }
@@ -306,10 +487,36 @@ func listProfiles(cmd *cobra.Command) error {
 	if err != nil {
 		return err
 	}
-	if !ok {
-		return displayProfileNames(profileController)
+	profiles, err := profileController.GetProfiles()
+	if err != nil {
+		return err
+	}
+	return printOutput(toProfileOutput(profiles), func() error {
+		if !ok {
+			return displayProfileNames(profileController)
+		}
+		return displayCompleteProfiles(profileController)
+	})
+}
+
+//profileOutput represents profile information displayed by list command, credentials are not displayed
+type profileOutput struct {
+	Name     string `json:"name"`
+	UserName string `json:"user"`
+	Endpoint string `json:"endpoint"`
+}
+
+//toProfileOutput maps profiles to profileOutput
+func toProfileOutput(profiles []entity.Profile) []profileOutput {
+	result := []profileOutput{}
+	for _, p := range profiles {
+		result = append(result, profileOutput{
+			Name:     p.Name,
+			UserName: p.UserName,
+			Endpoint: p.Endpoint,
+		})
 	}
-	return displayCompleteProfiles(profileController)
+	return result
 }
 
 //displayCompleteProfiles lists complete profile information as below
@@ -354,3 +561,152 @@ func displayProfileNames(p profile.Controller) (err error) {
 	}
 	return nil
 }
+
+//profileFieldOutput represents field of profile displayed by show command with source of its value
+type profileFieldOutput struct {
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+//getProfileFieldValues returns values of profile fields which are set, password is masked
+func getProfileFieldValues(p entity.Profile) map[string]string {
+	values := map[string]string{
+		entity.ProfileFieldName:     p.Name,
+		entity.ProfileFieldEndpoint: p.Endpoint,
+		entity.ProfileFieldUserName: p.UserName,
+	}
+	if len(p.Password) > 0 {
+		values[entity.ProfileFieldPassword] = maskedPassword
+	}
+	if p.Credential != nil {
+		values[entity.ProfileFieldCredentialBackend] = p.Credential.Backend
+		if len(p.Credential.Process) > 0 {
+			values[entity.ProfileFieldCredentialProcess] = p.Credential.Process
+		}
+	}
+	if p.AWS != nil {
+		values[entity.ProfileFieldAWSProfile] = p.AWS.ProfileName
+		values[entity.ProfileFieldAWSService] = p.AWS.ServiceName
+	}
+	if p.Certificate != nil {
+		for field, path := range map[string]*string{
+			entity.ProfileFieldCAFile:         p.Certificate.CAFilePath,
+			entity.ProfileFieldClientCertFile: p.Certificate.ClientCertificateFilePath,
+			entity.ProfileFieldClientKeyFile:  p.Certificate.ClientKeyFilePath,
+		} {
+			if path != nil {
+				values[field] = *path
+			}
+		}
+	}
+	if p.TLS != nil {
+		values[entity.ProfileFieldTLSServerName] = p.TLS.ServerName
+		values[entity.ProfileFieldTLSMinVersion] = p.TLS.MinVersion
+		values[entity.ProfileFieldTLSCABundles] = strings.Join(p.TLS.CABundles, ",")
+		values[entity.ProfileFieldTLSPins] = strings.Join(p.TLS.Pins, ",")
+		if p.TLS.Verify != nil {
+			values[entity.ProfileFieldTLSVerify] = strconv.FormatBool(*p.TLS.Verify)
+		}
+		if p.TLS.SystemCA != nil {
+			values[entity.ProfileFieldTLSSystemCA] = strconv.FormatBool(*p.TLS.SystemCA)
+		}
+	}
+	if p.MaxRetry != nil {
+		values[entity.ProfileFieldMaxRetry] = strconv.Itoa(*p.MaxRetry)
+	}
+	if p.Timeout != nil {
+		values[entity.ProfileFieldTimeout] = strconv.FormatInt(*p.Timeout, 10)
+	}
+	return values
+}
+
+//toProfileFieldOutput maps fields of profile which are set to rows in stable order. If sources is nil, every field
+//is read from config file, otherwise max retry and timeout which are not set are displayed with their default values
+func toProfileFieldOutput(p entity.Profile, sources map[string]string) []profileFieldOutput {
+	values := getProfileFieldValues(p)
+	defaults := map[string]string{}
+	if sources != nil {
+		if _, ok := values[entity.ProfileFieldMaxRetry]; !ok {
+			values[entity.ProfileFieldMaxRetry] = strconv.Itoa(client.DefaultMaxRetry)
+			defaults[entity.ProfileFieldMaxRetry] = entity.ProfileSourceDefault
+		}
+		if _, ok := values[entity.ProfileFieldTimeout]; !ok {
+			values[entity.ProfileFieldTimeout] = strconv.Itoa(client.DefaultTimeout)
+			defaults[entity.ProfileFieldTimeout] = entity.ProfileSourceDefault
+		}
+	}
+	result := []profileFieldOutput{}
+	for _, field := range entity.ProfileFields {
+		value, ok := values[field]
+		if !ok || len(value) < 1 {
+			continue
+		}
+		source := entity.ProfileSourceFile
+		if sources != nil {
+			source = sources[field]
+		}
+		if defaultSource, ok := defaults[field]; ok {
+			source = defaultSource
+		}
+		result = append(result, profileFieldOutput{
+			Field:  field,
+			Value:  value,
+			Source: source,
+		})
+	}
+	return result
+}
+
+//showProfile prints fields of profile from config file or of effective profile, default format is table
+func showProfile(cmd *cobra.Command, args []string) error {
+	effective, _ := cmd.Flags().GetBool(FlagProfileEffective)
+	profileController, err := GetProfileController()
+	if err != nil {
+		return err
+	}
+	var fields []profileFieldOutput
+	if effective {
+		name, err := rootCommand.PersistentFlags().GetString(flagProfileName)
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 {
+			name = args[0]
+		}
+		p, ok, err := profileController.GetEffectiveProfile(name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errNoProfileForExecution()
+		}
+		if insecure, _ := rootCommand.PersistentFlags().GetBool(flagInsecure); insecure {
+			if err = applyInsecureFlag(&p.Profile); err != nil {
+				return err
+			}
+			p.Sources[entity.ProfileFieldTLSVerify] = entity.ProfileSourceFlag
+		}
+		fields = toProfileFieldOutput(p.Profile, p.Sources)
+	} else {
+		if len(args) < 1 {
+			return fmt.Errorf("profile name is required unless --%s is used", FlagProfileEffective)
+		}
+		profiles, err := profileController.GetProfilesMap()
+		if err != nil {
+			return err
+		}
+		p, ok := profiles[args[0]]
+		if !ok {
+			return fmt.Errorf("profile '%s' does not exist", args[0])
+		}
+		fields = toProfileFieldOutput(p, nil)
+	}
+	return printOutput(fields, func() error {
+		f, err := formatter.New(formatter.Table)
+		if err != nil {
+			return err
+		}
+		return f.Format(os.Stdout, fields)
+	})
+}
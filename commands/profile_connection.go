@@ -0,0 +1,210 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"opensearch-cli/client"
+	controller "opensearch-cli/controller/connection"
+	"opensearch-cli/entity"
+	"opensearch-cli/entity/connection"
+	gateway "opensearch-cli/gateway/connection"
+	handler "opensearch-cli/handler/connection"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	TestProfileCommandName = "test"
+	//connectionTestFailed is exit code when any profile could not reach its cluster
+	connectionTestFailed = 1
+)
+
+//testProfileCmd tests connection to clusters of profiles
+var testProfileCmd = &cobra.Command{
+	Use:     TestProfileCommandName + " [profile_name ...]",
+	Aliases: []string{"ping"},
+	Short:   "Test connection to cluster of profiles",
+	Long: "Test connection to cluster of profiles and report latency, cluster name and version, authenticated user " +
+		"and roles, TLS certificate chain with expiry and installed plugins.\n" +
+		"If no profile name is given, profile is selected the same way as for any other command. " +
+		"Exit code is " + fmt.Sprint(connectionTestFailed) + " if any cluster is not reachable.",
+	Run: func(cmd *cobra.Command, args []string) {
+		results, err := testProfiles(args)
+		if err != nil {
+			DisplayError(err, TestProfileCommandName)
+			return
+		}
+		if err = printOutput(results, func() error {
+			return displayConnectionResults(results)
+		}); err != nil {
+			DisplayError(err, TestProfileCommandName)
+			return
+		}
+		for _, result := range results {
+			if !result.Reachable {
+				os.Exit(connectionTestFailed)
+			}
+		}
+	},
+}
+
+func init() {
+	profileCommand.AddCommand(testProfileCmd)
+	testProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+TestProfileCommandName)
+}
+
+//testProfiles tests connection of profiles with given names, profile for execution is tested if no name is given
+func testProfiles(names []string) ([]connection.Result, error) {
+	if len(names) == 0 {
+		p, err := GetProfile()
+		if err != nil {
+			return nil, err
+		}
+		return []connection.Result{testProfile(*p)}, nil
+	}
+	profileController, err := GetProfileController()
+	if err != nil {
+		return nil, err
+	}
+	var results []connection.Result
+	for _, name := range names {
+		p, _, err := profileController.GetProfileForExecution(name)
+		if err != nil {
+			return nil, err
+		}
+		if err = applyInsecureFlag(&p); err != nil {
+			return nil, err
+		}
+		results = append(results, testProfile(p))
+	}
+	return results, nil
+}
+
+//testProfile tests connection of profile, error while building gateway is reported as result
+func testProfile(p entity.Profile) connection.Result {
+	var result connection.Result
+	h, err := getConnectionHandler(&p)
+	if err != nil {
+		result.Errors = []string{err.Error()}
+	} else {
+		result = handler.TestConnection(h)
+	}
+	result.Profile = p.Name
+	result.Endpoint = p.Endpoint
+	return result
+}
+
+//getConnectionHandler returns handler for profile, every profile gets its own client since gateway configures it
+func getConnectionHandler(p *entity.Profile) (*handler.Handler, error) {
+	c, err := client.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	g, err := gateway.New(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return handler.New(controller.New(g)), nil
+}
+
+//displayConnectionResults prints result of every profile as list of fields
+func displayConnectionResults(results []connection.Result) (err error) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', alignLeft)
+	defer func() {
+		err = w.Flush()
+	}()
+	for i, result := range results {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		for _, row := range getConnectionResultRows(result) {
+			_, err = fmt.Fprintf(w, "%s\t%s\n", row[0], row[1])
+		}
+	}
+	return
+}
+
+//getConnectionResultRows returns fields of result which are set as label and value
+func getConnectionResultRows(result connection.Result) [][2]string {
+	status := "unreachable"
+	if result.Reachable {
+		status = fmt.Sprintf("reachable (%d ms)", result.LatencyMS)
+	}
+	rows := [][2]string{
+		{"Profile", result.Profile},
+		{"Endpoint", result.Endpoint},
+		{"Status", status},
+	}
+	add := func(label string, value string) {
+		if len(value) > 0 {
+			rows = append(rows, [2]string{label, value})
+		}
+	}
+	add("Cluster", result.ClusterName)
+	add("Version", strings.TrimSpace(result.Distribution+" "+result.Version))
+	add("User", result.User)
+	add("Roles", strings.Join(result.Roles, ", "))
+	for i, cert := range result.Certificates {
+		label := ""
+		if i == 0 {
+			label = "TLS"
+		}
+		add(label, fmt.Sprintf("%s (issuer %s) expires %s (%s)",
+			cert.Subject, cert.Issuer, cert.NotAfter.Format("2006-01-02"), describeExpiry(cert.ExpiresInDays)))
+	}
+	var plugins []string
+	for _, p := range result.Plugins {
+		plugins = append(plugins, p.Name+" "+p.Version)
+	}
+	add("Plugins", strings.Join(plugins, ", "))
+	for i, message := range result.Errors {
+		label := ""
+		if i == 0 {
+			label = "Errors"
+		}
+		add(label, message)
+	}
+	return rows
+}
+
+//describeExpiry describes days until certificate expires
+func describeExpiry(days int) string {
+	if days < 0 {
+		return fmt.Sprintf("expired %d days ago", -days)
+	}
+	return fmt.Sprintf("in %d days", days)
+}
+
+//offerProfileTest tests connection of new profile if user agrees, user is asked only if stdin is a terminal
+func offerProfileTest(cmd *cobra.Command, name string) {
+	test, _ := cmd.Flags().GetBool(FlagProfileTest)
+	if !test {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return
+		}
+		fmt.Printf("Do you want to test connection of profile %s? Y/N ", name)
+		answer := strings.ToLower(getUserInputAsText(nil))
+		if answer != "y" && answer != "yes" {
+			return
+		}
+	}
+	results, err := testProfiles([]string{name})
+	if err == nil {
+		err = displayConnectionResults(results)
+	}
+	DisplayError(err, TestProfileCommandName)
+}
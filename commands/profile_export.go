@@ -0,0 +1,123 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"opensearch-cli/entity"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ExportProfilesCommandName = "export"
+	ImportProfilesCommandName = "import"
+	FlagProfileFile           = "file"
+	FlagProfileStripSecrets   = "strip-secrets"
+	FlagProfileOverwrite      = "overwrite"
+	FlagProfileAllowProcess   = "allow-credential-process"
+)
+
+//exportProfilesCmd writes profiles in config file format so that they can be shared
+var exportProfilesCmd = &cobra.Command{
+	Use:   ExportProfilesCommandName + " [profile_name ...]",
+	Short: "Export profiles",
+	Long: "Export profiles in config file format, to share them with `" + ImportProfilesCommandName + "`. " +
+		"Every profile is exported if no profile name is given. Profiles are written to stdout unless --file is given.\n" +
+		"Use --" + FlagProfileStripSecrets + " to remove passwords. Credentials saved in keyring or encrypted file are never exported.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := exportProfiles(cmd, args); err != nil {
+			DisplayError(err, ExportProfilesCommandName)
+			return
+		}
+	},
+}
+
+//importProfilesCmd adds profiles from file to config file
+var importProfilesCmd = &cobra.Command{
+	Use:   ImportProfilesCommandName + " file_name",
+	Short: "Import profiles",
+	Long: "Import profiles from file in config file format, like the output of `" + ExportProfilesCommandName + "`. " +
+		"Profiles are added at the end of the config file. Use --" + FlagProfileOverwrite + " to replace profiles which already exist.\n" +
+		"Every profile must have a valid endpoint and credential backend. Profiles whose credentials are supplied by `credential.process` " +
+		"run that command on every execution, they are only imported with --" + FlagProfileAllowProcess + " after you reviewed the command.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := importProfiles(cmd, args[0]); err != nil {
+			DisplayError(err, ImportProfilesCommandName)
+			return
+		}
+		fmt.Println("Profiles imported successfully.")
+	},
+}
+
+func init() {
+	profileCommand.AddCommand(exportProfilesCmd)
+	exportProfilesCmd.Flags().StringP(FlagProfileFile, "f", "", "Write profiles to this file instead of stdout")
+	exportProfilesCmd.Flags().BoolP(FlagProfileStripSecrets, "", false, "Remove passwords from exported profiles")
+	exportProfilesCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+ExportProfilesCommandName)
+	profileCommand.AddCommand(importProfilesCmd)
+	importProfilesCmd.Flags().BoolP(FlagProfileOverwrite, "", false, "Replace profiles which already exist")
+	importProfilesCmd.Flags().BoolP(FlagProfileAllowProcess, "", false, "Import profiles whose credentials are supplied by credential process")
+	importProfilesCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+ImportProfilesCommandName)
+}
+
+//exportProfiles writes profiles as yaml to file or stdout, file is only readable by current user
+func exportProfiles(cmd *cobra.Command, names []string) error {
+	stripSecrets, _ := cmd.Flags().GetBool(FlagProfileStripSecrets)
+	fileName, _ := cmd.Flags().GetString(FlagProfileFile)
+	profileController, err := GetProfileController()
+	if err != nil {
+		return err
+	}
+	profiles, err := profileController.ExportProfiles(names, stripSecrets)
+	if err != nil {
+		return err
+	}
+	contents, err := yaml.Marshal(entity.Config{Profiles: profiles})
+	if err != nil {
+		return err
+	}
+	if len(fileName) == 0 {
+		_, err = os.Stdout.Write(contents)
+		return err
+	}
+	if err = os.WriteFile(fileName, contents, 0600); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d profile(s) to %s\n", len(profiles), fileName)
+	return nil
+}
+
+//importProfiles reads profiles from file and adds them to config file
+func importProfiles(cmd *cobra.Command, fileName string) error {
+	overwrite, _ := cmd.Flags().GetBool(FlagProfileOverwrite)
+	allowProcess, _ := cmd.Flags().GetBool(FlagProfileAllowProcess)
+	contents, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	var data entity.Config
+	if err = yaml.Unmarshal(contents, &data); err != nil {
+		return fmt.Errorf("invalid profiles file %s: %w", fileName, err)
+	}
+	if len(data.Profiles) == 0 {
+		return fmt.Errorf("no profiles found in %s", fileName)
+	}
+	profileController, err := GetProfileController()
+	if err != nil {
+		return err
+	}
+	return profileController.ImportProfiles(data.Profiles, overwrite, allowProcess)
+}
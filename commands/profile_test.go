@@ -13,13 +13,15 @@ package commands
 
 import (
 	"errors"
-	"fmt"
 	"opensearch-cli/controller/profile/mocks"
 	"opensearch-cli/entity"
+	"opensearch-cli/entity/connection"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/spf13/cobra"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
@@ -82,7 +84,18 @@ func TestCreateProfile(t *testing.T) {
 		mockProfileCtrl := mocks.NewMockController(mockCtrl)
 		mockProfileCtrl.EXPECT().CreateProfile(fakeInputProfile()).Return(errors.New("error"))
 		err := CreateProfile(mockProfileCtrl, fakeInputProfile())
-		assert.EqualError(t, err, fmt.Sprintf("failed to create profile %v due to: error", fakeInputProfile()))
+		assert.EqualError(t, err, "failed to create profile default due to: error")
+	})
+	t.Run("create profile failed does not print password", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockProfileCtrl := mocks.NewMockController(mockCtrl)
+		newProfile := fakeInputProfile()
+		newProfile.Password = "s3cr3t-passw0rd"
+		mockProfileCtrl.EXPECT().CreateProfile(newProfile).Return(errors.New("failed to save credentials: permission denied"))
+		err := CreateProfile(mockProfileCtrl, newProfile)
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), newProfile.Password)
 	})
 	t.Run("check mandatory create parameters are provided", func(t *testing.T) {
 		root := GetRoot()
@@ -132,6 +145,43 @@ func TestCreateProfile(t *testing.T) {
 	})
 }
 
+func TestGetCredentialBackend(t *testing.T) {
+	getCommand := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String(FlagProfileCredentialBackend, entity.CredentialBackendConfig, "")
+		cmd.Flags().String(FlagProfileCredentialProcess, "", "")
+		assert.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+	t.Run("config", func(t *testing.T) {
+		credential, err := getCredentialBackend(getCommand())
+		assert.NoError(t, err)
+		assert.Nil(t, credential)
+	})
+	t.Run("keyring", func(t *testing.T) {
+		credential, err := getCredentialBackend(getCommand("--credential-backend", "keyring"))
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.Credential{Backend: entity.CredentialBackendKeyring}, credential)
+	})
+	t.Run("process", func(t *testing.T) {
+		credential, err := getCredentialBackend(getCommand("--credential-backend", "process", "--credential-process", "vault-creds dev"))
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.Credential{Backend: entity.CredentialBackendProcess, Process: "vault-creds dev"}, credential)
+	})
+	t.Run("process without command", func(t *testing.T) {
+		_, err := getCredentialBackend(getCommand("--credential-backend", "process"))
+		assert.EqualError(t, err, "credential-process is required for credential-backend 'process'")
+	})
+	t.Run("command without process backend", func(t *testing.T) {
+		_, err := getCredentialBackend(getCommand("--credential-backend", "file", "--credential-process", "vault-creds"))
+		assert.EqualError(t, err, "credential-process can only be used with credential-backend 'process'")
+	})
+	t.Run("invalid backend", func(t *testing.T) {
+		_, err := getCredentialBackend(getCommand("--credential-backend", "vault"))
+		assert.EqualError(t, err, "invalid value for credential-backend, allowed values are config, keyring, file, process")
+	})
+}
+
 func TestDeleteProfileCommand(t *testing.T) {
 	t.Run("test delete profile command", func(t *testing.T) {
 		f, err := os.CreateTemp("", "profile-delete")
@@ -227,3 +277,177 @@ func TestListsProfileCommand(t *testing.T) {
 		assert.EqualValues(t, expected, f.Name())
 	})
 }
+
+func TestToProfileOutput(t *testing.T) {
+	t.Run("credentials are not displayed", func(t *testing.T) {
+		actual := toProfileOutput([]entity.Profile{fakeInputProfile()})
+		assert.EqualValues(t, []profileOutput{
+			{
+				Name:     "default",
+				UserName: "admin",
+				Endpoint: "localhost:9200",
+			},
+		}, actual)
+	})
+	t.Run("no profiles", func(t *testing.T) {
+		assert.EqualValues(t, []profileOutput{}, toProfileOutput(nil))
+	})
+}
+
+func TestToProfileFieldOutput(t *testing.T) {
+	timeout := int64(20)
+	p := fakeInputProfile()
+	p.Timeout = &timeout
+	t.Run("profile from file", func(t *testing.T) {
+		assert.EqualValues(t, []profileFieldOutput{
+			{Field: "name", Value: "default", Source: "file"},
+			{Field: "endpoint", Value: "localhost:9200", Source: "file"},
+			{Field: "user", Value: "admin", Source: "file"},
+			{Field: "password", Value: maskedPassword, Source: "file"},
+			{Field: "timeout", Value: "20", Source: "file"},
+		}, toProfileFieldOutput(p, nil))
+	})
+	t.Run("tls settings", func(t *testing.T) {
+		verify := false
+		actual := toProfileFieldOutput(entity.Profile{Name: "tls", TLS: &entity.TLS{
+			Verify:    &verify,
+			CABundles: []string{"ca.pem", "internal-ca.pem"},
+			Pins:      []string{"sha256//pin"},
+		}}, nil)
+		assert.EqualValues(t, []profileFieldOutput{
+			{Field: "name", Value: "tls", Source: "file"},
+			{Field: "tls.verify", Value: "false", Source: "file"},
+			{Field: "tls.ca_bundles", Value: "ca.pem,internal-ca.pem", Source: "file"},
+			{Field: "tls.pins", Value: "sha256//pin", Source: "file"},
+		}, actual)
+	})
+	t.Run("effective profile with defaults", func(t *testing.T) {
+		actual := toProfileFieldOutput(entity.Profile{Name: "environment", Endpoint: "http://opensearch:9200"}, map[string]string{
+			entity.ProfileFieldName:     entity.ProfileSourceEnv,
+			entity.ProfileFieldEndpoint: entity.ProfileSourceEnv,
+		})
+		assert.EqualValues(t, []profileFieldOutput{
+			{Field: "name", Value: "environment", Source: "env"},
+			{Field: "endpoint", Value: "http://opensearch:9200", Source: "env"},
+			{Field: "max_retry", Value: "4", Source: "default"},
+			{Field: "timeout", Value: "10", Source: "default"},
+		}, actual)
+	})
+}
+
+func TestEditProfileCommands(t *testing.T) {
+	f, err := os.CreateTemp("", "profile")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove(f.Name()))
+	}()
+	initial := entity.Config{Profiles: []entity.Profile{
+		{Name: "dev", Endpoint: "https://dev:9200", UserName: "admin", Password: "admin"},
+		{Name: "prod", Endpoint: "https://prod:9200"},
+	}}
+	contents, err := yaml.Marshal(initial)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(f.Name(), contents, 0600))
+	readProfiles := func() []entity.Profile {
+		contents, err := os.ReadFile(f.Name())
+		assert.NoError(t, err)
+		var actual entity.Config
+		assert.NoError(t, yaml.Unmarshal(contents, &actual))
+		return actual.Profiles
+	}
+	execute := func(args ...string) {
+		root := GetRoot()
+		root.SetArgs(append(args, "--"+flagConfig, f.Name()))
+		_, err := root.ExecuteC()
+		assert.NoError(t, err)
+	}
+	t.Run("update", func(t *testing.T) {
+		execute(ProfileCommandName, UpdateProfileCommandName, "dev", "--"+FlagProfileCreateEndpoint, "https://dev-2:9200")
+		profiles := readProfiles()
+		assert.Equal(t, "https://dev-2:9200", profiles[0].Endpoint)
+		assert.Equal(t, "admin", profiles[0].Password)
+	})
+	t.Run("update tls settings", func(t *testing.T) {
+		execute(ProfileCommandName, UpdateProfileCommandName, "prod", "--"+FlagProfileTLSVerify+"=false",
+			"--"+FlagProfileTLSMinVersion, "1.3", "--"+FlagProfileTLSCABundle, "../gateway/testdata/ca.cert")
+		verify := false
+		assert.Equal(t, &entity.TLS{Verify: &verify, MinVersion: "1.3", CABundles: []string{"../gateway/testdata/ca.cert"}}, readProfiles()[1].TLS)
+	})
+	t.Run("rename and copy", func(t *testing.T) {
+		execute(ProfileCommandName, RenameProfileCommandName, "dev", "qa")
+		execute(ProfileCommandName, CopyProfileCommandName, "qa", "dev")
+		var names []string
+		for _, p := range readProfiles() {
+			names = append(names, p.Name)
+		}
+		assert.Equal(t, []string{"qa", "prod", "dev"}, names)
+	})
+	t.Run("export and import", func(t *testing.T) {
+		exported := f.Name() + ".export"
+		defer func() {
+			assert.NoError(t, os.Remove(exported))
+		}()
+		execute(ProfileCommandName, ExportProfilesCommandName, "qa", "--"+FlagProfileFile, exported, "--"+FlagProfileStripSecrets)
+		contents, err := os.ReadFile(exported)
+		assert.NoError(t, err)
+		var actual entity.Config
+		assert.NoError(t, yaml.Unmarshal(contents, &actual))
+		assert.Equal(t, []entity.Profile{{Name: "qa", Endpoint: "https://dev-2:9200", UserName: "admin"}}, actual.Profiles)
+
+		actual.Profiles[0].Endpoint = "https://qa:9200"
+		contents, err = yaml.Marshal(actual)
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(exported, contents, 0600))
+		execute(ProfileCommandName, ImportProfilesCommandName, exported, "--"+FlagProfileOverwrite)
+		profiles := readProfiles()
+		assert.Equal(t, "qa", profiles[0].Name)
+		assert.Equal(t, "https://qa:9200", profiles[0].Endpoint)
+		assert.Empty(t, profiles[0].Password)
+	})
+}
+
+func TestGetConnectionResultRows(t *testing.T) {
+	t.Run("reachable", func(t *testing.T) {
+		rows := getConnectionResultRows(connection.Result{
+			Profile:      "dev",
+			Endpoint:     "https://localhost:9200",
+			Reachable:    true,
+			LatencyMS:    12,
+			ClusterName:  "docker-cluster",
+			Distribution: "opensearch",
+			Version:      "2.11.0",
+			User:         "admin",
+			Roles:        []string{"all_access", "own_index"},
+			Certificates: []connection.Certificate{
+				{Subject: "CN=node-0", Issuer: "CN=root-ca", NotAfter: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), ExpiresInDays: 76},
+				{Subject: "CN=root-ca", Issuer: "CN=root-ca", NotAfter: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), ExpiresInDays: -16},
+			},
+			Plugins: []connection.Plugin{{Name: "opensearch-security", Version: "2.11.0.0"}},
+		})
+		assert.Equal(t, [][2]string{
+			{"Profile", "dev"},
+			{"Endpoint", "https://localhost:9200"},
+			{"Status", "reachable (12 ms)"},
+			{"Cluster", "docker-cluster"},
+			{"Version", "opensearch 2.11.0"},
+			{"User", "admin"},
+			{"Roles", "all_access, own_index"},
+			{"TLS", "CN=node-0 (issuer CN=root-ca) expires 2027-01-01 (in 76 days)"},
+			{"", "CN=root-ca (issuer CN=root-ca) expires 2026-10-01 (expired 16 days ago)"},
+			{"Plugins", "opensearch-security 2.11.0.0"},
+		}, rows)
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		rows := getConnectionResultRows(connection.Result{
+			Profile:  "dev",
+			Endpoint: "https://localhost:9200",
+			Errors:   []string{"connection refused"},
+		})
+		assert.Equal(t, [][2]string{
+			{"Profile", "dev"},
+			{"Endpoint", "https://localhost:9200"},
+			{"Status", "unreachable"},
+			{"Errors", "connection refused"},
+		}, rows)
+	})
+}
@@ -0,0 +1,127 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	UpdateProfileCommandName = "update"
+	RenameProfileCommandName = "rename"
+	CopyProfileCommandName   = "copy"
+)
+
+//updateProfileCmd updates settings of profile which are given as flags
+var updateProfileCmd = &cobra.Command{
+	Use:   UpdateProfileCommandName + " profile_name",
+	Short: "Update profile",
+	Long: "Update settings and credentials of a named profile without changing its position in the config file. " +
+		"Only settings given as flags are changed. If --auth-type is given, authentication details are asked again as for create.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := updateProfile(cmd, args[0]); err != nil {
+			DisplayError(err, UpdateProfileCommandName)
+			return
+		}
+		fmt.Println("Profile updated successfully.")
+	},
+}
+
+//renameProfileCmd renames profile
+var renameProfileCmd = &cobra.Command{
+	Use:   RenameProfileCommandName + " profile_name new_profile_name",
+	Short: "Rename profile",
+	Long:  "Rename profile without changing its settings or its position in the config file.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		profileController, err := GetProfileController()
+		if err == nil {
+			err = profileController.RenameProfile(args[0], args[1])
+		}
+		if err != nil {
+			DisplayError(err, RenameProfileCommandName)
+			return
+		}
+		fmt.Println("Profile renamed successfully.")
+	},
+}
+
+//copyProfileCmd copies profile with new name
+var copyProfileCmd = &cobra.Command{
+	Use:   CopyProfileCommandName + " profile_name new_profile_name",
+	Short: "Copy profile",
+	Long:  "Copy settings and credentials of profile to a new profile, which is added at the end of the config file.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		profileController, err := GetProfileController()
+		if err == nil {
+			err = profileController.CopyProfile(args[0], args[1])
+		}
+		if err != nil {
+			DisplayError(err, CopyProfileCommandName)
+			return
+		}
+		fmt.Println("Profile copied successfully.")
+	},
+}
+
+func init() {
+	profileCommand.AddCommand(updateProfileCmd)
+	addProfileSettingsFlags(updateProfileCmd)
+	updateProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+UpdateProfileCommandName)
+	profileCommand.AddCommand(renameProfileCmd)
+	renameProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+RenameProfileCommandName)
+	profileCommand.AddCommand(copyProfileCmd)
+	copyProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+CopyProfileCommandName)
+}
+
+//updateProfile changes settings of profile which are given as flags, authentication is replaced
+//if auth-type or credential backend is given
+func updateProfile(cmd *cobra.Command, name string) error {
+	profileController, err := GetProfileController()
+	if err != nil {
+		return err
+	}
+	profiles, err := profileController.GetProfilesMap()
+	if err != nil {
+		return err
+	}
+	p, ok := profiles[name]
+	if !ok {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+	flags := cmd.Flags()
+	if flags.Changed(FlagProfileCreateEndpoint) {
+		p.Endpoint, _ = flags.GetString(FlagProfileCreateEndpoint)
+	}
+	if flags.Changed(FlagProfileMaxRetry) {
+		maxAttempt, _ := flags.GetInt(FlagProfileMaxRetry)
+		p.MaxRetry = &maxAttempt
+	}
+	if flags.Changed(FlagProfileTimeout) {
+		timeout, _ := flags.GetInt64(FlagProfileTimeout)
+		p.Timeout = &timeout
+	}
+	if flags.Changed(FlagProfileCreateAuthType) || flags.Changed(FlagProfileCredentialBackend) || flags.Changed(FlagProfileCredentialProcess) {
+		p.UserName, p.Password, p.Credential, p.AWS, p.Certificate = "", "", nil, nil, nil
+		if err = setAuthDetails(cmd, &p); err != nil {
+			return err
+		}
+	}
+	if err = setTLSSettings(cmd, &p); err != nil {
+		return err
+	}
+	return profileController.UpdateProfile(p)
+}
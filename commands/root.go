@@ -14,9 +14,12 @@ package commands
 import (
 	"fmt"
 	"opensearch-cli/entity"
+	"opensearch-cli/environment"
+	"opensearch-cli/formatter"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -25,6 +28,8 @@ const (
 	configFileType        = "yaml"
 	defaultConfigFileName = "config"
 	flagConfig            = "config"
+	flagInsecure          = "insecure"
+	flagOutput            = "output"
 	flagProfileName       = "profile"
 	ConfigEnvVarName      = "OPENSEARCH_CLI_CONFIG"
 	RootCommandName       = "opensearch-cli"
@@ -75,6 +80,11 @@ func init() {
 	configFilePath := GetDefaultConfigFilePath()
 	rootCommand.PersistentFlags().StringP(flagConfig, "c", "", fmt.Sprintf("Configuration file for opensearch-cli, default is %s", configFilePath))
 	rootCommand.PersistentFlags().StringP(flagProfileName, "p", "", "Use a specific profile from your configuration file")
+	rootCommand.PersistentFlags().String(flagOutput, "", fmt.Sprintf(
+		"Output format of the command result, default is command specific. Supported formats are: %s",
+		strings.Join(formatter.SupportedFormats(), ", ")))
+	rootCommand.PersistentFlags().Bool(flagInsecure, false,
+		"Skip verification of cluster certificate for this execution, pinned public keys are still checked")
 	rootCommand.Flags().BoolP("version", "v", false, "Version for opensearch-cli")
 	rootCommand.Flags().BoolP("help", "h", false, "Help for opensearch-cli")
 }
@@ -126,7 +136,62 @@ func GetProfile() (*entity.Profile, error) {
 		return nil, err
 	}
 	if !ok {
-		return nil, fmt.Errorf("no profile found for execution. Try %s %s --help for more information", RootCommandName, ProfileCommandName)
+		return nil, errNoProfileForExecution()
+	}
+	if err = applyInsecureFlag(&profile); err != nil {
+		return nil, err
 	}
 	return &profile, nil
 }
+
+//applyInsecureFlag disables verification of cluster certificate of profile if global insecure flag is set
+func applyInsecureFlag(p *entity.Profile) error {
+	insecure, err := rootCommand.PersistentFlags().GetBool(flagInsecure)
+	if err != nil || !insecure {
+		return err
+	}
+	settings := entity.TLS{}
+	if p.TLS != nil {
+		settings = *p.TLS
+	}
+	verify := false
+	settings.Verify = &verify
+	p.TLS = &settings
+	return nil
+}
+
+//errNoProfileForExecution returns error displayed when neither config file nor environment provides profile
+func errNoProfileForExecution() error {
+	return fmt.Errorf("no profile found for execution. Create a profile or set %s, try %s %s --help for more information",
+		environment.OPENSEARCH_ENDPOINT, RootCommandName, ProfileCommandName)
+}
+
+//newStreamWriter returns StreamWriter for format passed by global output flag, if flag is not set,
+// defaultFormat is used
+func newStreamWriter(defaultFormat string) (formatter.StreamWriter, error) {
+	format, err := rootCommand.PersistentFlags().GetString(flagOutput)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = defaultFormat
+	}
+	return formatter.NewStreamWriter(format, os.Stdout)
+}
+
+// printOutput renders data in the format requested by --output flag. If no format is requested,
+// defaultPrint is called to display the command's default output
+func printOutput(data interface{}, defaultPrint func() error) error {
+	format, err := rootCommand.PersistentFlags().GetString(flagOutput)
+	if err != nil {
+		return err
+	}
+	if format == "" {
+		return defaultPrint()
+	}
+	f, err := formatter.New(format)
+	if err != nil {
+		return err
+	}
+	return f.Format(os.Stdout, data)
+}
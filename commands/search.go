@@ -0,0 +1,95 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"encoding/json"
+	entity "opensearch-cli/entity/platform"
+	"opensearch-cli/formatter"
+	handler "opensearch-cli/handler/platform"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	searchCommandName    = "search"
+	searchBodyFlagName   = "body"
+	searchQueryFlagName  = "q"
+	searchFieldsFlagName = "fields"
+	searchSortFlagName   = "sort"
+	searchSizeFlagName   = "size"
+	searchAllFlagName    = "all"
+)
+
+//searchCommand searches documents of index
+var searchCommand = &cobra.Command{
+	Use:   searchCommandName + " index" + " [flags] ",
+	Short: "Search documents of an index with query DSL or shorthand flags",
+	Long: "Search documents of an index with a query DSL body or shorthand flags, flags take precedence over the body.\n" +
+		"Use `--q` for query string syntax, `--fields` to select source fields, `--sort` to sort by field:order and `--size` for number of hits. " +
+		"Use `--all` to page through every matching document with point in time and search_after, `--size` is then used as page size.\n" +
+		"Hits are streamed as jsonl by default, use the global `--output` flag to display them as csv or table.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := searchDocuments(cmd, args[0])
+		DisplayError(err, searchCommandName)
+	},
+}
+
+func init() {
+	searchCommand.Flags().StringP(searchBodyFlagName, "d", "", "Query DSL body as JSON, or file name with prefix '@'")
+	searchCommand.Flags().StringP(searchQueryFlagName, "", "", "Query in query string syntax, e.g. 'status:error AND service:web'")
+	searchCommand.Flags().StringSliceP(searchFieldsFlagName, "", nil, "Source fields to return, separated by ','")
+	searchCommand.Flags().StringSliceP(searchSortFlagName, "", nil, "Fields to sort by in format field or field:asc|desc, separated by ','")
+	searchCommand.Flags().IntP(searchSizeFlagName, "", 0, "Number of hits, or page size if --all is set")
+	searchCommand.Flags().BoolP(searchAllFlagName, "", false, "Return every matching document using point in time pagination")
+	searchCommand.Flags().BoolP("help", "h", false, "Help for "+searchCommandName)
+	GetRoot().AddCommand(searchCommand)
+}
+
+//GetSearchCommand returns search command
+func GetSearchCommand() *cobra.Command {
+	return searchCommand
+}
+
+//getSearchCommandRequest reads search request from flags
+func getSearchCommandRequest(cmd *cobra.Command, index string) entity.SearchCommandRequest {
+	request := entity.SearchCommandRequest{
+		Index: index,
+	}
+	request.Body, _ = cmd.Flags().GetString(searchBodyFlagName)
+	request.Query, _ = cmd.Flags().GetString(searchQueryFlagName)
+	request.Fields, _ = cmd.Flags().GetStringSlice(searchFieldsFlagName)
+	request.Sort, _ = cmd.Flags().GetStringSlice(searchSortFlagName)
+	request.Size, _ = cmd.Flags().GetInt(searchSizeFlagName)
+	request.All, _ = cmd.Flags().GetBool(searchAllFlagName)
+	return request
+}
+
+//searchDocuments streams hits page by page, default format is jsonl
+func searchDocuments(cmd *cobra.Command, index string) error {
+	h, err := getCurlHandler()
+	if err != nil {
+		return err
+	}
+	writer, err := newStreamWriter(formatter.JSONLines)
+	if err != nil {
+		return err
+	}
+	err = handler.SearchDocuments(h, getSearchCommandRequest(cmd, index), func(hits []json.RawMessage) error {
+		return writer.Write(hits)
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Flush()
+}
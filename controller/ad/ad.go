@@ -19,11 +19,15 @@ import (
 	"io"
 	"opensearch-cli/controller/platform"
 	entity "opensearch-cli/entity/ad"
+	osentity "opensearch-cli/entity/platform"
 	"opensearch-cli/gateway/ad"
 	"opensearch-cli/mapper"
 	admapper "opensearch-cli/mapper/ad"
+	"opensearch-cli/prompt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/cheggaaa/pb/v3"
 )
@@ -44,6 +48,84 @@ type Controller interface {
 	DeleteDetectorByName(context.Context, string, bool, bool) error
 	GetDetectorsByName(context.Context, string, bool) ([]*entity.DetectorOutput, error)
 	UpdateDetector(context.Context, entity.UpdateDetectorUserInput, bool, bool) error
+	GetDetectorStatus(context.Context, string) (*entity.DetectorStatus, error)
+	GetDetectorStatusByName(context.Context, string, bool) ([]*entity.DetectorStatus, error)
+	SearchAnomalyResults(context.Context, []string, entity.AnomalyResultFilter, func([]*entity.AnomalyResultOutput) error) error
+	SearchAnomalyResultsByName(context.Context, []string, entity.AnomalyResultFilter, func([]*entity.AnomalyResultOutput) error) error
+	StartHistoricalAnalysis(context.Context, string, string, string) error
+	StartHistoricalAnalysisByName(context.Context, string, string, string, bool) ([]string, error)
+	StopHistoricalAnalysis(context.Context, string) error
+	StopHistoricalAnalysisByName(context.Context, string, bool) error
+	GetHistoricalAnalysisStatus(context.Context, string) (*entity.HistoricalAnalysisStatus, error)
+	GetHistoricalAnalysisStatusByName(context.Context, string) ([]*entity.HistoricalAnalysisStatus, error)
+	WaitForHistoricalAnalysis(context.Context, []string, time.Duration, bool) ([]*entity.HistoricalAnalysisStatus, error)
+	PreviewAnomalyDetector(context.Context, entity.CreateDetectorRequest, string, string) ([]*entity.AnomalyResultOutput, error)
+	PreviewDetector(context.Context, string, string, string) ([]*entity.AnomalyResultOutput, error)
+	PreviewDetectorByName(context.Context, string, string, string) ([]*entity.AnomalyResultOutput, error)
+	ValidateAnomalyDetector(context.Context, entity.CreateDetectorRequest) ([]*entity.ValidationProblem, error)
+	PlanDetectors(context.Context, map[string]entity.CreateDetectorRequest, string) ([]*entity.DetectorPlan, error)
+	ApplyDetectorPlan(context.Context, []*entity.DetectorPlan, bool, bool) error
+	ExportDetectorsByName(context.Context, string, bool) ([]*entity.CreateDetectorRequest, []*entity.SkippedDetector, error)
+	CopyDetectors(context.Context, []*entity.CreateDetectorRequest, map[string]string, bool) ([]*entity.CopyResult, error)
+}
+
+const (
+	//maxResultsPageSize is maximum number of anomaly results fetched in a single search request
+	maxResultsPageSize = 500
+	//maxDetectorsPageSize is maximum number of detectors fetched in a single search request
+	maxDetectorsPageSize = 1000
+	//detectorNameKeywordField is keyword field of detector name, used to sort detectors and match exact name
+	detectorNameKeywordField = "name.keyword"
+	//taskProgressScale is number of progress bar units per historical analysis task
+	taskProgressScale = 100
+	//sources of problems found by ValidateAnomalyDetector
+	requestValidationSource  = "request"
+	indexValidationSource    = "index"
+	mappingValidationSource  = "mapping"
+	detectorValidationSource = "detector"
+	modelValidationSource    = "model"
+	//disabledState is state of detector which is not running
+	disabledState = "DISABLED"
+)
+
+//numericFieldTypes are field types accepted by numeric aggregations
+var numericFieldTypes = map[string]bool{
+	"long":          true,
+	"integer":       true,
+	"short":         true,
+	"byte":          true,
+	"double":        true,
+	"float":         true,
+	"half_float":    true,
+	"scaled_float":  true,
+	"unsigned_long": true,
+}
+
+//numericAggregations are feature aggregations which accept only numeric fields
+var numericAggregations = map[string]bool{
+	"sum":     true,
+	"average": true,
+	"min":     true,
+	"max":     true,
+}
+
+//dateFieldTypes are field types accepted as time field
+var dateFieldTypes = map[string]bool{
+	"date":       true,
+	"date_nanos": true,
+}
+
+//categoryFieldTypes are field types accepted as category field
+var categoryFieldTypes = map[string]bool{
+	"keyword": true,
+	"ip":      true,
+}
+
+//historicalTaskEndStates are states of historical analysis task which will not change anymore
+var historicalTaskEndStates = map[string]bool{
+	"FINISHED": true,
+	"FAILED":   true,
+	"STOPPED":  true,
 }
 
 type controller struct {
@@ -61,18 +143,34 @@ func New(reader io.Reader, openSearch platform.Controller, gateway ad.Gateway) C
 	}
 }
 
-func validateCreateRequest(r entity.CreateDetectorRequest) error {
+//getCreateRequestProblems returns every missing mandatory field of user request
+func getCreateRequestProblems(r entity.CreateDetectorRequest) []*entity.ValidationProblem {
+	var problems []*entity.ValidationProblem
+	add := func(field string, message string) {
+		problems = append(problems, &entity.ValidationProblem{
+			Source:  requestValidationSource,
+			Field:   field,
+			Message: message,
+		})
+	}
 	if len(r.Name) < 1 {
-		return fmt.Errorf("name field cannot be empty")
+		add("name", "name field cannot be empty")
 	}
 	if len(r.Features) < 1 {
-		return fmt.Errorf("features cannot be empty")
+		add("features", "features cannot be empty")
 	}
 	if len(r.Index) < 1 || len(r.Index[0]) < 1 {
-		return fmt.Errorf("index field cannot be empty and it should have at least one valid index")
+		add("index", "index field cannot be empty and it should have at least one valid index")
 	}
 	if len(r.Interval) < 1 {
-		return fmt.Errorf("interval field cannot be empty")
+		add("interval", "interval field cannot be empty")
+	}
+	return problems
+}
+
+func validateCreateRequest(r entity.CreateDetectorRequest) error {
+	if problems := getCreateRequestProblems(r); len(problems) > 0 {
+		return errors.New(problems[0].Message)
 	}
 	return nil
 }
@@ -101,32 +199,6 @@ func (c controller) StopDetector(ctx context.Context, ID string) error {
 	return nil
 }
 
-func (c controller) askForConfirmation(message *string) bool {
-
-	if message == nil {
-		return true
-	}
-	if len(*message) > 0 {
-		fmt.Print(*message)
-	}
-
-	var response string
-	_, err := fmt.Fscanln(c.reader, &response)
-	if err != nil {
-		//Exit if for some reason, we are not able to accept user input
-		fmt.Printf("failed to accept value from user due to %s", err)
-		os.Exit(1)
-	}
-	switch strings.ToLower(response) {
-	case "y", "yes":
-		return true
-	case "n", "no":
-		return false
-	default:
-		fmt.Printf("please type (y)es or (n)o and then press enter:")
-		return c.askForConfirmation(mapper.StringToStringPtr(""))
-	}
-}
 
 //DeleteDetector deletes detector based on DetectorID, if force is enabled, it stops before deletes
 func (c controller) DeleteDetector(ctx context.Context, id string, interactive bool, force bool) error {
@@ -135,14 +207,12 @@ func (c controller) DeleteDetector(ctx context.Context, id string, interactive b
 	}
 	proceed := true
 	if interactive {
-		proceed = c.askForConfirmation(
-			mapper.StringToStringPtr(
-				fmt.Sprintf(
-					"opensearch-cli will delete detector: %s . Do you want to proceed? Y/N ",
-					id,
-				),
-			),
-		)
+		var err error
+		proceed, err = prompt.Confirm(c.reader,
+			fmt.Sprintf("opensearch-cli will delete detector: %s . Do you want to proceed? Y/N ", id))
+		if err != nil {
+			return err
+		}
 	}
 	if !proceed {
 		return nil
@@ -244,10 +314,10 @@ func (c controller) cleanupCreatedDetectors(ctx context.Context, detectors []ent
 	}
 }
 
-func getFilterValues(ctx context.Context, request entity.CreateDetectorRequest, c controller) ([]interface{}, error) {
+func getFilterValues(ctx context.Context, request entity.CreateDetectorRequest, field string, c controller) ([]interface{}, error) {
 	var filterValues []interface{}
 	for _, index := range request.Index {
-		v, err := c.openSearch.GetDistinctValues(ctx, index, *request.PartitionField)
+		v, err := c.openSearch.GetDistinctValues(ctx, index, field)
 		if err != nil {
 			return nil, err
 		}
@@ -256,6 +326,15 @@ func getFilterValues(ctx context.Context, request entity.CreateDetectorRequest,
 	return filterValues, nil
 }
 
+//getPartitionField returns field to fan out detectors, only one field is allowed
+func getPartitionField(request entity.CreateDetectorRequest) (string, error) {
+	fields := admapper.MapToCategoryField(request)
+	if len(fields) != 1 {
+		return "", fmt.Errorf("creating one detector per value requires exactly one partition field, found %d", len(fields))
+	}
+	return fields[0], nil
+}
+
 //createProgressBar creates progress bar with suffix as counter and number of action completed, prefix as percentage
 func createProgressBar(total int) *pb.ProgressBar {
 	template := `{{string . "prefix"}}{{percent . }} {{bar . "[" "=" ">" "_" "]" }} {{counters . }}{{string . "suffix"}}`
@@ -290,36 +369,41 @@ func buildCompoundQuery(field string, value interface{}, userFilter json.RawMess
 	return marshal
 }
 
-//CreateMultiEntityAnomalyDetector creates multiple entity detector based on partition_by field
+//CreateMultiEntityAnomalyDetector creates detector based on user request. By default, category and partition field
+// are used to create single high cardinality detector. If request.FanOut is set, it creates one detector per distinct
+// value of partition field instead
 func (c controller) CreateMultiEntityAnomalyDetector(ctx context.Context, request entity.CreateDetectorRequest, interactive bool, display bool) ([]string, error) {
-	if request.PartitionField == nil || len(*request.PartitionField) < 1 {
+	if !request.FanOut {
 		result, err := c.CreateAnomalyDetector(ctx, request)
 		if err != nil {
 			return nil, err
 		}
 		return []string{*result}, err
 	}
-	filterValues, err := getFilterValues(ctx, request, c)
+	partitionField, err := getPartitionField(request)
+	if err != nil {
+		return nil, err
+	}
+	filterValues, err := getFilterValues(ctx, request, partitionField, c)
 	if err != nil {
 		return nil, err
 	}
 	if len(filterValues) < 1 {
 		return nil, fmt.Errorf(
 			"failed to get values for partition field: %s, check whether any data is available in index %s",
-			*request.PartitionField,
+			partitionField,
 			request.Index,
 		)
 	}
 	proceed := true
 	if interactive {
-		proceed = c.askForConfirmation(
-			mapper.StringToStringPtr(
-				fmt.Sprintf(
-					"opensearch-cli will create %d detector(s). Do you want to proceed? please type (y)es or (n)o and then press enter:",
-					len(filterValues),
-				),
-			),
-		)
+		proceed, err = prompt.Confirm(c.reader, fmt.Sprintf(
+			"opensearch-cli will create %d detector(s). Do you want to proceed? please type (y)es or (n)o and then press enter:",
+			len(filterValues),
+		))
+		if err != nil {
+			return nil, err
+		}
 	}
 	if !proceed {
 		return nil, nil
@@ -332,8 +416,11 @@ func (c controller) CreateMultiEntityAnomalyDetector(ctx context.Context, reques
 	name := request.Name
 	filter := request.Filter
 	var createdDetectors []entity.Detector
+	// every detector is single entity detector filtered by partition value
+	request.PartitionField = nil
+	request.CategoryField = nil
 	for _, value := range filterValues {
-		request.Filter = buildCompoundQuery(*request.PartitionField, value, filter)
+		request.Filter = buildCompoundQuery(partitionField, value, filter)
 		request.Name = fmt.Sprintf("%s-%s", name, value)
 		result, err := c.CreateAnomalyDetector(ctx, request)
 		if err != nil {
@@ -355,27 +442,45 @@ func (c controller) CreateMultiEntityAnomalyDetector(ctx context.Context, reques
 	return detectors, nil
 }
 
+//searchDetectorHits pages through every detector found by query sorted by name
+func (c controller) searchDetectorHits(ctx context.Context, query entity.SearchQuery) ([]entity.Hit, error) {
+	payload := entity.SearchRequest{
+		Query: query,
+		Size:  maxDetectorsPageSize,
+		Sort:  []interface{}{map[string]string{detectorNameKeywordField: "asc"}},
+	}
+	var hits []entity.Hit
+	for {
+		response, err := c.gateway.SearchDetector(ctx, payload)
+		if err != nil {
+			return nil, err
+		}
+		var data entity.SearchResponse
+		if err = json.Unmarshal(response, &data); err != nil {
+			return nil, err
+		}
+		hits = append(hits, data.Hits.Hits...)
+		if len(data.Hits.Hits) < maxDetectorsPageSize {
+			return hits, nil
+		}
+		payload.SearchAfter = data.Hits.Hits[len(data.Hits.Hits)-1].Sort
+	}
+}
+
 //SearchDetectorByName searches detector based on name
 func (c controller) SearchDetectorByName(ctx context.Context, name string) ([]entity.Detector, error) {
 	if len(name) < 1 {
 		return nil, fmt.Errorf("detector name cannot be empty")
 	}
-	payload := entity.SearchRequest{
-		Query: entity.SearchQuery{
-			Match: entity.Match{
-				Name: name,
-			},
+	hits, err := c.searchDetectorHits(ctx, entity.SearchQuery{
+		Match: &entity.Match{
+			Name: name,
 		},
-	}
-	response, err := c.gateway.SearchDetector(ctx, payload)
-	if err != nil {
-		return nil, err
-	}
-	detectors, err := admapper.MapToDetectors(response, name)
+	})
 	if err != nil {
 		return nil, err
 	}
-	return detectors, nil
+	return admapper.MapHitsToDetectors(hits, name), nil
 }
 
 //getDetectors expand pattern to fetch list of matched detectors and return detectors accepted by user
@@ -390,7 +495,8 @@ func (c controller) getDetectors(ctx context.Context, method string, pattern str
 		return nil, err
 	}
 	if len(matchedDetectors) < 1 {
-		fmt.Printf("no detectors matched by name %s\n", pattern)
+		//message is printed on stderr so that it does not corrupt output requested by --output flag
+		fmt.Fprintf(os.Stderr, "no detectors matched by name %s\n", pattern)
 		return nil, nil
 	}
 	if !warning {
@@ -401,13 +507,10 @@ func (c controller) getDetectors(ctx context.Context, method string, pattern str
 		fmt.Println(detector.Name)
 	}
 
-	proceed := c.askForConfirmation(
-		mapper.StringToStringPtr(
-			fmt.Sprintf("opensearch-cli will %s above matched detector(s). Do you want to proceed? Y/N ", method),
-		),
-	)
-	if !proceed {
-		return nil, nil
+	proceed, err := prompt.Confirm(c.reader,
+		fmt.Sprintf("opensearch-cli will %s above matched detector(s). Do you want to proceed? Y/N ", method))
+	if err != nil || !proceed {
+		return nil, err
 	}
 	return matchedDetectors, nil
 }
@@ -544,16 +647,10 @@ func (c controller) UpdateDetector(ctx context.Context, input entity.UpdateDetec
 				"new version for detector is available. Please fetch latest version and then merge your changes")
 		}
 	}
-	proceed := c.askForConfirmation(
-		mapper.StringToStringPtr(
-			fmt.Sprintf(
-				"opensearch-cli will update detector: %s . Do you want to proceed? Y/N ",
-				input.ID,
-			),
-		),
-	)
-	if !proceed {
-		return nil
+	proceed, err := prompt.Confirm(c.reader,
+		fmt.Sprintf("opensearch-cli will update detector: %s . Do you want to proceed? Y/N ", input.ID))
+	if err != nil || !proceed {
+		return err
 	}
 	if force { // stop detector implicit since force is true
 		err := c.StopDetector(ctx, input.ID)
@@ -574,3 +671,826 @@ func (c controller) UpdateDetector(ctx context.Context, input entity.UpdateDetec
 	}
 	return c.StartDetector(ctx, input.ID) // Start Detector if successfully updated it
 }
+
+//getDetectorStatus fetch detector's profile and maps it to status
+func (c controller) getDetectorStatus(ctx context.Context, detector entity.Detector) (*entity.DetectorStatus, error) {
+	response, err := c.gateway.GetDetectorProfile(ctx, detector.ID)
+	if err != nil {
+		return nil, err
+	}
+	return admapper.MapToDetectorStatus(detector, response)
+}
+
+//GetDetectorStatus fetch lifecycle status like state, initialization progress and error based on DetectorID
+func (c controller) GetDetectorStatus(ctx context.Context, ID string) (*entity.DetectorStatus, error) {
+	detector, err := c.GetDetector(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	return c.getDetectorStatus(ctx, entity.Detector{
+		ID:   detector.ID,
+		Name: detector.Name,
+	})
+}
+
+//GetDetectorStatusByName get detector status based on name pattern. It first calls SearchDetectorByName and then
+// gets lists of detectorId and fetch profile for individual detector
+func (c controller) GetDetectorStatusByName(ctx context.Context, pattern string, display bool) ([]*entity.DetectorStatus, error) {
+	matchedDetectors, err := c.getDetectors(ctx, "fetch status of", pattern, false)
+	if err != nil {
+		return nil, err
+	}
+	if matchedDetectors == nil {
+		return nil, nil
+	}
+	var bar *pb.ProgressBar
+	if display {
+		bar = createProgressBar(len(matchedDetectors))
+	}
+	var output []*entity.DetectorStatus
+	for _, detector := range matchedDetectors {
+		status, err := c.getDetectorStatus(ctx, detector)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, status)
+		if bar != nil {
+			bar.Increment()
+		}
+	}
+	if bar != nil {
+		bar.Finish()
+	}
+	return output, nil
+}
+
+//searchAnomalyResults pages through anomaly results of detectors using search_after, and passes
+// every page to process until limit is reached or no more results are available
+func (c controller) searchAnomalyResults(ctx context.Context, detectors []entity.Detector, filter entity.AnomalyResultFilter, process func([]*entity.AnomalyResultOutput) error) error {
+	names := map[string]string{}
+	var ids []string
+	for _, detector := range detectors {
+		if _, ok := names[detector.ID]; ok {
+			continue
+		}
+		names[detector.ID] = detector.Name
+		ids = append(ids, detector.ID)
+	}
+	remaining := filter.Limit
+	var searchAfter []interface{}
+	for {
+		size := maxResultsPageSize
+		if filter.Limit > 0 && remaining < size {
+			size = remaining
+		}
+		request, err := admapper.MapToAnomalyResultSearchRequest(ids, filter, size, searchAfter)
+		if err != nil {
+			return err
+		}
+		response, err := c.gateway.SearchAnomalyResults(ctx, request)
+		if err != nil {
+			return processEntityError(err)
+		}
+		var data entity.AnomalyResultSearchResponse
+		if err = json.Unmarshal(response, &data); err != nil {
+			return err
+		}
+		hits := data.Hits.Hits
+		if len(hits) < 1 {
+			return nil
+		}
+		var output []*entity.AnomalyResultOutput
+		for _, hit := range hits {
+			output = append(output, admapper.MapToAnomalyResultOutput(hit.Source, names[hit.Source.DetectorID]))
+		}
+		if err = process(output); err != nil {
+			return err
+		}
+		remaining -= len(hits)
+		if (filter.Limit > 0 && remaining < 1) || len(hits) < size {
+			return nil
+		}
+		searchAfter = hits[len(hits)-1].Sort
+	}
+}
+
+//SearchAnomalyResults search anomaly results based on list of DetectorID and filter, results are passed
+// to process page by page
+func (c controller) SearchAnomalyResults(ctx context.Context, IDs []string, filter entity.AnomalyResultFilter, process func([]*entity.AnomalyResultOutput) error) error {
+	var detectors []entity.Detector
+	for _, ID := range IDs {
+		detector, err := c.GetDetector(ctx, ID)
+		if err != nil {
+			return err
+		}
+		detectors = append(detectors, entity.Detector{
+			ID:   detector.ID,
+			Name: detector.Name,
+		})
+	}
+	if len(detectors) < 1 {
+		return fmt.Errorf("detector Id cannot be empty")
+	}
+	return c.searchAnomalyResults(ctx, detectors, filter, process)
+}
+
+//SearchAnomalyResultsByName search anomaly results based on list of name patterns. It first calls
+// SearchDetectorByName for every pattern and then searches results of all matched detectors
+func (c controller) SearchAnomalyResultsByName(ctx context.Context, patterns []string, filter entity.AnomalyResultFilter, process func([]*entity.AnomalyResultOutput) error) error {
+	var detectors []entity.Detector
+	for _, pattern := range patterns {
+		matchedDetectors, err := c.getDetectors(ctx, "search results of", pattern, false)
+		if err != nil {
+			return err
+		}
+		detectors = append(detectors, matchedDetectors...)
+	}
+	if len(detectors) < 1 {
+		return nil
+	}
+	return c.searchAnomalyResults(ctx, detectors, filter, process)
+}
+
+//startHistoricalAnalysis starts historical analysis of detector for the date range
+func (c controller) startHistoricalAnalysis(ctx context.Context, ID string, request *entity.HistoricalAnalysisRequest) error {
+	if len(ID) < 1 {
+		return fmt.Errorf("detector Id: %s cannot be empty", ID)
+	}
+	_, err := c.gateway.StartHistoricalAnalysis(ctx, ID, request)
+	if err != nil {
+		return processEntityError(err)
+	}
+	return nil
+}
+
+//StartHistoricalAnalysis starts historical analysis of detector based on DetectorID for time range from and to
+func (c controller) StartHistoricalAnalysis(ctx context.Context, ID string, from string, to string) error {
+	request, err := admapper.MapToHistoricalAnalysisRequest(from, to)
+	if err != nil {
+		return err
+	}
+	return c.startHistoricalAnalysis(ctx, ID, request)
+}
+
+//StartHistoricalAnalysisByName starts historical analysis of detectors based on name pattern. It first calls
+// SearchDetectorByName and then gets lists of detectorId and starts historical analysis of individual detectors.
+// IDs of detectors whose analysis is started are returned, none if user declined to proceed
+func (c controller) StartHistoricalAnalysisByName(ctx context.Context, pattern string, from string, to string, display bool) ([]string, error) {
+	request, err := admapper.MapToHistoricalAnalysisRequest(from, to)
+	if err != nil {
+		return nil, err
+	}
+	var started []string
+	err = c.processDetectorByAction(ctx, pattern, "start historical analysis of", func(ctx context.Context, ID string) error {
+		if err := c.startHistoricalAnalysis(ctx, ID, request); err != nil {
+			return err
+		}
+		started = append(started, ID)
+		return nil
+	}, display, true)
+	return started, err
+}
+
+//StopHistoricalAnalysis stops historical analysis of detector based on DetectorID
+func (c controller) StopHistoricalAnalysis(ctx context.Context, ID string) error {
+	if len(ID) < 1 {
+		return fmt.Errorf("detector Id: %s cannot be empty", ID)
+	}
+	if err := c.gateway.StopHistoricalAnalysis(ctx, ID); err != nil {
+		return processEntityError(err)
+	}
+	return nil
+}
+
+//StopHistoricalAnalysisByName stops historical analysis of detectors based on name pattern. It first calls
+// SearchDetectorByName and then gets lists of detectorId and stops historical analysis of individual detectors
+func (c controller) StopHistoricalAnalysisByName(ctx context.Context, pattern string, display bool) error {
+	return c.processDetectorByAction(ctx, pattern, "stop historical analysis of", c.StopHistoricalAnalysis, display, true)
+}
+
+//getDetectorTask fetch detector along with its latest historical analysis task
+func (c controller) getDetectorTask(ctx context.Context, ID string) (*entity.DetectorTaskResponse, error) {
+	if len(ID) < 1 {
+		return nil, fmt.Errorf("detector Id: %s cannot be empty", ID)
+	}
+	response, err := c.gateway.GetDetectorTask(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	var data entity.DetectorTaskResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+//GetHistoricalAnalysisStatus fetch status of latest historical analysis based on DetectorID
+func (c controller) GetHistoricalAnalysisStatus(ctx context.Context, ID string) (*entity.HistoricalAnalysisStatus, error) {
+	response, err := c.getDetectorTask(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	return admapper.MapToHistoricalAnalysisStatus(*response), nil
+}
+
+//GetHistoricalAnalysisStatusByName fetch status of latest historical analysis based on name pattern. It first calls
+// SearchDetectorByName and then gets lists of detectorId and fetch status for individual detector
+func (c controller) GetHistoricalAnalysisStatusByName(ctx context.Context, pattern string) ([]*entity.HistoricalAnalysisStatus, error) {
+	matchedDetectors, err := c.getDetectors(ctx, "fetch historical analysis status of", pattern, false)
+	if err != nil {
+		return nil, err
+	}
+	var output []*entity.HistoricalAnalysisStatus
+	for _, detector := range matchedDetectors {
+		status, err := c.GetHistoricalAnalysisStatus(ctx, detector.ID)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, status)
+	}
+	return output, nil
+}
+
+//createTaskProgressBar creates progress bar for tasks, with suffix as number of finished tasks, prefix as percentage
+func createTaskProgressBar(tasks int) *pb.ProgressBar {
+	template := `{{string . "prefix"}}{{percent . }} {{bar . "[" "=" ">" "_" "]" }}{{string . "suffix"}}`
+	bar := pb.New(tasks * taskProgressScale)
+	bar.SetTemplateString(template)
+	bar.SetMaxWidth(65)
+	bar.Start()
+	return bar
+}
+
+//WaitForHistoricalAnalysis polls historical analysis of detectors based on DetectorID every interval
+// until every task is finished, failed or stopped
+func (c controller) WaitForHistoricalAnalysis(ctx context.Context, IDs []string, interval time.Duration, display bool) ([]*entity.HistoricalAnalysisStatus, error) {
+	var bar *pb.ProgressBar
+	if display {
+		bar = createTaskProgressBar(len(IDs))
+		defer bar.Finish()
+	}
+	for {
+		var output []*entity.HistoricalAnalysisStatus
+		progress, finished := 0, 0
+		for _, ID := range IDs {
+			response, err := c.getDetectorTask(ctx, ID)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, admapper.MapToHistoricalAnalysisStatus(*response))
+			task := response.HistoricalTask
+			if task == nil || historicalTaskEndStates[task.State] {
+				progress += taskProgressScale
+				finished++
+				continue
+			}
+			progress += int(task.TaskProgress * taskProgressScale)
+		}
+		if bar != nil {
+			bar.SetCurrent(int64(progress))
+			bar.Set("suffix", fmt.Sprintf(" %d/%d finished", finished, len(IDs)))
+		}
+		if finished == len(IDs) {
+			return output, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+//previewDetector calls preview API and maps sample anomaly results for the detector name
+func (c controller) previewDetector(ctx context.Context, request *entity.PreviewRequest, name string) ([]*entity.AnomalyResultOutput, error) {
+	response, err := c.gateway.PreviewDetector(ctx, request)
+	if err != nil {
+		return nil, processEntityError(err)
+	}
+	var data entity.PreviewResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, err
+	}
+	output := []*entity.AnomalyResultOutput{}
+	for _, result := range data.AnomalyResult {
+		output = append(output, admapper.MapToAnomalyResultOutput(result, name))
+	}
+	return output, nil
+}
+
+//PreviewAnomalyDetector previews detector based on user request for time range from and to, without creating it
+func (c controller) PreviewAnomalyDetector(ctx context.Context, r entity.CreateDetectorRequest, from string, to string) ([]*entity.AnomalyResultOutput, error) {
+	if err := validateCreateRequest(r); err != nil {
+		return nil, err
+	}
+	detector, err := admapper.MapToCreateDetector(r)
+	if err != nil {
+		return nil, err
+	}
+	request, err := admapper.MapToPreviewRequest(from, to)
+	if err != nil {
+		return nil, err
+	}
+	request.Detector = detector
+	return c.previewDetector(ctx, request, r.Name)
+}
+
+//PreviewDetector previews existing detector based on DetectorID for time range from and to
+func (c controller) PreviewDetector(ctx context.Context, ID string, from string, to string) ([]*entity.AnomalyResultOutput, error) {
+	request, err := admapper.MapToPreviewRequest(from, to)
+	if err != nil {
+		return nil, err
+	}
+	detector, err := c.GetDetector(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	request.DetectorID = detector.ID
+	return c.previewDetector(ctx, request, detector.Name)
+}
+
+//PreviewDetectorByName previews existing detectors based on name pattern. It first calls SearchDetectorByName
+// and then gets lists of detectorId and previews individual detectors
+func (c controller) PreviewDetectorByName(ctx context.Context, pattern string, from string, to string) ([]*entity.AnomalyResultOutput, error) {
+	request, err := admapper.MapToPreviewRequest(from, to)
+	if err != nil {
+		return nil, err
+	}
+	matchedDetectors, err := c.getDetectors(ctx, "preview", pattern, false)
+	if err != nil {
+		return nil, err
+	}
+	output := []*entity.AnomalyResultOutput{}
+	for _, detector := range matchedDetectors {
+		request.DetectorID = detector.ID
+		results, err := c.previewDetector(ctx, request, detector.Name)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, results...)
+	}
+	return output, nil
+}
+
+//getValidationFields returns unique fields used by detector which have to exist in index mapping
+func getValidationFields(r entity.CreateDetectorRequest) []string {
+	seen := map[string]bool{}
+	var fields []string
+	add := func(field string) {
+		if len(field) < 1 || seen[field] {
+			return
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	add(r.TimeField)
+	for _, feature := range r.Features {
+		for _, field := range feature.Field {
+			add(field)
+		}
+	}
+	for _, field := range admapper.MapToCategoryField(r) {
+		add(field)
+	}
+	return fields
+}
+
+//getFieldTypes returns sorted types of field in field capabilities
+func getFieldTypes(capabilities map[string]osentity.FieldCapability) []string {
+	var types []string
+	for t := range capabilities {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+//getMappingProblems checks that time field is date, every feature field is aggregatable and category fields
+// are keyword or ip, in indices matched by index
+func getMappingProblems(r entity.CreateDetectorRequest, index string, response *osentity.FieldCapabilitiesResponse) []*entity.ValidationProblem {
+	var problems []*entity.ValidationProblem
+	add := func(field string, format string, a ...interface{}) {
+		problems = append(problems, &entity.ValidationProblem{
+			Source:  mappingValidationSource,
+			Field:   field,
+			Message: fmt.Sprintf(format, a...),
+		})
+	}
+	if len(r.TimeField) > 0 {
+		capabilities, ok := response.Fields[r.TimeField]
+		if !ok {
+			add("time_field", "time field %s does not exist in index %s", r.TimeField, index)
+		}
+		for _, t := range getFieldTypes(capabilities) {
+			if !dateFieldTypes[t] {
+				add("time_field", "time field %s must be date, found %s in index %s", r.TimeField, t, index)
+			}
+		}
+	}
+	for i, feature := range r.Features {
+		for _, field := range feature.Field {
+			name := fmt.Sprintf("features[%d].field", i)
+			capabilities, ok := response.Fields[field]
+			if !ok {
+				add(name, "feature field %s does not exist in index %s", field, index)
+				continue
+			}
+			for _, t := range getFieldTypes(capabilities) {
+				if !capabilities[t].Aggregatable {
+					add(name, "feature field %s of type %s is not aggregatable in index %s", field, t, index)
+					continue
+				}
+				for _, agg := range feature.AggregationType {
+					if numericAggregations[strings.ToLower(agg)] && !numericFieldTypes[t] {
+						add(name, "aggregation %s requires numeric field, found %s of type %s in index %s", agg, field, t, index)
+					}
+				}
+			}
+		}
+	}
+	for _, field := range admapper.MapToCategoryField(r) {
+		capabilities, ok := response.Fields[field]
+		if !ok {
+			add("category_field", "category field %s does not exist in index %s", field, index)
+		}
+		for _, t := range getFieldTypes(capabilities) {
+			if !categoryFieldTypes[t] {
+				add("category_field", "category field %s must be keyword or ip, found %s in index %s", field, t, index)
+			}
+		}
+	}
+	return problems
+}
+
+//getIndexProblems checks that every index exists and contains fields used by detector
+func (c controller) getIndexProblems(ctx context.Context, r entity.CreateDetectorRequest) []*entity.ValidationProblem {
+	fields := getValidationFields(r)
+	var problems []*entity.ValidationProblem
+	for _, index := range r.Index {
+		if len(index) < 1 || len(fields) < 1 {
+			continue
+		}
+		response, err := c.openSearch.GetFieldCapabilities(ctx, index, fields)
+		if err != nil {
+			problems = append(problems, &entity.ValidationProblem{
+				Source:  indexValidationSource,
+				Field:   "index",
+				Message: fmt.Sprintf("failed to get mapping of index %s due to %v", index, processEntityError(err)),
+			})
+			continue
+		}
+		if len(response.Indices) < 1 {
+			problems = append(problems, &entity.ValidationProblem{
+				Source:  indexValidationSource,
+				Field:   "index",
+				Message: fmt.Sprintf("index %s does not exist", index),
+			})
+			continue
+		}
+		problems = append(problems, getMappingProblems(r, index, response)...)
+	}
+	return problems
+}
+
+//validateDetector calls validate API for validationType and returns problems found
+func (c controller) validateDetector(ctx context.Context, validationType string, payload *entity.CreateDetector) ([]*entity.ValidationProblem, error) {
+	response, err := c.gateway.ValidateDetector(ctx, validationType, payload)
+	if err != nil {
+		return []*entity.ValidationProblem{{
+			Source:  validationType,
+			Message: processEntityError(err).Error(),
+		}}, nil
+	}
+	var data entity.ValidationResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, err
+	}
+	problems := admapper.MapToValidationProblems(detectorValidationSource, data.Detector)
+	return append(problems, admapper.MapToValidationProblems(modelValidationSource, data.Model)...), nil
+}
+
+//ValidateAnomalyDetector validates user request without creating detector and returns every problem found.
+// It checks mandatory fields and index mapping of fields used by detector, then calls validate API of detector.
+// Model is validated only if detector has no problem, since model validation stops at first detector problem
+func (c controller) ValidateAnomalyDetector(ctx context.Context, r entity.CreateDetectorRequest) ([]*entity.ValidationProblem, error) {
+	problems := getCreateRequestProblems(r)
+	requestIsComplete := len(problems) < 1
+	problems = append(problems, c.getIndexProblems(ctx, r)...)
+	if !requestIsComplete {
+		return problems, nil
+	}
+	payload, err := admapper.MapToCreateDetector(r)
+	if err != nil {
+		return append(problems, &entity.ValidationProblem{
+			Source:  requestValidationSource,
+			Message: err.Error(),
+		}), nil
+	}
+	for _, validationType := range []string{detectorValidationSource, modelValidationSource} {
+		found, err := c.validateDetector(ctx, validationType, payload)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, found...)
+		if len(found) > 0 {
+			break
+		}
+	}
+	return problems, nil
+}
+
+//getLiveDetector returns detector whose name is exactly name, nil if there is no such detector
+func (c controller) getLiveDetector(ctx context.Context, name string) (*entity.DetectorOutput, error) {
+	hits, err := c.searchDetectorHits(ctx, entity.SearchQuery{
+		Term: &entity.Term{
+			Name: name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, hit := range hits {
+		if hit.Source.Name == name {
+			return c.GetDetector(ctx, hit.ID)
+		}
+	}
+	return nil, nil
+}
+
+//planDetector compares request from file with live detector of same name
+func (c controller) planDetector(ctx context.Context, file string, r entity.CreateDetectorRequest) (*entity.DetectorPlan, error) {
+	if err := validateCreateRequest(r); err != nil {
+		return nil, fmt.Errorf("file %s cannot be accepted due to %v", file, err)
+	}
+	desired, err := admapper.MapToCreateDetector(r)
+	if err != nil {
+		return nil, fmt.Errorf("file %s cannot be accepted due to %v", file, err)
+	}
+	live, err := c.getLiveDetector(ctx, r.Name)
+	if err != nil {
+		return nil, err
+	}
+	plan := &entity.DetectorPlan{
+		Action:  entity.PlanActionCreate,
+		Name:    r.Name,
+		File:    file,
+		Request: &r,
+	}
+	if live == nil {
+		return plan, nil
+	}
+	plan.ID = live.ID
+	if plan.Changes, err = admapper.MapToDetectorDiff(*live, *desired); err != nil {
+		return nil, err
+	}
+	plan.Action = entity.PlanActionUpdate
+	if len(plan.Changes) < 1 {
+		plan.Action = entity.PlanActionUnchanged
+	}
+	return plan, nil
+}
+
+//PlanDetectors compares requests by file name with live detectors and returns actions required to reconcile
+// them. If prunePattern is not empty, live detectors matched by the pattern without file are deleted
+func (c controller) PlanDetectors(ctx context.Context, requests map[string]entity.CreateDetectorRequest, prunePattern string) ([]*entity.DetectorPlan, error) {
+	var files []string
+	for file := range requests {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	definedIn := map[string]string{}
+	var plans []*entity.DetectorPlan
+	for _, file := range files {
+		r := requests[file]
+		if other, ok := definedIn[r.Name]; ok {
+			return nil, fmt.Errorf("detector %s is defined in both %s and %s", r.Name, other, file)
+		}
+		definedIn[r.Name] = file
+		plan, err := c.planDetector(ctx, file, r)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	if len(prunePattern) < 1 {
+		return plans, nil
+	}
+	matchedDetectors, err := c.SearchDetectorByName(ctx, prunePattern)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(matchedDetectors, func(i, j int) bool {
+		return matchedDetectors[i].Name < matchedDetectors[j].Name
+	})
+	for _, detector := range matchedDetectors {
+		if _, ok := definedIn[detector.Name]; ok {
+			continue
+		}
+		plans = append(plans, &entity.DetectorPlan{
+			Action: entity.PlanActionDelete,
+			Name:   detector.Name,
+			ID:     detector.ID,
+		})
+	}
+	return plans, nil
+}
+
+//isDetectorRunning checks whether detector is initializing or running
+func (c controller) isDetectorRunning(ctx context.Context, plan *entity.DetectorPlan) (bool, error) {
+	status, err := c.getDetectorStatus(ctx, entity.Detector{
+		ID:   plan.ID,
+		Name: plan.Name,
+	})
+	if err != nil {
+		return false, err
+	}
+	return status.State != disabledState, nil
+}
+
+//updateDetectorByPlan updates detector with request of plan, running detector is stopped before update and
+// started afterwards
+func (c controller) updateDetectorByPlan(ctx context.Context, plan *entity.DetectorPlan) error {
+	running, err := c.isDetectorRunning(ctx, plan)
+	if err != nil {
+		return err
+	}
+	payload, err := admapper.MapToCreateDetector(*plan.Request)
+	if err != nil {
+		return err
+	}
+	if running {
+		if err = c.StopDetector(ctx, plan.ID); err != nil {
+			return err
+		}
+	}
+	update := entity.UpdateDetector(*payload)
+	if err = c.gateway.UpdateDetector(ctx, plan.ID, &update); err != nil {
+		return processEntityError(err)
+	}
+	if !running && !plan.Request.Start {
+		return nil
+	}
+	return c.StartDetector(ctx, plan.ID)
+}
+
+//deleteDetectorByPlan deletes detector, running detector is stopped before delete
+func (c controller) deleteDetectorByPlan(ctx context.Context, plan *entity.DetectorPlan) error {
+	running, err := c.isDetectorRunning(ctx, plan)
+	if err != nil {
+		return err
+	}
+	return c.DeleteDetector(ctx, plan.ID, false, running)
+}
+
+//applyDetectorPlan executes action of plan
+func (c controller) applyDetectorPlan(ctx context.Context, plan *entity.DetectorPlan) error {
+	switch plan.Action {
+	case entity.PlanActionCreate:
+		_, err := c.CreateAnomalyDetector(ctx, *plan.Request)
+		return err
+	case entity.PlanActionUpdate:
+		return c.updateDetectorByPlan(ctx, plan)
+	case entity.PlanActionDelete:
+		return c.deleteDetectorByPlan(ctx, plan)
+	}
+	return nil
+}
+
+//ApplyDetectorPlan creates, updates or deletes detectors as planned by PlanDetectors. If interactive is true,
+// user is asked for confirmation before any change. Every plan is applied even if some of them failed
+func (c controller) ApplyDetectorPlan(ctx context.Context, plans []*entity.DetectorPlan, interactive bool, display bool) error {
+	var changes []*entity.DetectorPlan
+	for _, plan := range plans {
+		if plan.Action != entity.PlanActionUnchanged {
+			changes = append(changes, plan)
+		}
+	}
+	if len(changes) < 1 {
+		if display {
+			fmt.Println("no changes to apply")
+		}
+		return nil
+	}
+	if interactive {
+		proceed, err := prompt.Confirm(c.reader,
+			fmt.Sprintf("opensearch-cli will apply above %d change(s). Do you want to proceed? Y/N ", len(changes)))
+		if err != nil || !proceed {
+			return err
+		}
+	}
+	var bar *pb.ProgressBar
+	if display {
+		bar = createProgressBar(len(changes))
+	}
+	var failedDetectors []string
+	for _, plan := range changes {
+		if err := c.applyDetectorPlan(ctx, plan); err != nil {
+			failedDetectors = append(failedDetectors, fmt.Sprintf("%s \t Reason: %s", plan.Name, err))
+			continue
+		}
+		if bar != nil {
+			bar.Increment()
+		}
+	}
+	if bar != nil {
+		bar.Finish()
+	}
+	if len(failedDetectors) < 1 {
+		return nil
+	}
+	fmt.Printf("\nfailed to apply %d following detector(s)\n", len(failedDetectors))
+	for _, detector := range failedDetectors {
+		fmt.Println(detector)
+	}
+	return fmt.Errorf("failed to apply %d detector(s)", len(failedDetectors))
+}
+
+//ExportDetectorsByName fetch detectors based on name pattern and maps them to requests accepted by
+// CreateAnomalyDetector, so that they can be created again. Detectors which cannot be mapped are skipped and returned
+// with reason instead of failing the whole export
+func (c controller) ExportDetectorsByName(ctx context.Context, pattern string, display bool) ([]*entity.CreateDetectorRequest, []*entity.SkippedDetector, error) {
+	detectors, err := c.GetDetectorsByName(ctx, pattern, display)
+	if err != nil {
+		return nil, nil, err
+	}
+	var requests []*entity.CreateDetectorRequest
+	var skipped []*entity.SkippedDetector
+	for _, detector := range detectors {
+		request, err := admapper.MapToCreateDetectorRequest(*detector)
+		if err != nil {
+			skipped = append(skipped, &entity.SkippedDetector{Name: detector.Name, Reason: err.Error()})
+			continue
+		}
+		requests = append(requests, request)
+	}
+	return requests, skipped, nil
+}
+
+//rewriteIndices replaces every index of request found in indexRewrites by its new name
+func rewriteIndices(r *entity.CreateDetectorRequest, indexRewrites map[string]string) {
+	for i, index := range r.Index {
+		if newIndex, ok := indexRewrites[index]; ok {
+			r.Index[i] = newIndex
+		}
+	}
+}
+
+//copyDetector creates detector from request unless detector with same name already exists
+func (c controller) copyDetector(ctx context.Context, r entity.CreateDetectorRequest) *entity.CopyResult {
+	result := &entity.CopyResult{
+		Name: r.Name,
+	}
+	live, err := c.getLiveDetector(ctx, r.Name)
+	if err != nil {
+		result.Status = entity.CopyStatusFailed
+		result.Reason = err.Error()
+		return result
+	}
+	if live != nil {
+		result.Status = entity.CopyStatusConflict
+		result.ID = live.ID
+		result.Reason = "detector with same name already exists"
+		return result
+	}
+	id, err := c.CreateAnomalyDetector(ctx, r)
+	if err != nil {
+		result.Status = entity.CopyStatusFailed
+		result.Reason = err.Error()
+		return result
+	}
+	result.Status = entity.CopyStatusCreated
+	result.ID = *id
+	return result
+}
+
+//CopyDetectors creates detectors from requests exported from another cluster, indices are renamed as given by
+// indexRewrites. Detectors whose name already exists are not modified and reported as conflict. Every request
+// is processed even if some of them failed
+func (c controller) CopyDetectors(ctx context.Context, requests []*entity.CreateDetectorRequest, indexRewrites map[string]string, display bool) ([]*entity.CopyResult, error) {
+	var bar *pb.ProgressBar
+	if display && len(requests) > 0 {
+		bar = createProgressBar(len(requests))
+	}
+	var results []*entity.CopyResult
+	notCopied := 0
+	for _, request := range requests {
+		r := *request
+		r.Index = append([]string{}, request.Index...)
+		rewriteIndices(&r, indexRewrites)
+		result := c.copyDetector(ctx, r)
+		if result.Status != entity.CopyStatusCreated {
+			notCopied++
+		}
+		results = append(results, result)
+		if bar != nil {
+			bar.Increment()
+		}
+	}
+	if bar != nil {
+		bar.Finish()
+	}
+	if notCopied > 0 {
+		return results, fmt.Errorf("failed to copy %d detector(s)", notCopied)
+	}
+	return results, nil
+}
@@ -19,11 +19,14 @@ import (
 	"fmt"
 	mockController "opensearch-cli/controller/platform/mocks"
 	entity "opensearch-cli/entity/ad"
+	osentity "opensearch-cli/entity/platform"
 	gateway "opensearch-cli/gateway/ad/mocks"
 	"opensearch-cli/mapper"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -211,6 +214,12 @@ func TestController_StopDetector(t *testing.T) {
 	})
 }
 
+func getCreateHighCardinalityDetector() *entity.CreateDetector {
+	detector := getCreateDetector()
+	detector.CategoryField = []string{"ip"}
+	return detector
+}
+
 func TestController_CreateAnomalyDetector(t *testing.T) {
 	t.Run("gateway failed", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
@@ -218,7 +227,7 @@ func TestController_CreateAnomalyDetector(t *testing.T) {
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
-		mockADGateway.EXPECT().CreateDetector(ctx, getCreateDetector()).Return(nil, errors.New("failed to connect"))
+		mockADGateway.EXPECT().CreateDetector(ctx, getCreateHighCardinalityDetector()).Return(nil, errors.New("failed to connect"))
 		mockESController := mockController.NewMockController(mockCtrl)
 		ctrl := New(os.Stdin, mockESController, mockADGateway)
 		_, err := ctrl.CreateAnomalyDetector(ctx, r)
@@ -230,7 +239,7 @@ func TestController_CreateAnomalyDetector(t *testing.T) {
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
-		mockADGateway.EXPECT().CreateDetector(ctx, getCreateDetector()).Return(nil, errors.New(string(helperLoadBytes(t, "create_failed_response.json"))))
+		mockADGateway.EXPECT().CreateDetector(ctx, getCreateHighCardinalityDetector()).Return(nil, errors.New(string(helperLoadBytes(t, "create_failed_response.json"))))
 		mockESController := mockController.NewMockController(mockCtrl)
 		ctrl := New(os.Stdin, mockESController, mockADGateway)
 		_, err := ctrl.CreateAnomalyDetector(ctx, r)
@@ -243,7 +252,7 @@ func TestController_CreateAnomalyDetector(t *testing.T) {
 		r := getCreateDetectorRequest()
 		r.Start = false
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
-		mockADGateway.EXPECT().CreateDetector(ctx, getCreateDetector()).Return(helperLoadBytes(t, "create_response.json"), nil)
+		mockADGateway.EXPECT().CreateDetector(ctx, getCreateHighCardinalityDetector()).Return(helperLoadBytes(t, "create_response.json"), nil)
 		mockESController := mockController.NewMockController(mockCtrl)
 		ctrl := New(os.Stdin, mockESController, mockADGateway)
 		detectorID, err := ctrl.CreateAnomalyDetector(ctx, r)
@@ -257,7 +266,7 @@ func TestController_CreateAnomalyDetector(t *testing.T) {
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
-		mockADGateway.EXPECT().CreateDetector(ctx, getCreateDetector()).Return(helperLoadBytes(t, "create_response.json"), nil)
+		mockADGateway.EXPECT().CreateDetector(ctx, getCreateHighCardinalityDetector()).Return(helperLoadBytes(t, "create_response.json"), nil)
 		mockADGateway.EXPECT().StartDetector(ctx, mockDetectorID).Return(nil)
 		mockESController := mockController.NewMockController(mockCtrl)
 		ctrl := New(os.Stdin, mockESController, mockADGateway)
@@ -272,7 +281,7 @@ func TestController_CreateAnomalyDetector(t *testing.T) {
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
-		mockADGateway.EXPECT().CreateDetector(ctx, getCreateDetector()).Return(helperLoadBytes(t, "create_response.json"), nil)
+		mockADGateway.EXPECT().CreateDetector(ctx, getCreateHighCardinalityDetector()).Return(helperLoadBytes(t, "create_response.json"), nil)
 		mockADGateway.EXPECT().StartDetector(ctx, mockDetectorID).Return(errors.New("error"))
 		mockESController := mockController.NewMockController(mockCtrl)
 		ctrl := New(os.Stdin, mockESController, mockADGateway)
@@ -371,6 +380,7 @@ func TestController_CreateMultiEntityAnomalyDetector(t *testing.T) {
 		defer mockCtrl.Finish()
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
+		r.FanOut = true
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		gatewayRequest := getCreateDetector()
 		gatewayRequest.Name = gatewayRequest.Name + "-" + "localhost"
@@ -390,6 +400,7 @@ func TestController_CreateMultiEntityAnomalyDetector(t *testing.T) {
 		defer mockCtrl.Finish()
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
+		r.FanOut = true
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		gatewayRequest := getCreateDetector()
 		gatewayRequest.Name = gatewayRequest.Name + "-" + "localhost"
@@ -410,6 +421,7 @@ func TestController_CreateMultiEntityAnomalyDetector(t *testing.T) {
 		defer mockCtrl.Finish()
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
+		r.FanOut = true
 		r.Filter = nil
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		gatewayRequest := getCreateDetector()
@@ -432,6 +444,7 @@ func TestController_CreateMultiEntityAnomalyDetector(t *testing.T) {
 		defer mockCtrl.Finish()
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
+		r.FanOut = true
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		gatewayRequest := getCreateDetector()
 		gatewayRequest.Name = gatewayRequest.Name + "-" + "localhost"
@@ -450,6 +463,7 @@ func TestController_CreateMultiEntityAnomalyDetector(t *testing.T) {
 		defer mockCtrl.Finish()
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
+		r.FanOut = true
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		gatewayRequest := getCreateDetector()
 		gatewayRequest.Name = gatewayRequest.Name + "-" + "localhost"
@@ -465,6 +479,7 @@ func TestController_CreateMultiEntityAnomalyDetector(t *testing.T) {
 		defer mockCtrl.Finish()
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
+		r.FanOut = true
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		gatewayRequest := getCreateDetector()
 		gatewayRequest.Name = gatewayRequest.Name + "-" + "localhost"
@@ -480,6 +495,7 @@ func TestController_CreateMultiEntityAnomalyDetector(t *testing.T) {
 		defer mockCtrl.Finish()
 		ctx := context.Background()
 		r := getCreateDetectorRequest()
+		r.FanOut = true
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		gatewayRequest := getCreateDetector()
 		gatewayRequest.Name = gatewayRequest.Name + "-" + "localhost"
@@ -495,15 +511,59 @@ func TestController_CreateMultiEntityAnomalyDetector(t *testing.T) {
 		_, err := ctrl.CreateMultiEntityAnomalyDetector(ctx, r, false, false)
 		assert.EqualError(t, err, "Cannot create anomaly detector with name [testdata-detector] as it's already used by detector [wR_1XXMBs3q1IVz33Sk-]")
 	})
+	t.Run("create high cardinality detector by default", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		r := getCreateDetectorRequest()
+		r.CategoryField = []string{"host"}
+		gatewayRequest := getCreateDetector()
+		gatewayRequest.CategoryField = []string{"host", "ip"}
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().CreateDetector(ctx, gatewayRequest).Return(helperLoadBytes(t, "create_response.json"), nil)
+		mockADGateway.EXPECT().StartDetector(ctx, mockDetectorID).Return(nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		detectorID, err := ctrl.CreateMultiEntityAnomalyDetector(ctx, r, true, false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{mockDetectorID}, detectorID)
+	})
+	t.Run("fan out requires single partition field", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		r := getCreateDetectorRequest()
+		r.FanOut = true
+		r.CategoryField = []string{"host"}
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.CreateMultiEntityAnomalyDetector(ctx, r, false, false)
+		assert.EqualError(t, err, "creating one detector per value requires exactly one partition field, found 2")
+	})
 }
 
 func getSearchPayload(name string) entity.SearchRequest {
 	return entity.SearchRequest{
 		Query: entity.SearchQuery{
-			Match: entity.Match{
+			Match: &entity.Match{
+				Name: name,
+			},
+		},
+		Size: maxDetectorsPageSize,
+		Sort: []interface{}{map[string]string{"name.keyword": "asc"}},
+	}
+}
+
+func getExactSearchPayload(name string) entity.SearchRequest {
+	return entity.SearchRequest{
+		Query: entity.SearchQuery{
+			Term: &entity.Term{
 				Name: name,
 			},
 		},
+		Size: maxDetectorsPageSize,
+		Sort: []interface{}{map[string]string{"name.keyword": "asc"}},
 	}
 }
 
@@ -978,3 +1038,885 @@ func TestController_UpdateDetector(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestController_GetDetectorStatus(t *testing.T) {
+	minutesLeft := 77
+	expected := &entity.DetectorStatus{
+		ID:                   "detectorID",
+		Name:                 "detector",
+		State:                "INIT",
+		InitProgress:         "70%",
+		EstimatedMinutesLeft: &minutesLeft,
+		CoordinatingNode:     "node1",
+		Nodes:                []string{"node1", "node2"},
+	}
+	t.Run("get detector failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetector(ctx, mockDetectorID).Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.GetDetectorStatus(ctx, mockDetectorID)
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("get profile failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetector(ctx, mockDetectorID).Return(helperLoadBytes(t, "get_response.json"), nil)
+		mockADGateway.EXPECT().GetDetectorProfile(ctx, "detectorID").Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.GetDetectorStatus(ctx, mockDetectorID)
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("get status", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetector(ctx, mockDetectorID).Return(helperLoadBytes(t, "get_response.json"), nil)
+		mockADGateway.EXPECT().GetDetectorProfile(ctx, "detectorID").Return(helperLoadBytes(t, "profile_response.json"), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		actual, err := ctrl.GetDetectorStatus(ctx, mockDetectorID)
+		assert.NoError(t, err)
+		assert.EqualValues(t, *expected, *actual)
+	})
+}
+
+func TestController_GetDetectorStatusByName(t *testing.T) {
+	t.Run("search detector gateway failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.GetDetectorStatusByName(ctx, "detector", false)
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("get profile failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(
+			helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().GetDetectorProfile(ctx, "detectorID").Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.GetDetectorStatusByName(ctx, "detector", false)
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("get status", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(
+			helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().GetDetectorProfile(ctx, "detectorID").Return([]byte(`{"state":"RUNNING"}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		actual, err := ctrl.GetDetectorStatusByName(ctx, "detector", false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.DetectorStatus{
+			{
+				ID:    "detectorID",
+				Name:  "detector",
+				State: "RUNNING",
+				Nodes: []string{},
+			},
+		}, actual)
+	})
+}
+
+func TestController_SearchAnomalyResults(t *testing.T) {
+	expected := []*entity.AnomalyResultOutput{
+		{
+			DetectorName: "detector",
+			DetectorID:   "detectorID",
+			StartTime:    "2021-03-09T16:00:00Z",
+			EndTime:      "2021-03-09T16:01:00Z",
+			AnomalyGrade: 0.82,
+			Confidence:   0.96,
+			Entity:       "host=host-1",
+			Features:     map[string]float64{"total_cpu": 98.5},
+		},
+	}
+	t.Run("get detector failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetector(ctx, mockDetectorID).Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		err := ctrl.SearchAnomalyResults(ctx, []string{mockDetectorID}, entity.AnomalyResultFilter{}, func([]*entity.AnomalyResultOutput) error {
+			return nil
+		})
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("search results failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetector(ctx, mockDetectorID).Return(helperLoadBytes(t, "get_response.json"), nil)
+		mockADGateway.EXPECT().SearchAnomalyResults(ctx, gomock.Any()).Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		err := ctrl.SearchAnomalyResults(ctx, []string{mockDetectorID}, entity.AnomalyResultFilter{}, func([]*entity.AnomalyResultOutput) error {
+			return nil
+		})
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("search results stops at limit", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetector(ctx, mockDetectorID).Return(helperLoadBytes(t, "get_response.json"), nil)
+		mockADGateway.EXPECT().SearchAnomalyResults(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, payload interface{}) ([]byte, error) {
+				request := payload.(*entity.AnomalyResultSearchRequest)
+				assert.EqualValues(t, 1, request.Size)
+				assert.Nil(t, request.SearchAfter)
+				return helperLoadBytes(t, "results_response.json"), nil
+			})
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		var actual []*entity.AnomalyResultOutput
+		err := ctrl.SearchAnomalyResults(ctx, []string{mockDetectorID}, entity.AnomalyResultFilter{Limit: 1}, func(page []*entity.AnomalyResultOutput) error {
+			actual = append(actual, page...)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, actual)
+	})
+	t.Run("search results by name stops when page is not full", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(
+			helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().SearchAnomalyResults(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, payload interface{}) ([]byte, error) {
+				request := payload.(*entity.AnomalyResultSearchRequest)
+				assert.EqualValues(t, maxResultsPageSize, request.Size)
+				return helperLoadBytes(t, "results_response.json"), nil
+			})
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		var actual []*entity.AnomalyResultOutput
+		err := ctrl.SearchAnomalyResultsByName(ctx, []string{"detector"}, entity.AnomalyResultFilter{}, func(page []*entity.AnomalyResultOutput) error {
+			actual = append(actual, page...)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, actual)
+	})
+	t.Run("search results by name without matched detectors", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("unknown")).Return(
+			helperLoadBytes(t, "search_response.json"), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		err := ctrl.SearchAnomalyResultsByName(ctx, []string{"unknown"}, entity.AnomalyResultFilter{}, func([]*entity.AnomalyResultOutput) error {
+			return errors.New("process should not be called")
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestController_StartHistoricalAnalysis(t *testing.T) {
+	t.Run("invalid time range", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		err := ctrl.StartHistoricalAnalysis(ctx, "detectorID", "", "")
+		assert.EqualError(t, err, "start of time range cannot be empty")
+	})
+	t.Run("start historical analysis gateway failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().StartHistoricalAnalysis(ctx, "detectorID", &entity.HistoricalAnalysisRequest{
+			StartTime: 1614556800000,
+			EndTime:   1614643200000,
+		}).Return(nil, errors.New(`{"error":{"type":"illegal_argument_exception","reason":"Detector is already running"},"status":400}`))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		err := ctrl.StartHistoricalAnalysis(ctx, "detectorID", "2021-03-01", "2021-03-02")
+		assert.EqualError(t, err, "Detector is already running")
+	})
+	t.Run("start historical analysis by name", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(
+			helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().StartHistoricalAnalysis(ctx, "detectorID", &entity.HistoricalAnalysisRequest{
+			StartTime: 1614556800000,
+			EndTime:   1614643200000,
+		}).Return([]byte(`{"_id":"taskID"}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		var stdin bytes.Buffer
+		stdin.Write([]byte("yes\n"))
+		ctrl := New(&stdin, mockESController, mockADGateway)
+		started, err := ctrl.StartHistoricalAnalysisByName(ctx, "detector", "2021-03-01", "2021-03-02", false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"detectorID"}, started)
+	})
+	t.Run("start historical analysis by name with invalid time range", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.StartHistoricalAnalysisByName(ctx, "detector", "tomorrow", "", false)
+		assert.Error(t, err)
+	})
+}
+
+func TestController_StopHistoricalAnalysis(t *testing.T) {
+	t.Run("stop historical analysis gateway failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().StopHistoricalAnalysis(ctx, "detectorID").Return(errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		err := ctrl.StopHistoricalAnalysis(ctx, "detectorID")
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("stop historical analysis by name", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(
+			helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().StopHistoricalAnalysis(ctx, "detectorID").Return(nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		var stdin bytes.Buffer
+		stdin.Write([]byte("yes\n"))
+		ctrl := New(&stdin, mockESController, mockADGateway)
+		err := ctrl.StopHistoricalAnalysisByName(ctx, "detector", false)
+		assert.NoError(t, err)
+	})
+}
+
+func TestController_GetHistoricalAnalysisStatus(t *testing.T) {
+	expected := &entity.HistoricalAnalysisStatus{
+		ID:       "detectorID",
+		Name:     "detector",
+		TaskID:   "f9DsTXgB8lQm4F1pPsEA",
+		State:    "RUNNING",
+		Progress: "45%",
+		From:     "2017-08-19T18:49:50Z",
+		To:       "2021-04-01T18:22:04Z",
+	}
+	t.Run("get task gateway failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetectorTask(ctx, "detectorID").Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.GetHistoricalAnalysisStatus(ctx, "detectorID")
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("get status by name", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(
+			helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().GetDetectorTask(ctx, "detectorID").Return(helperLoadBytes(t, "task_response.json"), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		actual, err := ctrl.GetHistoricalAnalysisStatusByName(ctx, "detector")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.HistoricalAnalysisStatus{expected}, actual)
+	})
+}
+
+func TestController_WaitForHistoricalAnalysis(t *testing.T) {
+	t.Run("wait until task is finished", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		gomock.InOrder(
+			mockADGateway.EXPECT().GetDetectorTask(ctx, "detectorID").Return(helperLoadBytes(t, "task_response.json"), nil),
+			mockADGateway.EXPECT().GetDetectorTask(ctx, "detectorID").Return(
+				[]byte(`{"_id":"detectorID","historical_analysis_task":{"task_id":"taskID","state":"FINISHED","task_progress":1.0}}`), nil),
+		)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		actual, err := ctrl.WaitForHistoricalAnalysis(ctx, []string{"detectorID"}, time.Millisecond, false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.HistoricalAnalysisStatus{
+			{
+				ID:       "detectorID",
+				TaskID:   "taskID",
+				State:    "FINISHED",
+				Progress: "100%",
+			},
+		}, actual)
+	})
+	t.Run("cancelled while waiting", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx, cancel := context.WithCancel(context.Background())
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetectorTask(ctx, "detectorID").DoAndReturn(func(context.Context, string) ([]byte, error) {
+			cancel()
+			return helperLoadBytes(t, "task_response.json"), nil
+		})
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.WaitForHistoricalAnalysis(ctx, []string{"detectorID"}, time.Minute, false)
+		assert.EqualError(t, err, "context canceled")
+	})
+}
+
+func TestController_PreviewDetector(t *testing.T) {
+	expected := []*entity.AnomalyResultOutput{
+		{
+			DetectorName: "detector",
+			DetectorID:   "detectorID",
+			StartTime:    "2021-03-09T16:00:00Z",
+			EndTime:      "2021-03-09T16:01:00Z",
+			AnomalyGrade: 0.82,
+			Confidence:   0.96,
+			Features:     map[string]float64{"total_cpu": 98.5},
+		},
+	}
+	t.Run("preview new detector", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		r := getCreateDetectorRequest()
+		r.Name = "detector"
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().PreviewDetector(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, payload interface{}) ([]byte, error) {
+				request := payload.(*entity.PreviewRequest)
+				assert.EqualValues(t, 1614556800000, request.PeriodStart)
+				assert.EqualValues(t, 1614643200000, request.PeriodEnd)
+				assert.Empty(t, request.DetectorID)
+				assert.EqualValues(t, "detector", request.Detector.Name)
+				return helperLoadBytes(t, "preview_response.json"), nil
+			})
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		actual, err := ctrl.PreviewAnomalyDetector(ctx, r, "2021-03-01", "2021-03-02")
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, actual)
+	})
+	t.Run("preview new detector with invalid request", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		r := getCreateDetectorRequest()
+		r.Name = ""
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.PreviewAnomalyDetector(ctx, r, "2021-03-01", "2021-03-02")
+		assert.EqualError(t, err, "name field cannot be empty")
+	})
+	t.Run("preview detector by id", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetector(ctx, mockDetectorID).Return(helperLoadBytes(t, "get_response.json"), nil)
+		mockADGateway.EXPECT().PreviewDetector(ctx, &entity.PreviewRequest{
+			PeriodStart: 1614556800000,
+			PeriodEnd:   1614643200000,
+			DetectorID:  "detectorID",
+		}).Return(helperLoadBytes(t, "preview_response.json"), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		actual, err := ctrl.PreviewDetector(ctx, mockDetectorID, "2021-03-01", "2021-03-02")
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, actual)
+	})
+	t.Run("preview detector by id with invalid time range", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.PreviewDetector(ctx, mockDetectorID, "2021-03-02", "2021-03-01")
+		assert.EqualError(t, err, "start of time range 2021-03-02T00:00:00Z must be before end of time range")
+	})
+	t.Run("preview detector by name failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(
+			helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().PreviewDetector(ctx, gomock.Any()).Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.PreviewDetectorByName(ctx, "detector", "2021-03-01", "2021-03-02")
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("preview detector by name", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(
+			helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().PreviewDetector(ctx, &entity.PreviewRequest{
+			PeriodStart: 1614556800000,
+			PeriodEnd:   1614643200000,
+			DetectorID:  "detectorID",
+		}).Return(helperLoadBytes(t, "preview_response.json"), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		actual, err := ctrl.PreviewDetectorByName(ctx, "detector", "2021-03-01", "2021-03-02")
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, actual)
+	})
+}
+
+func getFieldCapabilities() *osentity.FieldCapabilitiesResponse {
+	return &osentity.FieldCapabilitiesResponse{
+		Indices: []string{"order-1"},
+		Fields: map[string]map[string]osentity.FieldCapability{
+			"timestamp": {"date": {Type: "date", Searchable: true, Aggregatable: true}},
+			"value":     {"long": {Type: "long", Searchable: true, Aggregatable: true}},
+			"ip":        {"ip": {Type: "ip", Searchable: true, Aggregatable: true}},
+		},
+	}
+}
+
+func TestController_ValidateAnomalyDetector(t *testing.T) {
+	fields := []string{"timestamp", "value", "ip"}
+	t.Run("valid detector", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().ValidateDetector(ctx, "detector", getCreateHighCardinalityDetector()).Return([]byte(`{}`), nil)
+		mockADGateway.EXPECT().ValidateDetector(ctx, "model", getCreateHighCardinalityDetector()).Return([]byte(`{}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		mockESController.EXPECT().GetFieldCapabilities(ctx, "order*", fields).Return(getFieldCapabilities(), nil)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		problems, err := ctrl.ValidateAnomalyDetector(ctx, getCreateDetectorRequest())
+		assert.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+	t.Run("reports every local problem", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		r := getCreateDetectorRequest()
+		r.Name = ""
+		r.Index = []string{"order*", "missing"}
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		mockESController.EXPECT().GetFieldCapabilities(ctx, "order*", fields).Return(&osentity.FieldCapabilitiesResponse{
+			Indices: []string{"order-1"},
+			Fields: map[string]map[string]osentity.FieldCapability{
+				"timestamp": {"long": {Type: "long", Searchable: true, Aggregatable: true}},
+				"value":     {"keyword": {Type: "keyword", Searchable: true, Aggregatable: true}},
+			},
+		}, nil)
+		mockESController.EXPECT().GetFieldCapabilities(ctx, "missing", fields).Return(&osentity.FieldCapabilitiesResponse{}, nil)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		problems, err := ctrl.ValidateAnomalyDetector(ctx, r)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.ValidationProblem{
+			{Source: "request", Field: "name", Message: "name field cannot be empty"},
+			{Source: "mapping", Field: "time_field", Message: "time field timestamp must be date, found long in index order*"},
+			{Source: "mapping", Field: "features[0].field", Message: "aggregation sum requires numeric field, found value of type keyword in index order*"},
+			{Source: "mapping", Field: "category_field", Message: "category field ip does not exist in index order*"},
+			{Source: "index", Field: "index", Message: "index missing does not exist"},
+		}, problems)
+	})
+	t.Run("min and max require numeric field", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		r := getCreateDetectorRequest()
+		r.Features[0].AggregationType = []string{"min", "max"}
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().ValidateDetector(ctx, gomock.Any(), gomock.Any()).Return([]byte(`{}`), nil).AnyTimes()
+		mockESController := mockController.NewMockController(mockCtrl)
+		capabilities := getFieldCapabilities()
+		capabilities.Fields["value"] = map[string]osentity.FieldCapability{"keyword": {Type: "keyword", Searchable: true, Aggregatable: true}}
+		mockESController.EXPECT().GetFieldCapabilities(ctx, "order*", fields).Return(capabilities, nil)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		problems, err := ctrl.ValidateAnomalyDetector(ctx, r)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.ValidationProblem{
+			{Source: "mapping", Field: "features[0].field", Message: "aggregation min requires numeric field, found value of type keyword in index order*"},
+			{Source: "mapping", Field: "features[0].field", Message: "aggregation max requires numeric field, found value of type keyword in index order*"},
+		}, problems)
+	})
+	t.Run("model is not validated when detector has problems", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().ValidateDetector(ctx, "detector", gomock.Any()).Return(
+			[]byte(`{"detector":{"name":{"message":"Cannot create anomaly detector with name [testdata-detector] as it's already used by detector [id]"}}}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		mockESController.EXPECT().GetFieldCapabilities(ctx, "order*", fields).Return(getFieldCapabilities(), nil)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		problems, err := ctrl.ValidateAnomalyDetector(ctx, getCreateDetectorRequest())
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.ValidationProblem{
+			{Source: "detector", Field: "name", Message: "Cannot create anomaly detector with name [testdata-detector] as it's already used by detector [id]"},
+		}, problems)
+	})
+	t.Run("reports failures of validate api and mapping", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().ValidateDetector(ctx, "detector", gomock.Any()).Return([]byte(`{}`), nil)
+		mockADGateway.EXPECT().ValidateDetector(ctx, "model", gomock.Any()).Return(nil, errors.New("no handler found for uri"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		mockESController.EXPECT().GetFieldCapabilities(ctx, "order*", fields).Return(nil, errors.New("connection failed"))
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		problems, err := ctrl.ValidateAnomalyDetector(ctx, getCreateDetectorRequest())
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.ValidationProblem{
+			{Source: "index", Field: "index", Message: "failed to get mapping of index order* due to connection failed"},
+			{Source: "model", Message: "no handler found for uri"},
+		}, problems)
+	})
+}
+
+func getApplyDetectorRequest() entity.CreateDetectorRequest {
+	return entity.CreateDetectorRequest{
+		Name:        "detector",
+		Description: "Test detector",
+		TimeField:   "timestamp",
+		Index:       []string{"order*"},
+		Features: []entity.FeatureRequest{{
+			AggregationType: []string{"sum"},
+			Enabled:         true,
+			Field:           []string{"value"},
+		}},
+		Filter:   []byte(`{"bool": {"filter": {"exists": {"field": "value"}}}}`),
+		Interval: "5m",
+		Delay:    "1m",
+	}
+}
+
+func TestController_PlanDetectors(t *testing.T) {
+	t.Run("plan create, update and unchanged detectors", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		newDetector := getApplyDetectorRequest()
+		newDetector.Name = "new-detector"
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getExactSearchPayload("detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().GetDetector(ctx, "detectorID").Return(helperLoadBytes(t, "apply_get_response.json"), nil)
+		mockADGateway.EXPECT().SearchDetector(ctx, getExactSearchPayload("new-detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		plans, err := ctrl.PlanDetectors(ctx, map[string]entity.CreateDetectorRequest{
+			"b.json": newDetector,
+			"a.json": getApplyDetectorRequest(),
+		}, "")
+		assert.NoError(t, err)
+		assert.Len(t, plans, 2)
+		assert.EqualValues(t, entity.DetectorPlan{
+			Action: entity.PlanActionUnchanged, Name: "detector", ID: "detectorID", File: "a.json", Request: plans[0].Request,
+		}, *plans[0])
+		assert.EqualValues(t, entity.DetectorPlan{
+			Action: entity.PlanActionCreate, Name: "new-detector", File: "b.json", Request: &newDetector,
+		}, *plans[1])
+	})
+	t.Run("plan update and prune detectors", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		r := getApplyDetectorRequest()
+		r.Interval = "10m"
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getExactSearchPayload("detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().GetDetector(ctx, "detectorID").Return(helperLoadBytes(t, "apply_get_response.json"), nil)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("*detector")).Return(
+			[]byte(`{"hits":{"hits":[{"_id":"detectorID","_source":{"name":"detector"}},{"_id":"oldID","_source":{"name":"old-detector"}}]}}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		plans, err := ctrl.PlanDetectors(ctx, map[string]entity.CreateDetectorRequest{"a.json": r}, "*detector")
+		assert.NoError(t, err)
+		assert.Len(t, plans, 2)
+		assert.EqualValues(t, entity.PlanActionUpdate, plans[0].Action)
+		assert.EqualValues(t, []entity.FieldDiff{{Field: "detection_interval", Live: "5m", Desired: "10m"}}, plans[0].Changes)
+		assert.EqualValues(t, entity.DetectorPlan{Action: entity.PlanActionDelete, Name: "old-detector", ID: "oldID"}, *plans[1])
+	})
+	t.Run("prune pages through detectors", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		var hits []string
+		for i := 0; i < maxDetectorsPageSize; i++ {
+			hits = append(hits, fmt.Sprintf(`{"_id":"id-%04d","_source":{"name":"old-%04d"},"sort":["old-%04d"]}`, i, i, i))
+		}
+		nextPage := getSearchPayload("old-*")
+		nextPage.SearchAfter = []interface{}{fmt.Sprintf("old-%04d", maxDetectorsPageSize-1)}
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		gomock.InOrder(
+			mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("old-*")).Return(
+				[]byte(fmt.Sprintf(`{"hits":{"hits":[%s]}}`, strings.Join(hits, ","))), nil),
+			mockADGateway.EXPECT().SearchDetector(ctx, nextPage).Return(
+				[]byte(`{"hits":{"hits":[{"_id":"last","_source":{"name":"old-last"},"sort":["old-last"]}]}}`), nil),
+		)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		plans, err := ctrl.PlanDetectors(ctx, nil, "old-*")
+		assert.NoError(t, err)
+		assert.Len(t, plans, maxDetectorsPageSize+1)
+		assert.EqualValues(t, entity.DetectorPlan{Action: entity.PlanActionDelete, Name: "old-last", ID: "last"}, *plans[maxDetectorsPageSize])
+	})
+	t.Run("detector defined in two files", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getExactSearchPayload("detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().GetDetector(ctx, "detectorID").Return(helperLoadBytes(t, "apply_get_response.json"), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.PlanDetectors(ctx, map[string]entity.CreateDetectorRequest{
+			"a.json": getApplyDetectorRequest(),
+			"b.json": getApplyDetectorRequest(),
+		}, "")
+		assert.EqualError(t, err, "detector detector is defined in both a.json and b.json")
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		r := getApplyDetectorRequest()
+		r.Interval = ""
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.PlanDetectors(ctx, map[string]entity.CreateDetectorRequest{"a.json": r}, "")
+		assert.EqualError(t, err, "file a.json cannot be accepted due to interval field cannot be empty")
+	})
+}
+
+func TestController_ApplyDetectorPlan(t *testing.T) {
+	getPlans := func() []*entity.DetectorPlan {
+		create := getApplyDetectorRequest()
+		create.Name = "new-detector"
+		update := getApplyDetectorRequest()
+		update.Interval = "10m"
+		return []*entity.DetectorPlan{
+			{Action: entity.PlanActionUnchanged, Name: "same-detector", ID: "sameID"},
+			{Action: entity.PlanActionCreate, Name: "new-detector", Request: &create},
+			{Action: entity.PlanActionUpdate, Name: "detector", ID: "detectorID", Request: &update},
+			{Action: entity.PlanActionDelete, Name: "old-detector", ID: "oldID"},
+		}
+	}
+	t.Run("apply every change", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().CreateDetector(ctx, gomock.Any()).Return(helperLoadBytes(t, "create_response.json"), nil)
+		mockADGateway.EXPECT().GetDetectorProfile(ctx, "detectorID").Return(helperLoadBytes(t, "profile_response.json"), nil)
+		gomock.InOrder(
+			mockADGateway.EXPECT().StopDetector(ctx, "detectorID").Return(mapper.StringToStringPtr("Stopped detector: detectorID"), nil),
+			mockADGateway.EXPECT().UpdateDetector(ctx, "detectorID", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, payload interface{}) error {
+					assert.EqualValues(t, 10, payload.(*entity.UpdateDetector).Interval.Period.Duration)
+					return nil
+				}),
+			mockADGateway.EXPECT().StartDetector(ctx, "detectorID").Return(nil),
+		)
+		mockADGateway.EXPECT().GetDetectorProfile(ctx, "oldID").Return([]byte(`{"state":"DISABLED"}`), nil)
+		mockADGateway.EXPECT().DeleteDetector(ctx, "oldID").Return(nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		var stdin bytes.Buffer
+		stdin.Write([]byte("yes\n"))
+		ctrl := New(&stdin, mockESController, mockADGateway)
+		err := ctrl.ApplyDetectorPlan(ctx, getPlans(), true, false)
+		assert.NoError(t, err)
+	})
+	t.Run("apply cancelled by user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		var stdin bytes.Buffer
+		stdin.Write([]byte("no\n"))
+		ctrl := New(&stdin, mockESController, mockADGateway)
+		err := ctrl.ApplyDetectorPlan(ctx, getPlans(), true, false)
+		assert.NoError(t, err)
+	})
+	t.Run("apply continues after failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().CreateDetector(ctx, gomock.Any()).Return(nil, errors.New("failed to create"))
+		mockADGateway.EXPECT().GetDetectorProfile(ctx, "detectorID").Return([]byte(`{"state":"DISABLED"}`), nil)
+		mockADGateway.EXPECT().UpdateDetector(ctx, "detectorID", gomock.Any()).Return(nil)
+		mockADGateway.EXPECT().GetDetectorProfile(ctx, "oldID").Return(helperLoadBytes(t, "profile_response.json"), nil)
+		mockADGateway.EXPECT().StopDetector(ctx, "oldID").Return(mapper.StringToStringPtr("Stopped detector: oldID"), nil)
+		mockADGateway.EXPECT().DeleteDetector(ctx, "oldID").Return(nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		err := ctrl.ApplyDetectorPlan(ctx, getPlans(), false, false)
+		assert.EqualError(t, err, "failed to apply 1 detector(s)")
+	})
+	t.Run("nothing to apply", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		err := ctrl.ApplyDetectorPlan(ctx, getPlans()[:1], true, false)
+		assert.NoError(t, err)
+	})
+}
+
+func TestController_ExportDetectorsByName(t *testing.T) {
+	t.Run("export detectors", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().GetDetector(ctx, "detectorID").Return(helperLoadBytes(t, "get_response.json"), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		actual, skipped, err := ctrl.ExportDetectorsByName(ctx, "detector", false)
+		assert.NoError(t, err)
+		assert.Empty(t, skipped)
+		assert.EqualValues(t, []*entity.CreateDetectorRequest{{
+			Name:        "detector",
+			Description: "Test detector",
+			TimeField:   "timestamp",
+			Index:       []string{"order*"},
+			Features: []entity.FeatureRequest{{
+				AggregationType: []string{"sum"},
+				Enabled:         true,
+				Field:           []string{"value"},
+			}},
+			Filter:        []byte(`{"bool" : {"filter" : [{"exists" : {"field" : "value","boost" : 1.0}}],"adjust_pure_negative" : true,"boost" : 1.0}}`),
+			Interval:      "5m",
+			Delay:         "1m",
+			CategoryField: []string{},
+		}}, actual)
+	})
+	t.Run("get detector failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().GetDetector(ctx, "detectorID").Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, _, err := ctrl.ExportDetectorsByName(ctx, "detector", false)
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("detector which cannot be mapped is skipped", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getSearchPayload("detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		response := strings.Replace(string(helperLoadBytes(t, "get_response.json")),
+			`{"total_order":{"sum":{"field":"value"}}}`, `{"total_order":{"percentiles":{"field":"value"}}}`, 1)
+		mockADGateway.EXPECT().GetDetector(ctx, "detectorID").Return([]byte(response), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		actual, skipped, err := ctrl.ExportDetectorsByName(ctx, "detector", false)
+		assert.NoError(t, err)
+		assert.Empty(t, actual)
+		assert.Len(t, skipped, 1)
+		assert.Equal(t, "detector", skipped[0].Name)
+		assert.Contains(t, skipped[0].Reason, "detector detector")
+	})
+}
+
+func TestController_CopyDetectors(t *testing.T) {
+	t.Run("copy detectors and report conflicts", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		existing := getApplyDetectorRequest()
+		newDetector := getApplyDetectorRequest()
+		newDetector.Name = "new-detector"
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getExactSearchPayload("detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().GetDetector(ctx, "detectorID").Return(helperLoadBytes(t, "get_response.json"), nil)
+		mockADGateway.EXPECT().SearchDetector(ctx, getExactSearchPayload("new-detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().CreateDetector(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, payload interface{}) ([]byte, error) {
+				assert.EqualValues(t, []string{"order-copy*"}, payload.(*entity.CreateDetector).Index)
+				return helperLoadBytes(t, "create_response.json"), nil
+			})
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		results, err := ctrl.CopyDetectors(ctx, []*entity.CreateDetectorRequest{&existing, &newDetector}, map[string]string{"order*": "order-copy*"}, false)
+		assert.EqualError(t, err, "failed to copy 1 detector(s)")
+		assert.EqualValues(t, []*entity.CopyResult{
+			{Name: "detector", Status: entity.CopyStatusConflict, ID: "detectorID", Reason: "detector with same name already exists"},
+			{Name: "new-detector", Status: entity.CopyStatusCreated, ID: "m4ccEnIBTXsGi3mvMt9p"},
+		}, results)
+		assert.EqualValues(t, []string{"order*"}, newDetector.Index)
+	})
+	t.Run("copy continues after failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		first := getApplyDetectorRequest()
+		first.Name = "first-detector"
+		second := getApplyDetectorRequest()
+		second.Name = "second-detector"
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().SearchDetector(ctx, getExactSearchPayload("first-detector")).Return(nil, errors.New("search failed"))
+		mockADGateway.EXPECT().SearchDetector(ctx, getExactSearchPayload("second-detector")).Return(helperLoadBytes(t, "search_response.json"), nil)
+		mockADGateway.EXPECT().CreateDetector(ctx, gomock.Any()).Return(helperLoadBytes(t, "create_response.json"), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		results, err := ctrl.CopyDetectors(ctx, []*entity.CreateDetectorRequest{&first, &second}, nil, false)
+		assert.EqualError(t, err, "failed to copy 1 detector(s)")
+		assert.EqualValues(t, []*entity.CopyResult{
+			{Name: "first-detector", Status: entity.CopyStatusFailed, Reason: "search failed"},
+			{Name: "second-detector", Status: entity.CopyStatusCreated, ID: "m4ccEnIBTXsGi3mvMt9p"},
+		}, results)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	ad "opensearch-cli/entity/ad"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +36,35 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 	return m.recorder
 }
 
+// ApplyDetectorPlan mocks base method
+func (m *MockController) ApplyDetectorPlan(arg0 context.Context, arg1 []*ad.DetectorPlan, arg2, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDetectorPlan", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyDetectorPlan indicates an expected call of ApplyDetectorPlan
+func (mr *MockControllerMockRecorder) ApplyDetectorPlan(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDetectorPlan", reflect.TypeOf((*MockController)(nil).ApplyDetectorPlan), arg0, arg1, arg2, arg3)
+}
+
+// CopyDetectors mocks base method
+func (m *MockController) CopyDetectors(arg0 context.Context, arg1 []*ad.CreateDetectorRequest, arg2 map[string]string, arg3 bool) ([]*ad.CopyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyDetectors", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*ad.CopyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyDetectors indicates an expected call of CopyDetectors
+func (mr *MockControllerMockRecorder) CopyDetectors(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyDetectors", reflect.TypeOf((*MockController)(nil).CopyDetectors), arg0, arg1, arg2, arg3)
+}
+
 // CreateAnomalyDetector mocks base method
 func (m *MockController) CreateAnomalyDetector(arg0 context.Context, arg1 ad.CreateDetectorRequest) (*string, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +123,22 @@ func (mr *MockControllerMockRecorder) DeleteDetectorByName(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDetectorByName", reflect.TypeOf((*MockController)(nil).DeleteDetectorByName), arg0, arg1, arg2, arg3)
 }
 
+// ExportDetectorsByName mocks base method
+func (m *MockController) ExportDetectorsByName(arg0 context.Context, arg1 string, arg2 bool) ([]*ad.CreateDetectorRequest, []*ad.SkippedDetector, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDetectorsByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*ad.CreateDetectorRequest)
+	ret1, _ := ret[1].([]*ad.SkippedDetector)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExportDetectorsByName indicates an expected call of ExportDetectorsByName
+func (mr *MockControllerMockRecorder) ExportDetectorsByName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDetectorsByName", reflect.TypeOf((*MockController)(nil).ExportDetectorsByName), arg0, arg1, arg2)
+}
+
 // GetDetector mocks base method
 func (m *MockController) GetDetector(arg0 context.Context, arg1 string) (*ad.DetectorOutput, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +154,36 @@ func (mr *MockControllerMockRecorder) GetDetector(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetector", reflect.TypeOf((*MockController)(nil).GetDetector), arg0, arg1)
 }
 
+// GetDetectorStatus mocks base method
+func (m *MockController) GetDetectorStatus(arg0 context.Context, arg1 string) (*ad.DetectorStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetectorStatus", arg0, arg1)
+	ret0, _ := ret[0].(*ad.DetectorStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetectorStatus indicates an expected call of GetDetectorStatus
+func (mr *MockControllerMockRecorder) GetDetectorStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetectorStatus", reflect.TypeOf((*MockController)(nil).GetDetectorStatus), arg0, arg1)
+}
+
+// GetDetectorStatusByName mocks base method
+func (m *MockController) GetDetectorStatusByName(arg0 context.Context, arg1 string, arg2 bool) ([]*ad.DetectorStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetectorStatusByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*ad.DetectorStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetectorStatusByName indicates an expected call of GetDetectorStatusByName
+func (mr *MockControllerMockRecorder) GetDetectorStatusByName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetectorStatusByName", reflect.TypeOf((*MockController)(nil).GetDetectorStatusByName), arg0, arg1, arg2)
+}
+
 // GetDetectorsByName mocks base method
 func (m *MockController) GetDetectorsByName(arg0 context.Context, arg1 string, arg2 bool) ([]*ad.DetectorOutput, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +199,124 @@ func (mr *MockControllerMockRecorder) GetDetectorsByName(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetectorsByName", reflect.TypeOf((*MockController)(nil).GetDetectorsByName), arg0, arg1, arg2)
 }
 
+// GetHistoricalAnalysisStatus mocks base method
+func (m *MockController) GetHistoricalAnalysisStatus(arg0 context.Context, arg1 string) (*ad.HistoricalAnalysisStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalAnalysisStatus", arg0, arg1)
+	ret0, _ := ret[0].(*ad.HistoricalAnalysisStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalAnalysisStatus indicates an expected call of GetHistoricalAnalysisStatus
+func (mr *MockControllerMockRecorder) GetHistoricalAnalysisStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalAnalysisStatus", reflect.TypeOf((*MockController)(nil).GetHistoricalAnalysisStatus), arg0, arg1)
+}
+
+// GetHistoricalAnalysisStatusByName mocks base method
+func (m *MockController) GetHistoricalAnalysisStatusByName(arg0 context.Context, arg1 string) ([]*ad.HistoricalAnalysisStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalAnalysisStatusByName", arg0, arg1)
+	ret0, _ := ret[0].([]*ad.HistoricalAnalysisStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalAnalysisStatusByName indicates an expected call of GetHistoricalAnalysisStatusByName
+func (mr *MockControllerMockRecorder) GetHistoricalAnalysisStatusByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalAnalysisStatusByName", reflect.TypeOf((*MockController)(nil).GetHistoricalAnalysisStatusByName), arg0, arg1)
+}
+
+// PlanDetectors mocks base method
+func (m *MockController) PlanDetectors(arg0 context.Context, arg1 map[string]ad.CreateDetectorRequest, arg2 string) ([]*ad.DetectorPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanDetectors", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*ad.DetectorPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanDetectors indicates an expected call of PlanDetectors
+func (mr *MockControllerMockRecorder) PlanDetectors(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanDetectors", reflect.TypeOf((*MockController)(nil).PlanDetectors), arg0, arg1, arg2)
+}
+
+// PreviewAnomalyDetector mocks base method
+func (m *MockController) PreviewAnomalyDetector(arg0 context.Context, arg1 ad.CreateDetectorRequest, arg2, arg3 string) ([]*ad.AnomalyResultOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewAnomalyDetector", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*ad.AnomalyResultOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewAnomalyDetector indicates an expected call of PreviewAnomalyDetector
+func (mr *MockControllerMockRecorder) PreviewAnomalyDetector(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewAnomalyDetector", reflect.TypeOf((*MockController)(nil).PreviewAnomalyDetector), arg0, arg1, arg2, arg3)
+}
+
+// PreviewDetector mocks base method
+func (m *MockController) PreviewDetector(arg0 context.Context, arg1, arg2, arg3 string) ([]*ad.AnomalyResultOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewDetector", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*ad.AnomalyResultOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewDetector indicates an expected call of PreviewDetector
+func (mr *MockControllerMockRecorder) PreviewDetector(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewDetector", reflect.TypeOf((*MockController)(nil).PreviewDetector), arg0, arg1, arg2, arg3)
+}
+
+// PreviewDetectorByName mocks base method
+func (m *MockController) PreviewDetectorByName(arg0 context.Context, arg1, arg2, arg3 string) ([]*ad.AnomalyResultOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewDetectorByName", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*ad.AnomalyResultOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewDetectorByName indicates an expected call of PreviewDetectorByName
+func (mr *MockControllerMockRecorder) PreviewDetectorByName(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewDetectorByName", reflect.TypeOf((*MockController)(nil).PreviewDetectorByName), arg0, arg1, arg2, arg3)
+}
+
+// SearchAnomalyResults mocks base method
+func (m *MockController) SearchAnomalyResults(arg0 context.Context, arg1 []string, arg2 ad.AnomalyResultFilter, arg3 func([]*ad.AnomalyResultOutput) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAnomalyResults", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SearchAnomalyResults indicates an expected call of SearchAnomalyResults
+func (mr *MockControllerMockRecorder) SearchAnomalyResults(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAnomalyResults", reflect.TypeOf((*MockController)(nil).SearchAnomalyResults), arg0, arg1, arg2, arg3)
+}
+
+// SearchAnomalyResultsByName mocks base method
+func (m *MockController) SearchAnomalyResultsByName(arg0 context.Context, arg1 []string, arg2 ad.AnomalyResultFilter, arg3 func([]*ad.AnomalyResultOutput) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAnomalyResultsByName", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SearchAnomalyResultsByName indicates an expected call of SearchAnomalyResultsByName
+func (mr *MockControllerMockRecorder) SearchAnomalyResultsByName(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAnomalyResultsByName", reflect.TypeOf((*MockController)(nil).SearchAnomalyResultsByName), arg0, arg1, arg2, arg3)
+}
+
 // SearchDetectorByName mocks base method
 func (m *MockController) SearchDetectorByName(arg0 context.Context, arg1 string) ([]ad.Detector, error) {
 	m.ctrl.T.Helper()
@@ -166,6 +360,35 @@ func (mr *MockControllerMockRecorder) StartDetectorByName(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartDetectorByName", reflect.TypeOf((*MockController)(nil).StartDetectorByName), arg0, arg1, arg2)
 }
 
+// StartHistoricalAnalysis mocks base method
+func (m *MockController) StartHistoricalAnalysis(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartHistoricalAnalysis", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartHistoricalAnalysis indicates an expected call of StartHistoricalAnalysis
+func (mr *MockControllerMockRecorder) StartHistoricalAnalysis(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartHistoricalAnalysis", reflect.TypeOf((*MockController)(nil).StartHistoricalAnalysis), arg0, arg1, arg2, arg3)
+}
+
+// StartHistoricalAnalysisByName mocks base method
+func (m *MockController) StartHistoricalAnalysisByName(arg0 context.Context, arg1, arg2, arg3 string, arg4 bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartHistoricalAnalysisByName", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartHistoricalAnalysisByName indicates an expected call of StartHistoricalAnalysisByName
+func (mr *MockControllerMockRecorder) StartHistoricalAnalysisByName(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartHistoricalAnalysisByName", reflect.TypeOf((*MockController)(nil).StartHistoricalAnalysisByName), arg0, arg1, arg2, arg3, arg4)
+}
+
 // StopDetector mocks base method
 func (m *MockController) StopDetector(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -194,6 +417,34 @@ func (mr *MockControllerMockRecorder) StopDetectorByName(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopDetectorByName", reflect.TypeOf((*MockController)(nil).StopDetectorByName), arg0, arg1, arg2)
 }
 
+// StopHistoricalAnalysis mocks base method
+func (m *MockController) StopHistoricalAnalysis(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopHistoricalAnalysis", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopHistoricalAnalysis indicates an expected call of StopHistoricalAnalysis
+func (mr *MockControllerMockRecorder) StopHistoricalAnalysis(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopHistoricalAnalysis", reflect.TypeOf((*MockController)(nil).StopHistoricalAnalysis), arg0, arg1)
+}
+
+// StopHistoricalAnalysisByName mocks base method
+func (m *MockController) StopHistoricalAnalysisByName(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopHistoricalAnalysisByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopHistoricalAnalysisByName indicates an expected call of StopHistoricalAnalysisByName
+func (mr *MockControllerMockRecorder) StopHistoricalAnalysisByName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopHistoricalAnalysisByName", reflect.TypeOf((*MockController)(nil).StopHistoricalAnalysisByName), arg0, arg1, arg2)
+}
+
 // UpdateDetector mocks base method
 func (m *MockController) UpdateDetector(arg0 context.Context, arg1 ad.UpdateDetectorUserInput, arg2, arg3 bool) error {
 	m.ctrl.T.Helper()
@@ -207,3 +458,33 @@ func (mr *MockControllerMockRecorder) UpdateDetector(arg0, arg1, arg2, arg3 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDetector", reflect.TypeOf((*MockController)(nil).UpdateDetector), arg0, arg1, arg2, arg3)
 }
+
+// ValidateAnomalyDetector mocks base method
+func (m *MockController) ValidateAnomalyDetector(arg0 context.Context, arg1 ad.CreateDetectorRequest) ([]*ad.ValidationProblem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAnomalyDetector", arg0, arg1)
+	ret0, _ := ret[0].([]*ad.ValidationProblem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAnomalyDetector indicates an expected call of ValidateAnomalyDetector
+func (mr *MockControllerMockRecorder) ValidateAnomalyDetector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAnomalyDetector", reflect.TypeOf((*MockController)(nil).ValidateAnomalyDetector), arg0, arg1)
+}
+
+// WaitForHistoricalAnalysis mocks base method
+func (m *MockController) WaitForHistoricalAnalysis(arg0 context.Context, arg1 []string, arg2 time.Duration, arg3 bool) ([]*ad.HistoricalAnalysisStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForHistoricalAnalysis", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*ad.HistoricalAnalysisStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForHistoricalAnalysis indicates an expected call of WaitForHistoricalAnalysis
+func (mr *MockControllerMockRecorder) WaitForHistoricalAnalysis(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForHistoricalAnalysis", reflect.TypeOf((*MockController)(nil).WaitForHistoricalAnalysis), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,152 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	entity "opensearch-cli/entity/cluster"
+	gateway "opensearch-cli/gateway/cluster"
+	mapper "opensearch-cli/mapper/cluster"
+	"time"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_cluster.go -package=mocks . Controller
+
+//Controller is an interface for the cluster controllers
+type Controller interface {
+	GetHealth(context.Context) (*entity.Health, error)
+	WaitForHealth(context.Context, string, time.Duration, time.Duration) (*entity.Health, error)
+	ListNodes(context.Context) ([]entity.NodeOutput, error)
+	ExplainAllocation(context.Context, entity.AllocationExplainRequest) (*entity.AllocationExplanation, error)
+	GetSettings(context.Context, entity.SettingsRequest) ([]entity.SettingOutput, error)
+	PutSettings(context.Context, []string, bool) ([]entity.SettingOutput, error)
+}
+
+type controller struct {
+	gateway gateway.Gateway
+}
+
+//New returns new Controller instance
+func New(gateway gateway.Gateway) Controller {
+	return &controller{
+		gateway,
+	}
+}
+
+//GetHealth gets health of cluster
+func (c controller) GetHealth(ctx context.Context) (*entity.Health, error) {
+	response, err := c.gateway.GetHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var health entity.Health
+	if err = json.Unmarshal(response, &health); err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
+
+//WaitForHealth polls health of cluster every interval until its status is at least as good as status. If timeout is
+//reached first, last health is returned with TimedOut enabled
+func (c controller) WaitForHealth(ctx context.Context, status string, interval time.Duration, timeout time.Duration) (*entity.Health, error) {
+	if _, err := mapper.HasReachedStatus("", status); err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		health, err := c.GetHealth(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if done, _ := mapper.HasReachedStatus(health.Status, status); done {
+			return health, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			health.TimedOut = true
+			return health, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+//ListNodes gets nodes of cluster sorted by name
+func (c controller) ListNodes(ctx context.Context) ([]entity.NodeOutput, error) {
+	response, err := c.gateway.GetNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []entity.CatNode
+	if err = json.Unmarshal(response, &nodes); err != nil {
+		return nil, err
+	}
+	return mapper.MapToNodeOutput(nodes), nil
+}
+
+//ExplainAllocation explains why shard is unassigned or why it remains on its node, first unassigned shard is explained
+//if r.Index is empty
+func (c controller) ExplainAllocation(ctx context.Context, r entity.AllocationExplainRequest) (*entity.AllocationExplanation, error) {
+	payload, err := mapper.MapToAllocationExplainPayload(r)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.gateway.ExplainAllocation(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	var explanation entity.AllocationExplanation
+	if err = json.Unmarshal(response, &explanation); err != nil {
+		return nil, err
+	}
+	return &explanation, nil
+}
+
+//GetSettings gets cluster settings which match r.Names, optionally with defaults
+func (c controller) GetSettings(ctx context.Context, r entity.SettingsRequest) ([]entity.SettingOutput, error) {
+	response, err := c.gateway.GetSettings(ctx, r.IncludeDefaults)
+	if err != nil {
+		return nil, err
+	}
+	var settings entity.Settings
+	if err = json.Unmarshal(response, &settings); err != nil {
+		return nil, err
+	}
+	return mapper.MapToSettingOutput(settings, r.Names), nil
+}
+
+//PutSettings updates cluster settings from name=value pairs and returns settings which were changed
+func (c controller) PutSettings(ctx context.Context, values []string, transient bool) ([]entity.SettingOutput, error) {
+	payload, err := mapper.MapToSettingsUpdate(values, transient)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.gateway.PutSettings(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Acknowledged bool `json:"acknowledged"`
+		entity.Settings
+	}
+	if err = json.Unmarshal(response, &result); err != nil {
+		return nil, err
+	}
+	if !result.Acknowledged {
+		return nil, fmt.Errorf("update of cluster settings was not acknowledged")
+	}
+	return mapper.MapToSettingOutput(result.Settings, nil), nil
+}
@@ -0,0 +1,167 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	entity "opensearch-cli/entity/cluster"
+	"opensearch-cli/gateway/cluster/mocks"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func helperLoadBytes(t *testing.T, name string) []byte {
+	path := filepath.Join("testdata", name) // relative path
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contents
+}
+
+func getHealthResponse(status string) []byte {
+	return []byte(`{"cluster_name":"opensearch","status":"` + status + `","number_of_nodes":2,"unassigned_shards":1,"active_shards_percent_as_number":87.5}`)
+}
+
+func TestControllerGetHealth(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("get health success", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().GetHealth(ctx).Return(getHealthResponse("yellow"), nil)
+		health, err := New(mockedGateway).GetHealth(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, entity.Health{
+			ClusterName:         "opensearch",
+			Status:              "yellow",
+			NumberOfNodes:       2,
+			UnassignedShards:    1,
+			ActiveShardsPercent: 87.5,
+		}, *health)
+	})
+	t.Run("gateway failed", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().GetHealth(ctx).Return(nil, errors.New("connection refused"))
+		_, err := New(mockedGateway).GetHealth(ctx)
+		assert.EqualError(t, err, "connection refused")
+	})
+}
+
+func TestControllerWaitForHealth(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("wait until status is reached", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		gomock.InOrder(
+			mockedGateway.EXPECT().GetHealth(ctx).Return(getHealthResponse("red"), nil),
+			mockedGateway.EXPECT().GetHealth(ctx).Return(getHealthResponse("green"), nil),
+		)
+		health, err := New(mockedGateway).WaitForHealth(ctx, "yellow", time.Millisecond, time.Minute)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "green", health.Status)
+		assert.False(t, health.TimedOut)
+	})
+	t.Run("timed out", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().GetHealth(ctx).Return(getHealthResponse("yellow"), nil)
+		health, err := New(mockedGateway).WaitForHealth(ctx, "green", time.Minute, time.Millisecond)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "yellow", health.Status)
+		assert.True(t, health.TimedOut)
+	})
+	t.Run("invalid status", func(t *testing.T) {
+		_, err := New(mocks.NewMockGateway(mockCtrl)).WaitForHealth(ctx, "blue", time.Millisecond, time.Minute)
+		assert.EqualError(t, err, "invalid status blue, allowed statuses are green, yellow and red")
+	})
+}
+
+func TestControllerListNodes(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockedGateway := mocks.NewMockGateway(mockCtrl)
+	mockedGateway.EXPECT().GetNodes(ctx).Return(helperLoadBytes(t, "nodes_response.json"), nil)
+	nodes, err := New(mockedGateway).ListNodes(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []entity.NodeOutput{
+		{Name: "opensearch-node1", IP: "172.18.0.2", Roles: "dimr", ClusterManager: true, HeapPercent: "35", RAMPercent: "92", CPU: "6", Load1m: "0.52"},
+		{Name: "opensearch-node2", IP: "172.18.0.3", Roles: "dimr", HeapPercent: "41", RAMPercent: "93", CPU: "4", Load1m: "0.52"},
+	}, nodes)
+}
+
+func TestControllerExplainAllocation(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("first unassigned shard", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().ExplainAllocation(ctx, nil).Return(helperLoadBytes(t, "allocation_explain_response.json"), nil)
+		explanation, err := New(mockedGateway).ExplainAllocation(ctx, entity.AllocationExplainRequest{})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "logs-1", explanation.Index)
+		assert.EqualValues(t, "INDEX_CREATED", explanation.UnassignedInfo.Reason)
+		assert.Len(t, explanation.NodeAllocationDecisions, 2)
+	})
+	t.Run("given shard", func(t *testing.T) {
+		request := entity.AllocationExplainRequest{Index: "logs-1", Shard: 1, Primary: true}
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().ExplainAllocation(ctx, request).Return(helperLoadBytes(t, "allocation_explain_response.json"), nil)
+		_, err := New(mockedGateway).ExplainAllocation(ctx, request)
+		assert.NoError(t, err)
+	})
+	t.Run("shard without index", func(t *testing.T) {
+		_, err := New(mocks.NewMockGateway(mockCtrl)).ExplainAllocation(ctx, entity.AllocationExplainRequest{Shard: 1})
+		assert.EqualError(t, err, "index is required when shard or primary is given")
+	})
+}
+
+func TestControllerSettings(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("get settings by prefix", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().GetSettings(ctx, true).Return(helperLoadBytes(t, "settings_response.json"), nil)
+		settings, err := New(mockedGateway).GetSettings(ctx, entity.SettingsRequest{Names: []string{"cluster.routing"}, IncludeDefaults: true})
+		assert.NoError(t, err)
+		assert.EqualValues(t, []entity.SettingOutput{
+			{Scope: "transient", Name: "cluster.routing.allocation.disk.watermark.low", Value: "90%"},
+			{Scope: "persistent", Name: "cluster.routing.allocation.enable", Value: "primaries"},
+			{Scope: "defaults", Name: "cluster.routing.allocation.node_concurrent_recoveries", Value: "2"},
+		}, settings)
+	})
+	t.Run("put settings", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().PutSettings(ctx, map[string]interface{}{
+			"transient": map[string]interface{}{"cluster.routing.allocation.enable": "none"},
+		}).Return([]byte(`{"acknowledged":true,"persistent":{},"transient":{"cluster.routing.allocation.enable":"none"}}`), nil)
+		settings, err := New(mockedGateway).PutSettings(ctx, []string{"cluster.routing.allocation.enable=none"}, true)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []entity.SettingOutput{
+			{Scope: "transient", Name: "cluster.routing.allocation.enable", Value: "none"},
+		}, settings)
+	})
+	t.Run("put settings not acknowledged", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().PutSettings(ctx, gomock.Any()).Return([]byte(`{"acknowledged":false}`), nil)
+		_, err := New(mockedGateway).PutSettings(ctx, []string{"action.auto_create_index=null"}, false)
+		assert.EqualError(t, err, "update of cluster settings was not acknowledged")
+	})
+}
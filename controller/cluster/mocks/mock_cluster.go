@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: opensearch-cli/controller/cluster (interfaces: Controller)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	cluster "opensearch-cli/entity/cluster"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockController is a mock of Controller interface
+type MockController struct {
+	ctrl     *gomock.Controller
+	recorder *MockControllerMockRecorder
+}
+
+// MockControllerMockRecorder is the mock recorder for MockController
+type MockControllerMockRecorder struct {
+	mock *MockController
+}
+
+// NewMockController creates a new mock instance
+func NewMockController(ctrl *gomock.Controller) *MockController {
+	mock := &MockController{ctrl: ctrl}
+	mock.recorder = &MockControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockController) EXPECT() *MockControllerMockRecorder {
+	return m.recorder
+}
+
+// ExplainAllocation mocks base method
+func (m *MockController) ExplainAllocation(arg0 context.Context, arg1 cluster.AllocationExplainRequest) (*cluster.AllocationExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainAllocation", arg0, arg1)
+	ret0, _ := ret[0].(*cluster.AllocationExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainAllocation indicates an expected call of ExplainAllocation
+func (mr *MockControllerMockRecorder) ExplainAllocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainAllocation", reflect.TypeOf((*MockController)(nil).ExplainAllocation), arg0, arg1)
+}
+
+// GetHealth mocks base method
+func (m *MockController) GetHealth(arg0 context.Context) (*cluster.Health, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealth", arg0)
+	ret0, _ := ret[0].(*cluster.Health)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealth indicates an expected call of GetHealth
+func (mr *MockControllerMockRecorder) GetHealth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealth", reflect.TypeOf((*MockController)(nil).GetHealth), arg0)
+}
+
+// GetSettings mocks base method
+func (m *MockController) GetSettings(arg0 context.Context, arg1 cluster.SettingsRequest) ([]cluster.SettingOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", arg0, arg1)
+	ret0, _ := ret[0].([]cluster.SettingOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings
+func (mr *MockControllerMockRecorder) GetSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockController)(nil).GetSettings), arg0, arg1)
+}
+
+// ListNodes mocks base method
+func (m *MockController) ListNodes(arg0 context.Context) ([]cluster.NodeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodes", arg0)
+	ret0, _ := ret[0].([]cluster.NodeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodes indicates an expected call of ListNodes
+func (mr *MockControllerMockRecorder) ListNodes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodes", reflect.TypeOf((*MockController)(nil).ListNodes), arg0)
+}
+
+// PutSettings mocks base method
+func (m *MockController) PutSettings(arg0 context.Context, arg1 []string, arg2 bool) ([]cluster.SettingOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutSettings", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]cluster.SettingOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutSettings indicates an expected call of PutSettings
+func (mr *MockControllerMockRecorder) PutSettings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSettings", reflect.TypeOf((*MockController)(nil).PutSettings), arg0, arg1, arg2)
+}
+
+// WaitForHealth mocks base method
+func (m *MockController) WaitForHealth(arg0 context.Context, arg1 string, arg2, arg3 time.Duration) (*cluster.Health, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForHealth", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*cluster.Health)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForHealth indicates an expected call of WaitForHealth
+func (mr *MockControllerMockRecorder) WaitForHealth(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForHealth", reflect.TypeOf((*MockController)(nil).WaitForHealth), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,105 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	entity "opensearch-cli/entity/connection"
+	gateway "opensearch-cli/gateway/connection"
+	mapper "opensearch-cli/mapper/connection"
+	"time"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_connection.go -package=mocks . Controller
+
+//Controller is an interface for the connection test controller
+type Controller interface {
+	TestConnection(context.Context) entity.Result
+}
+
+type controller struct {
+	gateway gateway.Gateway
+	now     func() time.Time
+}
+
+//New returns new Controller instance
+func New(gateway gateway.Gateway) Controller {
+	return &controller{
+		gateway: gateway,
+		now:     time.Now,
+	}
+}
+
+//TestConnection calls cluster and reports its details, failures are added to errors of result. Certificates of
+//cluster are reported even if request failed, for instance because they could not be verified.
+//Authenticated user is only requested if security plugin is installed or plugins are unknown
+func (c controller) TestConnection(ctx context.Context) (result entity.Result) {
+	response, err := c.gateway.GetClusterInfo(ctx)
+	result.Certificates = mapper.MapToCertificates(response.TLS, c.now())
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	result.Reachable = true
+	result.LatencyMS = response.Latency.Milliseconds()
+	var info entity.ClusterInfo
+	if err = json.Unmarshal(response.Body, &info); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("invalid response from cluster: %s", err))
+	}
+	result.ClusterName = info.ClusterName
+	result.Distribution = info.Version.Distribution
+	result.Version = info.Version.Number
+
+	securityInstalled := true
+	if plugins, err := c.getPlugins(ctx); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to get plugins due to: %s", err))
+	} else {
+		result.Plugins = plugins
+		securityInstalled = mapper.HasPlugin(plugins, mapper.SecurityPluginName)
+	}
+	if !securityInstalled {
+		return result
+	}
+	if authInfo, err := c.getAuthInfo(ctx); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to get authenticated user due to: %s", err))
+	} else {
+		result.User = authInfo.UserName
+		result.Roles = authInfo.Roles
+	}
+	return result
+}
+
+func (c controller) getPlugins(ctx context.Context) ([]entity.Plugin, error) {
+	response, err := c.gateway.GetPlugins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var plugins []entity.CatPlugin
+	if err = json.Unmarshal(response, &plugins); err != nil {
+		return nil, err
+	}
+	return mapper.MapToPlugins(plugins), nil
+}
+
+func (c controller) getAuthInfo(ctx context.Context) (*entity.AuthInfo, error) {
+	response, err := c.gateway.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var authInfo entity.AuthInfo
+	if err = json.Unmarshal(response, &authInfo); err != nil {
+		return nil, err
+	}
+	return &authInfo, nil
+}
@@ -0,0 +1,131 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package connection
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	entity "opensearch-cli/entity/connection"
+	"opensearch-cli/gateway/connection/mocks"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func helperLoadBytes(t *testing.T, name string) []byte {
+	path := filepath.Join("testdata", name) // relative path
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contents
+}
+
+func TestControllerTestConnection(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	getController := func(g *mocks.MockGateway) controller {
+		return controller{gateway: g, now: func() time.Time { return now }}
+	}
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		notAfter := now.Add(90 * 24 * time.Hour)
+		mockGateway.EXPECT().GetClusterInfo(ctx).Return(entity.Response{
+			Body:    helperLoadBytes(t, "cluster_info_response.json"),
+			Latency: 25 * time.Millisecond,
+			TLS: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{
+				Subject:  pkix.Name{CommonName: "node-0"},
+				Issuer:   pkix.Name{CommonName: "root-ca"},
+				NotAfter: notAfter,
+			}}},
+		}, nil)
+		mockGateway.EXPECT().GetPlugins(ctx).Return(helperLoadBytes(t, "plugins_response.json"), nil)
+		mockGateway.EXPECT().GetAuthInfo(ctx).Return(helperLoadBytes(t, "authinfo_response.json"), nil)
+		result := getController(mockGateway).TestConnection(ctx)
+		assert.Equal(t, entity.Result{
+			Reachable:    true,
+			LatencyMS:    25,
+			ClusterName:  "docker-cluster",
+			Distribution: "opensearch",
+			Version:      "2.11.0",
+			User:         "admin",
+			Roles:        []string{"own_index", "all_access"},
+			Certificates: []entity.Certificate{{Subject: "CN=node-0", Issuer: "CN=root-ca", NotAfter: notAfter, ExpiresInDays: 90}},
+			Plugins: []entity.Plugin{
+				{Name: "opensearch-knn", Version: "2.11.0.0"},
+				{Name: "opensearch-security", Version: "2.11.0.0"},
+			},
+		}, result)
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().GetClusterInfo(ctx).Return(entity.Response{}, errors.New("connection refused"))
+		result := getController(mockGateway).TestConnection(ctx)
+		assert.Equal(t, entity.Result{Errors: []string{"connection refused"}}, result)
+	})
+	t.Run("certificates are reported if request failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().GetClusterInfo(ctx).Return(entity.Response{
+			TLS: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{
+				Subject:  pkix.Name{CommonName: "node-0"},
+				Issuer:   pkix.Name{CommonName: "unknown-ca"},
+				NotAfter: now.Add(24 * time.Hour),
+			}}},
+		}, errors.New("x509: certificate signed by unknown authority"))
+		result := getController(mockGateway).TestConnection(ctx)
+		assert.False(t, result.Reachable)
+		assert.Equal(t, []string{"x509: certificate signed by unknown authority"}, result.Errors)
+		if assert.Len(t, result.Certificates, 1) {
+			assert.Equal(t, "CN=node-0", result.Certificates[0].Subject)
+			assert.Equal(t, "CN=unknown-ca", result.Certificates[0].Issuer)
+		}
+	})
+	t.Run("security plugin is not installed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().GetClusterInfo(ctx).Return(entity.Response{Body: helperLoadBytes(t, "cluster_info_response.json")}, nil)
+		mockGateway.EXPECT().GetPlugins(ctx).Return([]byte(`[]`), nil)
+		result := getController(mockGateway).TestConnection(ctx)
+		assert.True(t, result.Reachable)
+		assert.Empty(t, result.User)
+		assert.Nil(t, result.Certificates)
+		assert.Empty(t, result.Errors)
+	})
+	t.Run("plugins and auth info failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().GetClusterInfo(ctx).Return(entity.Response{Body: helperLoadBytes(t, "cluster_info_response.json")}, nil)
+		mockGateway.EXPECT().GetPlugins(ctx).Return(nil, errors.New("403 Client Error"))
+		mockGateway.EXPECT().GetAuthInfo(ctx).Return(nil, errors.New("403 Client Error"))
+		result := getController(mockGateway).TestConnection(ctx)
+		assert.True(t, result.Reachable)
+		assert.Equal(t, []string{
+			"failed to get plugins due to: 403 Client Error",
+			"failed to get authenticated user due to: 403 Client Error",
+		}, result.Errors)
+	})
+}
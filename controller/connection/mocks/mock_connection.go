@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: opensearch-cli/controller/connection (interfaces: Controller)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	connection "opensearch-cli/entity/connection"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockController is a mock of Controller interface
+type MockController struct {
+	ctrl     *gomock.Controller
+	recorder *MockControllerMockRecorder
+}
+
+// MockControllerMockRecorder is the mock recorder for MockController
+type MockControllerMockRecorder struct {
+	mock *MockController
+}
+
+// NewMockController creates a new mock instance
+func NewMockController(ctrl *gomock.Controller) *MockController {
+	mock := &MockController{ctrl: ctrl}
+	mock.recorder = &MockControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockController) EXPECT() *MockControllerMockRecorder {
+	return m.recorder
+}
+
+// TestConnection mocks base method
+func (m *MockController) TestConnection(arg0 context.Context) connection.Result {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestConnection", arg0)
+	ret0, _ := ret[0].(connection.Result)
+	return ret0
+}
+
+// TestConnection indicates an expected call of TestConnection
+func (mr *MockControllerMockRecorder) TestConnection(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestConnection", reflect.TypeOf((*MockController)(nil).TestConnection), arg0)
+}
@@ -0,0 +1,108 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package credential
+
+import (
+	"bytes"
+	"fmt"
+	"opensearch-cli/entity"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -destination=mocks/mock_credential.go -package=mocks . Store
+type Store interface {
+	Get(profile string) (entity.Credentials, error)
+	Set(profile string, credentials entity.Credentials) error
+	Delete(profile string) error
+}
+
+//Passphrase returns passphrase of encrypted credentials file, confirm is true if file is created
+type Passphrase func(confirm bool) (string, error)
+
+//Options contains settings of credential backends
+type Options struct {
+	//FilePath is path of encrypted credentials file used by file backend
+	FilePath   string
+	Passphrase Passphrase
+}
+
+//Factory returns store for credential backend of profile
+type Factory func(credential entity.Credential) (Store, error)
+
+//runner runs command with input and returns its standard output
+type runner func(input []byte, name string, args ...string) ([]byte, error)
+
+//NewFactory returns factory which creates stores for keyring, file and process backends,
+//file store is shared so that passphrase is asked only once
+func NewFactory(o Options) Factory {
+	file := newFileStore(o.FilePath, o.Passphrase)
+	return func(credential entity.Credential) (Store, error) {
+		switch credential.Backend {
+		case entity.CredentialBackendKeyring:
+			return newKeyringStore(runCommand), nil
+		case entity.CredentialBackendFile:
+			return file, nil
+		case entity.CredentialBackendProcess:
+			if len(credential.Process) == 0 {
+				return nil, fmt.Errorf("credential process is required for %s backend", entity.CredentialBackendProcess)
+			}
+			return newProcessStore(credential.Process, runCommand), nil
+		}
+		return nil, fmt.Errorf("invalid credential backend %s, allowed backends are %s",
+			credential.Backend, strings.Join(entity.CredentialBackends, ", "))
+	}
+}
+
+//runCommand runs command and adds its standard error to error if command fails
+func runCommand(input []byte, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); len(message) > 0 {
+			return nil, fmt.Errorf("%w: %s", err, message)
+		}
+		return nil, err
+	}
+	return output, nil
+}
+
+type provider struct {
+	store       Store
+	profile     string
+	once        sync.Once
+	credentials entity.Credentials
+	err         error
+}
+
+//NewProvider returns provider which retrieves credentials of profile from store only once,
+//when they are needed first
+func NewProvider(s Store, profile string) entity.CredentialProvider {
+	return &provider{
+		store:   s,
+		profile: profile,
+	}
+}
+
+//Retrieve gets credentials from store on first call and returns same result afterwards
+func (p *provider) Retrieve() (entity.Credentials, error) {
+	p.once.Do(func() {
+		p.credentials, p.err = p.store.Get(p.profile)
+	})
+	return p.credentials, p.err
+}
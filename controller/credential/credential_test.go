@@ -0,0 +1,74 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package credential
+
+import (
+	"errors"
+	"opensearch-cli/controller/credential/mocks"
+	"opensearch-cli/entity"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory(Options{FilePath: "credentials"})
+	t.Run("keyring", func(t *testing.T) {
+		store, err := factory(entity.Credential{Backend: entity.CredentialBackendKeyring})
+		assert.NoError(t, err)
+		assert.IsType(t, keyringStore{}, store)
+	})
+	t.Run("file store is shared", func(t *testing.T) {
+		first, err := factory(entity.Credential{Backend: entity.CredentialBackendFile})
+		assert.NoError(t, err)
+		second, err := factory(entity.Credential{Backend: entity.CredentialBackendFile})
+		assert.NoError(t, err)
+		assert.Same(t, first, second)
+	})
+	t.Run("process", func(t *testing.T) {
+		store, err := factory(entity.Credential{Backend: entity.CredentialBackendProcess, Process: "vault-creds"})
+		assert.NoError(t, err)
+		assert.Equal(t, "vault-creds", store.(processStore).command)
+	})
+	t.Run("process without command", func(t *testing.T) {
+		_, err := factory(entity.Credential{Backend: entity.CredentialBackendProcess})
+		assert.EqualError(t, err, "credential process is required for process backend")
+	})
+	t.Run("invalid backend", func(t *testing.T) {
+		_, err := factory(entity.Credential{Backend: "vault"})
+		assert.EqualError(t, err, "invalid credential backend vault, allowed backends are config, keyring, file, process")
+	})
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("retrieves credentials once", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		store := mocks.NewMockStore(mockCtrl)
+		store.EXPECT().Get("dev").Return(entity.Credentials{UserName: "admin", Password: "secret"}, nil).Times(1)
+		p := NewProvider(store, "dev")
+		for i := 0; i < 2; i++ {
+			credentials, err := p.Retrieve()
+			assert.NoError(t, err)
+			assert.Equal(t, entity.Credentials{UserName: "admin", Password: "secret"}, credentials)
+		}
+	})
+	t.Run("store failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		store := mocks.NewMockStore(mockCtrl)
+		store.EXPECT().Get("dev").Return(entity.Credentials{}, errors.New("locked"))
+		_, err := NewProvider(store, "dev").Retrieve()
+		assert.EqualError(t, err, "locked")
+	})
+}
@@ -0,0 +1,230 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package credential
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"opensearch-cli/entity"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	fileVersion     = 1
+	keyIterations   = 600000
+	keyLength       = 32
+	saltLength      = 16
+	filePermissions = 0600
+)
+
+//encryptedFile is content of credentials file, data is json map of profile name to credentials
+//encrypted with AES-256-GCM using key derived from passphrase by PBKDF2-HMAC-SHA256
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+//fileStore saves credentials of all profiles in single file encrypted with passphrase
+type fileStore struct {
+	path       string
+	passphrase Passphrase
+	//secret is passphrase entered by user, it is asked only once
+	secret *string
+}
+
+func newFileStore(path string, passphrase Passphrase) *fileStore {
+	return &fileStore{
+		path:       path,
+		passphrase: passphrase,
+	}
+}
+
+//Get decrypts credentials file and returns credentials of profile
+func (f *fileStore) Get(profile string) (result entity.Credentials, err error) {
+	credentials, err := f.read()
+	if err != nil {
+		return result, err
+	}
+	result, ok := credentials[profile]
+	if !ok {
+		return result, fmt.Errorf("no credentials found in %s for profile %s", f.path, profile)
+	}
+	return result, nil
+}
+
+//Set saves credentials of profile in credentials file, file is created if it does not exist
+func (f *fileStore) Set(profile string, credentials entity.Credentials) error {
+	all, err := f.read()
+	if err != nil {
+		return err
+	}
+	all[profile] = credentials
+	return f.write(all)
+}
+
+//Delete removes credentials of profile from credentials file
+func (f *fileStore) Delete(profile string) error {
+	all, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[profile]; !ok {
+		return nil
+	}
+	delete(all, profile)
+	return f.write(all)
+}
+
+//getPassphrase asks for passphrase if it was not asked yet
+func (f *fileStore) getPassphrase(confirm bool) (string, error) {
+	if f.secret != nil {
+		return *f.secret, nil
+	}
+	if f.passphrase == nil {
+		return "", errors.New("passphrase is required to use encrypted credentials file")
+	}
+	secret, err := f.passphrase(confirm)
+	if err != nil {
+		return "", err
+	}
+	if len(secret) == 0 {
+		return "", errors.New("passphrase cannot be empty")
+	}
+	f.secret = &secret
+	return secret, nil
+}
+
+//read decrypts credentials file, empty map is returned if file does not exist
+func (f *fileStore) read() (map[string]entity.Credentials, error) {
+	result := map[string]entity.Credentials{}
+	contents, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var file encryptedFile
+	if err = json.Unmarshal(contents, &file); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %w", f.path, err)
+	}
+	if file.Version != fileVersion {
+		return nil, fmt.Errorf("unsupported version %d of credentials file %s", file.Version, f.path)
+	}
+	passphrase, err := f.getPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newCipher(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid credentials file %s: invalid nonce", f.path)
+	}
+	data, err := aead.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		f.secret = nil
+		return nil, fmt.Errorf("failed to decrypt credentials file %s, passphrase may be incorrect", f.path)
+	}
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %w", f.path, err)
+	}
+	return result, nil
+}
+
+//write encrypts credentials with new salt and nonce and replaces credentials file, file is written to temporary
+//file first so that credentials are not lost if write fails
+func (f *fileStore) write(credentials map[string]entity.Credentials) error {
+	_, statErr := os.Stat(f.path)
+	passphrase, err := f.getPassphrase(errors.Is(statErr, os.ErrNotExist))
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+	file := encryptedFile{
+		Version:    fileVersion,
+		Iterations: keyIterations,
+		Salt:       make([]byte, saltLength),
+	}
+	if _, err = rand.Read(file.Salt); err != nil {
+		return err
+	}
+	aead, err := newCipher(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err = rand.Read(file.Nonce); err != nil {
+		return err
+	}
+	file.Data = aead.Seal(nil, file.Nonce, data, nil)
+	contents, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	return replaceFile(f.path, contents)
+}
+
+//replaceFile writes contents to new temporary file in directory of path and renames it to path, temporary file
+//is created with owner only permissions and synced to disk before rename
+func replaceFile(path string, contents []byte) (err error) {
+	temporary, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = temporary.Close()
+			_ = os.Remove(temporary.Name())
+		}
+	}()
+	if err = temporary.Chmod(filePermissions); err != nil {
+		return err
+	}
+	if _, err = temporary.Write(contents); err != nil {
+		return err
+	}
+	if err = temporary.Sync(); err != nil {
+		return err
+	}
+	if err = temporary.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temporary.Name(), path)
+}
+
+//newCipher returns AES-256-GCM cipher with key derived from passphrase
+func newCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations < 1 {
+		return nil, fmt.Errorf("invalid iterations %d in credentials file", iterations)
+	}
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, iterations, keyLength, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,87 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package credential
+
+import (
+	"errors"
+	"opensearch-cli/entity"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//passphrase returns passphrase and records whether confirmation was asked
+func passphrase(secret string, confirmed *[]bool) Passphrase {
+	return func(confirm bool) (string, error) {
+		*confirmed = append(*confirmed, confirm)
+		return secret, nil
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	var confirmed []bool
+	store := newFileStore(path, passphrase("changeit", &confirmed))
+	admin := entity.Credentials{UserName: "admin", Password: "secret"}
+
+	t.Run("set creates file", func(t *testing.T) {
+		assert.NoError(t, store.Set("dev", admin))
+		assert.Equal(t, []bool{true}, confirmed)
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		contents, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(contents), "secret")
+		entries, err := os.ReadDir(filepath.Dir(path))
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+	t.Run("set ignores stale temporary file", func(t *testing.T) {
+		stale := path + ".tmp"
+		assert.NoError(t, os.WriteFile(stale, []byte("stale"), 0644))
+		defer os.Remove(stale)
+		assert.NoError(t, store.Set("dev", admin))
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+	t.Run("get with new store", func(t *testing.T) {
+		confirmed = nil
+		result, err := newFileStore(path, passphrase("changeit", &confirmed)).Get("dev")
+		assert.NoError(t, err)
+		assert.Equal(t, admin, result)
+		assert.Equal(t, []bool{false}, confirmed)
+	})
+	t.Run("wrong passphrase", func(t *testing.T) {
+		_, err := newFileStore(path, passphrase("guess", &confirmed)).Get("dev")
+		assert.EqualError(t, err, "failed to decrypt credentials file "+path+", passphrase may be incorrect")
+	})
+	t.Run("missing profile", func(t *testing.T) {
+		_, err := store.Get("prod")
+		assert.EqualError(t, err, "no credentials found in "+path+" for profile prod")
+	})
+	t.Run("delete", func(t *testing.T) {
+		assert.NoError(t, store.Delete("dev"))
+		_, err := store.Get("dev")
+		assert.Error(t, err)
+	})
+	t.Run("passphrase failed", func(t *testing.T) {
+		store := newFileStore(path, func(bool) (string, error) {
+			return "", errors.New("not a terminal")
+		})
+		_, err := store.Get("dev")
+		assert.EqualError(t, err, "not a terminal")
+	})
+}
@@ -0,0 +1,88 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package credential
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"opensearch-cli/entity"
+	"os/exec"
+)
+
+const (
+	secretTool       = "secret-tool"
+	keyringService   = "opensearch-cli"
+	attributeService = "service"
+	attributeProfile = "profile"
+)
+
+//keyringStore saves credentials in Secret Service keyring (GNOME Keyring, KWallet) using secret-tool from libsecret
+type keyringStore struct {
+	run runner
+}
+
+func newKeyringStore(run runner) Store {
+	return keyringStore{
+		run: run,
+	}
+}
+
+//attributes returns attributes which identify credentials of profile in keyring
+func attributes(profile string) []string {
+	return []string{attributeService, keyringService, attributeProfile, profile}
+}
+
+//Get looks up credentials of profile in keyring
+func (k keyringStore) Get(profile string) (result entity.Credentials, err error) {
+	output, err := k.run(nil, secretTool, append([]string{"lookup"}, attributes(profile)...)...)
+	//secret-tool fails without any message if secret does not exist
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) || (err == nil && len(output) == 0) {
+		return result, fmt.Errorf("no credentials found in keyring for profile %s", profile)
+	}
+	if err != nil {
+		return result, k.wrapError(err)
+	}
+	if err = json.Unmarshal(output, &result); err != nil {
+		return result, fmt.Errorf("invalid credentials found in keyring for profile %s: %w", profile, err)
+	}
+	return result, nil
+}
+
+//Set saves credentials of profile in keyring, existing credentials are replaced
+func (k keyringStore) Set(profile string, credentials entity.Credentials) error {
+	secret, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+	args := append([]string{"store", "--label=opensearch-cli profile " + profile}, attributes(profile)...)
+	if _, err = k.run(secret, secretTool, args...); err != nil {
+		return k.wrapError(err)
+	}
+	return nil
+}
+
+//Delete removes credentials of profile from keyring
+func (k keyringStore) Delete(profile string) error {
+	if _, err := k.run(nil, secretTool, append([]string{"clear"}, attributes(profile)...)...); err != nil {
+		return k.wrapError(err)
+	}
+	return nil
+}
+
+func (k keyringStore) wrapError(err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%s from libsecret is required to use keyring: %w", secretTool, err)
+	}
+	return fmt.Errorf("failed to access keyring: %w", err)
+}
@@ -0,0 +1,98 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package credential
+
+import (
+	"errors"
+	"fmt"
+	"opensearch-cli/entity"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type call struct {
+	input []byte
+	name  string
+	args  []string
+}
+
+//fakeRunner records calls and returns given output and error
+func fakeRunner(calls *[]call, output string, err error) runner {
+	return func(input []byte, name string, args ...string) ([]byte, error) {
+		*calls = append(*calls, call{input: input, name: name, args: args})
+		return []byte(output), err
+	}
+}
+
+func TestKeyringStoreGet(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var calls []call
+		store := newKeyringStore(fakeRunner(&calls, `{"user":"admin","password":"secret"}`, nil))
+		result, err := store.Get("dev")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.Credentials{UserName: "admin", Password: "secret"}, result)
+		assert.Equal(t, []call{{name: "secret-tool", args: []string{"lookup", "service", "opensearch-cli", "profile", "dev"}}}, calls)
+	})
+	t.Run("not found", func(t *testing.T) {
+		var calls []call
+		store := newKeyringStore(fakeRunner(&calls, "", &exec.ExitError{}))
+		_, err := store.Get("dev")
+		assert.EqualError(t, err, "no credentials found in keyring for profile dev")
+	})
+	t.Run("not found with wrapped exit error", func(t *testing.T) {
+		var calls []call
+		store := newKeyringStore(fakeRunner(&calls, "", fmt.Errorf("secret-tool failed: %w", &exec.ExitError{})))
+		_, err := store.Get("dev")
+		assert.EqualError(t, err, "no credentials found in keyring for profile dev")
+	})
+	t.Run("secret-tool is not installed", func(t *testing.T) {
+		var calls []call
+		store := newKeyringStore(fakeRunner(&calls, "", exec.ErrNotFound))
+		_, err := store.Get("dev")
+		assert.EqualError(t, err, "secret-tool from libsecret is required to use keyring: executable file not found in $PATH")
+	})
+	t.Run("invalid secret", func(t *testing.T) {
+		var calls []call
+		store := newKeyringStore(fakeRunner(&calls, "secret", nil))
+		_, err := store.Get("dev")
+		assert.Error(t, err)
+	})
+}
+
+func TestKeyringStoreSet(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var calls []call
+		store := newKeyringStore(fakeRunner(&calls, "", nil))
+		err := store.Set("dev", entity.Credentials{UserName: "admin", Password: "secret"})
+		assert.NoError(t, err)
+		assert.Equal(t, []call{{
+			input: []byte(`{"user":"admin","password":"secret"}`),
+			name:  "secret-tool",
+			args:  []string{"store", "--label=opensearch-cli profile dev", "service", "opensearch-cli", "profile", "dev"},
+		}}, calls)
+	})
+	t.Run("failed", func(t *testing.T) {
+		var calls []call
+		store := newKeyringStore(fakeRunner(&calls, "", errors.New("exit status 1: Cannot autolaunch D-Bus")))
+		err := store.Set("dev", entity.Credentials{UserName: "admin", Password: "secret"})
+		assert.EqualError(t, err, "failed to access keyring: exit status 1: Cannot autolaunch D-Bus")
+	})
+}
+
+func TestKeyringStoreDelete(t *testing.T) {
+	var calls []call
+	store := newKeyringStore(fakeRunner(&calls, "", nil))
+	assert.NoError(t, store.Delete("dev"))
+	assert.Equal(t, []call{{name: "secret-tool", args: []string{"clear", "service", "opensearch-cli", "profile", "dev"}}}, calls)
+}
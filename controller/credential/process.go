@@ -0,0 +1,61 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package credential
+
+import (
+	"encoding/json"
+	"fmt"
+	"opensearch-cli/entity"
+	"runtime"
+)
+
+//processStore gets credentials from standard output of command, like {"user": "admin", "password": "secret"}
+type processStore struct {
+	command string
+	run     runner
+}
+
+func newProcessStore(command string, run runner) Store {
+	return processStore{
+		command: command,
+		run:     run,
+	}
+}
+
+//Get runs command using shell and parses its output as credentials
+func (p processStore) Get(profile string) (result entity.Credentials, err error) {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	output, err := p.run(nil, shell, flag, p.command)
+	if err != nil {
+		return result, fmt.Errorf("credential process of profile %s failed: %w", profile, err)
+	}
+	if err = json.Unmarshal(output, &result); err != nil {
+		return result, fmt.Errorf("invalid output of credential process of profile %s, expected json with user and password: %w", profile, err)
+	}
+	if len(result.Password) == 0 {
+		return result, fmt.Errorf("credential process of profile %s did not return password", profile)
+	}
+	return result, nil
+}
+
+//Set fails since credentials are owned by command
+func (p processStore) Set(profile string, _ entity.Credentials) error {
+	return fmt.Errorf("credentials of profile %s are provided by credential process and cannot be saved", profile)
+}
+
+//Delete does nothing since credentials are owned by command
+func (p processStore) Delete(string) error {
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package credential
+
+import (
+	"opensearch-cli/entity"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessStoreGet(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands are written for sh")
+	}
+	t.Run("success", func(t *testing.T) {
+		store := newProcessStore(`echo '{"user": "admin", "password": "secret"}'`, runCommand)
+		result, err := store.Get("dev")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.Credentials{UserName: "admin", Password: "secret"}, result)
+	})
+	t.Run("command failed", func(t *testing.T) {
+		store := newProcessStore(`echo "token expired" >&2; exit 2`, runCommand)
+		_, err := store.Get("dev")
+		assert.EqualError(t, err, "credential process of profile dev failed: exit status 2: token expired")
+	})
+	t.Run("invalid output", func(t *testing.T) {
+		store := newProcessStore(`echo secret`, runCommand)
+		_, err := store.Get("dev")
+		assert.Error(t, err)
+	})
+	t.Run("no password", func(t *testing.T) {
+		store := newProcessStore(`echo '{"user": "admin"}'`, runCommand)
+		_, err := store.Get("dev")
+		assert.EqualError(t, err, "credential process of profile dev did not return password")
+	})
+}
+
+func TestProcessStoreSet(t *testing.T) {
+	store := newProcessStore("echo", runCommand)
+	err := store.Set("dev", entity.Credentials{})
+	assert.EqualError(t, err, "credentials of profile dev are provided by credential process and cannot be saved")
+	assert.NoError(t, store.Delete("dev"))
+}
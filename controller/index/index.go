@@ -0,0 +1,210 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	entity "opensearch-cli/entity/index"
+	gateway "opensearch-cli/gateway/index"
+	mapper "opensearch-cli/mapper/index"
+	"opensearch-cli/prompt"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_index.go -package=mocks . Controller
+
+//Controller is an interface for the index controllers
+type Controller interface {
+	ListIndices(context.Context, entity.ListRequest) ([]entity.CatIndex, error)
+	CreateIndex(context.Context, string, map[string]interface{}) error
+	DeleteIndex(context.Context, string, bool) error
+	GetMapping(context.Context, string) (json.RawMessage, error)
+	PutSettings(context.Context, string, map[string]interface{}) error
+	OpenIndex(context.Context, string) error
+	CloseIndex(context.Context, string) error
+	ListAliases(context.Context, string) ([]entity.AliasOutput, error)
+	AddAlias(context.Context, string, string, bool) error
+	RemoveAlias(context.Context, string, string) error
+}
+
+type controller struct {
+	reader  io.Reader
+	gateway gateway.Gateway
+}
+
+//New returns new Controller instance
+func New(reader io.Reader, gateway gateway.Gateway) Controller {
+	return &controller{
+		reader,
+		gateway,
+	}
+}
+
+//checkAcknowledged returns error if change of cluster state was not acknowledged
+func checkAcknowledged(response []byte, operation string, index string) error {
+	var ack entity.AcknowledgedResponse
+	if err := json.Unmarshal(response, &ack); err != nil {
+		return err
+	}
+	if !ack.Acknowledged {
+		return fmt.Errorf("%s of %s was not acknowledged", operation, index)
+	}
+	return nil
+}
+
+//ListIndices returns indices matched by r.Pattern, filtered by health and status, sorted by r.Sort
+func (c controller) ListIndices(ctx context.Context, r entity.ListRequest) ([]entity.CatIndex, error) {
+	response, err := c.gateway.CatIndices(ctx, r.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	var indices []entity.CatIndex
+	if err = json.Unmarshal(response, &indices); err != nil {
+		return nil, err
+	}
+	indices = mapper.FilterIndices(indices, r.Health, r.Status)
+	if err = mapper.SortIndices(indices, r.Sort); err != nil {
+		return nil, err
+	}
+	return indices, nil
+}
+
+//CreateIndex creates index with settings, mappings and aliases given by body
+func (c controller) CreateIndex(ctx context.Context, index string, body map[string]interface{}) error {
+	if len(index) < 1 {
+		return fmt.Errorf("index cannot be empty")
+	}
+	response, err := c.gateway.CreateIndex(ctx, index, body)
+	if err != nil {
+		return err
+	}
+	return checkAcknowledged(response, "creation", index)
+}
+
+//DeleteIndex deletes indices matched by index, if interactive is enabled, matched indices are listed and
+//user is asked for confirmation before they are deleted
+func (c controller) DeleteIndex(ctx context.Context, index string, interactive bool) error {
+	if len(index) < 1 {
+		return fmt.Errorf("index cannot be empty")
+	}
+	if interactive {
+		indices, err := c.ListIndices(ctx, entity.ListRequest{Pattern: index})
+		if err != nil {
+			return err
+		}
+		if len(indices) < 1 {
+			return fmt.Errorf("no indices matched %s", index)
+		}
+		fmt.Printf("%d indices matched %s\n", len(indices), index)
+		for _, matched := range indices {
+			fmt.Println(matched.Index)
+		}
+		proceed, err := prompt.Confirm(c.reader, "opensearch-cli will delete above matched indices. Do you want to proceed? Y/N ")
+		if err != nil || !proceed {
+			return err
+		}
+	}
+	response, err := c.gateway.DeleteIndex(ctx, index)
+	if err != nil {
+		return err
+	}
+	return checkAcknowledged(response, "deletion", index)
+}
+
+//GetMapping returns mappings of indices matched by index
+func (c controller) GetMapping(ctx context.Context, index string) (json.RawMessage, error) {
+	if len(index) < 1 {
+		return nil, fmt.Errorf("index cannot be empty")
+	}
+	response, err := c.gateway.GetMapping(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+//PutSettings updates dynamic settings of indices matched by index
+func (c controller) PutSettings(ctx context.Context, index string, settings map[string]interface{}) error {
+	if len(index) < 1 {
+		return fmt.Errorf("index cannot be empty")
+	}
+	response, err := c.gateway.PutSettings(ctx, index, settings)
+	if err != nil {
+		return err
+	}
+	return checkAcknowledged(response, "settings update", index)
+}
+
+//OpenIndex opens closed index
+func (c controller) OpenIndex(ctx context.Context, index string) error {
+	if len(index) < 1 {
+		return fmt.Errorf("index cannot be empty")
+	}
+	response, err := c.gateway.OpenIndex(ctx, index)
+	if err != nil {
+		return err
+	}
+	return checkAcknowledged(response, "opening", index)
+}
+
+//CloseIndex closes index
+func (c controller) CloseIndex(ctx context.Context, index string) error {
+	if len(index) < 1 {
+		return fmt.Errorf("index cannot be empty")
+	}
+	response, err := c.gateway.CloseIndex(ctx, index)
+	if err != nil {
+		return err
+	}
+	return checkAcknowledged(response, "closing", index)
+}
+
+//ListAliases returns aliases of indices matched by index, aliases of every index are returned if index is empty
+func (c controller) ListAliases(ctx context.Context, index string) ([]entity.AliasOutput, error) {
+	response, err := c.gateway.GetAliases(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	aliases := map[string]entity.IndexAliases{}
+	if err = json.Unmarshal(response, &aliases); err != nil {
+		return nil, err
+	}
+	return mapper.MapToAliasOutput(aliases), nil
+}
+
+//AddAlias adds alias to index, if isWriteIndex is enabled, index becomes write index of alias
+func (c controller) AddAlias(ctx context.Context, index string, alias string, isWriteIndex bool) error {
+	var writeIndex *bool
+	if isWriteIndex {
+		writeIndex = &isWriteIndex
+	}
+	return c.updateAliases(ctx, mapper.AliasActionAdd, index, alias, writeIndex)
+}
+
+//RemoveAlias removes alias from index
+func (c controller) RemoveAlias(ctx context.Context, index string, alias string) error {
+	return c.updateAliases(ctx, mapper.AliasActionRemove, index, alias, nil)
+}
+
+func (c controller) updateAliases(ctx context.Context, action string, index string, alias string, isWriteIndex *bool) error {
+	request, err := mapper.MapToUpdateAliasesRequest(action, index, alias, isWriteIndex)
+	if err != nil {
+		return err
+	}
+	response, err := c.gateway.UpdateAliases(ctx, request)
+	if err != nil {
+		return err
+	}
+	return checkAcknowledged(response, "alias update", index)
+}
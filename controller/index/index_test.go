@@ -0,0 +1,238 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	entity "opensearch-cli/entity/index"
+	"opensearch-cli/gateway/index/mocks"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const acknowledged = `{"acknowledged":true,"shards_acknowledged":true}`
+
+func helperLoadBytes(t *testing.T, name string) []byte {
+	path := filepath.Join("testdata", name) // relative path
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contents
+}
+
+func TestControllerListIndices(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("filter and sort", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CatIndices(ctx, "logs-*").Return(helperLoadBytes(t, "cat_indices_response.json"), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		indices, err := ctrl.ListIndices(ctx, entity.ListRequest{Pattern: "logs-*", Health: "green", Sort: "docs.count:desc"})
+		assert.NoError(t, err)
+		assert.Len(t, indices, 2)
+		assert.EqualValues(t, "logs-1", indices[0].Index)
+		assert.EqualValues(t, "416000", indices[0].StoreSize)
+		assert.EqualValues(t, "logs-3", indices[1].Index)
+	})
+	t.Run("sorted by name by default", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CatIndices(ctx, "").Return(helperLoadBytes(t, "cat_indices_response.json"), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		indices, err := ctrl.ListIndices(ctx, entity.ListRequest{})
+		assert.NoError(t, err)
+		var names []string
+		for _, i := range indices {
+			names = append(names, i.Index)
+		}
+		assert.EqualValues(t, []string{"logs-1", "logs-2", "logs-3"}, names)
+	})
+	t.Run("invalid sort", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CatIndices(ctx, "").Return(helperLoadBytes(t, "cat_indices_response.json"), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		_, err := ctrl.ListIndices(ctx, entity.ListRequest{Sort: "size"})
+		assert.EqualError(t, err, "invalid sort column size")
+	})
+	t.Run("gateway failed", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CatIndices(ctx, "").Return(nil, errors.New("failed"))
+		ctrl := New(os.Stdin, mockedGateway)
+		_, err := ctrl.ListIndices(ctx, entity.ListRequest{})
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestControllerCreateIndex(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	body := map[string]interface{}{"settings": map[string]interface{}{"number_of_shards": 1}}
+	t.Run("create success", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CreateIndex(ctx, "index1", body).Return([]byte(acknowledged), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.NoError(t, ctrl.CreateIndex(ctx, "index1", body))
+	})
+	t.Run("not acknowledged", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CreateIndex(ctx, "index1", body).Return([]byte(`{"acknowledged":false}`), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.EqualError(t, ctrl.CreateIndex(ctx, "index1", body), "creation of index1 was not acknowledged")
+	})
+	t.Run("empty index", func(t *testing.T) {
+		ctrl := New(os.Stdin, mocks.NewMockGateway(mockCtrl))
+		assert.EqualError(t, ctrl.CreateIndex(ctx, "", body), "index cannot be empty")
+	})
+}
+
+func TestControllerDeleteIndex(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("delete without confirmation", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().DeleteIndex(ctx, "index1").Return([]byte(`{"acknowledged":true}`), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.NoError(t, ctrl.DeleteIndex(ctx, "index1", false))
+	})
+	t.Run("stopped by user", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CatIndices(ctx, "index*").Return([]byte(`[{"index":"index2"},{"index":"index1"}]`), nil)
+		var stdin bytes.Buffer
+		stdin.Write([]byte("no\n"))
+		ctrl := New(&stdin, mockedGateway)
+		assert.NoError(t, ctrl.DeleteIndex(ctx, "index*", true))
+	})
+	t.Run("agreed by user after invalid answer", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CatIndices(ctx, "index1").Return([]byte(`[{"index":"index1"}]`), nil)
+		mockedGateway.EXPECT().DeleteIndex(ctx, "index1").Return([]byte(`{"acknowledged":true}`), nil)
+		var stdin bytes.Buffer
+		stdin.Write([]byte("maybe\ny\n"))
+		ctrl := New(&stdin, mockedGateway)
+		assert.NoError(t, ctrl.DeleteIndex(ctx, "index1", true))
+	})
+	t.Run("no indices matched", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CatIndices(ctx, "logs-*").Return([]byte(`[]`), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.EqualError(t, ctrl.DeleteIndex(ctx, "logs-*", true), "no indices matched logs-*")
+	})
+	t.Run("no answer from user", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CatIndices(ctx, "index1").Return([]byte(`[{"index":"index1"}]`), nil)
+		ctrl := New(&bytes.Buffer{}, mockedGateway)
+		assert.EqualError(t, ctrl.DeleteIndex(ctx, "index1", true), "failed to accept value from user due to EOF")
+	})
+	t.Run("gateway failed", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().DeleteIndex(ctx, "index1").Return(nil, errors.New("index_not_found_exception"))
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.EqualError(t, ctrl.DeleteIndex(ctx, "index1", false), "index_not_found_exception")
+	})
+}
+
+func TestControllerGetMapping(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("get mapping success", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mapping := `{"index1":{"mappings":{"properties":{"title":{"type":"text"}}}}}`
+		mockedGateway.EXPECT().GetMapping(ctx, "index1").Return([]byte(mapping), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		response, err := ctrl.GetMapping(ctx, "index1")
+		assert.NoError(t, err)
+		assert.JSONEq(t, mapping, string(response))
+	})
+	t.Run("empty index", func(t *testing.T) {
+		ctrl := New(os.Stdin, mocks.NewMockGateway(mockCtrl))
+		_, err := ctrl.GetMapping(ctx, "")
+		assert.EqualError(t, err, "index cannot be empty")
+	})
+}
+
+func TestControllerChangeIndex(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("put settings", func(t *testing.T) {
+		settings := map[string]interface{}{"index": map[string]interface{}{"number_of_replicas": 2}}
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().PutSettings(ctx, "index1", settings).Return([]byte(`{"acknowledged":true}`), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.NoError(t, ctrl.PutSettings(ctx, "index1", settings))
+	})
+	t.Run("open index", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().OpenIndex(ctx, "index1").Return([]byte(acknowledged), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.NoError(t, ctrl.OpenIndex(ctx, "index1"))
+	})
+	t.Run("close index not acknowledged", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().CloseIndex(ctx, "index1").Return([]byte(`{"acknowledged":false}`), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.EqualError(t, ctrl.CloseIndex(ctx, "index1"), "closing of index1 was not acknowledged")
+	})
+}
+
+func TestControllerAliases(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("list aliases", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().GetAliases(ctx, "").Return(helperLoadBytes(t, "aliases_response.json"), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		aliases, err := ctrl.ListAliases(ctx, "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []entity.AliasOutput{
+			{Alias: "errors", Index: "logs-1", Filtered: true},
+			{Alias: "logs", Index: "logs-1"},
+			{Alias: "logs", Index: "logs-2", IsWriteIndex: true},
+		}, aliases)
+	})
+	t.Run("add write alias", func(t *testing.T) {
+		isWriteIndex := true
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().UpdateAliases(ctx, entity.UpdateAliasesRequest{
+			Actions: []map[string]entity.AliasAction{
+				{"add": {Index: "logs-3", Alias: "logs", IsWriteIndex: &isWriteIndex}},
+			},
+		}).Return([]byte(`{"acknowledged":true}`), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.NoError(t, ctrl.AddAlias(ctx, "logs-3", "logs", true))
+	})
+	t.Run("remove alias", func(t *testing.T) {
+		mockedGateway := mocks.NewMockGateway(mockCtrl)
+		mockedGateway.EXPECT().UpdateAliases(ctx, entity.UpdateAliasesRequest{
+			Actions: []map[string]entity.AliasAction{
+				{"remove": {Index: "logs-1", Alias: "errors"}},
+			},
+		}).Return([]byte(`{"acknowledged":true}`), nil)
+		ctrl := New(os.Stdin, mockedGateway)
+		assert.NoError(t, ctrl.RemoveAlias(ctx, "logs-1", "errors"))
+	})
+	t.Run("empty alias", func(t *testing.T) {
+		ctrl := New(os.Stdin, mocks.NewMockGateway(mockCtrl))
+		assert.EqualError(t, ctrl.RemoveAlias(ctx, "logs-1", ""), "alias cannot be empty")
+	})
+}
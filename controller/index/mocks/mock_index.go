@@ -0,0 +1,180 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: opensearch-cli/controller/index (interfaces: Controller)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	jsontext "encoding/json/jsontext"
+	index "opensearch-cli/entity/index"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockController is a mock of Controller interface
+type MockController struct {
+	ctrl     *gomock.Controller
+	recorder *MockControllerMockRecorder
+}
+
+// MockControllerMockRecorder is the mock recorder for MockController
+type MockControllerMockRecorder struct {
+	mock *MockController
+}
+
+// NewMockController creates a new mock instance
+func NewMockController(ctrl *gomock.Controller) *MockController {
+	mock := &MockController{ctrl: ctrl}
+	mock.recorder = &MockControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockController) EXPECT() *MockControllerMockRecorder {
+	return m.recorder
+}
+
+// AddAlias mocks base method
+func (m *MockController) AddAlias(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAlias", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAlias indicates an expected call of AddAlias
+func (mr *MockControllerMockRecorder) AddAlias(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAlias", reflect.TypeOf((*MockController)(nil).AddAlias), arg0, arg1, arg2, arg3)
+}
+
+// CloseIndex mocks base method
+func (m *MockController) CloseIndex(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseIndex", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseIndex indicates an expected call of CloseIndex
+func (mr *MockControllerMockRecorder) CloseIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseIndex", reflect.TypeOf((*MockController)(nil).CloseIndex), arg0, arg1)
+}
+
+// CreateIndex mocks base method
+func (m *MockController) CreateIndex(arg0 context.Context, arg1 string, arg2 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIndex indicates an expected call of CreateIndex
+func (mr *MockControllerMockRecorder) CreateIndex(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockController)(nil).CreateIndex), arg0, arg1, arg2)
+}
+
+// DeleteIndex mocks base method
+func (m *MockController) DeleteIndex(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIndex", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIndex indicates an expected call of DeleteIndex
+func (mr *MockControllerMockRecorder) DeleteIndex(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIndex", reflect.TypeOf((*MockController)(nil).DeleteIndex), arg0, arg1, arg2)
+}
+
+// GetMapping mocks base method
+func (m *MockController) GetMapping(arg0 context.Context, arg1 string) (jsontext.Value, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMapping", arg0, arg1)
+	ret0, _ := ret[0].(jsontext.Value)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMapping indicates an expected call of GetMapping
+func (mr *MockControllerMockRecorder) GetMapping(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMapping", reflect.TypeOf((*MockController)(nil).GetMapping), arg0, arg1)
+}
+
+// ListAliases mocks base method
+func (m *MockController) ListAliases(arg0 context.Context, arg1 string) ([]index.AliasOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAliases", arg0, arg1)
+	ret0, _ := ret[0].([]index.AliasOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAliases indicates an expected call of ListAliases
+func (mr *MockControllerMockRecorder) ListAliases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAliases", reflect.TypeOf((*MockController)(nil).ListAliases), arg0, arg1)
+}
+
+// ListIndices mocks base method
+func (m *MockController) ListIndices(arg0 context.Context, arg1 index.ListRequest) ([]index.CatIndex, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIndices", arg0, arg1)
+	ret0, _ := ret[0].([]index.CatIndex)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIndices indicates an expected call of ListIndices
+func (mr *MockControllerMockRecorder) ListIndices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIndices", reflect.TypeOf((*MockController)(nil).ListIndices), arg0, arg1)
+}
+
+// OpenIndex mocks base method
+func (m *MockController) OpenIndex(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenIndex", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenIndex indicates an expected call of OpenIndex
+func (mr *MockControllerMockRecorder) OpenIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenIndex", reflect.TypeOf((*MockController)(nil).OpenIndex), arg0, arg1)
+}
+
+// PutSettings mocks base method
+func (m *MockController) PutSettings(arg0 context.Context, arg1 string, arg2 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutSettings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutSettings indicates an expected call of PutSettings
+func (mr *MockControllerMockRecorder) PutSettings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSettings", reflect.TypeOf((*MockController)(nil).PutSettings), arg0, arg1, arg2)
+}
+
+// RemoveAlias mocks base method
+func (m *MockController) RemoveAlias(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlias", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlias indicates an expected call of RemoveAlias
+func (mr *MockControllerMockRecorder) RemoveAlias(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlias", reflect.TypeOf((*MockController)(nil).RemoveAlias), arg0, arg1, arg2)
+}
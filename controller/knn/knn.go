@@ -14,31 +14,65 @@ package knn
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	platform "opensearch-cli/controller/platform"
 	entity "opensearch-cli/entity/knn"
+	osentity "opensearch-cli/entity/platform"
 	gateway "opensearch-cli/gateway/knn"
+	mapper "opensearch-cli/mapper/knn"
+	"sort"
 	"strings"
+	"time"
 )
 
 //go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_knn.go -package=mocks . Controller
 
 //Controller is an interface for the k-NN plugin controllers
 type Controller interface {
-	GetStatistics(context.Context, string, string) ([]byte, error)
+	GetStatistics(context.Context, string, string) (*entity.Stats, error)
 	WarmupIndices(context.Context, []string) (*entity.Shards, error)
+	ClearCache(context.Context, []string) (*entity.Shards, error)
+	TrainModel(context.Context, string, entity.TrainModelRequest) (string, error)
+	GetModel(context.Context, string) (*entity.Model, error)
+	ListModels(context.Context) ([]*entity.Model, error)
+	DeleteModel(context.Context, string) error
+	WaitForModel(context.Context, string, time.Duration) (*entity.Model, error)
+	SearchVector(context.Context, entity.SearchCommandRequest) ([]*entity.SearchResult, error)
+	IngestVectors(context.Context, entity.IngestRequest, io.Reader, int64, bool) (*entity.IngestResult, error)
 }
 
+const (
+	//maxModelsPageSize is maximum number of models fetched by ListModels
+	maxModelsPageSize = 1000
+	//modelBlobField is serialized model, which is excluded from list since it is not readable
+	modelBlobField = "model_blob"
+	//ingestBatchBytes is maximum size of bulk request body sent by IngestVectors
+	ingestBatchBytes = 5 * 1024 * 1024
+)
+
 type controller struct {
-	gateway gateway.Gateway
+	openSearch platform.Controller
+	gateway    gateway.Gateway
 }
 
 //GetStatistics gets stats data based on nodes and stat names
-func (c controller) GetStatistics(ctx context.Context, nodes string, names string) ([]byte, error) {
-	return c.gateway.GetStatistics(ctx, nodes, names)
+func (c controller) GetStatistics(ctx context.Context, nodes string, names string) (*entity.Stats, error) {
+	response, err := c.gateway.GetStatistics(ctx, nodes, names)
+	if err != nil {
+		return nil, err
+	}
+	var stats entity.Stats
+	if err = json.Unmarshal(response, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
 }
 
 //New returns new Controller instance
-func New(gateway gateway.Gateway) Controller {
+func New(c platform.Controller, gateway gateway.Gateway) Controller {
 	return &controller{
+		c,
 		gateway,
 	}
 }
@@ -58,3 +92,259 @@ func (c controller) WarmupIndices(ctx context.Context, index []string) (*entity.
 	}
 	return &warmupAPI.Shards, nil
 }
+
+//ClearCache will evict all the graphs for all of the shards (primaries and replicas)
+//of all the indices specified in the request from native memory
+func (c controller) ClearCache(ctx context.Context, index []string) (*entity.Shards, error) {
+	indices := strings.Join(index, ",")
+	response, err := c.gateway.ClearCache(ctx, indices)
+	if err != nil {
+		return nil, err
+	}
+	var clearCacheAPI entity.WarmupAPIResponse
+	err = json.Unmarshal(response, &clearCacheAPI)
+	if err != nil {
+		return nil, err
+	}
+	return &clearCacheAPI.Shards, nil
+}
+
+//validateTrainModelRequest checks whether mandatory fields of train request are provided
+func validateTrainModelRequest(r entity.TrainModelRequest) error {
+	if len(r.TrainingIndex) < 1 {
+		return fmt.Errorf("training_index field cannot be empty")
+	}
+	if len(r.TrainingField) < 1 {
+		return fmt.Errorf("training_field field cannot be empty")
+	}
+	if r.Dimension < 1 {
+		return fmt.Errorf("dimension field must be positive")
+	}
+	if len(r.Method) < 1 {
+		return fmt.Errorf("method field cannot be empty")
+	}
+	return nil
+}
+
+//TrainModel starts training of model and returns model id, modelID is optional
+func (c controller) TrainModel(ctx context.Context, modelID string, r entity.TrainModelRequest) (string, error) {
+	if err := validateTrainModelRequest(r); err != nil {
+		return "", err
+	}
+	response, err := c.gateway.TrainModel(ctx, modelID, r)
+	if err != nil {
+		return "", err
+	}
+	var data entity.TrainModelResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return "", err
+	}
+	return data.ModelID, nil
+}
+
+//GetModel gets model metadata by model id
+func (c controller) GetModel(ctx context.Context, modelID string) (*entity.Model, error) {
+	if len(modelID) < 1 {
+		return nil, fmt.Errorf("model id cannot be empty")
+	}
+	response, err := c.gateway.GetModel(ctx, modelID)
+	if err != nil {
+		return nil, err
+	}
+	var model entity.Model
+	if err = json.Unmarshal(response, &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+//ListModels gets metadata of every model sorted by model id, models are searched in pages
+//of maxModelsPageSize using search_after
+func (c controller) ListModels(ctx context.Context) ([]*entity.Model, error) {
+	request := entity.ModelSearchRequest{
+		Query: []byte(`{"match_all":{}}`),
+		Size:  maxModelsPageSize,
+		Source: entity.SourceFilter{
+			Excludes: []string{modelBlobField},
+		},
+		Sort: []interface{}{map[string]string{"_id": "asc"}},
+	}
+	models := []*entity.Model{}
+	for {
+		response, err := c.gateway.SearchModels(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		var data entity.ModelSearchResponse
+		if err = json.Unmarshal(response, &data); err != nil {
+			return nil, err
+		}
+		for _, hit := range data.Hits.Hits {
+			model := hit.Source
+			if len(model.ModelID) < 1 {
+				model.ModelID = hit.ID
+			}
+			models = append(models, &model)
+		}
+		if len(data.Hits.Hits) < maxModelsPageSize {
+			break
+		}
+		request.SearchAfter = data.Hits.Hits[len(data.Hits.Hits)-1].Sort
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].ModelID < models[j].ModelID
+	})
+	return models, nil
+}
+
+//DeleteModel deletes model by model id
+func (c controller) DeleteModel(ctx context.Context, modelID string) error {
+	if len(modelID) < 1 {
+		return fmt.Errorf("model id cannot be empty")
+	}
+	response, err := c.gateway.DeleteModel(ctx, modelID)
+	if err != nil {
+		return err
+	}
+	var data entity.DeleteModelResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return err
+	}
+	if len(data.Error) > 0 {
+		return fmt.Errorf("failed to delete model %s due to %s", modelID, data.Error)
+	}
+	return nil
+}
+
+//WaitForModel polls model metadata every interval until model state is created or failed
+func (c controller) WaitForModel(ctx context.Context, modelID string, interval time.Duration) (*entity.Model, error) {
+	for {
+		model, err := c.GetModel(ctx, modelID)
+		if err != nil {
+			return nil, err
+		}
+		if model.State == entity.ModelStateCreated || model.State == entity.ModelStateFailed {
+			return model, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+//SearchVector searches k nearest neighbors of vector given by user
+func (c controller) SearchVector(ctx context.Context, r entity.SearchCommandRequest) ([]*entity.SearchResult, error) {
+	payload, err := mapper.CommandToSearchRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.gateway.SearchIndex(ctx, r.Index, payload)
+	if err != nil {
+		return nil, err
+	}
+	var data entity.SearchResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, err
+	}
+	return mapper.MapToSearchResults(data), nil
+}
+
+//createIndexIfMissing creates k-NN index with vector field of given dimension if index doesn't exist
+func (c controller) createIndexIfMissing(ctx context.Context, r entity.IngestRequest, dimension int) error {
+	exists, err := c.openSearch.IndexExists(ctx, r.Index)
+	if err != nil || exists {
+		return err
+	}
+	return c.openSearch.CreateIndex(ctx, r.Index, mapper.MapToCreateIndexRequest(r.Field, dimension))
+}
+
+//validateIngestRequest checks whether ingest request is valid
+func validateIngestRequest(r entity.IngestRequest) error {
+	if len(r.Index) < 1 {
+		return fmt.Errorf("index cannot be empty")
+	}
+	if len(r.Field) < 1 {
+		return fmt.Errorf("field cannot be empty")
+	}
+	if r.BatchSize < 1 {
+		return fmt.Errorf("batch size must be positive")
+	}
+	if r.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be positive")
+	}
+	if r.Retries < 0 {
+		return fmt.Errorf("retries cannot be negative")
+	}
+	return nil
+}
+
+//IngestVectors reads documents from source in r.Format and loads them into index with bulk pipeline of
+//platform controller using r.Concurrency requests in parallel. size is number of bytes in source, used to display progress
+func (c controller) IngestVectors(ctx context.Context, r entity.IngestRequest, source io.Reader, size int64, display bool) (*entity.IngestResult, error) {
+	if err := validateIngestRequest(r); err != nil {
+		return nil, err
+	}
+	if display {
+		bar := platform.NewProgressBar(size)
+		defer bar.Finish()
+		source = bar.NewProxyReader(source)
+	}
+	reader, err := mapper.NewVectorReader(source, r.Format, r.VectorColumn, r.IDColumn)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	items, writer := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = writer.CloseWithError(c.writeBulkItems(ctx, r, reader, writer))
+	}()
+	result, err := c.openSearch.LoadBulk(ctx, osentity.BulkLoadRequest{
+		Index:       r.Index,
+		Format:      osentity.BulkFormatBulk,
+		Action:      osentity.BulkActionIndex,
+		BatchSize:   r.BatchSize,
+		BatchBytes:  ingestBatchBytes,
+		Concurrency: r.Concurrency,
+		Retries:     r.Retries,
+	}, items, 0, nil, false)
+	cancel()
+	_ = items.Close()
+	<-done
+	if result == nil {
+		return nil, err
+	}
+	return mapper.MapToIngestResult(*result), err
+}
+
+//writeBulkItems writes every document read from reader to w as bulk action and source, index is created before
+//first document is written if required
+func (c controller) writeBulkItems(ctx context.Context, r entity.IngestRequest, reader mapper.VectorReader, w io.Writer) error {
+	first := true
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if first && r.CreateIndex {
+			if err = c.createIndexIfMissing(ctx, r, len(document.Vector)); err != nil {
+				return err
+			}
+		}
+		first = false
+		payload, err := mapper.MapToBulkPayload(r.Index, r.Field, []*entity.VectorDocument{document})
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write(payload); err != nil {
+			return err
+		}
+	}
+}
@@ -15,9 +15,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	mockController "opensearch-cli/controller/platform/mocks"
 	entity "opensearch-cli/entity/knn"
+	platformEntity "opensearch-cli/entity/platform"
 	gateway "opensearch-cli/gateway/knn/mocks"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -31,7 +38,8 @@ func TestControllerGetStatistics(t *testing.T) {
 		mockGateway := gateway.NewMockGateway(mockCtrl)
 		ctx := context.Background()
 		mockGateway.EXPECT().GetStatistics(ctx, "", "").Return(nil, errors.New("gateway failed"))
-		ctrl := New(mockGateway)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
 		_, err := ctrl.GetStatistics(ctx, "", "")
 		assert.Error(t, err)
 	})
@@ -40,11 +48,33 @@ func TestControllerGetStatistics(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockGateway := gateway.NewMockGateway(mockCtrl)
 		ctx := context.Background()
-		mockGateway.EXPECT().GetStatistics(ctx, "node1", "stats").Return([]byte(`response succeeded`), nil)
-		ctrl := New(mockGateway)
+		response, err := ioutil.ReadFile("testdata/stats_response.json")
+		assert.NoError(t, err)
+		mockGateway.EXPECT().GetStatistics(ctx, "node1", "stats").Return(response, nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
 		result, err := ctrl.GetStatistics(ctx, "node1", "stats")
 		assert.NoError(t, err)
-		assert.EqualValues(t, []byte(`response succeeded`), result)
+		assert.EqualValues(t, "my-cluster", result.ClusterName)
+		assert.False(t, *result.CircuitBreakerTriggered)
+		assert.Len(t, result.NodeStats, 2)
+		node := result.NodeStats["node1"]
+		assert.EqualValues(t, 30, *node.HitCount)
+		assert.EqualValues(t, 7, *node.MissCount)
+		assert.EqualValues(t, 1024, *node.GraphMemoryUsage)
+		assert.True(t, *node.CacheCapacityReached)
+		assert.EqualValues(t, 1024, *node.IndicesInCache["my-index"].GraphMemoryUsage)
+	})
+	t.Run("invalid response", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().GetStatistics(ctx, "", "").Return([]byte(`response succeeded`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		_, err := ctrl.GetStatistics(ctx, "", "")
+		assert.Error(t, err)
 	})
 }
 
@@ -56,7 +86,8 @@ func TestControllerWarmupIndices(t *testing.T) {
 		mockGateway := gateway.NewMockGateway(mockCtrl)
 		ctx := context.Background()
 		mockGateway.EXPECT().WarmupIndices(ctx, "index1").Return(nil, errors.New("gateway failed"))
-		ctrl := New(mockGateway)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
 		_, err := ctrl.WarmupIndices(ctx, []string{"index1"})
 		assert.Error(t, err)
 	})
@@ -75,9 +106,366 @@ func TestControllerWarmupIndices(t *testing.T) {
 		rawMessage, err := json.Marshal(expectedResponse)
 		assert.NoError(t, err)
 		mockGateway.EXPECT().WarmupIndices(ctx, "index1").Return(rawMessage, nil)
-		ctrl := New(mockGateway)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
 		result, err := ctrl.WarmupIndices(ctx, []string{"index1"})
 		assert.NoError(t, err)
 		assert.EqualValues(t, expectedResponse.Shards, *result)
 	})
 }
+
+func TestControllerClearCache(t *testing.T) {
+	t.Run("gateway failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().ClearCache(ctx, "index1,index2").Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		_, err := ctrl.ClearCache(ctx, []string{"index1", "index2"})
+		assert.Error(t, err)
+	})
+	t.Run("clear cache success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().ClearCache(ctx, "index1").Return([]byte(`{"_shards":{"total":6,"successful":5,"failed":1}}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		result, err := ctrl.ClearCache(ctx, []string{"index1"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, entity.Shards{
+			Total:      6,
+			Successful: 5,
+			Failed:     1,
+		}, *result)
+	})
+}
+
+func getTrainModelRequest() entity.TrainModelRequest {
+	return entity.TrainModelRequest{
+		TrainingIndex: "train-index",
+		TrainingField: "train-field",
+		Dimension:     4,
+		Method:        []byte(`{"name":"ivf","engine":"faiss"}`),
+	}
+}
+
+func TestControllerTrainModel(t *testing.T) {
+	t.Run("invalid request", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		request := getTrainModelRequest()
+		request.Dimension = 0
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		_, err := ctrl.TrainModel(context.Background(), "model1", request)
+		assert.EqualError(t, err, "dimension field must be positive")
+	})
+	t.Run("gateway failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().TrainModel(ctx, "model1", getTrainModelRequest()).Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		_, err := ctrl.TrainModel(ctx, "model1", getTrainModelRequest())
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("train success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().TrainModel(ctx, "", getTrainModelRequest()).Return([]byte(`{"model_id":"generated"}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		modelID, err := ctrl.TrainModel(ctx, "", getTrainModelRequest())
+		assert.NoError(t, err)
+		assert.EqualValues(t, "generated", modelID)
+	})
+}
+
+func TestControllerGetModel(t *testing.T) {
+	t.Run("empty model id", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		_, err := ctrl.GetModel(context.Background(), "")
+		assert.EqualError(t, err, "model id cannot be empty")
+	})
+	t.Run("get model success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().GetModel(ctx, "model1").Return([]byte(`{"model_id":"model1","model_blob":"SXdG","state":"created","dimension":4,"engine":"faiss","space_type":"l2"}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		model, err := ctrl.GetModel(ctx, "model1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, entity.Model{
+			ModelID:   "model1",
+			State:     entity.ModelStateCreated,
+			Dimension: 4,
+			Engine:    "faiss",
+			SpaceType: "l2",
+		}, *model)
+	})
+}
+
+func TestControllerListModels(t *testing.T) {
+	t.Run("gateway failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().SearchModels(ctx, gomock.Any()).Return(nil, errors.New("gateway failed"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		_, err := ctrl.ListModels(ctx)
+		assert.EqualError(t, err, "gateway failed")
+	})
+	t.Run("list models success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().SearchModels(ctx, entity.ModelSearchRequest{
+			Query:  []byte(`{"match_all":{}}`),
+			Size:   maxModelsPageSize,
+			Source: entity.SourceFilter{Excludes: []string{"model_blob"}},
+			Sort:   []interface{}{map[string]string{"_id": "asc"}},
+		}).Return([]byte(`{"hits":{"hits":[
+			{"_id":"model2","_source":{"state":"training","dimension":8}},
+			{"_id":"model1","_source":{"model_id":"model1","state":"created","dimension":4}}
+		]}}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		models, err := ctrl.ListModels(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.Model{
+			{ModelID: "model1", State: entity.ModelStateCreated, Dimension: 4},
+			{ModelID: "model2", State: entity.ModelStateTraining, Dimension: 8},
+		}, models)
+	})
+	t.Run("list models in pages", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		hits := make([]string, maxModelsPageSize)
+		for i := range hits {
+			hits[i] = fmt.Sprintf(`{"_id":"model%04d","_source":{"state":"created"},"sort":["model%04d"]}`, i, i)
+		}
+		var requests []entity.ModelSearchRequest
+		gomock.InOrder(
+			mockGateway.EXPECT().SearchModels(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, r interface{}) ([]byte, error) {
+				requests = append(requests, r.(entity.ModelSearchRequest))
+				return []byte(`{"hits":{"hits":[` + strings.Join(hits, ",") + `]}}`), nil
+			}),
+			mockGateway.EXPECT().SearchModels(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, r interface{}) ([]byte, error) {
+				requests = append(requests, r.(entity.ModelSearchRequest))
+				return []byte(`{"hits":{"hits":[{"_id":"model9999","_source":{"state":"training"},"sort":["model9999"]}]}}`), nil
+			}),
+		)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		models, err := ctrl.ListModels(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, models, maxModelsPageSize+1)
+		assert.EqualValues(t, "model9999", models[maxModelsPageSize].ModelID)
+		assert.Nil(t, requests[0].SearchAfter)
+		assert.EqualValues(t, []interface{}{"model0999"}, requests[1].SearchAfter)
+	})
+}
+
+func TestControllerDeleteModel(t *testing.T) {
+	t.Run("delete failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().DeleteModel(ctx, "model1").Return([]byte(`{"model_id":"model1","result":"error","error":"model is in training"}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		err := ctrl.DeleteModel(ctx, "model1")
+		assert.EqualError(t, err, "failed to delete model model1 due to model is in training")
+	})
+	t.Run("delete success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().DeleteModel(ctx, "model1").Return([]byte(`{"model_id":"model1","result":"deleted"}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		err := ctrl.DeleteModel(ctx, "model1")
+		assert.NoError(t, err)
+	})
+}
+
+func TestControllerWaitForModel(t *testing.T) {
+	t.Run("wait until created", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		gomock.InOrder(
+			mockGateway.EXPECT().GetModel(ctx, "model1").Return([]byte(`{"model_id":"model1","state":"training"}`), nil),
+			mockGateway.EXPECT().GetModel(ctx, "model1").Return([]byte(`{"model_id":"model1","state":"created"}`), nil),
+		)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		model, err := ctrl.WaitForModel(ctx, "model1", time.Millisecond)
+		assert.NoError(t, err)
+		assert.EqualValues(t, entity.ModelStateCreated, model.State)
+	})
+	t.Run("wait until failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().GetModel(ctx, "model1").Return([]byte(`{"model_id":"model1","state":"failed","error":"not enough vectors"}`), nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		model, err := ctrl.WaitForModel(ctx, "model1", time.Millisecond)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "not enough vectors", model.Error)
+	})
+}
+
+func TestControllerSearchVector(t *testing.T) {
+	request := entity.SearchCommandRequest{
+		Index:  "index1",
+		Field:  "my_vector",
+		K:      2,
+		Vector: []byte("1,2"),
+	}
+	t.Run("invalid vector", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		invalid := request
+		invalid.Vector = nil
+		_, err := ctrl.SearchVector(context.Background(), invalid)
+		assert.EqualError(t, err, "vector cannot be empty")
+	})
+	t.Run("search success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := gateway.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		mockGateway.EXPECT().SearchIndex(ctx, "index1", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, payload interface{}) ([]byte, error) {
+				query := payload.(*entity.SearchRequest).Query.KNN["my_vector"]
+				assert.EqualValues(t, []float32{1, 2}, query.Vector)
+				assert.EqualValues(t, 2, query.K)
+				return []byte(`{"hits":{"hits":[{"_index":"index1","_id":"7","_score":0.9,"_source":{"color":"red"}}]}}`), nil
+			})
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(mockESController, mockGateway)
+		results, err := ctrl.SearchVector(ctx, request)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.SearchResult{{Index: "index1", ID: "7", Score: 0.9, Source: []byte(`{"color":"red"}`)}}, results)
+	})
+}
+
+func TestControllerIngestVectors(t *testing.T) {
+	request := entity.IngestRequest{
+		Index:        "index1",
+		Field:        "my_vector",
+		Format:       entity.FileFormatJSONL,
+		VectorColumn: "my_vector",
+		IDColumn:     "id",
+		BatchSize:    2,
+		Concurrency:  1,
+		Retries:      1,
+	}
+	source := `{"id":"1","my_vector":[1,2]}
+{"id":"2","my_vector":[3,4]}
+`
+	t.Run("invalid request", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		invalid := request
+		invalid.BatchSize = 0
+		ctrl := New(mockController.NewMockController(mockCtrl), gateway.NewMockGateway(mockCtrl))
+		_, err := ctrl.IngestVectors(context.Background(), invalid, strings.NewReader(source), 0, false)
+		assert.EqualError(t, err, "batch size must be positive")
+	})
+	t.Run("ingest with index creation", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockESController := mockController.NewMockController(mockCtrl)
+		create := request
+		create.CreateIndex = true
+		mockESController.EXPECT().IndexExists(gomock.Any(), "index1").Return(false, nil)
+		mockESController.EXPECT().CreateIndex(gomock.Any(), "index1", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, body interface{}) error {
+				assert.EqualValues(t, 2, body.(entity.CreateIndexRequest).Mappings.Properties["my_vector"].Dimension)
+				return nil
+			})
+		mockESController.EXPECT().LoadBulk(gomock.Any(), platformEntity.BulkLoadRequest{
+			Index:       "index1",
+			Format:      platformEntity.BulkFormatBulk,
+			Action:      platformEntity.BulkActionIndex,
+			BatchSize:   2,
+			BatchBytes:  ingestBatchBytes,
+			Concurrency: 1,
+			Retries:     1,
+		}, gomock.Any(), int64(0), nil, false).DoAndReturn(
+			func(_ context.Context, _ platformEntity.BulkLoadRequest, items io.Reader, _ int64, _ io.Writer, _ bool) (*platformEntity.BulkLoadResult, error) {
+				payload, err := ioutil.ReadAll(items)
+				assert.NoError(t, err)
+				assert.EqualValues(t, `{"index":{"_id":"1","_index":"index1"}}
+{"my_vector":[1,2]}
+{"index":{"_id":"2","_index":"index1"}}
+{"my_vector":[3,4]}
+`, string(payload))
+				return &platformEntity.BulkLoadResult{Loaded: 1, Failed: 1, Failures: []*platformEntity.BulkFailure{
+					{Type: "mapper_parsing_exception", Count: 1, Reason: "failed to parse"},
+				}}, nil
+			})
+		ctrl := New(mockESController, gateway.NewMockGateway(mockCtrl))
+		result, err := ctrl.IngestVectors(ctx, create, strings.NewReader(source), int64(len(source)), false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, &entity.IngestResult{
+			Indexed: 1,
+			Failed:  1,
+			Errors:  []string{"1 document(s) failed with mapper_parsing_exception: failed to parse"},
+		}, result)
+	})
+	t.Run("invalid document", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockESController := mockController.NewMockController(mockCtrl)
+		mockESController.EXPECT().LoadBulk(gomock.Any(), gomock.Any(), gomock.Any(), int64(0), nil, false).DoAndReturn(
+			func(_ context.Context, _ platformEntity.BulkLoadRequest, items io.Reader, _ int64, _ io.Writer, _ bool) (*platformEntity.BulkLoadResult, error) {
+				_, err := ioutil.ReadAll(items)
+				return &platformEntity.BulkLoadResult{}, err
+			})
+		ctrl := New(mockESController, gateway.NewMockGateway(mockCtrl))
+		_, err := ctrl.IngestVectors(context.Background(), request, strings.NewReader(`{"id":"1"}`+"\n"), 0, false)
+		assert.EqualError(t, err, "line 1: vector key my_vector not found")
+	})
+	t.Run("bulk load failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockESController := mockController.NewMockController(mockCtrl)
+		mockESController.EXPECT().LoadBulk(gomock.Any(), gomock.Any(), gomock.Any(), int64(0), nil, false).Return(nil, errors.New("connection refused"))
+		ctrl := New(mockESController, gateway.NewMockGateway(mockCtrl))
+		_, err := ctrl.IngestVectors(context.Background(), request, strings.NewReader(source), 0, false)
+		assert.EqualError(t, err, "connection refused")
+	})
+}
@@ -6,8 +6,10 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	knn "opensearch-cli/entity/knn"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,11 +37,55 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 	return m.recorder
 }
 
+// ClearCache mocks base method
+func (m *MockController) ClearCache(arg0 context.Context, arg1 []string) (*knn.Shards, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCache", arg0, arg1)
+	ret0, _ := ret[0].(*knn.Shards)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearCache indicates an expected call of ClearCache
+func (mr *MockControllerMockRecorder) ClearCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCache", reflect.TypeOf((*MockController)(nil).ClearCache), arg0, arg1)
+}
+
+// DeleteModel mocks base method
+func (m *MockController) DeleteModel(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteModel indicates an expected call of DeleteModel
+func (mr *MockControllerMockRecorder) DeleteModel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModel", reflect.TypeOf((*MockController)(nil).DeleteModel), arg0, arg1)
+}
+
+// GetModel mocks base method
+func (m *MockController) GetModel(arg0 context.Context, arg1 string) (*knn.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModel", arg0, arg1)
+	ret0, _ := ret[0].(*knn.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModel indicates an expected call of GetModel
+func (mr *MockControllerMockRecorder) GetModel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModel", reflect.TypeOf((*MockController)(nil).GetModel), arg0, arg1)
+}
+
 // GetStatistics mocks base method
-func (m *MockController) GetStatistics(arg0 context.Context, arg1, arg2 string) ([]byte, error) {
+func (m *MockController) GetStatistics(arg0 context.Context, arg1, arg2 string) (*knn.Stats, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatistics", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]byte)
+	ret0, _ := ret[0].(*knn.Stats)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -50,6 +96,81 @@ func (mr *MockControllerMockRecorder) GetStatistics(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockController)(nil).GetStatistics), arg0, arg1, arg2)
 }
 
+// IngestVectors mocks base method
+func (m *MockController) IngestVectors(arg0 context.Context, arg1 knn.IngestRequest, arg2 io.Reader, arg3 int64, arg4 bool) (*knn.IngestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IngestVectors", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*knn.IngestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IngestVectors indicates an expected call of IngestVectors
+func (mr *MockControllerMockRecorder) IngestVectors(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestVectors", reflect.TypeOf((*MockController)(nil).IngestVectors), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ListModels mocks base method
+func (m *MockController) ListModels(arg0 context.Context) ([]*knn.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModels", arg0)
+	ret0, _ := ret[0].([]*knn.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModels indicates an expected call of ListModels
+func (mr *MockControllerMockRecorder) ListModels(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModels", reflect.TypeOf((*MockController)(nil).ListModels), arg0)
+}
+
+// SearchVector mocks base method
+func (m *MockController) SearchVector(arg0 context.Context, arg1 knn.SearchCommandRequest) ([]*knn.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchVector", arg0, arg1)
+	ret0, _ := ret[0].([]*knn.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchVector indicates an expected call of SearchVector
+func (mr *MockControllerMockRecorder) SearchVector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchVector", reflect.TypeOf((*MockController)(nil).SearchVector), arg0, arg1)
+}
+
+// TrainModel mocks base method
+func (m *MockController) TrainModel(arg0 context.Context, arg1 string, arg2 knn.TrainModelRequest) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrainModel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrainModel indicates an expected call of TrainModel
+func (mr *MockControllerMockRecorder) TrainModel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrainModel", reflect.TypeOf((*MockController)(nil).TrainModel), arg0, arg1, arg2)
+}
+
+// WaitForModel mocks base method
+func (m *MockController) WaitForModel(arg0 context.Context, arg1 string, arg2 time.Duration) (*knn.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForModel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*knn.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForModel indicates an expected call of WaitForModel
+func (mr *MockControllerMockRecorder) WaitForModel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForModel", reflect.TypeOf((*MockController)(nil).WaitForModel), arg0, arg1, arg2)
+}
+
 // WarmupIndices mocks base method
 func (m *MockController) WarmupIndices(arg0 context.Context, arg1 []string) (*knn.Shards, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,269 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"opensearch-cli/entity/platform"
+	mapper "opensearch-cli/mapper/platform"
+	"sync"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+)
+
+//bulkRetryBackoff is delay before first retry of failed items, it grows linearly with every attempt
+var bulkRetryBackoff = time.Second
+
+//bulkItemFailure is item which could not be loaded with reason of failure
+type bulkItemFailure struct {
+	item  *platform.BulkItem
+	error platform.BulkItemError
+}
+
+//bulkProgress collects results of chunks loaded concurrently and writes failed items to dead letter
+type bulkProgress struct {
+	sync.Mutex
+	loaded     int
+	failed     int
+	failures   map[string]*platform.BulkFailure
+	deadLetter io.Writer
+	err        error
+}
+
+func (p *bulkProgress) add(loaded int, failures []bulkItemFailure, err error) {
+	p.Lock()
+	defer p.Unlock()
+	p.loaded += loaded
+	p.failed += len(failures)
+	for _, failure := range failures {
+		if summary, ok := p.failures[failure.error.Type]; ok {
+			summary.Count++
+		} else {
+			p.failures[failure.error.Type] = &platform.BulkFailure{
+				Type:   failure.error.Type,
+				Count:  1,
+				Reason: failure.error.Reason,
+			}
+		}
+		if p.deadLetter == nil {
+			continue
+		}
+		if _, writeErr := p.deadLetter.Write(mapper.MapToBulkBody([]*platform.BulkItem{failure.item})); writeErr != nil && err == nil {
+			err = fmt.Errorf("failed to write dead letter: %v", writeErr)
+		}
+	}
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+}
+
+func (p *bulkProgress) result() *platform.BulkLoadResult {
+	p.Lock()
+	defer p.Unlock()
+	return &platform.BulkLoadResult{
+		Loaded:   p.loaded,
+		Failed:   p.failed,
+		Failures: mapper.SortBulkFailures(p.failures),
+	}
+}
+
+//NewProgressBar creates and starts progress bar of total bytes
+func NewProgressBar(total int64) *pb.ProgressBar {
+	template := `{{string . "prefix"}}{{percent . }} {{bar . "[" "=" ">" "_" "]" }} {{counters . }}{{string . "suffix"}}`
+	bar := pb.New64(total)
+	bar.Set(pb.Bytes, true)
+	bar.SetTemplateString(template)
+	bar.SetMaxWidth(65)
+	bar.Start()
+	return bar
+}
+
+//IsRetryableStatus checks whether bulk item failed due to throttling or unavailable node
+func IsRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+//WaitForRetry waits before retry of given attempt of failed bulk items, delay grows linearly with every attempt.
+//It returns error of context if context is done before
+func WaitForRetry(ctx context.Context, attempt int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Duration(attempt+1) * bulkRetryBackoff):
+		return nil
+	}
+}
+
+//isRetryableRequestError checks whether whole bulk request was rejected due to throttling or unavailable cluster
+func isRetryableRequestError(err error) bool {
+	var requestError *platform.RequestError
+	if !errors.As(err, &requestError) {
+		return false
+	}
+	return requestError.StatusCode() == http.StatusTooManyRequests || requestError.StatusCode() == http.StatusServiceUnavailable
+}
+
+//validateBulkLoadRequest checks whether bulk load request is valid
+func validateBulkLoadRequest(r platform.BulkLoadRequest) error {
+	if len(r.Index) < 1 && r.Format != platform.BulkFormatBulk {
+		return fmt.Errorf("index cannot be empty unless format is %s", platform.BulkFormatBulk)
+	}
+	if r.Action != platform.BulkActionIndex && r.Action != platform.BulkActionCreate {
+		return fmt.Errorf("invalid action %s, only %s and %s are supported", r.Action, platform.BulkActionIndex, platform.BulkActionCreate)
+	}
+	if r.BatchSize < 1 {
+		return fmt.Errorf("batch size must be positive")
+	}
+	if r.BatchBytes < 1 {
+		return fmt.Errorf("batch bytes must be positive")
+	}
+	if r.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be positive")
+	}
+	if r.Retries < 0 {
+		return fmt.Errorf("retries cannot be negative")
+	}
+	return nil
+}
+
+//LoadBulk reads items from source in r.Format and sends them to bulk API in chunks of r.BatchSize items or
+//r.BatchBytes bytes, whichever is reached first, using r.Concurrency requests in parallel. Items failed with
+//retryable status are sent again up to r.Retries times, items which still failed are written to deadLetter if
+//provided. size is number of bytes in source used to display progress, progress is not displayed if size is unknown
+func (c controller) LoadBulk(ctx context.Context, r platform.BulkLoadRequest, source io.Reader, size int64, deadLetter io.Writer, display bool) (*platform.BulkLoadResult, error) {
+	if err := validateBulkLoadRequest(r); err != nil {
+		return nil, err
+	}
+	if display && size > 0 {
+		bar := NewProgressBar(size)
+		defer bar.Finish()
+		source = bar.NewProxyReader(source)
+	}
+	reader, err := mapper.NewBulkReader(source, r.Format, r.Index, r.Action, r.IDField)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	progress := &bulkProgress{
+		failures:   map[string]*platform.BulkFailure{},
+		deadLetter: deadLetter,
+	}
+	chunks := make(chan []*platform.BulkItem)
+	var wg sync.WaitGroup
+	for i := 0; i < r.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunks {
+				if ctx.Err() != nil {
+					continue
+				}
+				loaded, failures, err := c.loadChunk(ctx, r.Retries, chunk)
+				progress.add(loaded, failures, err)
+				if err != nil {
+					cancel()
+				}
+			}
+		}()
+	}
+	readErr := readChunks(ctx, r, reader, chunks)
+	close(chunks)
+	wg.Wait()
+	if readErr != nil {
+		return progress.result(), readErr
+	}
+	return progress.result(), progress.err
+}
+
+//readChunks reads every item and sends them in chunks limited by number of items and size of bulk body
+func readChunks(ctx context.Context, r platform.BulkLoadRequest, reader mapper.BulkReader, chunks chan<- []*platform.BulkItem) error {
+	var chunk []*platform.BulkItem
+	chunkBytes := 0
+	send := func() bool {
+		select {
+		case chunks <- chunk:
+			chunk, chunkBytes = nil, 0
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	for {
+		item, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		itemBytes := len(item.Action) + len(item.Source) + 2
+		if len(chunk) > 0 && chunkBytes+itemBytes > r.BatchBytes && !send() {
+			return nil
+		}
+		chunk = append(chunk, item)
+		chunkBytes += itemBytes
+		if len(chunk) >= r.BatchSize && !send() {
+			return nil
+		}
+	}
+	if len(chunk) > 0 {
+		send()
+	}
+	return nil
+}
+
+//loadChunk sends items to bulk API, items failed with retryable status are sent again up to retries times,
+//as well as every item of request rejected with retryable status. It returns number of loaded items and items which failed
+func (c controller) loadChunk(ctx context.Context, retries int, items []*platform.BulkItem) (int, []bulkItemFailure, error) {
+	loaded := 0
+	var failures []bulkItemFailure
+	pending := items
+	for attempt := 0; ; attempt++ {
+		response, err := c.Bulk(ctx, mapper.MapToBulkBody(pending))
+		if err != nil && (attempt >= retries || !isRetryableRequestError(err)) {
+			return loaded, failures, err
+		}
+		retry := pending
+		if err == nil {
+			retry = nil
+			for i, item := range response.Items {
+				if i >= len(pending) {
+					break
+				}
+				for _, result := range item {
+					if result.Error == nil {
+						loaded++
+						continue
+					}
+					if IsRetryableStatus(result.Status) && attempt < retries {
+						retry = append(retry, pending[i])
+						continue
+					}
+					failures = append(failures, bulkItemFailure{item: pending[i], error: *result.Error})
+				}
+			}
+		}
+		if len(retry) < 1 {
+			return loaded, failures, nil
+		}
+		pending = retry
+		if err = WaitForRetry(ctx, attempt); err != nil {
+			return loaded, failures, err
+		}
+	}
+}
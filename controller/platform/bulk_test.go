@@ -0,0 +1,154 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"opensearch-cli/entity/platform"
+	"opensearch-cli/gateway/platform/mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func getBulkLoadRequest() platform.BulkLoadRequest {
+	return platform.BulkLoadRequest{
+		Index:       "docs",
+		Format:      platform.BulkFormatNDJSON,
+		Action:      platform.BulkActionIndex,
+		BatchSize:   2,
+		BatchBytes:  1024,
+		Concurrency: 1,
+		Retries:     1,
+	}
+}
+
+func TestController_LoadBulk(t *testing.T) {
+	bulkRetryBackoff = time.Millisecond
+	source := "{\"a\":1}\n{\"a\":2}\n{\"a\":3}\n"
+	action := "{\"index\":{\"_index\":\"docs\"}}\n"
+	t.Run("invalid request", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctrl := New(mocks.NewMockGateway(mockCtrl))
+		request := getBulkLoadRequest()
+		request.Index = ""
+		_, err := ctrl.LoadBulk(context.Background(), request, strings.NewReader(source), 0, nil, false)
+		assert.EqualError(t, err, "index cannot be empty unless format is bulk")
+		request = getBulkLoadRequest()
+		request.Action = "delete"
+		_, err = ctrl.LoadBulk(context.Background(), request, strings.NewReader(source), 0, nil, false)
+		assert.EqualError(t, err, "invalid action delete, only index and create are supported")
+	})
+	t.Run("load in chunks", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		gomock.InOrder(
+			mockGateway.EXPECT().Bulk(gomock.Any(), []byte(action+"{\"a\":1}\n"+action+"{\"a\":2}\n")).Return([]byte(`{"items":[
+				{"index":{"_id":"1","status":201}},{"index":{"_id":"2","status":201}}]}`), nil),
+			mockGateway.EXPECT().Bulk(gomock.Any(), []byte(action+"{\"a\":3}\n")).Return([]byte(`{"items":[
+				{"index":{"_id":"3","status":201}}]}`), nil),
+		)
+		ctrl := New(mockGateway)
+		result, err := ctrl.LoadBulk(ctx, getBulkLoadRequest(), strings.NewReader(source), 0, nil, false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, &platform.BulkLoadResult{Loaded: 3}, result)
+	})
+	t.Run("chunk limited by bytes", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().Bulk(gomock.Any(), gomock.Any()).Times(3).Return([]byte(`{"items":[{"index":{"status":201}}]}`), nil)
+		ctrl := New(mockGateway)
+		request := getBulkLoadRequest()
+		request.BatchBytes = len(action) + 8
+		result, err := ctrl.LoadBulk(ctx, request, strings.NewReader(source), 0, nil, false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 3, result.Loaded)
+	})
+	t.Run("retry throttled items and write failed items to dead letter", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		gomock.InOrder(
+			mockGateway.EXPECT().Bulk(gomock.Any(), []byte(action+"{\"a\":1}\n"+action+"{\"a\":2}\n")).Return([]byte(`{"errors":true,"items":[
+				{"index":{"_id":"1","status":429,"error":{"type":"es_rejected_execution_exception","reason":"rejected"}}},
+				{"index":{"_id":"2","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}]}`), nil),
+			mockGateway.EXPECT().Bulk(gomock.Any(), []byte(action+"{\"a\":1}\n")).Return([]byte(`{"errors":true,"items":[
+				{"index":{"_id":"1","status":429,"error":{"type":"es_rejected_execution_exception","reason":"rejected"}}}]}`), nil),
+			mockGateway.EXPECT().Bulk(gomock.Any(), []byte(action+"{\"a\":3}\n")).Return([]byte(`{"items":[
+				{"index":{"_id":"3","status":201}}]}`), nil),
+		)
+		ctrl := New(mockGateway)
+		var deadLetter bytes.Buffer
+		result, err := ctrl.LoadBulk(ctx, getBulkLoadRequest(), strings.NewReader(source), 0, &deadLetter, false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, &platform.BulkLoadResult{
+			Loaded: 1,
+			Failed: 2,
+			Failures: []*platform.BulkFailure{
+				{Type: "es_rejected_execution_exception", Count: 1, Reason: "rejected"},
+				{Type: "mapper_parsing_exception", Count: 1, Reason: "failed to parse"},
+			},
+		}, result)
+		assert.EqualValues(t, action+"{\"a\":2}\n"+action+"{\"a\":1}\n", deadLetter.String())
+	})
+	t.Run("retry throttled request", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		throttled := platform.NewRequestError(http.StatusTooManyRequests, io.NopCloser(strings.NewReader("")), errors.New("429 Client Error"))
+		gomock.InOrder(
+			mockGateway.EXPECT().Bulk(gomock.Any(), []byte(action+"{\"a\":1}\n"+action+"{\"a\":2}\n")).Return(nil, throttled),
+			mockGateway.EXPECT().Bulk(gomock.Any(), []byte(action+"{\"a\":1}\n"+action+"{\"a\":2}\n")).Return([]byte(`{"items":[
+				{"index":{"status":201}},{"index":{"status":201}}]}`), nil),
+			mockGateway.EXPECT().Bulk(gomock.Any(), []byte(action+"{\"a\":3}\n")).Return(nil, throttled),
+			mockGateway.EXPECT().Bulk(gomock.Any(), []byte(action+"{\"a\":3}\n")).Return(nil, throttled),
+		)
+		ctrl := New(mockGateway)
+		result, err := ctrl.LoadBulk(ctx, getBulkLoadRequest(), strings.NewReader(source), 0, nil, false)
+		assert.EqualError(t, err, "429 Client Error")
+		assert.EqualValues(t, 2, result.Loaded)
+	})
+	t.Run("request failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().Bulk(gomock.Any(), gomock.Any()).Return(nil, errors.New("gateway failed"))
+		ctrl := New(mockGateway)
+		result, err := ctrl.LoadBulk(ctx, getBulkLoadRequest(), strings.NewReader(source), 0, nil, false)
+		assert.EqualError(t, err, "gateway failed")
+		assert.EqualValues(t, 0, result.Loaded)
+	})
+	t.Run("invalid source", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		ctrl := New(mockGateway)
+		_, err := ctrl.LoadBulk(ctx, getBulkLoadRequest(), strings.NewReader("not json\n"), 0, nil, false)
+		assert.EqualError(t, err, "line 1: invalid JSON object")
+	})
+}
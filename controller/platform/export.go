@@ -0,0 +1,219 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"opensearch-cli/entity/platform"
+	mapper "opensearch-cli/mapper/platform"
+	"sync"
+)
+
+//validateExportRequest checks whether export request and state of slices are valid
+func validateExportRequest(r platform.ExportRequest, slices []platform.ExportSliceState) error {
+	if len(r.Index) < 1 {
+		return fmt.Errorf("index cannot be empty")
+	}
+	if r.Slices < 1 {
+		return fmt.Errorf("slices must be positive")
+	}
+	if r.PageSize < 1 {
+		return fmt.Errorf("page size must be positive")
+	}
+	if len(r.Tiebreaker) < 1 {
+		return fmt.Errorf("tiebreaker cannot be empty")
+	}
+	if len(slices) != r.Slices {
+		return fmt.Errorf("expected state of %d slices but found %d", r.Slices, len(slices))
+	}
+	return nil
+}
+
+//mapToExportBody returns search body of export, query is either whole search body or only query clause
+func mapToExportBody(r platform.ExportRequest) (map[string]interface{}, error) {
+	body, err := mapper.MapToSearchBody(platform.SearchCommandRequest{Body: r.Query})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := body["query"]; !ok && len(body) > 0 {
+		body = map[string]interface{}{
+			"query": body,
+		}
+	}
+	if _, ok := body["from"]; ok {
+		return nil, fmt.Errorf("from cannot be used when index is exported")
+	}
+	body["size"] = r.PageSize
+	mapper.AddTiebreakerSort(body, r.Tiebreaker)
+	return body, nil
+}
+
+//isExportStarted checks whether any slice exported documents already
+func isExportStarted(slices []platform.ExportSliceState) bool {
+	for _, slice := range slices {
+		if slice.Done || len(slice.SearchAfter) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+//OpenExportPointInTime returns point in time r.PITID of interrupted export if it is still alive, so that resumed
+//export continues in same snapshot of index, otherwise new point in time is created. It returns false if export
+//already started in point in time which is not alive anymore, in which case documents indexed, updated or deleted
+//in between may be exported twice or not at all. Export sorted by _shard_doc fails instead, since its sort values
+//are not valid in other point in time
+func (c controller) OpenExportPointInTime(ctx context.Context, r platform.ExportRequest, slices []platform.ExportSliceState) (string, bool, error) {
+	if err := validateExportRequest(r, slices); err != nil {
+		return "", false, err
+	}
+	started := isExportStarted(slices)
+	if len(r.PITID) > 0 {
+		data, err := c.search(ctx, "", map[string]interface{}{
+			"size": 0,
+			"pit": map[string]interface{}{
+				"id":         r.PITID,
+				"keep_alive": exportPointInTimeKeepAlive,
+			},
+		})
+		if err == nil {
+			if len(data.PITID) > 0 {
+				return data.PITID, true, nil
+			}
+			return r.PITID, true, nil
+		}
+		if started && r.Tiebreaker == mapper.TiebreakerSortField {
+			return "", false, fmt.Errorf(
+				"point in time of interrupted export is not available anymore and export sorted by %s cannot be resumed in new point in time, "+
+					"start export again or sort it by unique field with doc values", mapper.TiebreakerSortField)
+		}
+	}
+	response, err := c.gateway.CreatePointInTime(ctx, r.Index, exportPointInTimeKeepAlive)
+	if err != nil {
+		return "", false, err
+	}
+	var pit platform.CreatePointInTimeResponse
+	if err = json.Unmarshal(response, &pit); err != nil {
+		return "", false, err
+	}
+	return pit.PITID, !started, nil
+}
+
+//ExportDocuments pages through documents of index matched by r.Query in point in time r.PITID, opened by
+//OpenExportPointInTime, with search_after. If r.Slices is greater than one, every slice is exported in parallel.
+//Slices continue after their SearchAfter and slices which are done are skipped, so that interrupted export can be
+//resumed. Pages are passed to process one at a time. Point in time is deleted once every slice is exported and
+//kept otherwise, so that export can be resumed in it
+func (c controller) ExportDocuments(ctx context.Context, r platform.ExportRequest, slices []platform.ExportSliceState, process func(platform.ExportPage) error) error {
+	if err := validateExportRequest(r, slices); err != nil {
+		return err
+	}
+	if len(r.PITID) < 1 {
+		return fmt.Errorf("point in time cannot be empty")
+	}
+	body, err := mapToExportBody(r)
+	if err != nil {
+		return err
+	}
+	exportCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var lock sync.Mutex
+	var exportErr error
+	var wg sync.WaitGroup
+	for _, slice := range slices {
+		if slice.Done {
+			continue
+		}
+		wg.Add(1)
+		go func(slice platform.ExportSliceState) {
+			defer wg.Done()
+			err := c.exportSlice(exportCtx, r, body, r.PITID, slice, func(page platform.ExportPage) error {
+				lock.Lock()
+				defer lock.Unlock()
+				if exportErr != nil {
+					return exportErr
+				}
+				return process(page)
+			})
+			if err == nil {
+				return
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if exportErr == nil {
+				exportErr = err
+			}
+			cancel()
+		}(slice)
+	}
+	wg.Wait()
+	if exportErr != nil {
+		return exportErr
+	}
+	_ = c.gateway.DeletePointInTime(ctx, r.PITID)
+	return nil
+}
+
+//exportSlice pages through documents of single slice starting after sort values of last exported document
+func (c controller) exportSlice(ctx context.Context, r platform.ExportRequest, body map[string]interface{}, pitID string, slice platform.ExportSliceState, process func(platform.ExportPage) error) error {
+	request := map[string]interface{}{}
+	for key, value := range body {
+		request[key] = value
+	}
+	if r.Slices > 1 {
+		request["slice"] = map[string]interface{}{
+			"id":  slice.ID,
+			"max": r.Slices,
+		}
+	}
+	searchAfter := slice.SearchAfter
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		request["pit"] = map[string]interface{}{
+			"id":         pitID,
+			"keep_alive": exportPointInTimeKeepAlive,
+		}
+		if len(searchAfter) > 0 {
+			request["search_after"] = searchAfter
+		}
+		data, err := c.search(ctx, "", request)
+		if err != nil {
+			return err
+		}
+		if len(data.PITID) > 0 {
+			pitID = data.PITID
+		}
+		page := platform.ExportPage{
+			Slice: slice.ID,
+			Done:  len(data.Hits.Hits) < r.PageSize,
+		}
+		for _, hit := range data.Hits.Hits {
+			item, err := mapper.MapToExportItem(hit, r.Routing)
+			if err != nil {
+				return err
+			}
+			page.Items = append(page.Items, item)
+			searchAfter = hit.Sort
+		}
+		page.SearchAfter = searchAfter
+		if err = process(page); err != nil {
+			return err
+		}
+		if page.Done {
+			return nil
+		}
+	}
+}
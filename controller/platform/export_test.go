@@ -0,0 +1,186 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"opensearch-cli/entity/platform"
+	"opensearch-cli/gateway/platform/mocks"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_ExportDocuments(t *testing.T) {
+	request := platform.ExportRequest{
+		Index:      "index1",
+		Query:      `{"term":{"a":1}}`,
+		Slices:     1,
+		PageSize:   2,
+		Routing:    true,
+		Tiebreaker: "_shard_doc",
+		PITID:      "pit1",
+	}
+	t.Run("invalid request", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctrl := New(mocks.NewMockGateway(mockCtrl))
+		err := ctrl.ExportDocuments(context.Background(), request, nil, nil)
+		assert.EqualError(t, err, "expected state of 1 slices but found 0")
+	})
+	t.Run("export pages", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		var bodies []string
+		record := func(_ context.Context, _ string, payload interface{}) {
+			body, _ := json.Marshal(payload)
+			bodies = append(bodies, string(body))
+		}
+		gomock.InOrder(
+			mockGateway.EXPECT().Search(gomock.Any(), "", gomock.Any()).Do(record).Return([]byte(`{"hits":{"hits":[
+				{"_id":"1","_routing":"r1","_source":{"a":1},"sort":["1"]},
+				{"_id":"2","_source":{"a":1},"sort":["2"]}]}}`), nil),
+			mockGateway.EXPECT().Search(gomock.Any(), "", gomock.Any()).Do(record).Return([]byte(`{"hits":{"hits":[]}}`), nil),
+			mockGateway.EXPECT().DeletePointInTime(ctx, "pit1").Return(nil),
+		)
+		ctrl := New(mockGateway)
+		var pages []platform.ExportPage
+		err := ctrl.ExportDocuments(ctx, request, []platform.ExportSliceState{{ID: 0}}, func(page platform.ExportPage) error {
+			pages = append(pages, page)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Len(t, pages, 2)
+		assert.EqualValues(t, []interface{}{"2"}, pages[0].SearchAfter)
+		assert.False(t, pages[0].Done)
+		assert.EqualValues(t, `{"index":{"_id":"1","routing":"r1"}}`, string(pages[0].Items[0].Action))
+		assert.EqualValues(t, `{"a":1}`, string(pages[0].Items[0].Source))
+		assert.True(t, pages[1].Done)
+		assert.Empty(t, pages[1].Items)
+		assert.JSONEq(t, `{"query":{"term":{"a":1}},"size":2,"sort":[{"_shard_doc":{"order":"asc"}}],"pit":{"id":"pit1","keep_alive":"10m"}}`, bodies[0])
+		assert.JSONEq(t, `{"query":{"term":{"a":1}},"size":2,"sort":[{"_shard_doc":{"order":"asc"}}],"pit":{"id":"pit1","keep_alive":"10m"},"search_after":["2"]}`, bodies[1])
+	})
+	t.Run("resume slices", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		var lock sync.Mutex
+		var bodies []string
+		mockGateway.EXPECT().Search(gomock.Any(), "", gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, _ string, payload interface{}) ([]byte, error) {
+			body, _ := json.Marshal(payload)
+			lock.Lock()
+			defer lock.Unlock()
+			bodies = append(bodies, string(body))
+			return []byte(`{"hits":{"hits":[{"_id":"1","_source":{},"sort":["1"]}]}}`), nil
+		})
+		mockGateway.EXPECT().DeletePointInTime(ctx, "pit1").Return(nil)
+		ctrl := New(mockGateway)
+		sliced := request
+		sliced.Slices = 3
+		sliced.Query = `{"query":{"match_all":{}},"sort":["a"]}`
+		var done []int
+		err := ctrl.ExportDocuments(ctx, sliced, []platform.ExportSliceState{
+			{ID: 0, Done: true},
+			{ID: 1, SearchAfter: []interface{}{"x"}},
+			{ID: 2},
+		}, func(page platform.ExportPage) error {
+			assert.True(t, page.Done)
+			done = append(done, page.Slice)
+			return nil
+		})
+		assert.NoError(t, err)
+		sort.Ints(done)
+		assert.EqualValues(t, []int{1, 2}, done)
+		sort.Strings(bodies)
+		assert.JSONEq(t, `{"query":{"match_all":{}},"size":2,"sort":["a",{"_shard_doc":{"order":"asc"}}],"pit":{"id":"pit1","keep_alive":"10m"},"slice":{"id":1,"max":3},"search_after":["x"]}`, bodies[0])
+		assert.JSONEq(t, `{"query":{"match_all":{}},"size":2,"sort":["a",{"_shard_doc":{"order":"asc"}}],"pit":{"id":"pit1","keep_alive":"10m"},"slice":{"id":2,"max":3}}`, bodies[1])
+	})
+	t.Run("search failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().Search(gomock.Any(), "", gomock.Any()).Return(nil, errors.New("search failed"))
+		ctrl := New(mockGateway)
+		err := ctrl.ExportDocuments(ctx, request, []platform.ExportSliceState{{ID: 0}}, nil)
+		assert.EqualError(t, err, "search failed")
+	})
+}
+
+func TestController_OpenExportPointInTime(t *testing.T) {
+	request := platform.ExportRequest{
+		Index:      "index1",
+		Slices:     1,
+		PageSize:   2,
+		Tiebreaker: "_shard_doc",
+	}
+	started := []platform.ExportSliceState{{ID: 0, SearchAfter: []interface{}{"1"}}}
+	t.Run("new export", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().CreatePointInTime(gomock.Any(), "index1", "10m").Return([]byte(`{"pit_id":"pit1"}`), nil)
+		pitID, consistent, err := New(mockGateway).OpenExportPointInTime(context.Background(), request, []platform.ExportSliceState{{ID: 0}})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "pit1", pitID)
+		assert.True(t, consistent)
+	})
+	t.Run("point in time of interrupted export is reused", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().Search(gomock.Any(), "", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, payload interface{}) ([]byte, error) {
+			body, _ := json.Marshal(payload)
+			assert.JSONEq(t, `{"size":0,"pit":{"id":"pit0","keep_alive":"10m"}}`, string(body))
+			return []byte(`{"hits":{"hits":[]}}`), nil
+		})
+		resumed := request
+		resumed.PITID = "pit0"
+		pitID, consistent, err := New(mockGateway).OpenExportPointInTime(context.Background(), resumed, started)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "pit0", pitID)
+		assert.True(t, consistent)
+	})
+	t.Run("expired point in time cannot be replaced for shard doc tiebreaker", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().Search(gomock.Any(), "", gomock.Any()).Return(nil, errors.New("404 Client Error"))
+		resumed := request
+		resumed.PITID = "pit0"
+		_, _, err := New(mockGateway).OpenExportPointInTime(context.Background(), resumed, started)
+		assert.EqualError(t, err, "point in time of interrupted export is not available anymore and export sorted by _shard_doc "+
+			"cannot be resumed in new point in time, start export again or sort it by unique field with doc values")
+	})
+	t.Run("expired point in time is replaced for custom tiebreaker", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().Search(gomock.Any(), "", gomock.Any()).Return(nil, errors.New("404 Client Error"))
+		mockGateway.EXPECT().CreatePointInTime(gomock.Any(), "index1", "10m").Return([]byte(`{"pit_id":"pit1"}`), nil)
+		resumed := request
+		resumed.PITID = "pit0"
+		resumed.Tiebreaker = "doc_id"
+		pitID, consistent, err := New(mockGateway).OpenExportPointInTime(context.Background(), resumed, started)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "pit1", pitID)
+		assert.False(t, consistent)
+	})
+}
@@ -6,6 +6,8 @@ package mocks
 
 import (
 	context "context"
+	jsontext "encoding/json/jsontext"
+	io "io"
 	platform "opensearch-cli/entity/platform"
 	reflect "reflect"
 
@@ -35,6 +37,35 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method
+func (m *MockController) Bulk(arg0 context.Context, arg1 []byte) (*platform.BulkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", arg0, arg1)
+	ret0, _ := ret[0].(*platform.BulkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk
+func (mr *MockControllerMockRecorder) Bulk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockController)(nil).Bulk), arg0, arg1)
+}
+
+// CreateIndex mocks base method
+func (m *MockController) CreateIndex(arg0 context.Context, arg1 string, arg2 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIndex indicates an expected call of CreateIndex
+func (mr *MockControllerMockRecorder) CreateIndex(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockController)(nil).CreateIndex), arg0, arg1, arg2)
+}
+
 // Curl mocks base method
 func (m *MockController) Curl(arg0 context.Context, arg1 platform.CurlCommandRequest) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +81,20 @@ func (mr *MockControllerMockRecorder) Curl(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Curl", reflect.TypeOf((*MockController)(nil).Curl), arg0, arg1)
 }
 
+// ExportDocuments mocks base method
+func (m *MockController) ExportDocuments(arg0 context.Context, arg1 platform.ExportRequest, arg2 []platform.ExportSliceState, arg3 func(platform.ExportPage) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDocuments", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDocuments indicates an expected call of ExportDocuments
+func (mr *MockControllerMockRecorder) ExportDocuments(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDocuments", reflect.TypeOf((*MockController)(nil).ExportDocuments), arg0, arg1, arg2, arg3)
+}
+
 // GetDistinctValues mocks base method
 func (m *MockController) GetDistinctValues(arg0 context.Context, arg1, arg2 string) ([]interface{}, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +109,78 @@ func (mr *MockControllerMockRecorder) GetDistinctValues(arg0, arg1, arg2 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDistinctValues", reflect.TypeOf((*MockController)(nil).GetDistinctValues), arg0, arg1, arg2)
 }
+
+// GetFieldCapabilities mocks base method
+func (m *MockController) GetFieldCapabilities(arg0 context.Context, arg1 string, arg2 []string) (*platform.FieldCapabilitiesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFieldCapabilities", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*platform.FieldCapabilitiesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFieldCapabilities indicates an expected call of GetFieldCapabilities
+func (mr *MockControllerMockRecorder) GetFieldCapabilities(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFieldCapabilities", reflect.TypeOf((*MockController)(nil).GetFieldCapabilities), arg0, arg1, arg2)
+}
+
+// IndexExists mocks base method
+func (m *MockController) IndexExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexExists indicates an expected call of IndexExists
+func (mr *MockControllerMockRecorder) IndexExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexExists", reflect.TypeOf((*MockController)(nil).IndexExists), arg0, arg1)
+}
+
+// LoadBulk mocks base method
+func (m *MockController) LoadBulk(arg0 context.Context, arg1 platform.BulkLoadRequest, arg2 io.Reader, arg3 int64, arg4 io.Writer, arg5 bool) (*platform.BulkLoadResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBulk", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*platform.BulkLoadResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadBulk indicates an expected call of LoadBulk
+func (mr *MockControllerMockRecorder) LoadBulk(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBulk", reflect.TypeOf((*MockController)(nil).LoadBulk), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// OpenExportPointInTime mocks base method
+func (m *MockController) OpenExportPointInTime(arg0 context.Context, arg1 platform.ExportRequest, arg2 []platform.ExportSliceState) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenExportPointInTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenExportPointInTime indicates an expected call of OpenExportPointInTime
+func (mr *MockControllerMockRecorder) OpenExportPointInTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenExportPointInTime", reflect.TypeOf((*MockController)(nil).OpenExportPointInTime), arg0, arg1, arg2)
+}
+
+// SearchDocuments mocks base method
+func (m *MockController) SearchDocuments(arg0 context.Context, arg1 platform.SearchCommandRequest, arg2 func([]jsontext.Value) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchDocuments", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SearchDocuments indicates an expected call of SearchDocuments
+func (mr *MockControllerMockRecorder) SearchDocuments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchDocuments", reflect.TypeOf((*MockController)(nil).SearchDocuments), arg0, arg1, arg2)
+}
@@ -14,6 +14,7 @@ package platform
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"opensearch-cli/entity/platform"
 	osg "opensearch-cli/gateway/platform"
 	mapper "opensearch-cli/mapper/platform"
@@ -27,8 +28,26 @@ import (
 type Controller interface {
 	GetDistinctValues(ctx context.Context, index string, field string) ([]interface{}, error)
 	Curl(ctx context.Context, param platform.CurlCommandRequest) ([]byte, error)
+	GetFieldCapabilities(ctx context.Context, index string, fields []string) (*platform.FieldCapabilitiesResponse, error)
+	Bulk(ctx context.Context, payload []byte) (*platform.BulkResponse, error)
+	IndexExists(ctx context.Context, index string) (bool, error)
+	CreateIndex(ctx context.Context, index string, body interface{}) error
+	LoadBulk(ctx context.Context, r platform.BulkLoadRequest, source io.Reader, size int64, deadLetter io.Writer, display bool) (*platform.BulkLoadResult, error)
+	SearchDocuments(ctx context.Context, r platform.SearchCommandRequest, process func([]json.RawMessage) error) error
+	OpenExportPointInTime(ctx context.Context, r platform.ExportRequest, slices []platform.ExportSliceState) (string, bool, error)
+	ExportDocuments(ctx context.Context, r platform.ExportRequest, slices []platform.ExportSliceState, process func(platform.ExportPage) error) error
 }
 
+const (
+	//searchAllPageSize is number of hits per page when every document is searched unless size is provided
+	searchAllPageSize = 1000
+	//pointInTimeKeepAlive is how long point in time is kept alive between pages
+	pointInTimeKeepAlive = "1m"
+	//exportPointInTimeKeepAlive is how long point in time of export is kept alive between pages, so that interrupted
+	//export can be resumed in same point in time
+	exportPointInTimeKeepAlive = "10m"
+)
+
 type controller struct {
 	gateway osg.Gateway
 }
@@ -40,33 +59,190 @@ func New(gateway osg.Gateway) Controller {
 	}
 }
 
-//GetDistinctValues get only unique values for given index, given field name
+//GetDistinctValues get only unique values for given index, given field name. It pages through
+// every value using composite aggregation
 func (c controller) GetDistinctValues(ctx context.Context, index string, field string) ([]interface{}, error) {
 	if len(index) == 0 || len(field) == 0 {
 		return nil, fmt.Errorf("index and field cannot be empty")
 	}
-	response, err := c.gateway.SearchDistinctValues(ctx, index, field)
+	var values []interface{}
+	var after map[string]interface{}
+	for {
+		response, err := c.gateway.SearchDistinctValues(ctx, index, field, after)
+		if err != nil {
+			return nil, err
+		}
+		var data platform.Response
+		err = json.Unmarshal(response, &data)
+		if err != nil {
+			return nil, err
+		}
+		for _, bucket := range data.Aggregations.Items.Buckets {
+			values = append(values, bucket.Key[osg.DistinctGroupName])
+		}
+		after = data.Aggregations.Items.AfterKey
+		if len(data.Aggregations.Items.Buckets) < osg.DistinctValuesPageSize || after == nil {
+			return values, nil
+		}
+	}
+}
+
+//Curl accept user request and convert to format which OpenSearch can understand
+func (c controller) Curl(ctx context.Context, param platform.CurlCommandRequest) ([]byte, error) {
+	curlRequest, err := mapper.CommandToCurlRequestParameter(param)
 	if err != nil {
 		return nil, err
 	}
-	var data platform.Response
-	err = json.Unmarshal(response, &data)
+	return c.gateway.Curl(ctx, curlRequest)
+}
+
+//GetFieldCapabilities get type and capabilities of fields for indices matched by index
+func (c controller) GetFieldCapabilities(ctx context.Context, index string, fields []string) (*platform.FieldCapabilitiesResponse, error) {
+	if len(index) == 0 || len(fields) == 0 {
+		return nil, fmt.Errorf("index and fields cannot be empty")
+	}
+	response, err := c.gateway.GetFieldCapabilities(ctx, index, fields)
 	if err != nil {
 		return nil, err
 	}
+	var data platform.FieldCapabilitiesResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
 
-	var values []interface{}
-	for _, bucket := range data.Aggregations.Items.Buckets {
-		values = append(values, bucket.Key)
+//Bulk sends newline delimited actions and documents to bulk API and returns result of every action
+func (c controller) Bulk(ctx context.Context, payload []byte) (*platform.BulkResponse, error) {
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("bulk payload cannot be empty")
+	}
+	response, err := c.gateway.Bulk(ctx, payload)
+	if err != nil {
+		return nil, err
 	}
-	return values, nil
+	var data platform.BulkResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
 }
 
-//Curl accept user request and convert to format which OpenSearch can understand
-func (c controller) Curl(ctx context.Context, param platform.CurlCommandRequest) ([]byte, error) {
-	curlRequest, err := mapper.CommandToCurlRequestParameter(param)
+//IndexExists checks whether index exists
+func (c controller) IndexExists(ctx context.Context, index string) (bool, error) {
+	if len(index) == 0 {
+		return false, fmt.Errorf("index cannot be empty")
+	}
+	return c.gateway.IndexExists(ctx, index)
+}
+
+//CreateIndex creates index with settings and mappings given by body
+func (c controller) CreateIndex(ctx context.Context, index string, body interface{}) error {
+	if len(index) == 0 {
+		return fmt.Errorf("index cannot be empty")
+	}
+	_, err := c.gateway.CreateIndex(ctx, index, body)
+	return err
+}
+
+//processHits maps hits to output and passes them to process
+func processHits(hits []platform.SearchHit, process func([]json.RawMessage) error) error {
+	output := make([]json.RawMessage, 0, len(hits))
+	for _, hit := range hits {
+		row, err := mapper.MapToSearchHitOutput(hit)
+		if err != nil {
+			return err
+		}
+		output = append(output, row)
+	}
+	return process(output)
+}
+
+//search searches documents with body and returns parsed response
+func (c controller) search(ctx context.Context, index string, body map[string]interface{}) (*platform.SearchResponse, error) {
+	response, err := c.gateway.Search(ctx, index, body)
 	if err != nil {
 		return nil, err
 	}
-	return c.gateway.Curl(ctx, curlRequest)
+	var data platform.SearchResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+//SearchDocuments searches documents of index with query DSL body and shorthand flags, hits are passed to
+//process page by page. If r.All is set, every matching document is returned by paging through point in time
+//with search_after, _id is added to sort as tiebreaker
+func (c controller) SearchDocuments(ctx context.Context, r platform.SearchCommandRequest, process func([]json.RawMessage) error) error {
+	if len(r.Index) < 1 {
+		return fmt.Errorf("index cannot be empty")
+	}
+	body, err := mapper.MapToSearchBody(r)
+	if err != nil {
+		return err
+	}
+	if !r.All {
+		response, err := c.search(ctx, r.Index, body)
+		if err != nil {
+			return err
+		}
+		return processHits(response.Hits.Hits, process)
+	}
+	return c.searchAll(ctx, r.Index, body, process)
+}
+
+//searchAll pages through every document matched by body using point in time, which is deleted at the end
+func (c controller) searchAll(ctx context.Context, index string, body map[string]interface{}, process func([]json.RawMessage) error) error {
+	if _, ok := body["from"]; ok {
+		return fmt.Errorf("from cannot be used when every document is searched")
+	}
+	size := searchAllPageSize
+	switch value := body["size"].(type) {
+	case float64:
+		size = int(value)
+	case int:
+		size = value
+	}
+	if size < 1 {
+		size = searchAllPageSize
+	}
+	body["size"] = size
+	mapper.AddTiebreakerSort(body, mapper.TiebreakerSortField)
+	response, err := c.gateway.CreatePointInTime(ctx, index, pointInTimeKeepAlive)
+	if err != nil {
+		return err
+	}
+	var pit platform.CreatePointInTimeResponse
+	if err = json.Unmarshal(response, &pit); err != nil {
+		return err
+	}
+	pitID := pit.PITID
+	defer func() {
+		_ = c.gateway.DeletePointInTime(ctx, pitID)
+	}()
+	for {
+		body["pit"] = map[string]interface{}{
+			"id":         pitID,
+			"keep_alive": pointInTimeKeepAlive,
+		}
+		data, err := c.search(ctx, "", body)
+		if err != nil {
+			return err
+		}
+		if len(data.PITID) > 0 {
+			pitID = data.PITID
+		}
+		hits := data.Hits.Hits
+		if len(hits) < 1 {
+			return nil
+		}
+		if err = processHits(hits, process); err != nil {
+			return err
+		}
+		if len(hits) < size {
+			return nil
+		}
+		body["search_after"] = hits[len(hits)-1].Sort
+	}
 }
@@ -13,12 +13,16 @@ package platform
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"opensearch-cli/entity/platform"
+	osg "opensearch-cli/gateway/platform"
 	"opensearch-cli/gateway/platform/mocks"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -69,7 +73,7 @@ func TestController_GetDistinctValues(t *testing.T) {
 
 		mockGateway := mocks.NewMockGateway(mockCtrl)
 		ctx := context.Background()
-		mockGateway.EXPECT().SearchDistinctValues(ctx, "example", "f1").Return(nil, errors.New("search failed"))
+		mockGateway.EXPECT().SearchDistinctValues(ctx, "example", "f1", nil).Return(nil, errors.New("search failed"))
 		ctrl := New(mockGateway)
 		_, err := ctrl.GetDistinctValues(ctx, "example", "f1")
 		assert.Error(t, err)
@@ -80,7 +84,7 @@ func TestController_GetDistinctValues(t *testing.T) {
 
 		mockGateway := mocks.NewMockGateway(mockCtrl)
 		ctx := context.Background()
-		mockGateway.EXPECT().SearchDistinctValues(ctx, "example", "f1").Return([]byte("No response"), nil)
+		mockGateway.EXPECT().SearchDistinctValues(ctx, "example", "f1", nil).Return([]byte("No response"), nil)
 		ctrl := New(mockGateway)
 		_, err := ctrl.GetDistinctValues(ctx, "example", "f1")
 		assert.Error(t, err)
@@ -90,14 +94,130 @@ func TestController_GetDistinctValues(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockGateway := mocks.NewMockGateway(mockCtrl)
 		ctx := context.Background()
-		expectedResult := helperConvertToInterface([]string{"Packaged Foods", "Dairy", "Meat and Seafood"})
-		mockGateway.EXPECT().SearchDistinctValues(ctx, "example", "f1").Return(helperLoadBytes(t, "search_result.json"), nil)
+		expectedResult := helperConvertToInterface([]string{"Dairy", "Meat and Seafood", "Packaged Foods"})
+		mockGateway.EXPECT().SearchDistinctValues(ctx, "example", "f1", nil).Return(helperLoadBytes(t, "search_result.json"), nil)
 		ctrl := New(mockGateway)
 		result, err := ctrl.GetDistinctValues(ctx, "example", "f1")
 		assert.NoError(t, err)
 		assert.EqualValues(t, expectedResult, result)
 
 	})
+	t.Run("get distinct values from every page", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		ctx := context.Background()
+		var buckets []string
+		var expectedResult []interface{}
+		for i := 0; i < osg.DistinctValuesPageSize; i++ {
+			buckets = append(buckets, fmt.Sprintf(`{"key":{"items":"v%d"},"doc_count":1}`, i))
+			expectedResult = append(expectedResult, fmt.Sprintf("v%d", i))
+		}
+		expectedResult = append(expectedResult, "last")
+		gomock.InOrder(
+			mockGateway.EXPECT().SearchDistinctValues(ctx, "example", "f1", nil).Return([]byte(fmt.Sprintf(
+				`{"aggregations":{"items":{"after_key":{"items":"v%d"},"buckets":[%s]}}}`,
+				osg.DistinctValuesPageSize-1, strings.Join(buckets, ","))), nil),
+			mockGateway.EXPECT().SearchDistinctValues(ctx, "example", "f1", map[string]interface{}{"items": fmt.Sprintf("v%d", osg.DistinctValuesPageSize-1)}).Return(
+				[]byte(`{"aggregations":{"items":{"after_key":{"items":"last"},"buckets":[{"key":{"items":"last"},"doc_count":1}]}}}`), nil),
+		)
+		ctrl := New(mockGateway)
+		result, err := ctrl.GetDistinctValues(ctx, "example", "f1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, expectedResult, result)
+	})
+}
+
+func TestController_GetFieldCapabilities(t *testing.T) {
+	t.Run("empty fields", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		ctrl := New(mockGateway)
+		_, err := ctrl.GetFieldCapabilities(context.Background(), "example", nil)
+		assert.EqualError(t, err, "index and fields cannot be empty")
+	})
+	t.Run("gateway failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().GetFieldCapabilities(ctx, "example", []string{"f1"}).Return(nil, errors.New("failed"))
+		ctrl := New(mockGateway)
+		_, err := ctrl.GetFieldCapabilities(ctx, "example", []string{"f1"})
+		assert.EqualError(t, err, "failed")
+	})
+	t.Run("get field capabilities success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().GetFieldCapabilities(ctx, "order*", []string{"timestamp", "value"}).Return(helperLoadBytes(t, "field_caps_result.json"), nil)
+		ctrl := New(mockGateway)
+		result, err := ctrl.GetFieldCapabilities(ctx, "order*", []string{"timestamp", "value"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, &platform.FieldCapabilitiesResponse{
+			Indices: []string{"order-1", "order-2"},
+			Fields: map[string]map[string]platform.FieldCapability{
+				"timestamp": {"date": {Type: "date", Searchable: true, Aggregatable: true}},
+				"value":     {"long": {Type: "long", Searchable: true, Aggregatable: true}},
+			},
+		}, result)
+	})
+}
+
+func TestController_Bulk(t *testing.T) {
+	t.Run("empty payload", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		ctrl := New(mockGateway)
+		_, err := ctrl.Bulk(context.Background(), nil)
+		assert.EqualError(t, err, "bulk payload cannot be empty")
+	})
+	t.Run("bulk success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().Bulk(ctx, []byte("payload")).Return([]byte(`{"took":3,"errors":true,"items":[
+			{"index":{"_index":"index1","_id":"1","status":201}},
+			{"index":{"_index":"index1","_id":"2","status":429,"error":{"type":"es_rejected_execution_exception","reason":"rejected"}}}
+		]}`), nil)
+		ctrl := New(mockGateway)
+		result, err := ctrl.Bulk(ctx, []byte("payload"))
+		assert.NoError(t, err)
+		assert.EqualValues(t, &platform.BulkResponse{
+			Took:   3,
+			Errors: true,
+			Items: []map[string]platform.BulkItemResult{
+				{"index": {Index: "index1", ID: "1", Status: 201}},
+				{"index": {Index: "index1", ID: "2", Status: 429, Error: &platform.BulkItemError{Type: "es_rejected_execution_exception", Reason: "rejected"}}},
+			},
+		}, result)
+	})
+}
+
+func TestController_CreateIndex(t *testing.T) {
+	t.Run("create index if missing", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().IndexExists(ctx, "index1").Return(false, nil)
+		mockGateway.EXPECT().CreateIndex(ctx, "index1", "body").Return([]byte(`{"acknowledged":true}`), nil)
+		ctrl := New(mockGateway)
+		exists, err := ctrl.IndexExists(ctx, "index1")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		assert.NoError(t, ctrl.CreateIndex(ctx, "index1", "body"))
+	})
+	t.Run("empty index", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctrl := New(mocks.NewMockGateway(mockCtrl))
+		assert.EqualError(t, ctrl.CreateIndex(context.Background(), "", nil), "index cannot be empty")
+	})
 }
 
 func TestController_Curl(t *testing.T) {
@@ -149,3 +269,84 @@ func TestController_Curl(t *testing.T) {
 		assert.EqualErrorf(t, err, "action cannot be empty", "wrong error message")
 	})
 }
+
+func TestController_SearchDocuments(t *testing.T) {
+	collect := func(result *[]string) func([]json.RawMessage) error {
+		return func(hits []json.RawMessage) error {
+			for _, hit := range hits {
+				*result = append(*result, string(hit))
+			}
+			return nil
+		}
+	}
+	t.Run("empty index", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctrl := New(mocks.NewMockGateway(mockCtrl))
+		err := ctrl.SearchDocuments(context.Background(), platform.SearchCommandRequest{}, nil)
+		assert.EqualError(t, err, "index cannot be empty")
+	})
+	t.Run("search single page", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().Search(ctx, "index1", map[string]interface{}{"size": 1}).Return([]byte(`{"hits":{"hits":[
+			{"_index":"index1","_id":"1","_score":1,"_source":{"a":1}}]}}`), nil)
+		ctrl := New(mockGateway)
+		var result []string
+		err := ctrl.SearchDocuments(ctx, platform.SearchCommandRequest{Index: "index1", Size: 1}, collect(&result))
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{`{"_index":"index1","_id":"1","_score":1,"a":1}`}, result)
+	})
+	t.Run("search every document with point in time", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		var bodies []string
+		record := func(_ context.Context, _ string, payload interface{}) {
+			body, _ := json.Marshal(payload)
+			bodies = append(bodies, string(body))
+		}
+		gomock.InOrder(
+			mockGateway.EXPECT().CreatePointInTime(ctx, "index1", "1m").Return([]byte(`{"pit_id":"pit1"}`), nil),
+			mockGateway.EXPECT().Search(ctx, "", gomock.Any()).Do(record).Return([]byte(`{"pit_id":"pit2","hits":{"hits":[
+				{"_index":"index1","_id":"1","_source":{"a":1},"sort":["1"]},
+				{"_index":"index1","_id":"2","_source":{"a":2},"sort":["2"]}]}}`), nil),
+			mockGateway.EXPECT().Search(ctx, "", gomock.Any()).Do(record).Return([]byte(`{"pit_id":"pit2","hits":{"hits":[
+				{"_index":"index1","_id":"3","_source":{"a":3},"sort":["3"]}]}}`), nil),
+			mockGateway.EXPECT().DeletePointInTime(ctx, "pit2").Return(nil),
+		)
+		ctrl := New(mockGateway)
+		var result []string
+		err := ctrl.SearchDocuments(ctx, platform.SearchCommandRequest{Index: "index1", Size: 2, All: true}, collect(&result))
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{
+			`{"_index":"index1","_id":"1","a":1}`,
+			`{"_index":"index1","_id":"2","a":2}`,
+			`{"_index":"index1","_id":"3","a":3}`,
+		}, result)
+		assert.JSONEq(t, `{"size":2,"sort":[{"_shard_doc":{"order":"asc"}}],"pit":{"id":"pit1","keep_alive":"1m"}}`, bodies[0])
+		assert.JSONEq(t, `{"size":2,"sort":[{"_shard_doc":{"order":"asc"}}],"pit":{"id":"pit2","keep_alive":"1m"},"search_after":["2"]}`, bodies[1])
+	})
+	t.Run("point in time is deleted on failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockGateway := mocks.NewMockGateway(mockCtrl)
+		mockGateway.EXPECT().CreatePointInTime(ctx, "index1", "1m").Return([]byte(`{"pit_id":"pit1"}`), nil)
+		mockGateway.EXPECT().Search(ctx, "", gomock.Any()).Return(nil, errors.New("search failed"))
+		mockGateway.EXPECT().DeletePointInTime(ctx, "pit1").Return(nil)
+		ctrl := New(mockGateway)
+		err := ctrl.SearchDocuments(ctx, platform.SearchCommandRequest{Index: "index1", All: true}, nil)
+		assert.EqualError(t, err, "search failed")
+	})
+	t.Run("from cannot be used with point in time", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctrl := New(mocks.NewMockGateway(mockCtrl))
+		err := ctrl.SearchDocuments(context.Background(), platform.SearchCommandRequest{Index: "index1", Body: `{"from":10}`, All: true}, nil)
+		assert.EqualError(t, err, "from cannot be used when every document is searched")
+	})
+}
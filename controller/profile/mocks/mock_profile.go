@@ -34,6 +34,20 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 	return m.recorder
 }
 
+// CopyProfile mocks base method
+func (m *MockController) CopyProfile(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyProfile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyProfile indicates an expected call of CopyProfile
+func (mr *MockControllerMockRecorder) CopyProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyProfile", reflect.TypeOf((*MockController)(nil).CopyProfile), arg0, arg1)
+}
+
 // CreateProfile mocks base method
 func (m *MockController) CreateProfile(arg0 entity.Profile) error {
 	m.ctrl.T.Helper()
@@ -62,6 +76,37 @@ func (mr *MockControllerMockRecorder) DeleteProfiles(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProfiles", reflect.TypeOf((*MockController)(nil).DeleteProfiles), arg0)
 }
 
+// ExportProfiles mocks base method
+func (m *MockController) ExportProfiles(arg0 []string, arg1 bool) ([]entity.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProfiles", arg0, arg1)
+	ret0, _ := ret[0].([]entity.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportProfiles indicates an expected call of ExportProfiles
+func (mr *MockControllerMockRecorder) ExportProfiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProfiles", reflect.TypeOf((*MockController)(nil).ExportProfiles), arg0, arg1)
+}
+
+// GetEffectiveProfile mocks base method
+func (m *MockController) GetEffectiveProfile(arg0 string) (entity.EffectiveProfile, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveProfile", arg0)
+	ret0, _ := ret[0].(entity.EffectiveProfile)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEffectiveProfile indicates an expected call of GetEffectiveProfile
+func (mr *MockControllerMockRecorder) GetEffectiveProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveProfile", reflect.TypeOf((*MockController)(nil).GetEffectiveProfile), arg0)
+}
+
 // GetProfileForExecution mocks base method
 func (m *MockController) GetProfileForExecution(arg0 string) (entity.Profile, bool, error) {
 	m.ctrl.T.Helper()
@@ -122,3 +167,45 @@ func (mr *MockControllerMockRecorder) GetProfilesMap() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfilesMap", reflect.TypeOf((*MockController)(nil).GetProfilesMap))
 }
+
+// ImportProfiles mocks base method
+func (m *MockController) ImportProfiles(arg0 []entity.Profile, arg1, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportProfiles", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportProfiles indicates an expected call of ImportProfiles
+func (mr *MockControllerMockRecorder) ImportProfiles(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProfiles", reflect.TypeOf((*MockController)(nil).ImportProfiles), arg0, arg1, arg2)
+}
+
+// RenameProfile mocks base method
+func (m *MockController) RenameProfile(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameProfile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameProfile indicates an expected call of RenameProfile
+func (mr *MockControllerMockRecorder) RenameProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameProfile", reflect.TypeOf((*MockController)(nil).RenameProfile), arg0, arg1)
+}
+
+// UpdateProfile mocks base method
+func (m *MockController) UpdateProfile(arg0 entity.Profile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile
+func (mr *MockControllerMockRecorder) UpdateProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockController)(nil).UpdateProfile), arg0)
+}
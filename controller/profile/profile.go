@@ -12,39 +12,62 @@
 package profile
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"opensearch-cli/controller/config"
+	"opensearch-cli/controller/credential"
 	"opensearch-cli/entity"
 	"opensearch-cli/environment"
 	"os"
+	"strconv"
 	"strings"
 )
 
 const (
 	DefaultProfileName = "default"
+	//EnvironmentProfileName is name of profile built from environment variables if config file has no profile for execution
+	EnvironmentProfileName = "environment"
 )
 
 //go:generate go run -mod=mod github.com/golang/mock/mockgen -destination=mocks/mock_profile.go -package=mocks . Controller
 type Controller interface {
 	CreateProfile(profile entity.Profile) error
+	UpdateProfile(profile entity.Profile) error
+	RenameProfile(name string, newName string) error
+	CopyProfile(name string, newName string) error
+	ExportProfiles(names []string, stripSecrets bool) ([]entity.Profile, error)
+	ImportProfiles(profiles []entity.Profile, overwrite bool, allowProcess bool) error
 	DeleteProfiles(names []string) error
 	GetProfiles() ([]entity.Profile, error)
 	GetProfileNames() ([]string, error)
 	GetProfilesMap() (map[string]entity.Profile, error)
 	GetProfileForExecution(name string) (entity.Profile, bool, error)
+	GetEffectiveProfile(name string) (entity.EffectiveProfile, bool, error)
 }
 
 type controller struct {
 	configCtrl config.Controller
+	stores     credential.Factory
 }
 
-//New returns new config controller instance
-func New(c config.Controller) Controller {
+//New returns new config controller instance, stores creates credential store for profiles whose
+//credentials are not saved in config file
+func New(c config.Controller, stores credential.Factory) Controller {
 	return &controller{
 		configCtrl: c,
+		stores:     stores,
 	}
 }
 
+//getCredentialStore returns store of profile credentials, nil is returned if credentials are saved in config file
+func (c controller) getCredentialStore(p entity.Profile) (credential.Store, error) {
+	if p.Credential == nil || p.Credential.Backend == entity.CredentialBackendConfig {
+		return nil, nil
+	}
+	return c.stores(*p.Credential)
+}
+
 //GetProfiles gets list of profiles fom config file
 func (c controller) GetProfiles() ([]entity.Profile, error) {
 	data, err := c.configCtrl.Read()
@@ -83,6 +106,9 @@ func (c controller) GetProfilesMap() (map[string]entity.Profile, error) {
 //CreateProfile creates profile by gets list of existing profiles, append new profile to list
 //and saves it in config file
 func (c controller) CreateProfile(p entity.Profile) error {
+	if err := c.saveCredentials(&p); err != nil {
+		return err
+	}
 	data, err := c.configCtrl.Read()
 	if err != nil {
 		return err
@@ -91,33 +117,261 @@ func (c controller) CreateProfile(p entity.Profile) error {
 	return c.configCtrl.Write(data)
 }
 
-//DeleteProfiles loads all profile, deletes selected profiles, and saves rest in config file
-func (c controller) DeleteProfiles(names []string) error {
-	profilesMap, err := c.GetProfilesMap()
+//saveCredentials saves user and password in credential store of profile and removes password from profile,
+//so that it is never written to config file
+func (c controller) saveCredentials(p *entity.Profile) error {
+	store, err := c.getCredentialStore(*p)
+	if err != nil || store == nil || len(p.Password) == 0 {
+		return err
+	}
+	credentials := entity.Credentials{UserName: p.UserName, Password: p.Password}
+	if err = store.Set(p.Name, credentials); err != nil {
+		return fmt.Errorf("failed to save credentials in %s backend due to: %w", p.Credential.Backend, err)
+	}
+	p.Password = ""
+	return nil
+}
+
+//deleteCredentials removes credentials of profile from its credential store
+func (c controller) deleteCredentials(p entity.Profile) error {
+	store, err := c.getCredentialStore(p)
+	if err == nil && store != nil {
+		err = store.Delete(p.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete credentials of profile %s due to: %w", p.Name, err)
+	}
+	return nil
+}
+
+//copyCredentials copies credentials of profile to profile with new name in same credential store,
+//credentials of process backend are not copied since command supplies them for any profile
+func (c controller) copyCredentials(p entity.Profile, newName string) error {
+	store, err := c.getCredentialStore(p)
+	if err != nil || store == nil || p.Credential.Backend == entity.CredentialBackendProcess {
+		return err
+	}
+	credentials, err := store.Get(p.Name)
+	if err == nil {
+		err = store.Set(newName, credentials)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy credentials of profile %s due to: %w", p.Name, err)
+	}
+	return nil
+}
+
+//getCredentialBackend returns name of backend where credentials of profile are saved
+func getCredentialBackend(p entity.Profile) string {
+	if p.Credential == nil {
+		return entity.CredentialBackendConfig
+	}
+	return p.Credential.Backend
+}
+
+//indexOfProfile returns position of profile in list, -1 is returned if profile does not exist
+func indexOfProfile(profiles []entity.Profile, name string) int {
+	for i, p := range profiles {
+		if p.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+//UpdateProfile replaces profile with same name without changing its position in config file, credentials are
+//removed from previous backend if backend is changed
+func (c controller) UpdateProfile(p entity.Profile) error {
+	data, err := c.configCtrl.Read()
 	if err != nil {
 		return err
 	}
+	index := indexOfProfile(data.Profiles, p.Name)
+	if index < 0 {
+		return fmt.Errorf("profile '%s' does not exist", p.Name)
+	}
+	previous := data.Profiles[index]
+	if err = c.saveCredentials(&p); err != nil {
+		return err
+	}
+	data.Profiles[index] = p
+	if err = c.configCtrl.Write(data); err != nil {
+		return err
+	}
+	if getCredentialBackend(previous) == getCredentialBackend(p) {
+		return nil
+	}
+	return c.deleteCredentials(previous)
+}
+
+//RenameProfile changes name of profile without changing its position in config file
+func (c controller) RenameProfile(name string, newName string) error {
+	data, err := c.configCtrl.Read()
+	if err != nil {
+		return err
+	}
+	index := indexOfProfile(data.Profiles, name)
+	if index < 0 {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+	if indexOfProfile(data.Profiles, newName) >= 0 {
+		return fmt.Errorf("profile %s already exists", newName)
+	}
+	previous := data.Profiles[index]
+	if err = c.copyCredentials(previous, newName); err != nil {
+		return err
+	}
+	data.Profiles[index].Name = newName
+	if err = c.configCtrl.Write(data); err != nil {
+		return err
+	}
+	return c.deleteCredentials(previous)
+}
+
+//CopyProfile adds copy of profile with new name at the end of config file
+func (c controller) CopyProfile(name string, newName string) error {
+	data, err := c.configCtrl.Read()
+	if err != nil {
+		return err
+	}
+	index := indexOfProfile(data.Profiles, name)
+	if index < 0 {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+	if indexOfProfile(data.Profiles, newName) >= 0 {
+		return fmt.Errorf("profile %s already exists", newName)
+	}
+	p := data.Profiles[index]
+	if err = c.copyCredentials(p, newName); err != nil {
+		return err
+	}
+	p.Name = newName
+	data.Profiles = append(data.Profiles, p)
+	return c.configCtrl.Write(data)
+}
+
+//ExportProfiles returns profiles with given names in order of config file, every profile is returned if no name
+//is given. Passwords are removed if stripSecrets is true
+func (c controller) ExportProfiles(names []string, stripSecrets bool) ([]entity.Profile, error) {
+	profiles, err := c.GetProfiles()
+	if err != nil {
+		return nil, err
+	}
 	var invalidProfileNames []string
+	selected := map[string]bool{}
 	for _, name := range names {
-		if _, ok := profilesMap[name]; !ok {
+		if indexOfProfile(profiles, name) < 0 {
 			invalidProfileNames = append(invalidProfileNames, name)
+		}
+		selected[name] = true
+	}
+	if len(invalidProfileNames) > 0 {
+		return nil, fmt.Errorf("no profiles found for: %s", strings.Join(invalidProfileNames, ", "))
+	}
+	result := []entity.Profile{}
+	for _, p := range profiles {
+		if len(names) > 0 && !selected[p.Name] {
 			continue
 		}
-		delete(profilesMap, name)
+		if stripSecrets {
+			p.Password = ""
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+//validateImportedProfile checks whether profile has valid endpoint and credential backend, profile whose
+//credentials are supplied by process is only valid if allowProcess is true since importing it runs command from file
+func validateImportedProfile(p entity.Profile, allowProcess bool) error {
+	if len(p.Name) == 0 {
+		return errors.New("profile name cannot be empty")
+	}
+	endpoint, err := url.ParseRequestURI(p.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || len(endpoint.Host) == 0 {
+		return fmt.Errorf("profile %s has invalid endpoint '%s'", p.Name, p.Endpoint)
+	}
+	if p.Credential == nil {
+		return nil
+	}
+	switch p.Credential.Backend {
+	case entity.CredentialBackendConfig, entity.CredentialBackendKeyring, entity.CredentialBackendFile:
+		if len(p.Credential.Process) > 0 {
+			return fmt.Errorf("profile %s has credential process but its credential backend is %s", p.Name, p.Credential.Backend)
+		}
+	case entity.CredentialBackendProcess:
+		if len(p.Credential.Process) == 0 {
+			return fmt.Errorf("profile %s has no credential process", p.Name)
+		}
+		if !allowProcess {
+			return fmt.Errorf("profile %s runs credential process '%s', importing it must be allowed explicitly", p.Name, p.Credential.Process)
+		}
+	default:
+		return fmt.Errorf("profile %s has invalid credential backend '%s', allowed values are %s",
+			p.Name, p.Credential.Backend, strings.Join(entity.CredentialBackends, ", "))
 	}
+	return nil
+}
+
+//ImportProfiles adds profiles at the end of config file, profile which already exists is replaced
+//at its position if overwrite is true. Profiles with credential process are only imported if allowProcess is true
+func (c controller) ImportProfiles(profiles []entity.Profile, overwrite bool, allowProcess bool) error {
+	data, err := c.configCtrl.Read()
+	if err != nil {
+		return err
+	}
+	imported := map[string]bool{}
+	for _, p := range profiles {
+		if err = validateImportedProfile(p, allowProcess); err != nil {
+			return err
+		}
+		if imported[p.Name] {
+			return fmt.Errorf("profile %s is defined more than once", p.Name)
+		}
+		imported[p.Name] = true
+		if !overwrite && indexOfProfile(data.Profiles, p.Name) >= 0 {
+			return fmt.Errorf("profile %s already exists", p.Name)
+		}
+	}
+	for _, p := range profiles {
+		if err = c.saveCredentials(&p); err != nil {
+			return err
+		}
+		if index := indexOfProfile(data.Profiles, p.Name); index >= 0 {
+			data.Profiles[index] = p
+			continue
+		}
+		data.Profiles = append(data.Profiles, p)
+	}
+	return c.configCtrl.Write(data)
+}
 
+//DeleteProfiles loads all profile, deletes selected profiles, and saves rest in config file in same order
+func (c controller) DeleteProfiles(names []string) error {
 	//load config
 	data, err := c.configCtrl.Read()
 	if err != nil {
 		return err
 	}
+	var invalidProfileNames []string
+	deleted := map[string]bool{}
+	for _, name := range names {
+		if indexOfProfile(data.Profiles, name) < 0 {
+			invalidProfileNames = append(invalidProfileNames, name)
+			continue
+		}
+		deleted[name] = true
+	}
 
-	//empty existing profile
-	data.Profiles = nil
-	for _, p := range profilesMap {
-		// add existing profiles to the list
-		data.Profiles = append(data.Profiles, p)
+	var profiles, deletedProfiles []entity.Profile
+	for _, p := range data.Profiles {
+		if deleted[p.Name] {
+			deletedProfiles = append(deletedProfiles, p)
+			continue
+		}
+		profiles = append(profiles, p)
 	}
+	data.Profiles = profiles
 
 	//save config
 	err = c.configCtrl.Write(data)
@@ -125,6 +379,13 @@ func (c controller) DeleteProfiles(names []string) error {
 		return err
 	}
 
+	//remove credentials of deleted profiles from their stores
+	for _, p := range deletedProfiles {
+		if err = c.deleteCredentials(p); err != nil {
+			return err
+		}
+	}
+
 	// if found any invalid profiles
 	if len(invalidProfileNames) > 0 {
 		return fmt.Errorf("no profiles found for: %s", strings.Join(invalidProfileNames, ", "))
@@ -135,25 +396,112 @@ func (c controller) DeleteProfiles(names []string) error {
 // GetProfileForExecution returns profile information for current command execution
 // if profile name is provided as an argument, will return the profile,
 // if profile name is not provided as argument, we will check for environment variable
-// in session, then will check for profile named `default`
+// in session, then will check for profile named `default`, then will build profile from
+// environment variables if endpoint is set. Environment variables override fields of profile.
 // bool determines whether profile is valid or not
 func (c controller) GetProfileForExecution(name string) (value entity.Profile, ok bool, err error) {
+	effective, ok, err := c.GetEffectiveProfile(name)
+	return effective.Profile, ok, err
+}
+
+// GetEffectiveProfile returns profile for current command execution like GetProfileForExecution
+// along with source of every field which is set
+func (c controller) GetEffectiveProfile(name string) (value entity.EffectiveProfile, ok bool, err error) {
 	profiles, err := c.GetProfilesMap()
 	if err != nil {
 		return
 	}
+	value.Sources = map[string]string{}
+	source := entity.ProfileSourceFlag
+	if name == "" {
+		if envProfileName, exists := os.LookupEnv(environment.OPENSEARCH_PROFILE); exists {
+			name, source = envProfileName, entity.ProfileSourceEnv
+		}
+	}
 	if name != "" {
-		if value, ok = profiles[name]; ok {
-			return
+		if value.Profile, ok = profiles[name]; !ok {
+			return value, ok, fmt.Errorf("profile '%s' does not exist", name)
+		}
+	} else if value.Profile, ok = profiles[DefaultProfileName]; ok {
+		source = entity.ProfileSourceDefault
+	} else if endpoint, exists := os.LookupEnv(environment.OPENSEARCH_ENDPOINT); exists && endpoint != "" {
+		value.Profile = entity.Profile{Name: EnvironmentProfileName}
+		source, ok = entity.ProfileSourceEnv, true
+	} else {
+		return value, false, nil
+	}
+	value.Sources[entity.ProfileFieldName] = source
+	setFileSources(&value)
+	overrideFromEnvironment(&value)
+	store, err := c.getCredentialStore(value.Profile)
+	if err != nil {
+		return value, false, err
+	}
+	if store != nil {
+		value.Profile.CredentialProvider = credential.NewProvider(store, value.Profile.Name)
+	}
+	return value, ok, nil
+}
+
+//setFileSources marks every field which is set in profile as read from config file
+func setFileSources(p *entity.EffectiveProfile) {
+	set := map[string]bool{
+		entity.ProfileFieldEndpoint: p.Profile.Endpoint != "",
+		entity.ProfileFieldUserName: p.Profile.UserName != "",
+		entity.ProfileFieldPassword: p.Profile.Password != "",
+		entity.ProfileFieldMaxRetry: p.Profile.MaxRetry != nil,
+		entity.ProfileFieldTimeout:  p.Profile.Timeout != nil,
+	}
+	if p.Profile.Credential != nil {
+		set[entity.ProfileFieldCredentialBackend] = p.Profile.Credential.Backend != ""
+		set[entity.ProfileFieldCredentialProcess] = p.Profile.Credential.Process != ""
+	}
+	if p.Profile.AWS != nil {
+		set[entity.ProfileFieldAWSProfile] = p.Profile.AWS.ProfileName != ""
+		set[entity.ProfileFieldAWSService] = p.Profile.AWS.ServiceName != ""
+	}
+	if p.Profile.Certificate != nil {
+		set[entity.ProfileFieldCAFile] = p.Profile.Certificate.CAFilePath != nil
+		set[entity.ProfileFieldClientCertFile] = p.Profile.Certificate.ClientCertificateFilePath != nil
+		set[entity.ProfileFieldClientKeyFile] = p.Profile.Certificate.ClientKeyFilePath != nil
+	}
+	if p.Profile.TLS != nil {
+		set[entity.ProfileFieldTLSVerify] = p.Profile.TLS.Verify != nil
+		set[entity.ProfileFieldTLSServerName] = p.Profile.TLS.ServerName != ""
+		set[entity.ProfileFieldTLSMinVersion] = p.Profile.TLS.MinVersion != ""
+		set[entity.ProfileFieldTLSSystemCA] = p.Profile.TLS.SystemCA != nil
+		set[entity.ProfileFieldTLSCABundles] = len(p.Profile.TLS.CABundles) > 0
+		set[entity.ProfileFieldTLSPins] = len(p.Profile.TLS.Pins) > 0
+	}
+	for field, ok := range set {
+		if ok {
+			p.Sources[field] = entity.ProfileSourceFile
+		}
+	}
+}
+
+//overrideFromEnvironment overrides fields of profile with environment variables which are set,
+//max retry and timeout which are not numbers are ignored
+func overrideFromEnvironment(p *entity.EffectiveProfile) {
+	override := func(variable string, field string, value *string) {
+		if env, ok := os.LookupEnv(variable); ok && env != "" {
+			*value = env
+			p.Sources[field] = entity.ProfileSourceEnv
+		}
+	}
+	override(environment.OPENSEARCH_ENDPOINT, entity.ProfileFieldEndpoint, &p.Profile.Endpoint)
+	override(environment.OPENSEARCH_USER, entity.ProfileFieldUserName, &p.Profile.UserName)
+	override(environment.OPENSEARCH_PASSWORD, entity.ProfileFieldPassword, &p.Profile.Password)
+	if env, ok := os.LookupEnv(environment.OPENSEARCH_MAX_RETRY); ok {
+		if maxRetry, err := strconv.Atoi(env); err == nil {
+			p.Profile.MaxRetry = &maxRetry
+			p.Sources[entity.ProfileFieldMaxRetry] = entity.ProfileSourceEnv
 		}
-		return value, ok, fmt.Errorf("profile '%s' does not exist", name)
 	}
-	if envProfileName, exists := os.LookupEnv(environment.OPENSEARCH_PROFILE); exists {
-		if value, ok = profiles[envProfileName]; ok {
-			return
+	if env, ok := os.LookupEnv(environment.OPENSEARCH_TIMEOUT); ok {
+		if timeout, err := strconv.ParseInt(env, 10, 64); err == nil {
+			p.Profile.Timeout = &timeout
+			p.Sources[entity.ProfileFieldTimeout] = entity.ProfileSourceEnv
 		}
-		return value, ok, fmt.Errorf("profile '%s' does not exist", envProfileName)
 	}
-	value, ok = profiles[DefaultProfileName]
-	return
 }
@@ -14,6 +14,8 @@ package profile
 import (
 	"errors"
 	config "opensearch-cli/controller/config/mocks"
+	"opensearch-cli/controller/credential"
+	credentials "opensearch-cli/controller/credential/mocks"
 	"opensearch-cli/entity"
 	"opensearch-cli/environment"
 	"os"
@@ -56,7 +58,7 @@ func TestControllerGetProfilesMap(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		actual, err := ctrl.GetProfilesMap()
 		assert.NoError(t, err)
 		expected := map[string]entity.Profile{}
@@ -71,7 +73,7 @@ func TestControllerGetProfilesMap(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, errors.New("failed to read"))
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		_, err := ctrl.GetProfilesMap()
 		assert.EqualError(t, err, "failed to read")
 	})
@@ -109,7 +111,7 @@ func TestControllerGetProfiles(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(profiles, nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		actual, err := ctrl.GetProfiles()
 		assert.NoError(t, err)
 		expectedProfiles := []entity.Profile{
@@ -147,7 +149,7 @@ func TestControllerGetProfileNames(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		names, err := ctrl.GetProfileNames()
 		assert.NoError(t, err)
 		assert.EqualValues(t, []string{"local", "default"}, names)
@@ -157,19 +159,40 @@ func TestControllerGetProfileNames(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, errors.New("failed to read"))
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		_, err := ctrl.GetProfileNames()
 		assert.EqualError(t, err, "failed to read")
 	})
 }
 
+//unsetEnvironment unsets environment variables which change profile for execution and restores them when test is done
+func unsetEnvironment(t *testing.T) {
+	for _, variable := range []string{
+		environment.OPENSEARCH_PROFILE,
+		environment.OPENSEARCH_ENDPOINT,
+		environment.OPENSEARCH_USER,
+		environment.OPENSEARCH_PASSWORD,
+		environment.OPENSEARCH_MAX_RETRY,
+		environment.OPENSEARCH_TIMEOUT,
+	} {
+		if value, ok := os.LookupEnv(variable); ok {
+			assert.NoError(t, os.Unsetenv(variable))
+			variable := variable
+			t.Cleanup(func() {
+				assert.NoError(t, os.Setenv(variable, value))
+			})
+		}
+	}
+}
+
 func TestControllerGetProfileForExecution(t *testing.T) {
+	unsetEnvironment(t)
 	t.Run("provided profile name: success", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		p, ok, err := ctrl.GetProfileForExecution("local")
 		assert.NoError(t, err)
 		assert.True(t, ok)
@@ -188,7 +211,7 @@ func TestControllerGetProfileForExecution(t *testing.T) {
 		}
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(getDefaultConfig(), nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		p, ok, err := ctrl.GetProfileForExecution("")
 		assert.NoError(t, err)
 		assert.True(t, ok)
@@ -199,7 +222,7 @@ func TestControllerGetProfileForExecution(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		oldValue, ok := os.LookupEnv(environment.OPENSEARCH_PROFILE)
 		if ok {
 			assert.NoError(t, os.Unsetenv(environment.OPENSEARCH_PROFILE))
@@ -223,7 +246,7 @@ func TestControllerGetProfileForExecution(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, errors.New("failed to read"))
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		_, _, err := ctrl.GetProfileForExecution("local")
 		assert.EqualError(t, err, "failed to read")
 	})
@@ -232,7 +255,7 @@ func TestControllerGetProfileForExecution(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		_, ok, err := ctrl.GetProfileForExecution("invalid")
 		assert.EqualError(t, err, "profile 'invalid' does not exist")
 		assert.False(t, ok)
@@ -245,7 +268,7 @@ func TestControllerCreateProfile(t *testing.T) {
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, nil)
 		mockConfigCtrl.EXPECT().Write(getDefaultConfig()).Return(nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		err := ctrl.CreateProfile(getDefaultConfig().Profiles[0])
 		assert.NoError(t, err)
 	})
@@ -254,7 +277,7 @@ func TestControllerCreateProfile(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, errors.New("failed to read"))
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		err := ctrl.CreateProfile(getDefaultConfig().Profiles[0])
 		assert.EqualError(t, err, "failed to read")
 	})
@@ -264,22 +287,107 @@ func TestControllerCreateProfile(t *testing.T) {
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, nil)
 		mockConfigCtrl.EXPECT().Write(getDefaultConfig()).Return(errors.New("failed to write"))
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		err := ctrl.CreateProfile(getDefaultConfig().Profiles[0])
 		assert.EqualError(t, err, "failed to write")
 	})
 }
 
+//getStores returns factory which returns store for any backend
+func getStores(store credential.Store) credential.Factory {
+	return func(entity.Credential) (credential.Store, error) {
+		return store, nil
+	}
+}
+
+func getKeyringProfile() entity.Profile {
+	return entity.Profile{
+		Name:       "keyring",
+		Endpoint:   "https://localhost:9200",
+		UserName:   "admin",
+		Credential: &entity.Credential{Backend: entity.CredentialBackendKeyring},
+	}
+}
+
+func TestControllerCredentials(t *testing.T) {
+	unsetEnvironment(t)
+	t.Run("create saves password in store", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockStore := credentials.NewMockStore(mockCtrl)
+		mockStore.EXPECT().Set("keyring", entity.Credentials{UserName: "admin", Password: "secret"}).Return(nil)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, nil)
+		mockConfigCtrl.EXPECT().Write(entity.Config{Profiles: []entity.Profile{getKeyringProfile()}}).Return(nil)
+		ctrl := New(mockConfigCtrl, getStores(mockStore))
+		p := getKeyringProfile()
+		p.Password = "secret"
+		assert.NoError(t, ctrl.CreateProfile(p))
+	})
+	t.Run("create fails if store failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockStore := credentials.NewMockStore(mockCtrl)
+		mockStore.EXPECT().Set("keyring", gomock.Any()).Return(errors.New("keyring is locked"))
+		ctrl := New(mockConfigCtrl, getStores(mockStore))
+		p := getKeyringProfile()
+		p.Password = "secret"
+		err := ctrl.CreateProfile(p)
+		assert.EqualError(t, err, "failed to save credentials in keyring backend due to: keyring is locked")
+	})
+	t.Run("delete removes credentials from store", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockStore := credentials.NewMockStore(mockCtrl)
+		mockStore.EXPECT().Delete("keyring").Return(nil)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{Profiles: []entity.Profile{getKeyringProfile()}}, nil)
+		mockConfigCtrl.EXPECT().Write(entity.Config{}).Return(nil)
+		ctrl := New(mockConfigCtrl, getStores(mockStore))
+		assert.NoError(t, ctrl.DeleteProfiles([]string{"keyring"}))
+	})
+	t.Run("effective profile retrieves credentials from store", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockStore := credentials.NewMockStore(mockCtrl)
+		mockStore.EXPECT().Get("keyring").Return(entity.Credentials{UserName: "admin", Password: "secret"}, nil)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{Profiles: []entity.Profile{getKeyringProfile()}}, nil)
+		ctrl := New(mockConfigCtrl, getStores(mockStore))
+		value, ok, err := ctrl.GetEffectiveProfile("keyring")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, entity.ProfileSourceFile, value.Sources[entity.ProfileFieldCredentialBackend])
+		assert.Empty(t, value.Profile.Password)
+		result, err := value.Profile.CredentialProvider.Retrieve()
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", result.Password)
+	})
+	t.Run("effective profile with invalid backend", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		p := getKeyringProfile()
+		p.Credential.Backend = "vault"
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{Profiles: []entity.Profile{p}}, nil)
+		ctrl := New(mockConfigCtrl, credential.NewFactory(credential.Options{}))
+		_, ok, err := ctrl.GetEffectiveProfile("keyring")
+		assert.EqualError(t, err, "invalid credential backend vault, allowed backends are config, keyring, file, process")
+		assert.False(t, ok)
+	})
+}
+
 func TestControllerDeleteProfile(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
-		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil).Times(2)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
 		expectedConfig := getSampleConfig()
 		expectedConfig.Profiles = []entity.Profile{expectedConfig.Profiles[1]}
 		mockConfigCtrl.EXPECT().Write(expectedConfig).Return(nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		err := ctrl.DeleteProfiles([]string{getSampleConfig().Profiles[0].Name})
 		assert.NoError(t, err)
 	})
@@ -287,11 +395,11 @@ func TestControllerDeleteProfile(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
-		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil).Times(2)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
 		expectedConfig := getSampleConfig()
 		expectedConfig.Profiles = []entity.Profile{expectedConfig.Profiles[1]}
 		mockConfigCtrl.EXPECT().Write(expectedConfig).Return(nil)
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		err := ctrl.DeleteProfiles([]string{getSampleConfig().Profiles[0].Name, "invalid-profile1", "invalid-profile2"})
 		assert.EqualError(t, err, "no profiles found for: invalid-profile1, invalid-profile2")
 	})
@@ -300,7 +408,7 @@ func TestControllerDeleteProfile(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
 		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, errors.New("failed to read"))
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		err := ctrl.DeleteProfiles([]string{getSampleConfig().Profiles[0].Name})
 		assert.EqualError(t, err, "failed to read")
 	})
@@ -308,12 +416,367 @@ func TestControllerDeleteProfile(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		mockConfigCtrl := config.NewMockController(mockCtrl)
-		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil).Times(2)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
 		expectedConfig := getSampleConfig()
 		expectedConfig.Profiles = []entity.Profile{expectedConfig.Profiles[1]}
 		mockConfigCtrl.EXPECT().Write(expectedConfig).Return(errors.New("failed to write"))
-		ctrl := New(mockConfigCtrl)
+		ctrl := New(mockConfigCtrl, nil)
 		err := ctrl.DeleteProfiles([]string{getSampleConfig().Profiles[0].Name})
 		assert.EqualError(t, err, "failed to write")
 	})
 }
+
+func TestControllerGetEffectiveProfile(t *testing.T) {
+	unsetEnvironment(t)
+	t.Run("environment overrides fields of named profile", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
+		t.Setenv(environment.OPENSEARCH_USER, "ci")
+		t.Setenv(environment.OPENSEARCH_TIMEOUT, "30")
+		t.Setenv(environment.OPENSEARCH_MAX_RETRY, "not a number")
+		ctrl := New(mockConfigCtrl, nil)
+		p, ok, err := ctrl.GetEffectiveProfile("local")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		timeout := int64(30)
+		assert.EqualValues(t, entity.Profile{
+			Name:     "local",
+			Endpoint: "https://localhost:9200",
+			UserName: "ci",
+			Password: "admin",
+			Timeout:  &timeout,
+		}, p.Profile)
+		assert.EqualValues(t, map[string]string{
+			entity.ProfileFieldName:     entity.ProfileSourceFlag,
+			entity.ProfileFieldEndpoint: entity.ProfileSourceFile,
+			entity.ProfileFieldUserName: entity.ProfileSourceEnv,
+			entity.ProfileFieldPassword: entity.ProfileSourceFile,
+			entity.ProfileFieldTimeout:  entity.ProfileSourceEnv,
+		}, p.Sources)
+	})
+	t.Run("tls settings from file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		verify := true
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{Profiles: []entity.Profile{{
+			Name:     "tls",
+			Endpoint: "https://localhost:9200",
+			TLS:      &entity.TLS{Verify: &verify, Pins: []string{"pin"}},
+		}}}, nil)
+		ctrl := New(mockConfigCtrl, nil)
+		p, ok, err := ctrl.GetEffectiveProfile("tls")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, entity.ProfileSourceFile, p.Sources[entity.ProfileFieldTLSVerify])
+		assert.Equal(t, entity.ProfileSourceFile, p.Sources[entity.ProfileFieldTLSPins])
+		assert.NotContains(t, p.Sources, entity.ProfileFieldTLSServerName)
+	})
+	t.Run("profile built from environment", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, nil)
+		t.Setenv(environment.OPENSEARCH_ENDPOINT, "http://opensearch:9200")
+		t.Setenv(environment.OPENSEARCH_PASSWORD, "secret")
+		ctrl := New(mockConfigCtrl, nil)
+		p, ok, err := ctrl.GetProfileForExecution("")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.EqualValues(t, entity.Profile{
+			Name:     EnvironmentProfileName,
+			Endpoint: "http://opensearch:9200",
+			Password: "secret",
+		}, p)
+	})
+	t.Run("default profile is preferred over environment", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getDefaultConfig(), nil)
+		t.Setenv(environment.OPENSEARCH_ENDPOINT, "http://opensearch:9200")
+		ctrl := New(mockConfigCtrl, nil)
+		p, ok, err := ctrl.GetEffectiveProfile("")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.EqualValues(t, DefaultProfileName, p.Profile.Name)
+		assert.EqualValues(t, "http://opensearch:9200", p.Profile.Endpoint)
+		assert.EqualValues(t, entity.ProfileSourceDefault, p.Sources[entity.ProfileFieldName])
+	})
+	t.Run("missing named profile is not built from environment", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, nil)
+		t.Setenv(environment.OPENSEARCH_ENDPOINT, "http://opensearch:9200")
+		t.Setenv(environment.OPENSEARCH_PROFILE, "prod")
+		ctrl := New(mockConfigCtrl, nil)
+		_, ok, err := ctrl.GetEffectiveProfile("")
+		assert.EqualError(t, err, "profile 'prod' does not exist")
+		assert.False(t, ok)
+	})
+	t.Run("no profile and no endpoint", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, nil)
+		ctrl := New(mockConfigCtrl, nil)
+		_, ok, err := ctrl.GetEffectiveProfile("")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func getThreeProfilesConfig() entity.Config {
+	return entity.Config{
+		Profiles: []entity.Profile{
+			{Name: "dev", Endpoint: "https://dev:9200", UserName: "admin", Password: "dev123"},
+			{Name: "staging", Endpoint: "https://staging:9200"},
+			{Name: "prod", Endpoint: "https://prod:9200", UserName: "admin", Password: "prod123"},
+		}}
+}
+
+func TestControllerDeleteProfileKeepsOrder(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockConfigCtrl := config.NewMockController(mockCtrl)
+	mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+	expected := getThreeProfilesConfig()
+	expected.Profiles = []entity.Profile{expected.Profiles[0], expected.Profiles[2]}
+	mockConfigCtrl.EXPECT().Write(expected).Return(nil)
+	ctrl := New(mockConfigCtrl, nil)
+	assert.NoError(t, ctrl.DeleteProfiles([]string{"staging"}))
+}
+
+func TestControllerUpdateProfile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		expected := getThreeProfilesConfig()
+		expected.Profiles[1].Endpoint = "https://staging-2:9200"
+		mockConfigCtrl.EXPECT().Write(expected).Return(nil)
+		ctrl := New(mockConfigCtrl, nil)
+		assert.NoError(t, ctrl.UpdateProfile(expected.Profiles[1]))
+	})
+	t.Run("backend changed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockStore := credentials.NewMockStore(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{Profiles: []entity.Profile{getKeyringProfile()}}, nil)
+		updated := getKeyringProfile()
+		updated.Credential = nil
+		updated.Password = "secret"
+		mockConfigCtrl.EXPECT().Write(entity.Config{Profiles: []entity.Profile{updated}}).Return(nil)
+		mockStore.EXPECT().Delete("keyring").Return(nil)
+		ctrl := New(mockConfigCtrl, getStores(mockStore))
+		assert.NoError(t, ctrl.UpdateProfile(updated))
+	})
+	t.Run("profile does not exist", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		ctrl := New(mockConfigCtrl, nil)
+		err := ctrl.UpdateProfile(entity.Profile{Name: "qa"})
+		assert.EqualError(t, err, "profile 'qa' does not exist")
+	})
+}
+
+func TestControllerRenameProfile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		expected := getThreeProfilesConfig()
+		expected.Profiles[1].Name = "qa"
+		mockConfigCtrl.EXPECT().Write(expected).Return(nil)
+		ctrl := New(mockConfigCtrl, nil)
+		assert.NoError(t, ctrl.RenameProfile("staging", "qa"))
+	})
+	t.Run("credentials are moved", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockStore := credentials.NewMockStore(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{Profiles: []entity.Profile{getKeyringProfile()}}, nil)
+		renamed := getKeyringProfile()
+		renamed.Name = "vault"
+		mockConfigCtrl.EXPECT().Write(entity.Config{Profiles: []entity.Profile{renamed}}).Return(nil)
+		admin := entity.Credentials{UserName: "admin", Password: "secret"}
+		gomock.InOrder(
+			mockStore.EXPECT().Get("keyring").Return(admin, nil),
+			mockStore.EXPECT().Set("vault", admin).Return(nil),
+			mockStore.EXPECT().Delete("keyring").Return(nil),
+		)
+		ctrl := New(mockConfigCtrl, getStores(mockStore))
+		assert.NoError(t, ctrl.RenameProfile("keyring", "vault"))
+	})
+	t.Run("new name exists", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		ctrl := New(mockConfigCtrl, nil)
+		err := ctrl.RenameProfile("staging", "prod")
+		assert.EqualError(t, err, "profile prod already exists")
+	})
+}
+
+func TestControllerCopyProfile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		expected := getThreeProfilesConfig()
+		copied := expected.Profiles[0]
+		copied.Name = "dev-2"
+		expected.Profiles = append(expected.Profiles, copied)
+		mockConfigCtrl.EXPECT().Write(expected).Return(nil)
+		ctrl := New(mockConfigCtrl, nil)
+		assert.NoError(t, ctrl.CopyProfile("dev", "dev-2"))
+	})
+	t.Run("profile does not exist", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		ctrl := New(mockConfigCtrl, nil)
+		err := ctrl.CopyProfile("qa", "qa-2")
+		assert.EqualError(t, err, "profile 'qa' does not exist")
+	})
+}
+
+func TestControllerExportProfiles(t *testing.T) {
+	t.Run("all profiles", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		ctrl := New(mockConfigCtrl, nil)
+		profiles, err := ctrl.ExportProfiles(nil, false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, getThreeProfilesConfig().Profiles, profiles)
+	})
+	t.Run("selected profiles in config order without secrets", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		ctrl := New(mockConfigCtrl, nil)
+		profiles, err := ctrl.ExportProfiles([]string{"prod", "dev"}, true)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []entity.Profile{
+			{Name: "dev", Endpoint: "https://dev:9200", UserName: "admin"},
+			{Name: "prod", Endpoint: "https://prod:9200", UserName: "admin"},
+		}, profiles)
+	})
+	t.Run("invalid profiles", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		ctrl := New(mockConfigCtrl, nil)
+		_, err := ctrl.ExportProfiles([]string{"dev", "qa"}, false)
+		assert.EqualError(t, err, "no profiles found for: qa")
+	})
+}
+
+func TestControllerImportProfiles(t *testing.T) {
+	t.Run("new profiles are added at the end", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
+		expected := getSampleConfig()
+		expected.Profiles = append(expected.Profiles, getThreeProfilesConfig().Profiles...)
+		mockConfigCtrl.EXPECT().Write(expected).Return(nil)
+		ctrl := New(mockConfigCtrl, nil)
+		assert.NoError(t, ctrl.ImportProfiles(getThreeProfilesConfig().Profiles, false, false))
+	})
+	t.Run("existing profile is replaced in place", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		expected := getThreeProfilesConfig()
+		expected.Profiles[1].Endpoint = "https://staging-2:9200"
+		expected.Profiles = append(expected.Profiles, entity.Profile{Name: "qa", Endpoint: "http://qa:9200"})
+		mockConfigCtrl.EXPECT().Write(expected).Return(nil)
+		ctrl := New(mockConfigCtrl, nil)
+		err := ctrl.ImportProfiles([]entity.Profile{expected.Profiles[1], expected.Profiles[3]}, true, false)
+		assert.NoError(t, err)
+	})
+	t.Run("existing profile without overwrite", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getThreeProfilesConfig(), nil)
+		ctrl := New(mockConfigCtrl, nil)
+		err := ctrl.ImportProfiles([]entity.Profile{{Name: "qa", Endpoint: "http://qa:9200"}, {Name: "dev", Endpoint: "https://dev:9200"}}, false, false)
+		assert.EqualError(t, err, "profile dev already exists")
+	})
+	t.Run("duplicate profile", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, nil)
+		ctrl := New(mockConfigCtrl, nil)
+		err := ctrl.ImportProfiles([]entity.Profile{{Name: "qa", Endpoint: "http://qa:9200"}, {Name: "qa", Endpoint: "http://qa:9200"}}, false, false)
+		assert.EqualError(t, err, "profile qa is defined more than once")
+	})
+	t.Run("invalid profiles", func(t *testing.T) {
+		process := &entity.Credential{Backend: entity.CredentialBackendProcess, Process: "vault read secret"}
+		for name, test := range map[string]struct {
+			profile entity.Profile
+			err     string
+		}{
+			"no endpoint": {
+				profile: entity.Profile{Name: "qa"},
+				err:     "profile qa has invalid endpoint ''",
+			},
+			"endpoint without scheme": {
+				profile: entity.Profile{Name: "qa", Endpoint: "qa:9200"},
+				err:     "profile qa has invalid endpoint 'qa:9200'",
+			},
+			"invalid backend": {
+				profile: entity.Profile{Name: "qa", Endpoint: "http://qa:9200", Credential: &entity.Credential{Backend: "vault"}},
+				err:     "profile qa has invalid credential backend 'vault', allowed values are config, keyring, file, process",
+			},
+			"process without process backend": {
+				profile: entity.Profile{Name: "qa", Endpoint: "http://qa:9200", Credential: &entity.Credential{Backend: entity.CredentialBackendKeyring, Process: "echo"}},
+				err:     "profile qa has credential process but its credential backend is keyring",
+			},
+			"process not allowed": {
+				profile: entity.Profile{Name: "qa", Endpoint: "http://qa:9200", Credential: process},
+				err:     "profile qa runs credential process 'vault read secret', importing it must be allowed explicitly",
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				mockCtrl := gomock.NewController(t)
+				defer mockCtrl.Finish()
+				mockConfigCtrl := config.NewMockController(mockCtrl)
+				mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, nil)
+				ctrl := New(mockConfigCtrl, nil)
+				assert.EqualError(t, ctrl.ImportProfiles([]entity.Profile{test.profile}, false, false), test.err)
+			})
+		}
+	})
+	t.Run("allowed process", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, nil)
+		profile := entity.Profile{Name: "qa", Endpoint: "http://qa:9200",
+			Credential: &entity.Credential{Backend: entity.CredentialBackendProcess, Process: "vault read secret"}}
+		mockConfigCtrl.EXPECT().Write(entity.Config{Profiles: []entity.Profile{profile}}).Return(nil)
+		ctrl := New(mockConfigCtrl, func(entity.Credential) (credential.Store, error) { return nil, nil })
+		assert.NoError(t, ctrl.ImportProfiles([]entity.Profile{profile}, false, true))
+	})
+}
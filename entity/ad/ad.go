@@ -36,14 +36,15 @@ type Interval struct {
 
 //CreateDetector represents Detector creation request
 type CreateDetector struct {
-	Name        string          `json:"name"`
-	Description string          `json:"description,omitempty"`
-	TimeField   string          `json:"time_field"`
-	Index       []string        `json:"indices"`
-	Features    []Feature       `json:"feature_attributes"`
-	Filter      json.RawMessage `json:"filter_query,omitempty"`
-	Interval    Interval        `json:"detection_interval"`
-	Delay       Interval        `json:"window_delay"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description,omitempty"`
+	TimeField     string          `json:"time_field"`
+	Index         []string        `json:"indices"`
+	Features      []Feature       `json:"feature_attributes"`
+	Filter        json.RawMessage `json:"filter_query,omitempty"`
+	Interval      Interval        `json:"detection_interval"`
+	Delay         Interval        `json:"window_delay"`
+	CategoryField []string        `json:"category_field,omitempty"`
 }
 
 //FeatureRequest represents feature request
@@ -53,7 +54,9 @@ type FeatureRequest struct {
 	Field           []string `json:"field"`
 }
 
-//CreateDetectorRequest represents request for AD
+//CreateDetectorRequest represents request for AD. CategoryField creates high cardinality detector,
+//PartitionField is treated as category field unless FanOut is set, which creates one detector per
+//distinct value of the field instead
 type CreateDetectorRequest struct {
 	Name           string           `json:"name"`
 	Description    string           `json:"description"`
@@ -64,7 +67,9 @@ type CreateDetectorRequest struct {
 	Interval       string           `json:"interval"`
 	Delay          string           `json:"window_delay"`
 	Start          bool             `json:"start"`
-	PartitionField *string          `json:"partition_field"`
+	CategoryField  []string         `json:"category_field"`
+	PartitionField *string          `json:"partition_field,omitempty"`
+	FanOut         bool             `json:"-"`
 }
 
 //Bool type for must query
@@ -105,14 +110,23 @@ type Match struct {
 	Name string `json:"name"`
 }
 
-//SearchQuery contains match names
+//Term specifies exact name, which is matched against keyword field of name
+type Term struct {
+	Name string `json:"name.keyword"`
+}
+
+//SearchQuery contains either match names or exact name
 type SearchQuery struct {
-	Match Match `json:"match"`
+	Match *Match `json:"match,omitempty"`
+	Term  *Term  `json:"term,omitempty"`
 }
 
-//SearchRequest represents structure for search detectors
+//SearchRequest represents structure for search detectors, search after is sort values of last hit of previous page
 type SearchRequest struct {
-	Query SearchQuery `json:"query"`
+	Query       SearchQuery   `json:"query"`
+	Size        int           `json:"size,omitempty"`
+	Sort        []interface{} `json:"sort,omitempty"`
+	SearchAfter []interface{} `json:"search_after,omitempty"`
 }
 
 //Source contains detectors metadata
@@ -122,8 +136,9 @@ type Source struct {
 
 //Hit contains search results
 type Hit struct {
-	ID     string `json:"_id"`
-	Source Source `json:"_source"`
+	ID     string        `json:"_id"`
+	Source Source        `json:"_source"`
+	Sort   []interface{} `json:"sort,omitempty"`
 }
 
 //Container represents structure for search response
@@ -161,6 +176,7 @@ type DetectorOutput struct {
 	Filter        json.RawMessage `json:"filter_query"`
 	Interval      string          `json:"detection_interval"`
 	Delay         string          `json:"window_delay"`
+	CategoryField []string        `json:"category_field,omitempty"`
 	LastUpdatedAt uint64          `json:"last_update_time"`
 	SchemaVersion int32           `json:"schema_version"`
 }
@@ -170,3 +186,250 @@ type UpdateDetectorUserInput DetectorOutput
 
 // UpdateDetector represents detector's settings updated by api
 type UpdateDetector CreateDetector
+
+//InitProgress represents initialization progress of detector
+type InitProgress struct {
+	Percentage           string `json:"percentage"`
+	EstimatedMinutesLeft int    `json:"estimated_minutes_left"`
+	NeededShingles       int    `json:"needed_shingles"`
+}
+
+//ModelProfile represents model hosted by a node
+type ModelProfile struct {
+	ModelID          string `json:"model_id"`
+	ModelSizeInBytes int64  `json:"model_size_in_bytes"`
+	NodeID           string `json:"node_id"`
+}
+
+//DetectorProfile represents detector profile response
+type DetectorProfile struct {
+	State            string         `json:"state"`
+	Error            string         `json:"error"`
+	InitProgress     *InitProgress  `json:"init_progress"`
+	CoordinatingNode string         `json:"coordinating_node"`
+	Models           []ModelProfile `json:"models"`
+	TotalSizeInBytes int64          `json:"total_size_in_bytes"`
+}
+
+//DetectorStatus represents detector's lifecycle status displayed to user
+type DetectorStatus struct {
+	ID                   string   `json:"id"`
+	Name                 string   `json:"name"`
+	State                string   `json:"state"`
+	InitProgress         string   `json:"init_progress"`
+	EstimatedMinutesLeft *int     `json:"estimated_minutes_left"`
+	Error                string   `json:"error"`
+	CoordinatingNode     string   `json:"coordinating_node"`
+	Nodes                []string `json:"nodes"`
+}
+
+//AnomalyResultFilter represents user's filter to search anomaly results
+type AnomalyResultFilter struct {
+	From            string
+	To              string
+	MinAnomalyGrade float64
+	MinConfidence   float64
+	Entity          string
+	Limit           int
+}
+
+//ResultFilter type for filter query
+type ResultFilter struct {
+	Filter []interface{} `json:"filter"`
+}
+
+//ResultQuery type to represent anomaly result query
+type ResultQuery struct {
+	Bool ResultFilter `json:"bool"`
+}
+
+//SortOrder represents sort order of field
+type SortOrder struct {
+	Order        string `json:"order"`
+	UnmappedType string `json:"unmapped_type,omitempty"`
+}
+
+//AnomalyResultSearchRequest represents structure for search anomaly results
+type AnomalyResultSearchRequest struct {
+	Query       ResultQuery            `json:"query"`
+	Sort        []map[string]SortOrder `json:"sort"`
+	Size        int                    `json:"size"`
+	SearchAfter []interface{}          `json:"search_after,omitempty"`
+}
+
+//ResultEntity represents category field value of anomaly result
+type ResultEntity struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+//FeatureData represents feature value of anomaly result
+type FeatureData struct {
+	FeatureID   string  `json:"feature_id"`
+	FeatureName string  `json:"feature_name"`
+	Data        float64 `json:"data"`
+}
+
+//AnomalyResult represents anomaly result document
+type AnomalyResult struct {
+	DetectorID    string         `json:"detector_id"`
+	ModelID       string         `json:"model_id"`
+	AnomalyGrade  float64        `json:"anomaly_grade"`
+	Confidence    float64        `json:"confidence"`
+	DataStartTime int64          `json:"data_start_time"`
+	DataEndTime   int64          `json:"data_end_time"`
+	Entity        []ResultEntity `json:"entity"`
+	FeatureData   []FeatureData  `json:"feature_data"`
+	Error         string         `json:"error"`
+}
+
+//AnomalyResultHit contains anomaly result and its sort values
+type AnomalyResultHit struct {
+	ID     string        `json:"_id"`
+	Source AnomalyResult `json:"_source"`
+	Sort   []interface{} `json:"sort"`
+}
+
+//AnomalyResultContainer represents structure for anomaly result hits
+type AnomalyResultContainer struct {
+	Hits []AnomalyResultHit `json:"hits"`
+}
+
+//AnomalyResultSearchResponse represents structure for search anomaly results response
+type AnomalyResultSearchResponse struct {
+	Hits AnomalyResultContainer `json:"hits"`
+}
+
+//AnomalyResultOutput represents anomaly result displayed to user
+type AnomalyResultOutput struct {
+	DetectorName string             `json:"detector_name"`
+	DetectorID   string             `json:"detector_id"`
+	StartTime    string             `json:"start_time"`
+	EndTime      string             `json:"end_time"`
+	AnomalyGrade float64            `json:"anomaly_grade"`
+	Confidence   float64            `json:"confidence"`
+	Entity       string             `json:"entity"`
+	Features     map[string]float64 `json:"features"`
+	Error        string             `json:"error"`
+}
+
+//HistoricalAnalysisRequest represents date range of historical analysis
+type HistoricalAnalysisRequest struct {
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+}
+
+//HistoricalTask represents historical analysis task of detector
+type HistoricalTask struct {
+	TaskID             string                    `json:"task_id"`
+	State              string                    `json:"state"`
+	TaskProgress       float64                   `json:"task_progress"`
+	InitProgress       float64                   `json:"init_progress"`
+	Error              string                    `json:"error"`
+	DetectionDateRange HistoricalAnalysisRequest `json:"detection_date_range"`
+}
+
+//DetectorTaskResponse represents detector's setting along with its historical analysis task
+type DetectorTaskResponse struct {
+	ID              string          `json:"_id"`
+	AnomalyDetector AnomalyDetector `json:"anomaly_detector"`
+	HistoricalTask  *HistoricalTask `json:"historical_analysis_task"`
+}
+
+//HistoricalAnalysisStatus represents historical analysis status displayed to user
+type HistoricalAnalysisStatus struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	TaskID   string `json:"task_id"`
+	State    string `json:"state"`
+	Progress string `json:"progress"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Error    string `json:"error"`
+}
+
+//PreviewRequest represents time range and detector to preview, either DetectorID or Detector is set
+type PreviewRequest struct {
+	PeriodStart int64           `json:"period_start"`
+	PeriodEnd   int64           `json:"period_end"`
+	DetectorID  string          `json:"detector_id,omitempty"`
+	Detector    *CreateDetector `json:"detector,omitempty"`
+}
+
+//PreviewResponse represents sample anomaly results computed by preview
+type PreviewResponse struct {
+	AnomalyResult []AnomalyResult `json:"anomaly_result"`
+}
+
+//ValidationIssue represents problem of detector configuration found by validate API
+type ValidationIssue struct {
+	Message        string            `json:"message"`
+	SubIssues      map[string]string `json:"sub_issues,omitempty"`
+	SuggestedValue json.RawMessage   `json:"suggested_value,omitempty"`
+}
+
+//ValidationResponse represents issues found by validate API, grouped by validation type and field
+type ValidationResponse struct {
+	Detector map[string]ValidationIssue `json:"detector,omitempty"`
+	Model    map[string]ValidationIssue `json:"model,omitempty"`
+}
+
+//ValidationProblem represents problem of detector configuration displayed to user
+type ValidationProblem struct {
+	Source  string `json:"source"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+//ValidationResult represents problems of detector configuration from single file
+type ValidationResult struct {
+	File     string               `json:"file"`
+	Valid    bool                 `json:"valid"`
+	Problems []*ValidationProblem `json:"problems"`
+}
+
+//SkippedDetector represents detector which cannot be exported in the format accepted by create command
+type SkippedDetector struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+//FieldDiff represents difference of single detector field between live and desired configuration
+type FieldDiff struct {
+	Field   string `json:"field"`
+	Live    string `json:"live"`
+	Desired string `json:"desired"`
+}
+
+//DetectorPlan represents action required to reconcile live detector with its configuration file
+type DetectorPlan struct {
+	Action  string                 `json:"action"`
+	Name    string                 `json:"name"`
+	ID      string                 `json:"id,omitempty"`
+	File    string                 `json:"file,omitempty"`
+	Changes []FieldDiff            `json:"changes,omitempty"`
+	Request *CreateDetectorRequest `json:"-"`
+}
+
+//actions of DetectorPlan
+const (
+	PlanActionCreate    = "create"
+	PlanActionUpdate    = "update"
+	PlanActionDelete    = "delete"
+	PlanActionUnchanged = "unchanged"
+)
+
+//CopyResult represents outcome of copying detector to another cluster
+type CopyResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+//statuses of CopyResult
+const (
+	CopyStatusCreated  = "created"
+	CopyStatusConflict = "conflict"
+	CopyStatusFailed   = "failed"
+)
@@ -170,7 +170,7 @@ func getSearchRequestJSON() string {
 func getSearchRequest() interface{} {
 	return SearchRequest{
 		Query: SearchQuery{
-			Match: Match{Name: "test-d"},
+			Match: &Match{Name: "test-d"},
 		},
 	}
 }
@@ -0,0 +1,149 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+const (
+	HealthStatusGreen  = "green"
+	HealthStatusYellow = "yellow"
+	HealthStatusRed    = "red"
+)
+
+const (
+	SettingsScopeTransient  = "transient"
+	SettingsScopePersistent = "persistent"
+	SettingsScopeDefaults   = "defaults"
+)
+
+//Health represents response of cluster health API
+type Health struct {
+	ClusterName             string  `json:"cluster_name"`
+	Status                  string  `json:"status"`
+	TimedOut                bool    `json:"timed_out"`
+	NumberOfNodes           int     `json:"number_of_nodes"`
+	NumberOfDataNodes       int     `json:"number_of_data_nodes"`
+	ActivePrimaryShards     int     `json:"active_primary_shards"`
+	ActiveShards            int     `json:"active_shards"`
+	RelocatingShards        int     `json:"relocating_shards"`
+	InitializingShards      int     `json:"initializing_shards"`
+	UnassignedShards        int     `json:"unassigned_shards"`
+	DelayedUnassignedShards int     `json:"delayed_unassigned_shards"`
+	NumberOfPendingTasks    int     `json:"number_of_pending_tasks"`
+	ActiveShardsPercent     float64 `json:"active_shards_percent_as_number"`
+}
+
+//CatNode represents node returned by cat nodes API, cluster manager is reported as master before OpenSearch 2.0
+type CatNode struct {
+	IP             string `json:"ip"`
+	HeapPercent    string `json:"heap.percent"`
+	RAMPercent     string `json:"ram.percent"`
+	CPU            string `json:"cpu"`
+	Load1m         string `json:"load_1m"`
+	Load5m         string `json:"load_5m"`
+	Load15m        string `json:"load_15m"`
+	NodeRole       string `json:"node.role"`
+	Master         string `json:"master"`
+	ClusterManager string `json:"cluster_manager"`
+	Name           string `json:"name"`
+}
+
+//NodeOutput represents node displayed to user
+type NodeOutput struct {
+	Name           string `json:"name"`
+	IP             string `json:"ip"`
+	Roles          string `json:"roles"`
+	ClusterManager bool   `json:"cluster_manager"`
+	HeapPercent    string `json:"heap.percent"`
+	RAMPercent     string `json:"ram.percent"`
+	CPU            string `json:"cpu"`
+	Load1m         string `json:"load_1m"`
+}
+
+//AllocationExplainRequest represents shard to explain, first unassigned shard is explained if Index is empty
+type AllocationExplainRequest struct {
+	Index   string `json:"index"`
+	Shard   int    `json:"shard"`
+	Primary bool   `json:"primary"`
+}
+
+//UnassignedInfo represents reason why shard is unassigned
+type UnassignedInfo struct {
+	Reason               string `json:"reason"`
+	At                   string `json:"at"`
+	Details              string `json:"details,omitempty"`
+	LastAllocationStatus string `json:"last_allocation_status"`
+}
+
+//AllocationNode represents node which holds shard
+type AllocationNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+//Decider represents decision of single allocation decider
+type Decider struct {
+	Decider     string `json:"decider"`
+	Decision    string `json:"decision"`
+	Explanation string `json:"explanation"`
+}
+
+//NodeAllocationDecision represents whether shard can be allocated to node
+type NodeAllocationDecision struct {
+	NodeID        string    `json:"node_id"`
+	NodeName      string    `json:"node_name"`
+	NodeDecision  string    `json:"node_decision"`
+	WeightRanking int       `json:"weight_ranking"`
+	Deciders      []Decider `json:"deciders"`
+}
+
+//AllocationExplanation represents response of cluster allocation explain API
+type AllocationExplanation struct {
+	Index                   string                   `json:"index"`
+	Shard                   int                      `json:"shard"`
+	Primary                 bool                     `json:"primary"`
+	CurrentState            string                   `json:"current_state"`
+	CurrentNode             *AllocationNode          `json:"current_node,omitempty"`
+	UnassignedInfo          *UnassignedInfo          `json:"unassigned_info,omitempty"`
+	CanAllocate             string                   `json:"can_allocate,omitempty"`
+	CanRemainOnCurrentNode  string                   `json:"can_remain_on_current_node,omitempty"`
+	CanRebalanceCluster     string                   `json:"can_rebalance_cluster,omitempty"`
+	AllocateExplanation     string                   `json:"allocate_explanation,omitempty"`
+	RebalanceExplanation    string                   `json:"rebalance_explanation,omitempty"`
+	NodeAllocationDecisions []NodeAllocationDecision `json:"node_allocation_decisions,omitempty"`
+}
+
+//DeciderOutput represents decision of decider for node displayed to user
+type DeciderOutput struct {
+	Node        string `json:"node"`
+	Decision    string `json:"decision"`
+	Decider     string `json:"decider"`
+	Explanation string `json:"explanation"`
+}
+
+//Settings represents cluster settings in flat format
+type Settings struct {
+	Persistent map[string]interface{} `json:"persistent"`
+	Transient  map[string]interface{} `json:"transient"`
+	Defaults   map[string]interface{} `json:"defaults,omitempty"`
+}
+
+//SettingsRequest represents settings to get, every setting is returned if Names is empty
+type SettingsRequest struct {
+	Names           []string
+	IncludeDefaults bool
+}
+
+//SettingOutput represents single setting displayed to user
+type SettingOutput struct {
+	Scope string `json:"scope"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,80 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package connection
+
+import (
+	"crypto/tls"
+	"time"
+)
+
+//ClusterInfo is response of root endpoint of cluster
+type ClusterInfo struct {
+	Name        string  `json:"name"`
+	ClusterName string  `json:"cluster_name"`
+	ClusterUUID string  `json:"cluster_uuid"`
+	Version     Version `json:"version"`
+}
+
+type Version struct {
+	Distribution string `json:"distribution"`
+	Number       string `json:"number"`
+}
+
+//AuthInfo is response of security plugin for authenticated user
+type AuthInfo struct {
+	UserName     string   `json:"user_name"`
+	Roles        []string `json:"roles"`
+	BackendRoles []string `json:"backend_roles"`
+}
+
+//CatPlugin is plugin installed on node
+type CatPlugin struct {
+	Name      string `json:"name"`
+	Component string `json:"component"`
+	Version   string `json:"version"`
+}
+
+//Response contains body of response, time taken by request and TLS connection state which is nil for http
+type Response struct {
+	Body    []byte
+	Latency time.Duration
+	TLS     *tls.ConnectionState
+}
+
+//Certificate is certificate presented by cluster, expires in days is negative if certificate is expired
+type Certificate struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	NotAfter      time.Time `json:"not_after"`
+	ExpiresInDays int       `json:"expires_in_days"`
+}
+
+type Plugin struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+//Result is result of connection test of profile, cluster is reachable if root endpoint responded successfully
+type Result struct {
+	Profile      string        `json:"profile"`
+	Endpoint     string        `json:"endpoint"`
+	Reachable    bool          `json:"reachable"`
+	LatencyMS    int64         `json:"latency_ms"`
+	ClusterName  string        `json:"cluster_name,omitempty"`
+	Distribution string        `json:"distribution,omitempty"`
+	Version      string        `json:"version,omitempty"`
+	User         string        `json:"user,omitempty"`
+	Roles        []string      `json:"roles,omitempty"`
+	Certificates []Certificate `json:"certificates,omitempty"`
+	Plugins      []Plugin      `json:"plugins,omitempty"`
+	Errors       []string      `json:"errors,omitempty"`
+}
@@ -0,0 +1,75 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import "encoding/json"
+
+//CatIndex represents index returned by cat indices API, sizes are in bytes
+type CatIndex struct {
+	Health       string `json:"health"`
+	Status       string `json:"status"`
+	Index        string `json:"index"`
+	UUID         string `json:"uuid"`
+	Primaries    string `json:"pri"`
+	Replicas     string `json:"rep"`
+	DocsCount    string `json:"docs.count"`
+	DocsDeleted  string `json:"docs.deleted"`
+	StoreSize    string `json:"store.size"`
+	PriStoreSize string `json:"pri.store.size"`
+}
+
+//ListRequest represents filter and sort of listed indices, Sort is column name optionally followed by :asc or :desc
+type ListRequest struct {
+	Pattern string
+	Health  string
+	Status  string
+	Sort    string
+}
+
+//AcknowledgedResponse represents response of APIs which change cluster state
+type AcknowledgedResponse struct {
+	Acknowledged       bool `json:"acknowledged"`
+	ShardsAcknowledged bool `json:"shards_acknowledged"`
+}
+
+//AliasMetadata represents properties of alias
+type AliasMetadata struct {
+	Filter        json.RawMessage `json:"filter,omitempty"`
+	IndexRouting  string          `json:"index_routing,omitempty"`
+	SearchRouting string          `json:"search_routing,omitempty"`
+	IsWriteIndex  *bool           `json:"is_write_index,omitempty"`
+}
+
+//IndexAliases represents aliases of index
+type IndexAliases struct {
+	Aliases map[string]AliasMetadata `json:"aliases"`
+}
+
+//AliasOutput represents alias of index displayed to user
+type AliasOutput struct {
+	Alias        string `json:"alias"`
+	Index        string `json:"index"`
+	IsWriteIndex bool   `json:"is_write_index"`
+	Filtered     bool   `json:"filtered"`
+}
+
+//AliasAction represents index and alias of add or remove action
+type AliasAction struct {
+	Index        string `json:"index"`
+	Alias        string `json:"alias"`
+	IsWriteIndex *bool  `json:"is_write_index,omitempty"`
+}
+
+//UpdateAliasesRequest represents request of aliases API, every action is either add or remove
+type UpdateAliasesRequest struct {
+	Actions []map[string]AliasAction `json:"actions"`
+}
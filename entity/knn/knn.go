@@ -11,6 +11,8 @@
 
 package knn
 
+import "encoding/json"
+
 //Shards represents number of shards succeeded or failed to warmup
 type Shards struct {
 	Total      int `json:"total"`
@@ -18,11 +20,266 @@ type Shards struct {
 	Failed     int `json:"failed"`
 }
 
-//WarmupAPIResponse warmup api response structure
+//WarmupAPIResponse warmup and clear cache api response structure
 type WarmupAPIResponse struct {
 	Shards Shards `json:"_shards"`
 }
 
+//NodesSummary represents number of nodes which returned stats
+type NodesSummary struct {
+	Total      int `json:"total"`
+	Successful int `json:"successful"`
+	Failed     int `json:"failed"`
+}
+
+//IndexCacheStats represents graph memory used by index on node
+type IndexCacheStats struct {
+	GraphMemoryUsage           *int64   `json:"graph_memory_usage,omitempty"`
+	GraphMemoryUsagePercentage *float64 `json:"graph_memory_usage_percentage,omitempty"`
+	GraphCount                 *int64   `json:"graph_count,omitempty"`
+}
+
+//NodeStats represents stats of node, stats not requested by stat names are nil
+type NodeStats struct {
+	EvictionCount              *int64                     `json:"eviction_count,omitempty"`
+	MissCount                  *int64                     `json:"miss_count,omitempty"`
+	HitCount                   *int64                     `json:"hit_count,omitempty"`
+	GraphMemoryUsage           *int64                     `json:"graph_memory_usage,omitempty"`
+	GraphMemoryUsagePercentage *float64                   `json:"graph_memory_usage_percentage,omitempty"`
+	GraphIndexRequests         *int64                     `json:"graph_index_requests,omitempty"`
+	GraphIndexErrors           *int64                     `json:"graph_index_errors,omitempty"`
+	KNNQueryRequests           *int64                     `json:"knn_query_requests,omitempty"`
+	GraphQueryRequests         *int64                     `json:"graph_query_requests,omitempty"`
+	GraphQueryErrors           *int64                     `json:"graph_query_errors,omitempty"`
+	IndicesInCache             map[string]IndexCacheStats `json:"indices_in_cache,omitempty"`
+	CacheCapacityReached       *bool                      `json:"cache_capacity_reached,omitempty"`
+	LoadExceptionCount         *int64                     `json:"load_exception_count,omitempty"`
+	LoadSuccessCount           *int64                     `json:"load_success_count,omitempty"`
+	TotalLoadTime              *int64                     `json:"total_load_time,omitempty"`
+	ScriptCompilations         *int64                     `json:"script_compilations,omitempty"`
+	ScriptCompilationErrors    *int64                     `json:"script_compilation_errors,omitempty"`
+	ScriptQueryRequests        *int64                     `json:"script_query_requests,omitempty"`
+	ScriptQueryErrors          *int64                     `json:"script_query_errors,omitempty"`
+}
+
+//Stats represents stats api response structure
+type Stats struct {
+	Nodes                   NodesSummary         `json:"_nodes"`
+	ClusterName             string               `json:"cluster_name"`
+	CircuitBreakerTriggered *bool                `json:"circuit_breaker_triggered,omitempty"`
+	NodeStats               map[string]NodeStats `json:"nodes"`
+}
+
+//NodeStatsOutput represents stats of node displayed to user as table row, counters are deltas in watch mode
+type NodeStatsOutput struct {
+	Node                       string   `json:"node"`
+	GraphMemoryUsage           *int64   `json:"graph_memory_usage,omitempty"`
+	GraphMemoryUsagePercentage *float64 `json:"graph_memory_usage_percentage,omitempty"`
+	CacheCapacityReached       *bool    `json:"cache_capacity_reached,omitempty"`
+	HitCount                   *int64   `json:"hit_count,omitempty"`
+	MissCount                  *int64   `json:"miss_count,omitempty"`
+	EvictionCount              *int64   `json:"eviction_count,omitempty"`
+	LoadSuccessCount           *int64   `json:"load_success_count,omitempty"`
+	LoadExceptionCount         *int64   `json:"load_exception_count,omitempty"`
+	KNNQueryRequests           *int64   `json:"knn_query_requests,omitempty"`
+	GraphQueryErrors           *int64   `json:"graph_query_errors,omitempty"`
+}
+
+//states of model
+const (
+	ModelStateCreated  = "created"
+	ModelStateFailed   = "failed"
+	ModelStateTraining = "training"
+)
+
+//TrainModelRequest is input of model training, method describes IVF/PQ configuration as accepted by plugin
+type TrainModelRequest struct {
+	TrainingIndex          string          `json:"training_index"`
+	TrainingField          string          `json:"training_field"`
+	Dimension              int             `json:"dimension"`
+	Description            string          `json:"description,omitempty"`
+	MaxTrainingVectorCount int             `json:"max_training_vector_count,omitempty"`
+	SearchSize             int             `json:"search_size,omitempty"`
+	Method                 json.RawMessage `json:"method"`
+}
+
+//TrainModelResponse train model api response structure
+type TrainModelResponse struct {
+	ModelID string `json:"model_id"`
+}
+
+//Model represents model metadata
+type Model struct {
+	ModelID     string `json:"model_id"`
+	State       string `json:"state"`
+	Timestamp   string `json:"timestamp"`
+	Description string `json:"description"`
+	Error       string `json:"error"`
+	SpaceType   string `json:"space_type"`
+	Dimension   int    `json:"dimension"`
+	Engine      string `json:"engine"`
+}
+
+//ModelSearchRequest represents search request on model metadata
+type ModelSearchRequest struct {
+	Query       json.RawMessage `json:"query"`
+	Size        int             `json:"size"`
+	Source      SourceFilter    `json:"_source"`
+	Sort        []interface{}   `json:"sort,omitempty"`
+	SearchAfter []interface{}   `json:"search_after,omitempty"`
+}
+
+//SourceFilter filters fields of documents returned by search
+type SourceFilter struct {
+	Excludes []string `json:"excludes"`
+}
+
+//ModelHit represents model found by search
+type ModelHit struct {
+	ID     string        `json:"_id"`
+	Source Model         `json:"_source"`
+	Sort   []interface{} `json:"sort"`
+}
+
+//ModelContainer contains models found by search
+type ModelContainer struct {
+	Hits []ModelHit `json:"hits"`
+}
+
+//ModelSearchResponse search models api response structure
+type ModelSearchResponse struct {
+	Hits ModelContainer `json:"hits"`
+}
+
+//DeleteModelResponse delete model api response structure
+type DeleteModelResponse struct {
+	ModelID string `json:"model_id"`
+	Result  string `json:"result"`
+	Error   string `json:"error,omitempty"`
+}
+
+//vector formats accepted by search
+const (
+	VectorFormatAuto   = "auto"
+	VectorFormatJSON   = "json"
+	VectorFormatCSV    = "csv"
+	VectorFormatBinary = "binary"
+)
+
+//SearchCommandRequest represents user input of k-NN search, vector is raw input in VectorFormat and
+//filter is query in JSON or file name prefixed by @
+type SearchCommandRequest struct {
+	Index        string
+	Field        string
+	K            int
+	Vector       []byte
+	VectorFormat string
+	Filter       string
+}
+
+//KNNQueryField represents k-NN query on a vector field
+type KNNQueryField struct {
+	Vector []float32       `json:"vector"`
+	K      int             `json:"k"`
+	Filter json.RawMessage `json:"filter,omitempty"`
+}
+
+//KNNQuery represents knn query clause by field name
+type KNNQuery struct {
+	KNN map[string]KNNQueryField `json:"knn"`
+}
+
+//SearchRequest represents k-NN search request
+type SearchRequest struct {
+	Size   int          `json:"size"`
+	Query  KNNQuery     `json:"query"`
+	Source SourceFilter `json:"_source"`
+}
+
+//SearchHit represents document found by search
+type SearchHit struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Score  float64         `json:"_score"`
+	Source json.RawMessage `json:"_source"`
+}
+
+//SearchContainer contains documents found by search
+type SearchContainer struct {
+	Hits []SearchHit `json:"hits"`
+}
+
+//SearchResponse search api response structure
+type SearchResponse struct {
+	Hits SearchContainer `json:"hits"`
+}
+
+//SearchResult represents document found by k-NN search displayed to user
+type SearchResult struct {
+	Index  string          `json:"index"`
+	ID     string          `json:"id"`
+	Score  float64         `json:"score"`
+	Source json.RawMessage `json:"source,omitempty"`
+}
+
+//formats of files accepted by ingest
+const (
+	FileFormatAuto  = "auto"
+	FileFormatCSV   = "csv"
+	FileFormatJSONL = "jsonl"
+	FileFormatNPY   = "npy"
+	FileFormatFVECS = "fvecs"
+)
+
+//VectorDocument represents document with vector and metadata read from file, ID is optional
+type VectorDocument struct {
+	ID       string
+	Vector   []float32
+	Metadata map[string]interface{}
+}
+
+//IngestRequest represents user input of ingest, VectorColumn and IDColumn are used by CSV and JSONL files
+type IngestRequest struct {
+	Index        string
+	Field        string
+	Format       string
+	VectorColumn string
+	IDColumn     string
+	BatchSize    int
+	Concurrency  int
+	Retries      int
+	CreateIndex  bool
+}
+
+//IngestResult represents number of documents indexed or failed, Errors contains reasons of first failures
+type IngestResult struct {
+	Indexed int      `json:"indexed"`
+	Failed  int      `json:"failed"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+//FieldMapping represents mapping of field
+type FieldMapping struct {
+	Type      string `json:"type"`
+	Dimension int    `json:"dimension,omitempty"`
+}
+
+//IndexMappings represents mappings of index
+type IndexMappings struct {
+	Properties map[string]FieldMapping `json:"properties"`
+}
+
+//IndexSettings represents settings of k-NN index
+type IndexSettings struct {
+	KNN bool `json:"index.knn"`
+}
+
+//CreateIndexRequest represents request to create k-NN index
+type CreateIndexRequest struct {
+	Settings IndexSettings `json:"settings"`
+	Mappings IndexMappings `json:"mappings"`
+}
+
 //RootCause gives information about type and reason
 type RootCause struct {
 	Type   string `json:"type"`
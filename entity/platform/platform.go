@@ -11,6 +11,8 @@
 
 package platform
 
+import "encoding/json"
+
 // Terms contains fields
 type Terms struct {
 	Field string `json:"field"`
@@ -21,9 +23,21 @@ type DistinctGroups struct {
 	Term Terms `json:"terms"`
 }
 
+// Composite pages through distinct groups, After is the key of last bucket from previous page
+type Composite struct {
+	Size    int32                       `json:"size"`
+	Sources []map[string]DistinctGroups `json:"sources"`
+	After   map[string]interface{}      `json:"after,omitempty"`
+}
+
+// CompositeGroups contains composite aggregation
+type CompositeGroups struct {
+	Composite Composite `json:"composite"`
+}
+
 // Aggregate contains list of items
 type Aggregate struct {
-	Group DistinctGroups `json:"items"`
+	Group CompositeGroups `json:"items"`
 }
 
 // SearchRequest structure for request
@@ -34,13 +48,14 @@ type SearchRequest struct {
 
 // Bucket represents bucket used by ES for aggregations
 type Bucket struct {
-	Key      interface{} `json:"key"`
-	DocCount int64       `json:"doc_count"`
+	Key      map[string]interface{} `json:"key"`
+	DocCount int64                  `json:"doc_count"`
 }
 
 // Items contains buckets defined by response
 type Items struct {
-	Buckets []Bucket `json:"buckets"`
+	AfterKey map[string]interface{} `json:"after_key"`
+	Buckets  []Bucket               `json:"buckets"`
 }
 
 // Aggregations contains items defined by response
@@ -53,6 +68,20 @@ type Response struct {
 	Aggregations Aggregations `json:"aggregations"`
 }
 
+// FieldCapability describes type of field and whether it can be searched and aggregated
+type FieldCapability struct {
+	Type         string   `json:"type"`
+	Searchable   bool     `json:"searchable"`
+	Aggregatable bool     `json:"aggregatable"`
+	Indices      []string `json:"indices,omitempty"`
+}
+
+// FieldCapabilitiesResponse contains matched indices and capabilities of fields per type
+type FieldCapabilitiesResponse struct {
+	Indices []string                              `json:"indices"`
+	Fields  map[string]map[string]FieldCapability `json:"fields"`
+}
+
 // CurlRequest contains parameter to execute REST Action
 type CurlRequest struct {
 	Action       string
@@ -75,3 +104,157 @@ type CurlCommandRequest struct {
 	OutputFormat     string
 	OutputFilterPath string
 }
+
+// BulkItemError describes why single bulk item failed
+type BulkItemError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// BulkItemResult is result of single action of bulk request
+type BulkItemResult struct {
+	Index  string         `json:"_index"`
+	ID     string         `json:"_id"`
+	Status int            `json:"status"`
+	Error  *BulkItemError `json:"error,omitempty"`
+}
+
+// BulkResponse contains result of every action of bulk request by action name, in request order
+type BulkResponse struct {
+	Took   int                         `json:"took"`
+	Errors bool                        `json:"errors"`
+	Items  []map[string]BulkItemResult `json:"items"`
+}
+
+// formats of bulk source and actions used for documents
+const (
+	BulkFormatAuto   = "auto"
+	BulkFormatNDJSON = "ndjson"
+	BulkFormatJSON   = "json"
+	BulkFormatCSV    = "csv"
+	BulkFormatBulk   = "bulk"
+	BulkActionIndex  = "index"
+	BulkActionCreate = "create"
+)
+
+// BulkItem is action line of bulk request followed by optional source line, source is empty for delete action
+type BulkItem struct {
+	Action []byte
+	Source []byte
+}
+
+// BulkLoadRequest describes how documents are read and sent to bulk API
+type BulkLoadRequest struct {
+	Index       string
+	Format      string
+	Action      string
+	IDField     string
+	BatchSize   int
+	BatchBytes  int
+	Concurrency int
+	Retries     int
+}
+
+// BulkFailure counts failed items by error type with reason of first failure as example
+type BulkFailure struct {
+	Type   string `json:"type"`
+	Count  int    `json:"count"`
+	Reason string `json:"reason"`
+}
+
+// BulkLoadResult contains number of loaded and failed items with summary of failures
+type BulkLoadResult struct {
+	Loaded   int            `json:"loaded"`
+	Failed   int            `json:"failed"`
+	Failures []*BulkFailure `json:"failures,omitempty"`
+}
+
+// SearchCommandRequest contains query DSL body and shorthand flags of search command
+type SearchCommandRequest struct {
+	Index  string
+	Body   string
+	Query  string
+	Fields []string
+	Sort   []string
+	Size   int
+	All    bool
+}
+
+// SearchHit is document found by search
+type SearchHit struct {
+	Index   string          `json:"_index"`
+	ID      string          `json:"_id"`
+	Score   *float64        `json:"_score"`
+	Routing string          `json:"_routing,omitempty"`
+	Source  json.RawMessage `json:"_source,omitempty"`
+	Fields  json.RawMessage `json:"fields,omitempty"`
+	Sort    []interface{}   `json:"sort,omitempty"`
+}
+
+// SearchHits contains documents found by search
+type SearchHits struct {
+	Hits []SearchHit `json:"hits"`
+}
+
+// SearchResponse is response of search API, PITID is set when point in time was searched
+type SearchResponse struct {
+	PITID string     `json:"pit_id,omitempty"`
+	Hits  SearchHits `json:"hits"`
+}
+
+// CreatePointInTimeResponse is response of create point in time API
+type CreatePointInTimeResponse struct {
+	PITID string `json:"pit_id"`
+}
+
+// DeletePointInTimeRequest is request of delete point in time API
+type DeletePointInTimeRequest struct {
+	PITIDs []string `json:"pit_id"`
+}
+
+// ExportRequest describes documents of index exported in bulk format, documents are sorted by Tiebreaker within
+// point in time PITID
+type ExportRequest struct {
+	Index      string
+	Query      string
+	Slices     int
+	PageSize   int
+	Routing    bool
+	Tiebreaker string
+	PITID      string
+}
+
+// ExportSliceState is progress of single slice, SearchAfter contains sort values of last exported document
+type ExportSliceState struct {
+	ID          int           `json:"id"`
+	SearchAfter []interface{} `json:"search_after,omitempty"`
+	Exported    int           `json:"exported"`
+	Done        bool          `json:"done"`
+}
+
+// ExportPage is page of documents exported by slice, Done is set on last page of slice
+type ExportPage struct {
+	Slice       int
+	Items       []*BulkItem
+	SearchAfter []interface{}
+	Done        bool
+}
+
+// ExportCheckpoint is progress of export stored in checkpoint file, Offset is size of output file
+// which contains every document exported by slices
+type ExportCheckpoint struct {
+	Index      string             `json:"index"`
+	Query      string             `json:"query,omitempty"`
+	Tiebreaker string             `json:"tiebreaker"`
+	PITID      string             `json:"pit_id,omitempty"`
+	Offset     int64              `json:"offset"`
+	Slices     []ExportSliceState `json:"slices"`
+}
+
+// ExportResult contains number of exported documents, Consistent is false if resumed export could not continue
+// in point in time of interrupted export, so documents changed in between may be duplicated or missing
+type ExportResult struct {
+	Exported   int  `json:"exported"`
+	Resumed    bool `json:"resumed"`
+	Consistent bool `json:"consistent"`
+}
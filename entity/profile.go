@@ -23,13 +23,121 @@ type Trust struct {
 	ClientKeyFilePath         *string
 }
 
+//TLS contains settings to verify certificate of cluster. Certificate is verified unless verify is false, CA certificates
+//of system are trusted unless system CA is false. Pins are base64 encoded SHA-256 hashes of public key, one of
+//certificates presented by cluster must match any of them
+type TLS struct {
+	Verify     *bool    `yaml:"verify,omitempty"`
+	ServerName string   `yaml:"server_name,omitempty"`
+	MinVersion string   `yaml:"min_version,omitempty"`
+	SystemCA   *bool    `yaml:"system_ca,omitempty"`
+	CABundles  []string `yaml:"ca_bundles,omitempty"`
+	Pins       []string `yaml:"pins,omitempty"`
+}
+
+//Credential contains backend where user and password of profile are stored, process is command
+//which prints credentials as json and is only used by process backend
+type Credential struct {
+	Backend string `yaml:"backend"`
+	Process string `yaml:"process,omitempty"`
+}
+
+//Credentials are user and password used for basic authentication
+type Credentials struct {
+	UserName string `json:"user"`
+	Password string `json:"password"`
+}
+
+//CredentialProvider retrieves credentials of profile when request is sent
+type CredentialProvider interface {
+	Retrieve() (Credentials, error)
+}
+
 type Profile struct {
-	Name        string  `yaml:"name"`
-	Endpoint    string  `yaml:"endpoint"`
-	UserName    string  `yaml:"user,omitempty"`
-	Password    string  `yaml:"password,omitempty"`
-	AWS         *AWSIAM `yaml:"aws_iam,omitempty"`
-	Certificate *Trust  `yaml:"certificate,omitempty"`
-	MaxRetry    *int    `yaml:"max_retry,omitempty"`
-	Timeout     *int64  `yaml:"timeout,omitempty"`
+	Name        string      `yaml:"name"`
+	Endpoint    string      `yaml:"endpoint"`
+	UserName    string      `yaml:"user,omitempty"`
+	Password    string      `yaml:"password,omitempty"`
+	Credential  *Credential `yaml:"credential,omitempty"`
+	AWS         *AWSIAM     `yaml:"aws_iam,omitempty"`
+	Certificate *Trust      `yaml:"certificate,omitempty"`
+	TLS         *TLS        `yaml:"tls,omitempty"`
+	MaxRetry    *int        `yaml:"max_retry,omitempty"`
+	Timeout     *int64      `yaml:"timeout,omitempty"`
+	//CredentialProvider is set for profiles whose credentials are not stored in config file
+	CredentialProvider CredentialProvider `yaml:"-"`
+}
+
+const (
+	CredentialBackendConfig  = "config"
+	CredentialBackendKeyring = "keyring"
+	CredentialBackendFile    = "file"
+	CredentialBackendProcess = "process"
+)
+
+//CredentialBackends are backends where credentials of profile can be stored
+var CredentialBackends = []string{
+	CredentialBackendConfig,
+	CredentialBackendKeyring,
+	CredentialBackendFile,
+	CredentialBackendProcess,
+}
+
+const (
+	ProfileSourceFlag    = "flag"
+	ProfileSourceEnv     = "env"
+	ProfileSourceFile    = "file"
+	ProfileSourceDefault = "default"
+)
+
+const (
+	ProfileFieldName              = "name"
+	ProfileFieldEndpoint          = "endpoint"
+	ProfileFieldUserName          = "user"
+	ProfileFieldPassword          = "password"
+	ProfileFieldCredentialBackend = "credential.backend"
+	ProfileFieldCredentialProcess = "credential.process"
+	ProfileFieldAWSProfile        = "aws_iam.profile"
+	ProfileFieldAWSService        = "aws_iam.service"
+	ProfileFieldCAFile            = "certificate.ca"
+	ProfileFieldClientCertFile    = "certificate.client_certificate"
+	ProfileFieldClientKeyFile     = "certificate.client_key"
+	ProfileFieldTLSVerify         = "tls.verify"
+	ProfileFieldTLSServerName     = "tls.server_name"
+	ProfileFieldTLSMinVersion     = "tls.min_version"
+	ProfileFieldTLSSystemCA       = "tls.system_ca"
+	ProfileFieldTLSCABundles      = "tls.ca_bundles"
+	ProfileFieldTLSPins           = "tls.pins"
+	ProfileFieldMaxRetry          = "max_retry"
+	ProfileFieldTimeout           = "timeout"
+)
+
+//ProfileFields are fields of profile in display order
+var ProfileFields = []string{
+	ProfileFieldName,
+	ProfileFieldEndpoint,
+	ProfileFieldUserName,
+	ProfileFieldPassword,
+	ProfileFieldCredentialBackend,
+	ProfileFieldCredentialProcess,
+	ProfileFieldAWSProfile,
+	ProfileFieldAWSService,
+	ProfileFieldCAFile,
+	ProfileFieldClientCertFile,
+	ProfileFieldClientKeyFile,
+	ProfileFieldTLSVerify,
+	ProfileFieldTLSServerName,
+	ProfileFieldTLSMinVersion,
+	ProfileFieldTLSSystemCA,
+	ProfileFieldTLSCABundles,
+	ProfileFieldTLSPins,
+	ProfileFieldMaxRetry,
+	ProfileFieldTimeout,
+}
+
+//EffectiveProfile is profile used for execution with source of every field which is set, source is one of
+//flag, env, file or default
+type EffectiveProfile struct {
+	Profile Profile
+	Sources map[string]string
 }
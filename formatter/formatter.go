@@ -0,0 +1,156 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+// Package formatter renders command results in formats like json, yaml, table, csv,
+// jsonpath and go-template, so that every command can be parsed in the same way.
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	JSON       = "json"
+	JSONLines  = "jsonl"
+	YAML       = "yaml"
+	Table      = "table"
+	CSV        = "csv"
+	JSONPath   = "jsonpath"
+	GoTemplate = "go-template"
+
+	expressionSeparator = "="
+)
+
+// Formatter renders data on writer
+type Formatter interface {
+	Format(w io.Writer, data interface{}) error
+}
+
+// SupportedFormats returns list of formats accepted by New
+func SupportedFormats() []string {
+	return []string{
+		JSON,
+		JSONLines,
+		YAML,
+		Table,
+		CSV,
+		JSONPath + expressionSeparator + "<expression>",
+		GoTemplate + expressionSeparator + "<template>",
+	}
+}
+
+// New returns Formatter based on format. jsonpath and go-template format
+// expects expression after '=', for ex: jsonpath={.name} or go-template={{.name}}
+func New(format string) (Formatter, error) {
+	name, expression := format, ""
+	if i := strings.Index(format, expressionSeparator); i > -1 {
+		name, expression = format[:i], format[i+1:]
+	}
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case JSON:
+		return jsonFormatter{}, nil
+	case JSONLines:
+		return jsonLinesFormatter{}, nil
+	case YAML:
+		return yamlFormatter{}, nil
+	case Table:
+		return tableFormatter{}, nil
+	case CSV:
+		return csvFormatter{}, nil
+	case JSONPath:
+		return newJSONPathFormatter(expression)
+	case GoTemplate:
+		return newTemplateFormatter(expression)
+	}
+	return nil, fmt.Errorf("invalid output format: %s. Supported formats are: %s", format, strings.Join(SupportedFormats(), ", "))
+}
+
+// toJSON converts data to json bytes, if data is already json, it is returned as it is and
+// raw yaml data is converted to json
+func toJSON(data interface{}) ([]byte, error) {
+	switch v := data.(type) {
+	case json.RawMessage:
+		return v, nil
+	case []byte:
+		if json.Valid(v) {
+			return v, nil
+		}
+		//responses like curl with yaml output format are converted, field order is not preserved
+		var value interface{}
+		if err := yaml.Unmarshal(v, &value); err == nil {
+			switch value.(type) {
+			case map[string]interface{}, []interface{}:
+				return json.Marshal(value)
+			}
+		}
+		return nil, fmt.Errorf("data is not a valid json or yaml document")
+	}
+	return json.Marshal(data)
+}
+
+// toGeneric converts data to generic json values like map[string]interface{}, []interface{}
+// numbers are decoded as json.Number to preserve precision
+func toGeneric(data interface{}) (interface{}, error) {
+	contents, err := toJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+	var result interface{}
+	if err = decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type jsonFormatter struct{}
+
+// Format prints data as indented json
+func (jsonFormatter) Format(w io.Writer, data interface{}) error {
+	contents, err := toJSON(data)
+	if err != nil {
+		return err
+	}
+	var formatted bytes.Buffer
+	if err = json.Indent(&formatted, contents, "", "  "); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, formatted.String())
+	return err
+}
+
+type jsonLinesFormatter struct{}
+
+// Format prints every item of list as compact json in separate line, if data is not a list,
+// it is printed as single line
+func (jsonLinesFormatter) Format(w io.Writer, data interface{}) error {
+	items, err := toItems(data)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		contents, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintln(w, string(contents)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testProfile struct {
+	Name     string   `json:"name"`
+	Endpoint string   `json:"endpoint"`
+	Retry    int      `json:"retry"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+func getTestData() []testProfile {
+	return []testProfile{
+		{
+			Name:     "default",
+			Endpoint: "http://localhost:9200",
+			Retry:    3,
+		},
+		{
+			Name:     "dev",
+			Endpoint: "https://localhost:9200",
+			Retry:    1,
+			Tags:     []string{"a", "b"},
+		},
+	}
+}
+
+func format(t *testing.T, name string, data interface{}) string {
+	f, err := New(name)
+	assert.NoError(t, err)
+	var b bytes.Buffer
+	assert.NoError(t, f.Format(&b, data))
+	return b.String()
+}
+
+func TestNew(t *testing.T) {
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := New("xml")
+		assert.Error(t, err)
+	})
+	t.Run("format is case insensitive", func(t *testing.T) {
+		_, err := New("JSON")
+		assert.NoError(t, err)
+	})
+	t.Run("empty jsonpath", func(t *testing.T) {
+		_, err := New("jsonpath=")
+		assert.Error(t, err)
+	})
+	t.Run("invalid jsonpath", func(t *testing.T) {
+		_, err := New("jsonpath={name}")
+		assert.Error(t, err)
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := New("go-template={{.name")
+		assert.Error(t, err)
+	})
+}
+
+func TestJSONFormatter(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		expected := "{\n  \"name\": \"dev\",\n  \"endpoint\": \"https://localhost:9200\",\n  \"retry\": 1,\n  \"tags\": [\n    \"a\",\n    \"b\"\n  ]\n}\n"
+		assert.EqualValues(t, expected, format(t, JSON, getTestData()[1]))
+	})
+	t.Run("raw json", func(t *testing.T) {
+		assert.EqualValues(t, "{\n  \"a\": 1\n}\n", format(t, JSON, []byte(`{"a":1}`)))
+	})
+	t.Run("raw yaml", func(t *testing.T) {
+		assert.EqualValues(t, "{\n  \"cluster_name\": \"docker-cluster\",\n  \"number_of_nodes\": 2\n}\n",
+			format(t, JSON, []byte("---\ncluster_name: \"docker-cluster\"\nnumber_of_nodes: 2\n")))
+	})
+	t.Run("invalid raw json", func(t *testing.T) {
+		f, err := New(JSON)
+		assert.NoError(t, err)
+		assert.Error(t, f.Format(&bytes.Buffer{}, []byte(`green`)))
+	})
+}
+
+func TestYAMLFormatter(t *testing.T) {
+	t.Run("keeps field order", func(t *testing.T) {
+		expected := `- name: default
+  endpoint: http://localhost:9200
+  retry: 3
+- name: dev
+  endpoint: https://localhost:9200
+  retry: 1
+  tags:
+    - a
+    - b
+`
+		assert.EqualValues(t, expected, format(t, YAML, getTestData()))
+	})
+	t.Run("quotes string which looks like number", func(t *testing.T) {
+		assert.EqualValues(t, "a: \"1\"\n", format(t, YAML, json.RawMessage(`{"a":"1"}`)))
+	})
+}
+
+func TestTableFormatter(t *testing.T) {
+	t.Run("list of objects", func(t *testing.T) {
+		expected := "name      endpoint                 retry   tags\n" +
+			"----      --------                 -----   ----\n" +
+			"default   http://localhost:9200    3       \n" +
+			"dev       https://localhost:9200   1       [\"a\",\"b\"]\n"
+		assert.EqualValues(t, expected, format(t, Table, getTestData()))
+	})
+	t.Run("single object", func(t *testing.T) {
+		expected := "a   b\n-   -\n1   {\"c\":true}\n"
+		assert.EqualValues(t, expected, format(t, Table, []byte(`{"a":1,"b":{"c":true}}`)))
+	})
+	t.Run("list of values", func(t *testing.T) {
+		expected := "value\n-----\nx\ny\n"
+		assert.EqualValues(t, expected, format(t, Table, []string{"x", "y"}))
+	})
+}
+
+type tabularData struct{}
+
+func (tabularData) Header() []string {
+	return []string{"node", "hits"}
+}
+
+func (tabularData) Rows() [][]string {
+	return [][]string{{"node1", "10"}}
+}
+
+func TestCSVFormatter(t *testing.T) {
+	t.Run("list of objects", func(t *testing.T) {
+		expected := "name,endpoint,retry,tags\ndefault,http://localhost:9200,3,\ndev,https://localhost:9200,1,\"[\"\"a\"\",\"\"b\"\"]\"\n"
+		assert.EqualValues(t, expected, format(t, CSV, getTestData()))
+	})
+	t.Run("tabular data", func(t *testing.T) {
+		assert.EqualValues(t, "node,hits\nnode1,10\n", format(t, CSV, tabularData{}))
+	})
+}
+
+func TestJSONPathFormatter(t *testing.T) {
+	t.Run("wildcard", func(t *testing.T) {
+		assert.EqualValues(t, "default dev\n", format(t, "jsonpath={[*].name}", getTestData()))
+	})
+	t.Run("index and literal", func(t *testing.T) {
+		assert.EqualValues(t, "name: dev\ttag: b\n", format(t, `jsonpath=name: {.[1].name}{"\t"}tag: {.[1].tags[-1]}`, getTestData()))
+	})
+	t.Run("nested value", func(t *testing.T) {
+		assert.EqualValues(t, "[\"a\",\"b\"]\n", format(t, "jsonpath={$.tags}", getTestData()[1]))
+	})
+	t.Run("missing field", func(t *testing.T) {
+		assert.EqualValues(t, "\n", format(t, "jsonpath={.unknown}", getTestData()[1]))
+	})
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	t.Run("range", func(t *testing.T) {
+		assert.EqualValues(t, "default=3,dev=1,\n", format(t, "go-template={{range .}}{{.name}}={{.retry}},{{end}}", getTestData()))
+	})
+	t.Run("json function", func(t *testing.T) {
+		assert.EqualValues(t, "[\"a\",\"b\"]\n", format(t, "go-template={{json .tags}}", getTestData()[1]))
+	})
+}
+
+func TestJSONLinesFormatter(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		expected := "{\"name\":\"default\",\"endpoint\":\"http://localhost:9200\",\"retry\":3}\n" +
+			"{\"name\":\"dev\",\"endpoint\":\"https://localhost:9200\",\"retry\":1,\"tags\":[\"a\",\"b\"]}\n"
+		assert.EqualValues(t, expected, format(t, JSONLines, getTestData()))
+	})
+	t.Run("single object", func(t *testing.T) {
+		assert.EqualValues(t, "{\"b\":1,\"a\":2}\n", format(t, JSONLines, []byte(`{"b": 1, "a": 2}`)))
+	})
+}
+
+func TestStreamWriter(t *testing.T) {
+	data := getTestData()
+	stream := func(t *testing.T, name string) string {
+		var b bytes.Buffer
+		s, err := NewStreamWriter(name, &b)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Write(data[:1]))
+		assert.NoError(t, s.Write(data[1:]))
+		assert.NoError(t, s.Flush())
+		return b.String()
+	}
+	t.Run("csv header is written once", func(t *testing.T) {
+		expected := "name,endpoint,retry\ndefault,http://localhost:9200,3\ndev,https://localhost:9200,1\n"
+		assert.EqualValues(t, expected, stream(t, CSV))
+	})
+	t.Run("jsonl", func(t *testing.T) {
+		assert.EqualValues(t, format(t, JSONLines, data), stream(t, JSONLines))
+	})
+	t.Run("buffered formats are rendered as single list", func(t *testing.T) {
+		assert.EqualValues(t, format(t, YAML, data), stream(t, YAML))
+		assert.EqualValues(t, format(t, Table, data), stream(t, Table))
+	})
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := NewStreamWriter("xml", &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
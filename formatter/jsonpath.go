@@ -0,0 +1,168 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const wildcard = "*"
+
+// segment is either literal text or path expression of jsonpath template
+type segment struct {
+	text string
+	path []string
+}
+
+type jsonPathFormatter struct {
+	segments []segment
+}
+
+// newJSONPathFormatter parses expression like "{.name}{"\n"}" or "id: {.items[*].id}"
+// Expression inside braces supports field (.name), index ([0]) and wildcard ([*]) selectors.
+func newJSONPathFormatter(expression string) (Formatter, error) {
+	if len(strings.TrimSpace(expression)) < 1 {
+		return nil, fmt.Errorf("jsonpath expression cannot be empty. Example: %s={.name}", JSONPath)
+	}
+	var segments []segment
+	for len(expression) > 0 {
+		start := strings.Index(expression, "{")
+		if start < 0 {
+			segments = append(segments, segment{text: expression})
+			break
+		}
+		end := strings.Index(expression[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid jsonpath expression: unclosed brace in %s", expression)
+		}
+		if start > 0 {
+			segments = append(segments, segment{text: expression[:start]})
+		}
+		s, err := parseSegment(strings.TrimSpace(expression[start+1 : start+end]))
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, *s)
+		expression = expression[start+end+1:]
+	}
+	return jsonPathFormatter{segments: segments}, nil
+}
+
+// parseSegment parses either quoted string literal or path
+func parseSegment(expression string) (*segment, error) {
+	if strings.HasPrefix(expression, `"`) {
+		text, err := strconv.Unquote(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jsonpath literal %s due to %v", expression, err)
+		}
+		return &segment{text: text}, nil
+	}
+	expression = strings.TrimPrefix(expression, "$")
+	if !strings.HasPrefix(expression, ".") && !strings.HasPrefix(expression, "[") {
+		return nil, fmt.Errorf("invalid jsonpath expression %s, expression should start with '.'", expression)
+	}
+	path := []string{}
+	for _, field := range strings.Split(strings.ReplaceAll(expression, "[", ".["), ".") {
+		if len(field) < 1 {
+			continue
+		}
+		if strings.HasPrefix(field, "[") {
+			if !strings.HasSuffix(field, "]") {
+				return nil, fmt.Errorf("invalid jsonpath expression %s, missing ']'", expression)
+			}
+			field = field[1 : len(field)-1]
+			if _, err := strconv.Atoi(field); err != nil && field != wildcard {
+				return nil, fmt.Errorf("invalid index %s in jsonpath expression %s", field, expression)
+			}
+		}
+		path = append(path, field)
+	}
+	return &segment{path: path}, nil
+}
+
+// Format prints values selected by jsonpath expression, multiple values are separated by space
+func (f jsonPathFormatter) Format(w io.Writer, data interface{}) error {
+	value, err := toGeneric(data)
+	if err != nil {
+		return err
+	}
+	var output strings.Builder
+	for _, s := range f.segments {
+		if s.path == nil {
+			output.WriteString(s.text)
+			continue
+		}
+		results, err := lookup([]interface{}{value}, s.path)
+		if err != nil {
+			return err
+		}
+		var texts []string
+		for _, r := range results {
+			text, err := toCell(r)
+			if err != nil {
+				return err
+			}
+			texts = append(texts, text)
+		}
+		output.WriteString(strings.Join(texts, " "))
+	}
+	_, err = fmt.Fprintln(w, output.String())
+	return err
+}
+
+// lookup walks through path for every value and returns matched values
+func lookup(values []interface{}, path []string) ([]interface{}, error) {
+	if len(path) < 1 {
+		return values, nil
+	}
+	field := path[0]
+	var result []interface{}
+	for _, value := range values {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if field == wildcard {
+				keys := make([]string, 0, len(v))
+				for key := range v {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					result = append(result, v[key])
+				}
+				continue
+			}
+			if child, ok := v[field]; ok {
+				result = append(result, child)
+			}
+		case []interface{}:
+			if field == wildcard {
+				result = append(result, v...)
+				continue
+			}
+			index, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("field %s cannot be applied on list", field)
+			}
+			if index < 0 {
+				index += len(v)
+			}
+			if index >= 0 && index < len(v) {
+				result = append(result, v[index])
+			}
+		}
+	}
+	return lookup(result, path[1:])
+}
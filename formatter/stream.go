@@ -0,0 +1,140 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamWriter renders list of items page by page. Formats which can be streamed like
+// jsonl and csv are written as soon as page is received, other formats are written on Flush.
+type StreamWriter interface {
+	Write(page interface{}) error
+	Flush() error
+}
+
+// NewStreamWriter returns StreamWriter for given format
+func NewStreamWriter(format string, w io.Writer) (StreamWriter, error) {
+	f, err := New(format)
+	if err != nil {
+		return nil, err
+	}
+	switch f.(type) {
+	case jsonLinesFormatter:
+		return &jsonLinesStreamWriter{w: w}, nil
+	case csvFormatter:
+		return &csvStreamWriter{writer: csv.NewWriter(w)}, nil
+	}
+	return &bufferedStreamWriter{w: w, formatter: f, items: []interface{}{}}, nil
+}
+
+// toItems converts page into list of json values
+func toItems(page interface{}) ([]interface{}, error) {
+	contents, err := toJSON(page)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if items, ok := value.([]interface{}); ok {
+		return items, nil
+	}
+	return []interface{}{value}, nil
+}
+
+type jsonLinesStreamWriter struct {
+	w io.Writer
+}
+
+// Write prints every item of page as compact json in separate line
+func (s *jsonLinesStreamWriter) Write(page interface{}) error {
+	return jsonLinesFormatter{}.Format(s.w, page)
+}
+
+// Flush is no-op since items are written immediately
+func (s *jsonLinesStreamWriter) Flush() error {
+	return nil
+}
+
+type csvStreamWriter struct {
+	writer *csv.Writer
+	header []string
+}
+
+// Write prints rows of page, header is derived from first page and written only once
+func (s *csvStreamWriter) Write(page interface{}) error {
+	header, rows, err := toRows(page)
+	if err != nil {
+		return err
+	}
+	if s.header == nil {
+		s.header = header
+		if err = s.writer.Write(header); err != nil {
+			return err
+		}
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[name] = i
+	}
+	for _, row := range rows {
+		record := make([]string, len(s.header))
+		for i, name := range s.header {
+			if j, ok := index[name]; ok {
+				record[i] = row[j]
+			}
+		}
+		if err = s.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+// Flush flushes buffered records
+func (s *csvStreamWriter) Flush() error {
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+type bufferedStreamWriter struct {
+	w         io.Writer
+	formatter Formatter
+	items     []interface{}
+}
+
+// Write collects items of page
+func (s *bufferedStreamWriter) Write(page interface{}) error {
+	items, err := toItems(page)
+	if err != nil {
+		return err
+	}
+	s.items = append(s.items, items...)
+	return nil
+}
+
+// Flush renders collected items as list
+func (s *bufferedStreamWriter) Flush() error {
+	if s.formatter == nil {
+		return fmt.Errorf("formatter is not initialized")
+	}
+	return s.formatter.Format(s.w, s.items)
+}
@@ -0,0 +1,227 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	padding     = 3
+	alignLeft   = 0
+	valueColumn = "value"
+)
+
+// Tabular can be implemented by data to control header and rows displayed by table and csv formats.
+// If data doesn't implement Tabular, every object is displayed as row and its fields as columns.
+type Tabular interface {
+	Header() []string
+	Rows() [][]string
+}
+
+type tableFormatter struct{}
+
+// Format prints data as table, as below
+/*
+name       endpoint
+----       --------
+default    https://localhost:9200
+*/
+func (tableFormatter) Format(w io.Writer, data interface{}) error {
+	header, rows, err := toRows(data)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', alignLeft)
+	underline := make([]string, len(header))
+	for i, name := range header {
+		underline[i] = strings.Repeat("-", len(name))
+	}
+	for _, row := range append([][]string{header, underline}, rows...) {
+		if _, err = fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+type csvFormatter struct{}
+
+// Format prints data as comma separated values with header as first record
+func (csvFormatter) Format(w io.Writer, data interface{}) error {
+	header, rows, err := toRows(data)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err = writer.Write(header); err != nil {
+		return err
+	}
+	if err = writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// toRows converts data into header and rows. If data is list of objects, every object is a row
+// and union of fields are columns. If data is an object, it is displayed as single row.
+func toRows(data interface{}) ([]string, [][]string, error) {
+	if t, ok := data.(Tabular); ok {
+		return t.Header(), t.Rows(), nil
+	}
+	contents, err := toJSON(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, nil, err
+	}
+	var items []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		items = v
+	default:
+		items = []interface{}{v}
+	}
+	var header []string
+	columns := map[string]bool{}
+	for _, item := range items {
+		object, ok := item.(*orderedObject)
+		if !ok {
+			object = &orderedObject{keys: []string{valueColumn}, values: map[string]interface{}{valueColumn: item}}
+		}
+		for _, key := range object.keys {
+			if !columns[key] {
+				columns[key] = true
+				header = append(header, key)
+			}
+		}
+	}
+	var rows [][]string
+	for _, item := range items {
+		object, ok := item.(*orderedObject)
+		if !ok {
+			object = &orderedObject{values: map[string]interface{}{valueColumn: item}}
+		}
+		row := make([]string, len(header))
+		for i, key := range header {
+			if row[i], err = toCell(object.values[key]); err != nil {
+				return nil, nil, err
+			}
+		}
+		rows = append(rows, row)
+	}
+	return header, rows, nil
+}
+
+// toCell converts value into text, nested values are displayed as compact json
+func toCell(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprintf("%t", v), nil
+	}
+	contents, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(contents), nil
+}
+
+// orderedObject represents json object which remembers order of its keys
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON marshals object by keeping order of keys
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteString(":")
+		b.Write(v)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// decodeOrdered decodes next json value from decoder, objects are decoded as *orderedObject
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	delimiter, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+	switch delimiter {
+	case '{':
+		object := &orderedObject{values: map[string]interface{}{}}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			name := key.(string)
+			if _, exists := object.values[name]; !exists {
+				object.keys = append(object.keys, name)
+			}
+			object.values[name] = value
+		}
+		_, err = decoder.Token() // consume '}'
+		return object, err
+	case '[':
+		values := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		_, err = decoder.Token() // consume ']'
+		return values, err
+	}
+	return nil, fmt.Errorf("unexpected delimiter %v", delimiter)
+}
@@ -0,0 +1,55 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+type templateFormatter struct {
+	template *template.Template
+}
+
+// newTemplateFormatter parses go template. Data is converted to generic json values,
+// hence fields are accessed by json names, for ex: {{.name}}
+func newTemplateFormatter(expression string) (Formatter, error) {
+	if len(strings.TrimSpace(expression)) < 1 {
+		return nil, fmt.Errorf("template cannot be empty. Example: %s={{.name}}", GoTemplate)
+	}
+	t, err := template.New("output").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			contents, err := json.Marshal(v)
+			return string(contents), err
+		},
+	}).Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s due to %v", expression, err)
+	}
+	return templateFormatter{template: t}, nil
+}
+
+// Format executes template against data
+func (f templateFormatter) Format(w io.Writer, data interface{}) error {
+	value, err := toGeneric(data)
+	if err != nil {
+		return err
+	}
+	if err = f.template.Execute(w, value); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w)
+	return err
+}
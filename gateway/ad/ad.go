@@ -23,13 +23,20 @@ import (
 )
 
 const (
-	baseURL           = "_plugins/_anomaly_detection/detectors"
-	startURLTemplate  = baseURL + "/%s/" + "_start"
-	stopURLTemplate   = baseURL + "/%s/" + "_stop"
-	searchURLTemplate = baseURL + "/_search"
-	deleteURLTemplate = baseURL + "/%s"
-	getURLTemplate    = baseURL + "/%s"
-	updateURLTemplate = baseURL + "/%s"
+	baseURL             = "_plugins/_anomaly_detection/detectors"
+	startURLTemplate    = baseURL + "/%s/" + "_start"
+	stopURLTemplate     = baseURL + "/%s/" + "_stop"
+	searchURLTemplate   = baseURL + "/_search"
+	deleteURLTemplate   = baseURL + "/%s"
+	getURLTemplate      = baseURL + "/%s"
+	updateURLTemplate   = baseURL + "/%s"
+	profileURLTemplate  = baseURL + "/%s/" + "_profile"
+	profileAllQuery     = "_all=true"
+	resultsURLTemplate  = baseURL + "/results/_search"
+	historicalQuery     = "historical=true"
+	taskQuery           = "task=true"
+	previewURLTemplate  = baseURL + "/_preview"
+	validateURLTemplate = baseURL + "/_validate/%s"
 )
 
 //go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_ad.go -package=mocks . Gateway
@@ -43,6 +50,13 @@ type Gateway interface {
 	SearchDetector(context.Context, interface{}) ([]byte, error)
 	GetDetector(context.Context, string) ([]byte, error)
 	UpdateDetector(context.Context, string, interface{}) error
+	GetDetectorProfile(context.Context, string) ([]byte, error)
+	SearchAnomalyResults(context.Context, interface{}) ([]byte, error)
+	StartHistoricalAnalysis(context.Context, string, interface{}) ([]byte, error)
+	StopHistoricalAnalysis(context.Context, string) error
+	GetDetectorTask(context.Context, string) ([]byte, error)
+	PreviewDetector(context.Context, interface{}) ([]byte, error)
+	ValidateDetector(context.Context, string, interface{}) ([]byte, error)
 }
 
 type gateway struct {
@@ -350,3 +364,282 @@ func (g *gateway) UpdateDetector(ctx context.Context, ID string, payload interfa
 	}
 	return nil
 }
+
+func (g *gateway) buildProfileURL(ID string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(profileURLTemplate, ID)
+	endpoint.RawQuery = profileAllQuery
+	return endpoint, nil
+}
+
+/*GetDetectorProfile Returns information related to the current state of the detector and memory usage,
+including current errors and shingle size, to help troubleshoot the detector.
+It calls http request: GET _plugins/_anomaly_detection/detectors/<detectorId>/_profile?_all=true
+Sample Output:
+{
+  "state": "INIT",
+  "error": "",
+  "init_progress": {
+    "percentage": "70%",
+    "estimated_minutes_left": 77,
+    "needed_shingles": 77
+  },
+  "coordinating_node": "2Bmzo0vSRHm1RAeDKMI8bA",
+  "models": [
+    {
+      "model_id": "detectorId_model_rcf_0",
+      "model_size_in_bytes": 1608616,
+      "node_id": "2Bmzo0vSRHm1RAeDKMI8bA"
+    }
+  ],
+  "total_size_in_bytes": 1608616
+}*/
+func (g *gateway) GetDetectorProfile(ctx context.Context, ID string) ([]byte, error) {
+	profileURL, err := g.buildProfileURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	profileRequest, err := g.BuildRequest(ctx, http.MethodGet, nil, profileURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(profileRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (g *gateway) buildResultsURL() (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = resultsURLTemplate
+	return endpoint, nil
+}
+
+/*SearchAnomalyResults Returns anomaly results for a search query.
+It calls http request: POST _plugins/_anomaly_detection/detectors/results/_search
+Sample Input:
+{
+  "query": {
+    "bool": {
+      "filter": [
+        { "terms": { "detector_id": ["detectorId"] } },
+        { "range": { "anomaly_grade": { "gt": 0 } } }
+      ]
+    }
+  },
+  "sort": [{ "data_end_time": "desc" }],
+  "size": 100
+}*/
+func (g *gateway) SearchAnomalyResults(ctx context.Context, payload interface{}) ([]byte, error) {
+	resultsURL, err := g.buildResultsURL()
+	if err != nil {
+		return nil, err
+	}
+	searchRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, resultsURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(searchRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+/*StartHistoricalAnalysis Starts historical analysis of an anomaly detector for given date range.
+It calls http request: POST _plugins/_anomaly_detection/detectors/<detectorId>/_start
+Sample Input:
+{
+  "start_time": 1503168590000,
+  "end_time": 1617301324000
+}
+Sample Output:
+{
+  "_id": "f9DsTXgB8lQm4F1pPsEA",
+  "_version": 0,
+  "_seq_no": 0,
+  "_primary_term": 0
+}*/
+func (g *gateway) StartHistoricalAnalysis(ctx context.Context, ID string, payload interface{}) ([]byte, error) {
+	startURL, err := g.buildStartURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	startRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, startURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(startRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (g *gateway) buildStopHistoricalURL(ID string) (*url.URL, error) {
+	endpoint, err := g.buildStopURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.RawQuery = historicalQuery
+	return endpoint, nil
+}
+
+// StopHistoricalAnalysis Stops historical analysis of an anomaly detector.
+// It calls http request: POST _plugins/_anomaly_detection/detectors/<detectorId>/_stop?historical=true
+func (g *gateway) StopHistoricalAnalysis(ctx context.Context, ID string) error {
+	stopURL, err := g.buildStopHistoricalURL(ID)
+	if err != nil {
+		return err
+	}
+	stopRequest, err := g.BuildRequest(ctx, http.MethodPost, nil, stopURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return err
+	}
+	_, err = g.Call(stopRequest, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (g *gateway) buildTaskURL(ID string) (*url.URL, error) {
+	endpoint, err := g.buildGetURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.RawQuery = taskQuery
+	return endpoint, nil
+}
+
+/*GetDetectorTask Returns detector configuration along with its latest historical analysis task.
+It calls http request: GET _plugins/_anomaly_detection/detectors/<detectorId>?task=true
+Sample Output:
+{
+  "_id": "detectorId",
+  "anomaly_detector": { ... },
+  "historical_analysis_task": {
+    "task_id": "f9DsTXgB8lQm4F1pPsEA",
+    "state": "RUNNING",
+    "task_progress": 0.45,
+    "init_progress": 1.0,
+    "error": "",
+    "detection_date_range": {
+      "start_time": 1503168590000,
+      "end_time": 1617301324000
+    }
+  }
+}*/
+func (g *gateway) GetDetectorTask(ctx context.Context, ID string) ([]byte, error) {
+	taskURL, err := g.buildTaskURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	taskRequest, err := g.BuildRequest(ctx, http.MethodGet, nil, taskURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(taskRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (g *gateway) buildPreviewURL() (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = previewURLTemplate
+	return endpoint, nil
+}
+
+/*PreviewDetector Returns sample anomaly results of a detector for the period without creating it.
+Either detector configuration or id of existing detector is accepted.
+It calls http request: POST _plugins/_anomaly_detection/detectors/_preview
+Sample Input:
+{
+  "period_start": 1612982516000,
+  "period_end": 1614278539000,
+  "detector_id": "VEHKTXwBwf_U8gjUXY2s"
+}
+Sample Output:
+{
+  "anomaly_result": [
+    {
+      "detector_id": "VEHKTXwBwf_U8gjUXY2s",
+      "data_start_time": 1613586955000,
+      "data_end_time": 1613587015000,
+      "feature_data": [
+        {
+          "feature_id": "test-feature",
+          "feature_name": "test",
+          "data": 1
+        }
+      ]
+    }
+  ],
+  "anomaly_detector": { ... }
+}*/
+func (g *gateway) PreviewDetector(ctx context.Context, payload interface{}) ([]byte, error) {
+	previewURL, err := g.buildPreviewURL()
+	if err != nil {
+		return nil, err
+	}
+	previewRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, previewURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(previewRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (g *gateway) buildValidateURL(validationType string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(validateURLTemplate, validationType)
+	return endpoint, nil
+}
+
+/*ValidateDetector Validates detector configuration without creating it, validationType is either detector or model.
+It calls http request: POST _plugins/_anomaly_detection/detectors/_validate/<validationType>
+Sample Input is same as CreateDetector
+Sample Output:
+{
+  "detector": {
+    "feature_attributes": {
+      "message": "Feature has invalid query returning empty aggregated data: average_total_rev",
+      "sub_issues": {
+        "average_total_rev": "Feature has invalid query returning empty aggregated data"
+      }
+    }
+  }
+}*/
+func (g *gateway) ValidateDetector(ctx context.Context, validationType string, payload interface{}) ([]byte, error) {
+	validateURL, err := g.buildValidateURL(validationType)
+	if err != nil {
+		return nil, err
+	}
+	validateRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, validateURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(validateRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -170,7 +170,7 @@ func TestGateway_SearchDetector(t *testing.T) {
 		assert.NoError(t, err)
 		response, err := testGateway.SearchDetector(ctx, ad.SearchRequest{
 			Query: ad.SearchQuery{
-				Match: ad.Match{
+				Match: &ad.Match{
 					Name: "detector-name",
 				},
 			}})
@@ -188,7 +188,7 @@ func TestGateway_SearchDetector(t *testing.T) {
 		assert.NoError(t, err)
 		_, err = testGateway.SearchDetector(ctx, ad.SearchRequest{
 			Query: ad.SearchQuery{
-				Match: ad.Match{
+				Match: &ad.Match{
 					Name: "detector-name",
 				},
 			}})
@@ -359,3 +359,231 @@ func TestGateway_UpdateDetector(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestGateway_GetDetectorProfile(t *testing.T) {
+	ctx := context.Background()
+	t.Run("connection failed", func(t *testing.T) {
+		testClient := getTestClient(t, `connection failed`, 400, http.MethodGet, "/_profile?_all=true")
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.GetDetectorProfile(ctx, "id")
+		assert.EqualError(t, err, "connection failed")
+	})
+	t.Run("get profile success", func(t *testing.T) {
+		testClient := getTestClient(t, string(helperLoadBytes(t, "profile_result.json")), 200, http.MethodGet, "/_profile?_all=true")
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		resp, err := testGateway.GetDetectorProfile(ctx, "id")
+		assert.NoError(t, err)
+		assert.EqualValues(t, helperLoadBytes(t, "profile_result.json"), resp)
+	})
+}
+
+func TestGateway_SearchAnomalyResults(t *testing.T) {
+	ctx := context.Background()
+	getResultsClient := func(t *testing.T, response []byte, code int) *client.Client {
+		return mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, req.URL.String(), "http://localhost:9200/_plugins/_anomaly_detection/detectors/results/_search")
+			assert.EqualValues(t, req.Method, http.MethodPost)
+			body, _ := io.ReadAll(req.Body)
+			assert.JSONEq(t, `{"size":10}`, string(body))
+			return &http.Response{
+				StatusCode: code,
+				Body:       io.NopCloser(bytes.NewBuffer(response)),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+	}
+	t.Run("search failed", func(t *testing.T) {
+		testGateway, err := New(getResultsClient(t, []byte("connection failed"), 400), &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.SearchAnomalyResults(ctx, map[string]int{"size": 10})
+		assert.EqualError(t, err, "connection failed")
+	})
+	t.Run("search succeeded", func(t *testing.T) {
+		responseData := helperLoadBytes(t, "results_result.json")
+		testGateway, err := New(getResultsClient(t, responseData, 200), &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.SearchAnomalyResults(ctx, map[string]int{"size": 10})
+		assert.NoError(t, err)
+		assert.EqualValues(t, responseData, response)
+	})
+}
+
+func TestGateway_StartHistoricalAnalysis(t *testing.T) {
+	ctx := context.Background()
+	t.Run("connection failed", func(t *testing.T) {
+		testClient := getTestClient(t, `connection failed`, 400, http.MethodPost, "/_start")
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.StartHistoricalAnalysis(ctx, "id", ad.HistoricalAnalysisRequest{StartTime: 1, EndTime: 2})
+		assert.EqualError(t, err, "connection failed")
+	})
+	t.Run("start historical analysis succeeded", func(t *testing.T) {
+		testClient := getTestClient(t, `{"_id": "taskID"}`, 200, http.MethodPost, "/_start")
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.StartHistoricalAnalysis(ctx, "id", ad.HistoricalAnalysisRequest{StartTime: 1, EndTime: 2})
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"_id": "taskID"}`, string(response))
+	})
+}
+
+func TestGateway_StopHistoricalAnalysis(t *testing.T) {
+	ctx := context.Background()
+	t.Run("connection failed", func(t *testing.T) {
+		testClient := getTestClient(t, `connection failed`, 400, http.MethodPost, "/_stop?historical=true")
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		err = testGateway.StopHistoricalAnalysis(ctx, "id")
+		assert.EqualError(t, err, "connection failed")
+	})
+	t.Run("stop historical analysis succeeded", func(t *testing.T) {
+		testClient := getTestClient(t, `Stopped detector: id`, 200, http.MethodPost, "/_stop?historical=true")
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		err = testGateway.StopHistoricalAnalysis(ctx, "id")
+		assert.NoError(t, err)
+	})
+}
+
+func TestGateway_GetDetectorTask(t *testing.T) {
+	ctx := context.Background()
+	t.Run("connection failed", func(t *testing.T) {
+		testClient := getTestClient(t, `connection failed`, 400, http.MethodGet, "?task=true")
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.GetDetectorTask(ctx, "id")
+		assert.EqualError(t, err, "connection failed")
+	})
+	t.Run("get task succeeded", func(t *testing.T) {
+		testClient := getTestClient(t, string(helperLoadBytes(t, "task_result.json")), 200, http.MethodGet, "?task=true")
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.GetDetectorTask(ctx, "id")
+		assert.NoError(t, err)
+		assert.EqualValues(t, helperLoadBytes(t, "task_result.json"), response)
+	})
+}
+
+func TestGateway_PreviewDetector(t *testing.T) {
+	ctx := context.Background()
+	getPreviewClient := func(t *testing.T, response []byte, code int) *client.Client {
+		return mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, req.URL.String(), "http://localhost:9200/_plugins/_anomaly_detection/detectors/_preview")
+			assert.EqualValues(t, req.Method, http.MethodPost)
+			body, _ := io.ReadAll(req.Body)
+			assert.JSONEq(t, `{"period_start":1,"period_end":2,"detector_id":"id"}`, string(body))
+			return &http.Response{
+				StatusCode: code,
+				Body:       io.NopCloser(bytes.NewBuffer(response)),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+	}
+	request := ad.PreviewRequest{PeriodStart: 1, PeriodEnd: 2, DetectorID: "id"}
+	t.Run("preview failed", func(t *testing.T) {
+		testGateway, err := New(getPreviewClient(t, []byte("connection failed"), 400), &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.PreviewDetector(ctx, request)
+		assert.EqualError(t, err, "connection failed")
+	})
+	t.Run("preview succeeded", func(t *testing.T) {
+		responseData := helperLoadBytes(t, "preview_result.json")
+		testGateway, err := New(getPreviewClient(t, responseData, 200), &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.PreviewDetector(ctx, request)
+		assert.NoError(t, err)
+		assert.EqualValues(t, responseData, response)
+	})
+}
+
+func TestGateway_ValidateDetector(t *testing.T) {
+	ctx := context.Background()
+	getValidateClient := func(t *testing.T, response []byte, code int) *client.Client {
+		return mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, req.URL.String(), "http://localhost:9200/_plugins/_anomaly_detection/detectors/_validate/model")
+			assert.EqualValues(t, req.Method, http.MethodPost)
+			body, _ := io.ReadAll(req.Body)
+			assert.JSONEq(t, `{"name":"detector"}`, string(body))
+			return &http.Response{
+				StatusCode: code,
+				Body:       io.NopCloser(bytes.NewBuffer(response)),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+	}
+	t.Run("validate failed", func(t *testing.T) {
+		testGateway, err := New(getValidateClient(t, []byte("connection failed"), 400), &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.ValidateDetector(ctx, "model", map[string]string{"name": "detector"})
+		assert.EqualError(t, err, "connection failed")
+	})
+	t.Run("validate succeeded", func(t *testing.T) {
+		responseData := helperLoadBytes(t, "validate_result.json")
+		testGateway, err := New(getValidateClient(t, responseData, 200), &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ValidateDetector(ctx, "model", map[string]string{"name": "detector"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, responseData, response)
+	})
+}
@@ -78,6 +78,66 @@ func (mr *MockGatewayMockRecorder) GetDetector(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetector", reflect.TypeOf((*MockGateway)(nil).GetDetector), arg0, arg1)
 }
 
+// GetDetectorProfile mocks base method
+func (m *MockGateway) GetDetectorProfile(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetectorProfile", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetectorProfile indicates an expected call of GetDetectorProfile
+func (mr *MockGatewayMockRecorder) GetDetectorProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetectorProfile", reflect.TypeOf((*MockGateway)(nil).GetDetectorProfile), arg0, arg1)
+}
+
+// GetDetectorTask mocks base method
+func (m *MockGateway) GetDetectorTask(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetectorTask", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetectorTask indicates an expected call of GetDetectorTask
+func (mr *MockGatewayMockRecorder) GetDetectorTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetectorTask", reflect.TypeOf((*MockGateway)(nil).GetDetectorTask), arg0, arg1)
+}
+
+// PreviewDetector mocks base method
+func (m *MockGateway) PreviewDetector(arg0 context.Context, arg1 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewDetector", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewDetector indicates an expected call of PreviewDetector
+func (mr *MockGatewayMockRecorder) PreviewDetector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewDetector", reflect.TypeOf((*MockGateway)(nil).PreviewDetector), arg0, arg1)
+}
+
+// SearchAnomalyResults mocks base method
+func (m *MockGateway) SearchAnomalyResults(arg0 context.Context, arg1 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAnomalyResults", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAnomalyResults indicates an expected call of SearchAnomalyResults
+func (mr *MockGatewayMockRecorder) SearchAnomalyResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAnomalyResults", reflect.TypeOf((*MockGateway)(nil).SearchAnomalyResults), arg0, arg1)
+}
+
 // SearchDetector mocks base method
 func (m *MockGateway) SearchDetector(arg0 context.Context, arg1 interface{}) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +167,21 @@ func (mr *MockGatewayMockRecorder) StartDetector(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartDetector", reflect.TypeOf((*MockGateway)(nil).StartDetector), arg0, arg1)
 }
 
+// StartHistoricalAnalysis mocks base method
+func (m *MockGateway) StartHistoricalAnalysis(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartHistoricalAnalysis", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartHistoricalAnalysis indicates an expected call of StartHistoricalAnalysis
+func (mr *MockGatewayMockRecorder) StartHistoricalAnalysis(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartHistoricalAnalysis", reflect.TypeOf((*MockGateway)(nil).StartHistoricalAnalysis), arg0, arg1, arg2)
+}
+
 // StopDetector mocks base method
 func (m *MockGateway) StopDetector(arg0 context.Context, arg1 string) (*string, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +197,20 @@ func (mr *MockGatewayMockRecorder) StopDetector(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopDetector", reflect.TypeOf((*MockGateway)(nil).StopDetector), arg0, arg1)
 }
 
+// StopHistoricalAnalysis mocks base method
+func (m *MockGateway) StopHistoricalAnalysis(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopHistoricalAnalysis", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopHistoricalAnalysis indicates an expected call of StopHistoricalAnalysis
+func (mr *MockGatewayMockRecorder) StopHistoricalAnalysis(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopHistoricalAnalysis", reflect.TypeOf((*MockGateway)(nil).StopHistoricalAnalysis), arg0, arg1)
+}
+
 // UpdateDetector mocks base method
 func (m *MockGateway) UpdateDetector(arg0 context.Context, arg1 string, arg2 interface{}) error {
 	m.ctrl.T.Helper()
@@ -135,3 +224,18 @@ func (mr *MockGatewayMockRecorder) UpdateDetector(arg0, arg1, arg2 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDetector", reflect.TypeOf((*MockGateway)(nil).UpdateDetector), arg0, arg1, arg2)
 }
+
+// ValidateDetector mocks base method
+func (m *MockGateway) ValidateDetector(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDetector", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateDetector indicates an expected call of ValidateDetector
+func (mr *MockGatewayMockRecorder) ValidateDetector(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDetector", reflect.TypeOf((*MockGateway)(nil).ValidateDetector), arg0, arg1, arg2)
+}
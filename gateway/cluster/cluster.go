@@ -0,0 +1,127 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	healthURL            = "_cluster/health"
+	catNodesURL          = "_cat/nodes"
+	catNodesQuery        = "format=json"
+	allocationExplainURL = "_cluster/allocation/explain"
+	settingsURL          = "_cluster/settings"
+	settingsQuery        = "flat_settings=true"
+	includeDefaultsQuery = "&include_defaults=true"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_cluster.go -package=mocks . Gateway
+
+// Gateway interface to cluster APIs
+type Gateway interface {
+	GetHealth(ctx context.Context) ([]byte, error)
+	GetNodes(ctx context.Context) ([]byte, error)
+	ExplainAllocation(ctx context.Context, payload interface{}) ([]byte, error)
+	GetSettings(ctx context.Context, includeDefaults bool) ([]byte, error)
+	PutSettings(ctx context.Context, payload interface{}) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildURL constructs url of path with query
+func (g *gateway) buildURL(path string, query string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	endpoint.RawQuery = query
+	return endpoint, nil
+}
+
+//call sends request to path and returns response
+func (g *gateway) call(ctx context.Context, method string, path string, query string, payload interface{}) ([]byte, error) {
+	requestURL, err := g.buildURL(path, query)
+	if err != nil {
+		return nil, err
+	}
+	request, err := g.BuildRequest(ctx, method, payload, requestURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*GetHealth returns health of cluster
+GET _cluster/health
+{
+  "cluster_name": "opensearch",
+  "status": "yellow",
+  "timed_out": false,
+  "number_of_nodes": 1,
+  "number_of_data_nodes": 1,
+  "active_primary_shards": 5,
+  "active_shards": 5,
+  "relocating_shards": 0,
+  "initializing_shards": 0,
+  "unassigned_shards": 3,
+  "delayed_unassigned_shards": 0,
+  "number_of_pending_tasks": 0,
+  "number_of_in_flight_fetch": 0,
+  "task_max_waiting_in_queue_millis": 0,
+  "active_shards_percent_as_number": 62.5
+}
+*/
+func (g *gateway) GetHealth(ctx context.Context) ([]byte, error) {
+	return g.call(ctx, http.MethodGet, healthURL, "", nil)
+}
+
+// GetNodes returns nodes of cluster with their roles and resource usage using cat nodes API
+func (g *gateway) GetNodes(ctx context.Context) ([]byte, error) {
+	return g.call(ctx, http.MethodGet, catNodesURL, catNodesQuery, nil)
+}
+
+// ExplainAllocation explains allocation of shard given by payload, first unassigned shard is explained if payload is nil
+func (g *gateway) ExplainAllocation(ctx context.Context, payload interface{}) ([]byte, error) {
+	return g.call(ctx, http.MethodGet, allocationExplainURL, "", payload)
+}
+
+// GetSettings returns persistent and transient cluster settings in flat format, optionally with defaults
+func (g *gateway) GetSettings(ctx context.Context, includeDefaults bool) ([]byte, error) {
+	query := settingsQuery
+	if includeDefaults {
+		query += includeDefaultsQuery
+	}
+	return g.call(ctx, http.MethodGet, settingsURL, query, nil)
+}
+
+// PutSettings updates persistent or transient cluster settings given by payload and returns them in flat format
+func (g *gateway) PutSettings(ctx context.Context, payload interface{}) ([]byte, error) {
+	return g.call(ctx, http.MethodPut, settingsURL, settingsQuery, payload)
+}
@@ -0,0 +1,151 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestClient(t *testing.T, method string, url string, body string, code int, response []byte) *client.Client {
+	return mocks.NewTestClient(func(req *http.Request) *http.Response {
+		// Test request parameters
+		assert.Equal(t, method, req.Method)
+		assert.Equal(t, url, req.URL.String())
+		assert.EqualValues(t, len(req.Header), 2)
+		var data []byte
+		if req.Body != nil {
+			var err error
+			data, err = io.ReadAll(req.Body)
+			assert.NoError(t, err)
+		}
+		if len(body) > 0 {
+			assert.JSONEq(t, body, string(data))
+		} else {
+			assert.Empty(t, data)
+		}
+		return &http.Response{
+			StatusCode: code,
+			// Send response to be tested
+			Body: io.NopCloser(bytes.NewBuffer(response)),
+			// Must be set to non-nil value or it panics
+			Header:  make(http.Header),
+			Status:  "SOME OUTPUT",
+			Request: req,
+		}
+	})
+}
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	testGateway, err := New(c, &entity.Profile{
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	})
+	assert.NoError(t, err)
+	return testGateway
+}
+
+func TestGatewayRequests(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		call   func(Gateway) ([]byte, error)
+	}{
+		{
+			name:   "health",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/_cluster/health",
+			call: func(g Gateway) ([]byte, error) {
+				return g.GetHealth(ctx)
+			},
+		},
+		{
+			name:   "nodes",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/_cat/nodes?format=json",
+			call: func(g Gateway) ([]byte, error) {
+				return g.GetNodes(ctx)
+			},
+		},
+		{
+			name:   "explain first unassigned shard",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/_cluster/allocation/explain",
+			call: func(g Gateway) ([]byte, error) {
+				return g.ExplainAllocation(ctx, nil)
+			},
+		},
+		{
+			name:   "explain shard",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/_cluster/allocation/explain",
+			body:   `{"index":"index1","shard":0,"primary":true}`,
+			call: func(g Gateway) ([]byte, error) {
+				return g.ExplainAllocation(ctx, map[string]interface{}{"index": "index1", "shard": 0, "primary": true})
+			},
+		},
+		{
+			name:   "settings",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/_cluster/settings?flat_settings=true",
+			call: func(g Gateway) ([]byte, error) {
+				return g.GetSettings(ctx, false)
+			},
+		},
+		{
+			name:   "settings with defaults",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/_cluster/settings?flat_settings=true&include_defaults=true",
+			call: func(g Gateway) ([]byte, error) {
+				return g.GetSettings(ctx, true)
+			},
+		},
+		{
+			name:   "put settings",
+			method: http.MethodPut,
+			url:    "http://localhost:9200/_cluster/settings?flat_settings=true",
+			body:   `{"persistent":{"cluster.routing.allocation.enable":"primaries"}}`,
+			call: func(g Gateway) ([]byte, error) {
+				return g.PutSettings(ctx, map[string]interface{}{
+					"persistent": map[string]interface{}{"cluster.routing.allocation.enable": "primaries"},
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := getTestClient(t, tt.method, tt.url, tt.body, 200, []byte("success"))
+			actual, err := tt.call(getTestGateway(t, testClient))
+			assert.NoError(t, err)
+			assert.EqualValues(t, "success", string(actual))
+		})
+	}
+	t.Run("request failed", func(t *testing.T) {
+		testClient := getTestClient(t, http.MethodGet, "http://localhost:9200/_cluster/allocation/explain", "", 400,
+			[]byte(`{"error":{"reason":"unable to find any unassigned shards to explain"}}`))
+		_, err := getTestGateway(t, testClient).ExplainAllocation(ctx, nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unable to find any unassigned shards to explain")
+	})
+}
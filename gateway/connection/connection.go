@@ -0,0 +1,151 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package connection
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	"opensearch-cli/entity/connection"
+	gw "opensearch-cli/gateway"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	authInfoURL     = "_plugins/_security/authinfo"
+	catPluginsURL   = "_cat/plugins"
+	catPluginsQuery = "format=json"
+	httpsScheme     = "https"
+	httpsPort       = "443"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_connection.go -package=mocks . Gateway
+
+// Gateway interface to APIs used to test connection to cluster
+type Gateway interface {
+	GetClusterInfo(ctx context.Context) (connection.Response, error)
+	GetAuthInfo(ctx context.Context) ([]byte, error)
+	GetPlugins(ctx context.Context) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildURL constructs url of path with query
+func (g *gateway) buildURL(path string, query string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	endpoint.RawQuery = query
+	return endpoint, nil
+}
+
+//call sends GET request to path and returns response
+func (g *gateway) call(ctx context.Context, path string, query string) ([]byte, error) {
+	requestURL, err := g.buildURL(path, query)
+	if err != nil {
+		return nil, err
+	}
+	request, err := g.BuildRequest(ctx, http.MethodGet, nil, requestURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+// GetClusterInfo calls root endpoint once without retries and measures time taken by request. If request fails,
+// certificates presented by cluster are still captured so that they can be reported
+func (g *gateway) GetClusterInfo(ctx context.Context) (result connection.Response, err error) {
+	requestURL, err := g.buildURL("/", "")
+	if err != nil {
+		return
+	}
+	request, err := g.BuildRequest(ctx, http.MethodGet, nil, requestURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return
+	}
+	probe := g.withoutRetry()
+	start := time.Now()
+	result.Body, result.TLS, err = probe.ExecuteWithTLS(request)
+	result.Latency = time.Since(start)
+	if err != nil && result.TLS == nil && requestURL.Scheme == httpsScheme {
+		result.TLS = g.getPeerCertificates(ctx, requestURL)
+	}
+	return
+}
+
+//withoutRetry returns gateway which sends requests with HTTP client of gateway but does not retry them
+func (g *gateway) withoutRetry() *gw.HTTPGateway {
+	c := retryablehttp.NewClient()
+	c.HTTPClient = g.Client.HTTPClient.HTTPClient
+	c.RetryMax = 0
+	c.ErrorHandler = g.Client.HTTPClient.ErrorHandler
+	c.Logger = nil
+	return &gw.HTTPGateway{
+		Client:  &client.Client{HTTPClient: c},
+		Profile: g.Profile,
+	}
+}
+
+//getPeerCertificates connects to endpoint without verifying its certificates and returns TLS connection state,
+//nothing is sent over connection. Nil is returned if connection cannot be established
+func (g *gateway) getPeerCertificates(ctx context.Context, endpoint *url.URL) *tls.ConnectionState {
+	config, err := gw.GetTLSConfig(g.Profile)
+	if err != nil {
+		return nil
+	}
+	config.InsecureSkipVerify = true
+	config.VerifyConnection = nil
+	port := endpoint.Port()
+	if len(port) == 0 {
+		port = httpsPort
+	}
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: g.Client.HTTPClient.HTTPClient.Timeout},
+		Config:    config,
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(endpoint.Hostname(), port))
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+	state := conn.(*tls.Conn).ConnectionState()
+	return &state
+}
+
+// GetAuthInfo gets user authenticated by security plugin
+func (g *gateway) GetAuthInfo(ctx context.Context) ([]byte, error) {
+	return g.call(ctx, authInfoURL, "")
+}
+
+// GetPlugins gets plugins installed on every node
+func (g *gateway) GetPlugins(ctx context.Context) ([]byte, error) {
+	return g.call(ctx, catPluginsURL, catPluginsQuery)
+}
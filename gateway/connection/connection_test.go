@@ -0,0 +1,134 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package connection
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestClient(t *testing.T, url string, code int, response []byte, state *tls.ConnectionState) *client.Client {
+	return mocks.NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, url, req.URL.String())
+		return &http.Response{
+			StatusCode: code,
+			Body:       io.NopCloser(bytes.NewBuffer(response)),
+			Header:     make(http.Header),
+			Status:     "SOME OUTPUT",
+			Request:    req,
+			TLS:        state,
+		}
+	})
+}
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	testGateway, err := New(c, &entity.Profile{
+		Endpoint: "https://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	})
+	assert.NoError(t, err)
+	return testGateway
+}
+
+func TestGatewayGetClusterInfo(t *testing.T) {
+	ctx := context.Background()
+	t.Run("success", func(t *testing.T) {
+		state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{}}}
+		testClient := getTestClient(t, "https://localhost:9200/", 200, []byte(`{"cluster_name":"docker-cluster"}`), state)
+		result, err := getTestGateway(t, testClient).GetClusterInfo(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"cluster_name":"docker-cluster"}`, string(result.Body))
+		assert.Same(t, state, result.TLS)
+		assert.True(t, result.Latency > 0)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		testClient := getTestClient(t, "https://localhost:9200/", 401, []byte(`Unauthorized`), nil)
+		_, err := getTestGateway(t, testClient).GetClusterInfo(ctx)
+		assert.EqualError(t, err, "401 Client Error: SOME OUTPUT for url: https://localhost:9200/")
+	})
+	t.Run("request is not retried", func(t *testing.T) {
+		calls := 0
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			calls++
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       io.NopCloser(bytes.NewBufferString(`unavailable`)),
+				Header:     make(http.Header),
+				Status:     "SOME OUTPUT",
+				Request:    req,
+			}
+		})
+		_, err := getTestGateway(t, testClient).GetClusterInfo(ctx)
+		assert.EqualError(t, err, "503 Server Error: SOME OUTPUT for url: https://localhost:9200/")
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("certificates are captured if verification failed", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testGateway, err := New(testClient, &entity.Profile{Endpoint: server.URL})
+		assert.NoError(t, err)
+		result, err := testGateway.GetClusterInfo(ctx)
+		assert.Error(t, err)
+		if assert.NotNil(t, result.TLS) && assert.NotEmpty(t, result.TLS.PeerCertificates) {
+			assert.Equal(t, server.Certificate().Raw, result.TLS.PeerCertificates[0].Raw)
+		}
+	})
+}
+
+func TestGatewayRequests(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		url  string
+		call func(Gateway) ([]byte, error)
+	}{
+		{
+			name: "auth info",
+			url:  "https://localhost:9200/_plugins/_security/authinfo",
+			call: func(g Gateway) ([]byte, error) {
+				return g.GetAuthInfo(ctx)
+			},
+		},
+		{
+			name: "plugins",
+			url:  "https://localhost:9200/_cat/plugins?format=json",
+			call: func(g Gateway) ([]byte, error) {
+				return g.GetPlugins(ctx)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := getTestClient(t, tt.url, 200, []byte(`{}`), nil)
+			response, err := tt.call(getTestGateway(t, testClient))
+			assert.NoError(t, err)
+			assert.Equal(t, `{}`, string(response))
+		})
+	}
+}
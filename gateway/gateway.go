@@ -15,7 +15,6 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,10 +25,8 @@ import (
 	"opensearch-cli/client"
 	"opensearch-cli/entity"
 	"opensearch-cli/entity/platform"
-	"opensearch-cli/environment"
 	"opensearch-cli/gateway/aws/signer"
 	"os"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -47,60 +44,25 @@ func GetDefaultHeaders() map[string]string {
 		"content-type": "application/json",
 	}
 }
-func GetTLSConfig(trust *entity.Trust) (*tls.Config, error) {
-	config := &tls.Config{}
-	if trust.ClientCertificateFilePath != nil && trust.ClientKeyFilePath != nil {
-		cert, err := tls.LoadX509KeyPair(*trust.ClientCertificateFilePath, *trust.ClientKeyFilePath)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"error creating x509 keypair from client cert file %s and client key file %s",
-				*trust.ClientCertificateFilePath, *trust.ClientKeyFilePath)
-		}
-		config.Certificates = []tls.Certificate{cert}
-	}
-	caCertPool := x509.NewCertPool()
-	if trust.CAFilePath != nil {
-		caCert, err := os.ReadFile(*trust.CAFilePath)
-		if err != nil {
-			return nil, fmt.Errorf("error opening certificate file %s, error: %s", *trust.CAFilePath, err)
-		}
-		caCertPool.AppendCertsFromPEM(caCert)
-		config.RootCAs = caCertPool
-	}
-
-	return config, nil
-}
 
 // NewHTTPGateway creates new HTTPGateway instance
 func NewHTTPGateway(c *client.Client, p *entity.Profile) (*HTTPGateway, error) {
 
-	if p.Certificate != nil {
-		tlsConfig, err := GetTLSConfig(p.Certificate)
-		if err != nil {
-			return nil, err
-		}
-		c.HTTPClient.HTTPClient.Transport = &http.Transport{
-			TLSClientConfig: tlsConfig,
-		}
+	transport, err := BuildTransport(c.HTTPClient.HTTPClient.Transport, p)
+	if err != nil {
+		return nil, err
 	}
+	c.HTTPClient.HTTPClient.Transport = transport
 
-	// set max retry if provided by command
+	// set max retry if provided by profile, environment variable is already applied by profile resolution
 	if p.MaxRetry != nil {
 		c.HTTPClient.RetryMax = *p.MaxRetry
 	}
-	//override with environment variable if exists
-	if val, ok := overrideValue(p, environment.OPENSEARCH_MAX_RETRY); ok {
-		c.HTTPClient.RetryMax = *val
-	}
 
-	// set connection timeout if provided by command
+	// set connection timeout if provided by profile
 	if p.Timeout != nil {
 		c.HTTPClient.HTTPClient.Timeout = time.Duration(*p.Timeout) * time.Second
 	}
-	//override with environment variable if exists
-	if duration, ok := overrideValue(p, environment.OPENSEARCH_TIMEOUT); ok {
-		c.HTTPClient.HTTPClient.Timeout = time.Duration(*duration) * time.Second
-	}
 
 	return &HTTPGateway{
 		Client:  c,
@@ -108,16 +70,6 @@ func NewHTTPGateway(c *client.Client, p *entity.Profile) (*HTTPGateway, error) {
 	}, nil
 }
 
-func overrideValue(p *entity.Profile, envVariable string) (*int, bool) {
-	if val, ok := os.LookupEnv(envVariable); ok {
-		//ignore error from non positive number
-		if attempt, err := strconv.Atoi(val); err == nil {
-			return &attempt, true
-		}
-	}
-	return nil, false
-}
-
 // isValidResponse checks whether the response is valid or not by checking the status code
 func (g *HTTPGateway) isValidResponse(response *http.Response) error {
 	if response == nil {
@@ -144,15 +96,22 @@ func (g *HTTPGateway) isValidResponse(response *http.Response) error {
 
 // Execute calls request using http and check if status code is ok or not
 func (g *HTTPGateway) Execute(req *retryablehttp.Request) ([]byte, error) {
+	body, _, err := g.ExecuteWithTLS(req)
+	return body, err
+}
+
+// ExecuteWithTLS calls request like Execute and returns TLS connection state of response as well,
+// state is nil if endpoint does not use https
+func (g *HTTPGateway) ExecuteWithTLS(req *retryablehttp.Request) ([]byte, *tls.ConnectionState, error) {
 	if g.Profile.AWS != nil {
 		//sign request
 		if err := signer.SignRequest(req, *g.Profile.AWS, signer.GetV4Signer); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 	response, err := g.Client.HTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer func() {
 		err := response.Body.Close()
@@ -161,9 +120,10 @@ func (g *HTTPGateway) Execute(req *retryablehttp.Request) ([]byte, error) {
 		}
 	}()
 	if err = g.isValidResponse(response); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return io.ReadAll(response.Body)
+	body, err := io.ReadAll(response.Body)
+	return body, response.TLS, err
 }
 
 // Call calls request using http and return error if status code is not expected
@@ -203,8 +163,8 @@ func (g *HTTPGateway) BuildCurlRequest(ctx context.Context, method string, paylo
 		return nil, err
 	}
 	req := r.WithContext(ctx)
-	if len(g.Profile.UserName) != 0 {
-		req.SetBasicAuth(g.Profile.UserName, g.Profile.Password)
+	if err = g.setBasicAuth(req); err != nil {
+		return nil, err
 	}
 	if len(headers) == 0 {
 		return req, nil
@@ -215,6 +175,26 @@ func (g *HTTPGateway) BuildCurlRequest(ctx context.Context, method string, paylo
 	return req, nil
 }
 
+// setBasicAuth sets user and password of profile to request, credentials which are not saved in profile are
+// retrieved from credential provider only when request is built
+func (g *HTTPGateway) setBasicAuth(req *retryablehttp.Request) error {
+	user, password := g.Profile.UserName, g.Profile.Password
+	if len(password) == 0 && g.Profile.CredentialProvider != nil {
+		credentials, err := g.Profile.CredentialProvider.Retrieve()
+		if err != nil {
+			return fmt.Errorf("failed to get credentials of profile %s due to: %w", g.Profile.Name, err)
+		}
+		if len(user) == 0 {
+			user = credentials.UserName
+		}
+		password = credentials.Password
+	}
+	if len(user) != 0 {
+		req.SetBasicAuth(user, password)
+	}
+	return nil
+}
+
 // BuildCurlMultipartFormRequest builds multipart file-upload request based on method and add payload (in byte)
 func (g *HTTPGateway) BuildCurlMultipartFormRequest(ctx context.Context, method string, filePath string, url string, headers map[string]string) (*retryablehttp.Request, error) {
 	file, _ := os.Open(filePath)
@@ -234,8 +214,8 @@ func (g *HTTPGateway) BuildCurlMultipartFormRequest(ctx context.Context, method
 		return nil, err
 	}
 	req := r.WithContext(ctx)
-	if len(g.Profile.UserName) != 0 {
-		req.SetBasicAuth(g.Profile.UserName, g.Profile.Password)
+	if err = g.setBasicAuth(req); err != nil {
+		return nil, err
 	}
 	if len(headers) == 0 {
 		return req, nil
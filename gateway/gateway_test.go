@@ -12,11 +12,13 @@
 package gateway
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"opensearch-cli/client/mocks"
 	"opensearch-cli/entity"
 	"opensearch-cli/environment"
 	"opensearch-cli/mapper"
-	"os"
 	"testing"
 	"time"
 
@@ -48,6 +50,66 @@ func TestGetValidEndpoint(t *testing.T) {
 	})
 }
 
+//credentialProvider returns credentials and counts calls
+type credentialProvider struct {
+	credentials entity.Credentials
+	err         error
+	calls       int
+}
+
+func (c *credentialProvider) Retrieve() (entity.Credentials, error) {
+	c.calls++
+	return c.credentials, c.err
+}
+
+func TestGatewayBasicAuth(t *testing.T) {
+	t.Run("credentials from provider", func(t *testing.T) {
+		provider := &credentialProvider{credentials: entity.Credentials{UserName: "admin", Password: "secret"}}
+		profile := entity.Profile{Name: "test1", Endpoint: "https://localhost:9200", CredentialProvider: provider}
+		g, err := NewHTTPGateway(mocks.NewTestClient(nil), &profile)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, provider.calls)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, nil, "https://localhost:9200", nil)
+		assert.NoError(t, err)
+		user, password, ok := req.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "admin", user)
+		assert.Equal(t, "secret", password)
+		assert.Equal(t, 1, provider.calls)
+	})
+	t.Run("password of profile is preferred", func(t *testing.T) {
+		provider := &credentialProvider{credentials: entity.Credentials{UserName: "admin", Password: "secret"}}
+		profile := entity.Profile{Name: "test1", UserName: "foo", Password: "bar", CredentialProvider: provider}
+		g, err := NewHTTPGateway(mocks.NewTestClient(nil), &profile)
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, nil, "https://localhost:9200", nil)
+		assert.NoError(t, err)
+		user, password, _ := req.BasicAuth()
+		assert.Equal(t, "foo", user)
+		assert.Equal(t, "bar", password)
+		assert.Equal(t, 0, provider.calls)
+	})
+	t.Run("user of profile is kept", func(t *testing.T) {
+		provider := &credentialProvider{credentials: entity.Credentials{Password: "secret"}}
+		profile := entity.Profile{Name: "test1", UserName: "foo", CredentialProvider: provider}
+		g, err := NewHTTPGateway(mocks.NewTestClient(nil), &profile)
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, nil, "https://localhost:9200", nil)
+		assert.NoError(t, err)
+		user, password, _ := req.BasicAuth()
+		assert.Equal(t, "foo", user)
+		assert.Equal(t, "secret", password)
+	})
+	t.Run("provider failed", func(t *testing.T) {
+		provider := &credentialProvider{err: errors.New("keyring is locked")}
+		profile := entity.Profile{Name: "test1", CredentialProvider: provider}
+		g, err := NewHTTPGateway(mocks.NewTestClient(nil), &profile)
+		assert.NoError(t, err)
+		_, err = g.BuildRequest(context.Background(), http.MethodGet, nil, "https://localhost:9200", nil)
+		assert.EqualError(t, err, "failed to get credentials of profile test1 due to: keyring is locked")
+	})
+}
+
 func TestGatewayRetryVal(t *testing.T) {
 	t.Run("default retry max value", func(t *testing.T) {
 		profile := entity.Profile{
@@ -71,23 +133,22 @@ func TestGatewayRetryVal(t *testing.T) {
 		assert.NoError(t, err)
 		assert.EqualValues(t, valAttempt, testClient.HTTPClient.RetryMax)
 	})
-
-	t.Run("override from environment variable", func(t *testing.T) {
-		val := os.Getenv(environment.OPENSEARCH_MAX_RETRY)
-		defer func() {
-			assert.NoError(t, os.Setenv(environment.OPENSEARCH_MAX_RETRY, val))
-		}()
-		os.Setenv(environment.OPENSEARCH_MAX_RETRY, "10")
+	t.Run("environment variable is applied by profile resolution", func(t *testing.T) {
+		t.Setenv(environment.OPENSEARCH_MAX_RETRY, "10")
+		t.Setenv(environment.OPENSEARCH_TIMEOUT, "5")
 		valAttempt := 2
+		timeout := int64(60)
 		profile := entity.Profile{
 			Name:     "test1",
 			Endpoint: "https://localhost:9200",
 			MaxRetry: &valAttempt,
+			Timeout:  &timeout,
 		}
 		testClient := mocks.NewTestClient(nil)
 		_, err := NewHTTPGateway(testClient, &profile)
 		assert.NoError(t, err)
-		assert.EqualValues(t, 10, testClient.HTTPClient.RetryMax)
+		assert.EqualValues(t, valAttempt, testClient.HTTPClient.RetryMax)
+		assert.EqualValues(t, time.Duration(timeout)*time.Second, testClient.HTTPClient.HTTPClient.Timeout)
 	})
 }
 
@@ -114,24 +175,6 @@ func TestGatewayConnectionTimeout(t *testing.T) {
 		assert.NoError(t, err)
 		assert.EqualValues(t, time.Duration(timeout)*time.Second, testClient.HTTPClient.HTTPClient.Timeout)
 	})
-
-	t.Run("override from environment variable", func(t *testing.T) {
-		val := os.Getenv(environment.OPENSEARCH_TIMEOUT)
-		defer func() {
-			assert.NoError(t, os.Setenv(environment.OPENSEARCH_TIMEOUT, val))
-		}()
-		os.Setenv(environment.OPENSEARCH_TIMEOUT, "5")
-		timeout := int64(60)
-		profile := entity.Profile{
-			Name:     "test1",
-			Endpoint: "https://localhost:9200",
-			Timeout:  &timeout,
-		}
-		testClient := mocks.NewTestClient(nil)
-		_, err := NewHTTPGateway(testClient, &profile)
-		assert.NoError(t, err)
-		assert.EqualValues(t, 5*time.Second, testClient.HTTPClient.HTTPClient.Timeout)
-	})
 }
 
 func TestGatewayTLSConnection(t *testing.T) {
@@ -0,0 +1,167 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	catIndicesURL         = "_cat/indices"
+	catIndicesURLTemplate = catIndicesURL + "/%s"
+	catIndicesQuery       = "format=json&bytes=b"
+	mappingURLTemplate    = "%s/_mapping"
+	settingsURLTemplate   = "%s/_settings"
+	openURLTemplate       = "%s/_open"
+	closeURLTemplate      = "%s/_close"
+	aliasURL              = "_alias"
+	aliasURLTemplate      = "%s/_alias"
+	aliasesURL            = "_aliases"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_index.go -package=mocks . Gateway
+
+// Gateway interface to index APIs
+type Gateway interface {
+	CatIndices(ctx context.Context, pattern string) ([]byte, error)
+	CreateIndex(ctx context.Context, index string, payload interface{}) ([]byte, error)
+	DeleteIndex(ctx context.Context, index string) ([]byte, error)
+	GetMapping(ctx context.Context, index string) ([]byte, error)
+	PutSettings(ctx context.Context, index string, payload interface{}) ([]byte, error)
+	OpenIndex(ctx context.Context, index string) ([]byte, error)
+	CloseIndex(ctx context.Context, index string) ([]byte, error)
+	GetAliases(ctx context.Context, index string) ([]byte, error)
+	UpdateAliases(ctx context.Context, payload interface{}) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildURL constructs url of path with query
+func (g *gateway) buildURL(path string, query string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	endpoint.RawQuery = query
+	return endpoint, nil
+}
+
+//call sends request to path and returns response
+func (g *gateway) call(ctx context.Context, method string, path string, query string, payload interface{}) ([]byte, error) {
+	requestURL, err := g.buildURL(path, query)
+	if err != nil {
+		return nil, err
+	}
+	request, err := g.BuildRequest(ctx, method, payload, requestURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*CatIndices returns indices matched by pattern, every index is returned if pattern is empty
+GET _cat/indices/logs-*?format=json&bytes=b
+[
+  {
+    "health": "green",
+    "status": "open",
+    "index": "logs-1",
+    "uuid": "0hZ3bJ9kQwKX6sbE8Sx3Ag",
+    "pri": "1",
+    "rep": "1",
+    "docs.count": "1000",
+    "docs.deleted": "0",
+    "store.size": "416000",
+    "pri.store.size": "208000"
+  }
+]
+*/
+func (g *gateway) CatIndices(ctx context.Context, pattern string) ([]byte, error) {
+	path := catIndicesURL
+	if len(pattern) > 0 {
+		path = fmt.Sprintf(catIndicesURLTemplate, pattern)
+	}
+	return g.call(ctx, http.MethodGet, path, catIndicesQuery, nil)
+}
+
+// CreateIndex creates index with settings, mappings and aliases given by payload
+func (g *gateway) CreateIndex(ctx context.Context, index string, payload interface{}) ([]byte, error) {
+	return g.call(ctx, http.MethodPut, index, "", payload)
+}
+
+// DeleteIndex deletes index
+func (g *gateway) DeleteIndex(ctx context.Context, index string) ([]byte, error) {
+	return g.call(ctx, http.MethodDelete, index, "", nil)
+}
+
+// GetMapping returns mappings of indices matched by index
+func (g *gateway) GetMapping(ctx context.Context, index string) ([]byte, error) {
+	return g.call(ctx, http.MethodGet, fmt.Sprintf(mappingURLTemplate, index), "", nil)
+}
+
+// PutSettings updates dynamic settings of indices matched by index
+func (g *gateway) PutSettings(ctx context.Context, index string, payload interface{}) ([]byte, error) {
+	return g.call(ctx, http.MethodPut, fmt.Sprintf(settingsURLTemplate, index), "", payload)
+}
+
+// OpenIndex opens closed index
+func (g *gateway) OpenIndex(ctx context.Context, index string) ([]byte, error) {
+	return g.call(ctx, http.MethodPost, fmt.Sprintf(openURLTemplate, index), "", nil)
+}
+
+// CloseIndex closes index
+func (g *gateway) CloseIndex(ctx context.Context, index string) ([]byte, error) {
+	return g.call(ctx, http.MethodPost, fmt.Sprintf(closeURLTemplate, index), "", nil)
+}
+
+/*GetAliases returns aliases of indices matched by index, aliases of every index are returned if index is empty
+GET logs-1/_alias
+{
+  "logs-1": {
+    "aliases": {
+      "logs": {
+        "is_write_index": true
+      }
+    }
+  }
+}
+*/
+func (g *gateway) GetAliases(ctx context.Context, index string) ([]byte, error) {
+	path := aliasURL
+	if len(index) > 0 {
+		path = fmt.Sprintf(aliasURLTemplate, index)
+	}
+	return g.call(ctx, http.MethodGet, path, "", nil)
+}
+
+// UpdateAliases adds or removes aliases atomically with actions given by payload
+func (g *gateway) UpdateAliases(ctx context.Context, payload interface{}) ([]byte, error) {
+	return g.call(ctx, http.MethodPost, aliasesURL, "", payload)
+}
@@ -0,0 +1,180 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestClient(t *testing.T, method string, url string, body string, code int, response []byte) *client.Client {
+	return mocks.NewTestClient(func(req *http.Request) *http.Response {
+		// Test request parameters
+		assert.Equal(t, method, req.Method)
+		assert.Equal(t, url, req.URL.String())
+		assert.EqualValues(t, len(req.Header), 2)
+		if len(body) > 0 {
+			data, err := io.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, body, string(data))
+		}
+		return &http.Response{
+			StatusCode: code,
+			// Send response to be tested
+			Body: io.NopCloser(bytes.NewBuffer(response)),
+			// Must be set to non-nil value or it panics
+			Header:  make(http.Header),
+			Status:  "SOME OUTPUT",
+			Request: req,
+		}
+	})
+}
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	testGateway, err := New(c, &entity.Profile{
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	})
+	assert.NoError(t, err)
+	return testGateway
+}
+
+func TestGatewayRequests(t *testing.T) {
+	ctx := context.Background()
+	settings := map[string]interface{}{"index": map[string]interface{}{"number_of_replicas": 2}}
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		call   func(Gateway) ([]byte, error)
+	}{
+		{
+			name:   "cat every index",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/_cat/indices?format=json&bytes=b",
+			call: func(g Gateway) ([]byte, error) {
+				return g.CatIndices(ctx, "")
+			},
+		},
+		{
+			name:   "cat indices by pattern",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/_cat/indices/logs-%2A?format=json&bytes=b",
+			call: func(g Gateway) ([]byte, error) {
+				return g.CatIndices(ctx, "logs-*")
+			},
+		},
+		{
+			name:   "create index",
+			method: http.MethodPut,
+			url:    "http://localhost:9200/index1",
+			body:   `{"settings":{"index":{"number_of_replicas":2}}}`,
+			call: func(g Gateway) ([]byte, error) {
+				return g.CreateIndex(ctx, "index1", map[string]interface{}{"settings": settings})
+			},
+		},
+		{
+			name:   "delete index",
+			method: http.MethodDelete,
+			url:    "http://localhost:9200/index1",
+			call: func(g Gateway) ([]byte, error) {
+				return g.DeleteIndex(ctx, "index1")
+			},
+		},
+		{
+			name:   "get mapping",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/index1/_mapping",
+			call: func(g Gateway) ([]byte, error) {
+				return g.GetMapping(ctx, "index1")
+			},
+		},
+		{
+			name:   "put settings",
+			method: http.MethodPut,
+			url:    "http://localhost:9200/index1/_settings",
+			body:   `{"index":{"number_of_replicas":2}}`,
+			call: func(g Gateway) ([]byte, error) {
+				return g.PutSettings(ctx, "index1", settings)
+			},
+		},
+		{
+			name:   "open index",
+			method: http.MethodPost,
+			url:    "http://localhost:9200/index1/_open",
+			call: func(g Gateway) ([]byte, error) {
+				return g.OpenIndex(ctx, "index1")
+			},
+		},
+		{
+			name:   "close index",
+			method: http.MethodPost,
+			url:    "http://localhost:9200/index1/_close",
+			call: func(g Gateway) ([]byte, error) {
+				return g.CloseIndex(ctx, "index1")
+			},
+		},
+		{
+			name:   "get every alias",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/_alias",
+			call: func(g Gateway) ([]byte, error) {
+				return g.GetAliases(ctx, "")
+			},
+		},
+		{
+			name:   "get aliases of index",
+			method: http.MethodGet,
+			url:    "http://localhost:9200/index1/_alias",
+			call: func(g Gateway) ([]byte, error) {
+				return g.GetAliases(ctx, "index1")
+			},
+		},
+		{
+			name:   "update aliases",
+			method: http.MethodPost,
+			url:    "http://localhost:9200/_aliases",
+			body:   `{"actions":[{"add":{"index":"index1","alias":"alias1"}}]}`,
+			call: func(g Gateway) ([]byte, error) {
+				return g.UpdateAliases(ctx, map[string]interface{}{
+					"actions": []interface{}{
+						map[string]interface{}{"add": map[string]string{"index": "index1", "alias": "alias1"}},
+					},
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := getTestClient(t, tt.method, tt.url, tt.body, 200, []byte("success"))
+			actual, err := tt.call(getTestGateway(t, testClient))
+			assert.NoError(t, err)
+			assert.EqualValues(t, "success", string(actual))
+		})
+	}
+	t.Run("request failed", func(t *testing.T) {
+		testClient := getTestClient(t, http.MethodDelete, "http://localhost:9200/index1", "", 404, []byte(`{"error":"index_not_found_exception"}`))
+		_, err := getTestGateway(t, testClient).DeleteIndex(ctx, "index1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "index_not_found_exception")
+	})
+}
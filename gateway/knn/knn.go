@@ -29,6 +29,13 @@ const (
 	statsURL                 = baseURL + "/stats"
 	nodeStatsURLTemplate     = baseURL + "/%s/stats/%s"
 	warmupIndicesURLTemplate = baseURL + "/warmup/%s"
+	clearCacheURLTemplate    = baseURL + "/clear_cache/%s"
+	modelsURL                = baseURL + "/models"
+	modelURLTemplate         = modelsURL + "/%s"
+	trainModelURL            = modelsURL + "/_train"
+	trainModelURLTemplate    = modelsURL + "/%s/_train"
+	searchModelsURL          = modelsURL + "/_search"
+	searchIndexURLTemplate   = "%s/_search"
 )
 
 //go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_knn.go -package=mocks . Gateway
@@ -37,6 +44,12 @@ const (
 type Gateway interface {
 	GetStatistics(ctx context.Context, nodes string, names string) ([]byte, error)
 	WarmupIndices(ctx context.Context, indices string) ([]byte, error)
+	ClearCache(ctx context.Context, indices string) ([]byte, error)
+	TrainModel(ctx context.Context, modelID string, payload interface{}) ([]byte, error)
+	GetModel(ctx context.Context, modelID string) ([]byte, error)
+	SearchModels(ctx context.Context, payload interface{}) ([]byte, error)
+	DeleteModel(ctx context.Context, modelID string) ([]byte, error)
+	SearchIndex(ctx context.Context, index string, payload interface{}) ([]byte, error)
 }
 
 type gateway struct {
@@ -78,6 +91,16 @@ func (g *gateway) buildWarmupURL(indices string) (*url.URL, error) {
 	return endpoint, nil
 }
 
+//buildClearCacheURL to construct url for clearing cache of indices
+func (g *gateway) buildClearCacheURL(indices string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(clearCacheURLTemplate, indices)
+	return endpoint, nil
+}
+
 /*GetStatistics provides information about the current status of the KNN Plugin.
 GET /_plugins/_knn/stats
 {
@@ -175,3 +198,157 @@ func (g gateway) WarmupIndices(ctx context.Context, indices string) ([]byte, err
 	}
 	return response, nil
 }
+
+/* ClearCache will evict graphs of given indices from native memory
+POST /_plugins/_knn/clear_cache/index1,index2
+{
+	"_shards" : {
+		"total" : 6,
+		"successful" : 6,
+		"failed" : 0
+	}
+}
+*/
+func (g gateway) ClearCache(ctx context.Context, indices string) ([]byte, error) {
+	clearCacheURL, err := g.buildClearCacheURL(indices)
+	if err != nil {
+		return nil, err
+	}
+	request, err := g.BuildRequest(ctx, http.MethodPost, nil, clearCacheURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(request, http.StatusOK)
+	if err != nil {
+		return nil, processKNNError(err)
+	}
+	return response, nil
+}
+
+//buildModelURL to construct url for model APIs
+func (g *gateway) buildModelURL(path string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	return endpoint, nil
+}
+
+//callModelAPI sends request to model API and returns response
+func (g gateway) callModelAPI(ctx context.Context, method string, path string, payload interface{}) ([]byte, error) {
+	modelURL, err := g.buildModelURL(path)
+	if err != nil {
+		return nil, err
+	}
+	request, err := g.BuildRequest(ctx, method, payload, modelURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(request, http.StatusOK)
+	if err != nil {
+		return nil, processKNNError(err)
+	}
+	return response, nil
+}
+
+/* TrainModel starts training of model, if modelID is empty, model id is generated by plugin
+POST /_plugins/_knn/models/{model_id}/_train
+{
+	"training_index": "train-index-name",
+	"training_field": "train-field-name",
+	"dimension": 16,
+	"description": "My model",
+	"method": {
+		"name": "ivf",
+		"engine": "faiss",
+		"parameters": {
+			"nlist": 4
+		}
+	}
+}
+{
+	"model_id": "model_x"
+}
+*/
+func (g gateway) TrainModel(ctx context.Context, modelID string, payload interface{}) ([]byte, error) {
+	path := trainModelURL
+	if modelID != "" {
+		path = fmt.Sprintf(trainModelURLTemplate, modelID)
+	}
+	return g.callModelAPI(ctx, http.MethodPost, path, payload)
+}
+
+/* GetModel fetches model metadata
+GET /_plugins/_knn/models/{model_id}
+{
+	"model_id" : "test-model",
+	"model_blob" : "SXdGbIAAAAAAAAAAAA...",
+	"state" : "created",
+	"timestamp" : "2021-11-15T18:45:07.505369036Z",
+	"description" : "Default",
+	"error" : "",
+	"space_type" : "l2",
+	"dimension" : 128,
+	"engine" : "faiss"
+}
+*/
+func (g gateway) GetModel(ctx context.Context, modelID string) ([]byte, error) {
+	return g.callModelAPI(ctx, http.MethodGet, fmt.Sprintf(modelURLTemplate, modelID), nil)
+}
+
+//SearchModels searches models metadata by query
+func (g gateway) SearchModels(ctx context.Context, payload interface{}) ([]byte, error) {
+	return g.callModelAPI(ctx, http.MethodPost, searchModelsURL, payload)
+}
+
+/* DeleteModel deletes model
+DELETE /_plugins/_knn/models/{model_id}
+{
+	"model_id": "test-model",
+	"result": "deleted"
+}
+*/
+func (g gateway) DeleteModel(ctx context.Context, modelID string) ([]byte, error) {
+	return g.callModelAPI(ctx, http.MethodDelete, fmt.Sprintf(modelURLTemplate, modelID), nil)
+}
+
+//buildSearchIndexURL to construct url for searching index
+func (g *gateway) buildSearchIndexURL(index string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(searchIndexURLTemplate, index)
+	return endpoint, nil
+}
+
+/* SearchIndex searches index with k-NN query
+POST /index1/_search
+{
+	"size": 2,
+	"query": {
+		"knn": {
+			"my_vector": {
+				"vector": [2.0, 3.0],
+				"k": 2
+			}
+		}
+	}
+}
+*/
+func (g gateway) SearchIndex(ctx context.Context, index string, payload interface{}) ([]byte, error) {
+	searchURL, err := g.buildSearchIndexURL(index)
+	if err != nil {
+		return nil, err
+	}
+	request, err := g.BuildRequest(ctx, http.MethodPost, payload, searchURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(request, http.StatusOK)
+	if err != nil {
+		return nil, processKNNError(err)
+	}
+	return response, nil
+}
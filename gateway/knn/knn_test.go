@@ -173,3 +173,126 @@ func TestGatewayWarmupIndices(t *testing.T) {
 		assert.EqualErrorf(t, err, "no such index", "failed to parse error")
 	})
 }
+
+func TestGatewayModels(t *testing.T) {
+	ctx := context.Background()
+	profile := &entity.Profile{
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	}
+	t.Run("train model with id", func(t *testing.T) {
+		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/models/model1/_train", 200, []byte(`{"model_id":"model1"}`))
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		actual, err := testGateway.TrainModel(ctx, "model1", knn.TrainModelRequest{})
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"model_id":"model1"}`, string(actual))
+	})
+	t.Run("train model without id", func(t *testing.T) {
+		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/models/_train", 200, []byte(`{"model_id":"generated"}`))
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		actual, err := testGateway.TrainModel(ctx, "", knn.TrainModelRequest{})
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"model_id":"generated"}`, string(actual))
+	})
+	t.Run("get model", func(t *testing.T) {
+		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/models/model1", 200, []byte("success"))
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		actual, err := testGateway.GetModel(ctx, "model1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(actual))
+	})
+	t.Run("search models", func(t *testing.T) {
+		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/models/_search", 200, []byte("success"))
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		actual, err := testGateway.SearchModels(ctx, knn.ModelSearchRequest{})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(actual))
+	})
+	t.Run("delete model", func(t *testing.T) {
+		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/models/model1", 200, []byte("success"))
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		actual, err := testGateway.DeleteModel(ctx, "model1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(actual))
+	})
+	t.Run("failed due to missing model", func(t *testing.T) {
+		response, _ := json.Marshal(knn.ErrorResponse{
+			KNNError: knn.Error{
+				RootCause: []knn.RootCause{
+					{
+						Type:   "resource_not_found_exception",
+						Reason: "Unable to find model: model1",
+					},
+				},
+			},
+			Status: 404,
+		})
+		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/models/model1", 404, response)
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		_, err = testGateway.GetModel(ctx, "model1")
+		assert.EqualError(t, err, "Unable to find model: model1")
+	})
+}
+
+func TestGatewayClearCache(t *testing.T) {
+	ctx := context.Background()
+	t.Run("clear cache of indices", func(t *testing.T) {
+
+		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/clear_cache/index1,index2", 200, []byte("success"))
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		actual, err := testGateway.ClearCache(ctx, "index1,index2")
+		assert.NoError(t, err)
+		assert.EqualValues(t, string(actual), "success")
+	})
+	t.Run("failed due to invalid index", func(t *testing.T) {
+
+		response, _ := json.Marshal(knn.ErrorResponse{
+			KNNError: knn.Error{
+				RootCause: []knn.RootCause{
+					{
+						Type:   "index_not_found_exception",
+						Reason: "no such index",
+					},
+				},
+			},
+			Status: 404,
+		})
+		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/clear_cache/index1", 404, response)
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.ClearCache(ctx, "index1")
+		assert.EqualErrorf(t, err, "no such index", "failed to parse error")
+	})
+}
+
+func TestGatewaySearchIndex(t *testing.T) {
+	ctx := context.Background()
+	t.Run("search index", func(t *testing.T) {
+		testClient := getTestClient(t, "http://localhost:9200/index1/_search", 200, []byte("success"))
+		testGateway, err := New(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		actual, err := testGateway.SearchIndex(ctx, "index1", knn.SearchRequest{})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(actual))
+	})
+}
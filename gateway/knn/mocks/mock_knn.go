@@ -34,6 +34,51 @@ func (m *MockGateway) EXPECT() *MockGatewayMockRecorder {
 	return m.recorder
 }
 
+// ClearCache mocks base method
+func (m *MockGateway) ClearCache(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCache", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearCache indicates an expected call of ClearCache
+func (mr *MockGatewayMockRecorder) ClearCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCache", reflect.TypeOf((*MockGateway)(nil).ClearCache), arg0, arg1)
+}
+
+// DeleteModel mocks base method
+func (m *MockGateway) DeleteModel(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModel", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteModel indicates an expected call of DeleteModel
+func (mr *MockGatewayMockRecorder) DeleteModel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModel", reflect.TypeOf((*MockGateway)(nil).DeleteModel), arg0, arg1)
+}
+
+// GetModel mocks base method
+func (m *MockGateway) GetModel(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModel", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModel indicates an expected call of GetModel
+func (mr *MockGatewayMockRecorder) GetModel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModel", reflect.TypeOf((*MockGateway)(nil).GetModel), arg0, arg1)
+}
+
 // GetStatistics mocks base method
 func (m *MockGateway) GetStatistics(arg0 context.Context, arg1, arg2 string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +94,51 @@ func (mr *MockGatewayMockRecorder) GetStatistics(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockGateway)(nil).GetStatistics), arg0, arg1, arg2)
 }
 
+// SearchIndex mocks base method
+func (m *MockGateway) SearchIndex(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchIndex", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchIndex indicates an expected call of SearchIndex
+func (mr *MockGatewayMockRecorder) SearchIndex(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIndex", reflect.TypeOf((*MockGateway)(nil).SearchIndex), arg0, arg1, arg2)
+}
+
+// SearchModels mocks base method
+func (m *MockGateway) SearchModels(arg0 context.Context, arg1 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchModels", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchModels indicates an expected call of SearchModels
+func (mr *MockGatewayMockRecorder) SearchModels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchModels", reflect.TypeOf((*MockGateway)(nil).SearchModels), arg0, arg1)
+}
+
+// TrainModel mocks base method
+func (m *MockGateway) TrainModel(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrainModel", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrainModel indicates an expected call of TrainModel
+func (mr *MockGatewayMockRecorder) TrainModel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrainModel", reflect.TypeOf((*MockGateway)(nil).TrainModel), arg0, arg1, arg2)
+}
+
 // WarmupIndices mocks base method
 func (m *MockGateway) WarmupIndices(arg0 context.Context, arg1 string) ([]byte, error) {
 	m.ctrl.T.Helper()
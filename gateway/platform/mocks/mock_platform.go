@@ -35,6 +35,51 @@ func (m *MockGateway) EXPECT() *MockGatewayMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method
+func (m *MockGateway) Bulk(arg0 context.Context, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk
+func (mr *MockGatewayMockRecorder) Bulk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockGateway)(nil).Bulk), arg0, arg1)
+}
+
+// CreateIndex mocks base method
+func (m *MockGateway) CreateIndex(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIndex indicates an expected call of CreateIndex
+func (mr *MockGatewayMockRecorder) CreateIndex(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockGateway)(nil).CreateIndex), arg0, arg1, arg2)
+}
+
+// CreatePointInTime mocks base method
+func (m *MockGateway) CreatePointInTime(arg0 context.Context, arg1, arg2 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePointInTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePointInTime indicates an expected call of CreatePointInTime
+func (mr *MockGatewayMockRecorder) CreatePointInTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePointInTime", reflect.TypeOf((*MockGateway)(nil).CreatePointInTime), arg0, arg1, arg2)
+}
+
 // Curl mocks base method
 func (m *MockGateway) Curl(arg0 context.Context, arg1 platform.CurlRequest) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -50,17 +95,76 @@ func (mr *MockGatewayMockRecorder) Curl(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Curl", reflect.TypeOf((*MockGateway)(nil).Curl), arg0, arg1)
 }
 
+// DeletePointInTime mocks base method
+func (m *MockGateway) DeletePointInTime(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePointInTime", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePointInTime indicates an expected call of DeletePointInTime
+func (mr *MockGatewayMockRecorder) DeletePointInTime(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePointInTime", reflect.TypeOf((*MockGateway)(nil).DeletePointInTime), arg0, arg1)
+}
+
+// GetFieldCapabilities mocks base method
+func (m *MockGateway) GetFieldCapabilities(arg0 context.Context, arg1 string, arg2 []string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFieldCapabilities", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFieldCapabilities indicates an expected call of GetFieldCapabilities
+func (mr *MockGatewayMockRecorder) GetFieldCapabilities(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFieldCapabilities", reflect.TypeOf((*MockGateway)(nil).GetFieldCapabilities), arg0, arg1, arg2)
+}
+
+// IndexExists mocks base method
+func (m *MockGateway) IndexExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexExists indicates an expected call of IndexExists
+func (mr *MockGatewayMockRecorder) IndexExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexExists", reflect.TypeOf((*MockGateway)(nil).IndexExists), arg0, arg1)
+}
+
+// Search mocks base method
+func (m *MockGateway) Search(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search
+func (mr *MockGatewayMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockGateway)(nil).Search), arg0, arg1, arg2)
+}
+
 // SearchDistinctValues mocks base method
-func (m *MockGateway) SearchDistinctValues(arg0 context.Context, arg1, arg2 string) ([]byte, error) {
+func (m *MockGateway) SearchDistinctValues(arg0 context.Context, arg1, arg2 string, arg3 map[string]interface{}) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchDistinctValues", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "SearchDistinctValues", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchDistinctValues indicates an expected call of SearchDistinctValues
-func (mr *MockGatewayMockRecorder) SearchDistinctValues(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockGatewayMockRecorder) SearchDistinctValues(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchDistinctValues", reflect.TypeOf((*MockGateway)(nil).SearchDistinctValues), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchDistinctValues", reflect.TypeOf((*MockGateway)(nil).SearchDistinctValues), arg0, arg1, arg2, arg3)
 }
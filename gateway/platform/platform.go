@@ -13,6 +13,7 @@ package platform
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -20,18 +21,41 @@ import (
 	"opensearch-cli/entity"
 	"opensearch-cli/entity/platform"
 	gw "opensearch-cli/gateway"
+	"strings"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
-const search = "_search"
+const (
+	search = "_search"
+	bulk   = "_bulk"
+
+	pointInTimeURL   = "_search/point_in_time"
+	pointInTimeQuery = "keep_alive=%s"
+	// bulkContentType is content type of bulk request body
+	bulkContentType   = "application/x-ndjson"
+	fieldCapabilities = "_field_caps"
+	// ignore missing indices, so that caller can check which index exists from response
+	fieldCapabilitiesQuery = "ignore_unavailable=true&allow_no_indices=true"
+	// DistinctGroupName is name of the composite aggregation and its source
+	DistinctGroupName = "items"
+	// DistinctValuesPageSize is number of distinct values fetched by single request
+	DistinctValuesPageSize = 1000
+)
 
 //go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_platform.go -package=mocks . Gateway
 
 // Gateway interface to call OpenSearch
 type Gateway interface {
-	SearchDistinctValues(ctx context.Context, index string, field string) ([]byte, error)
+	SearchDistinctValues(ctx context.Context, index string, field string, after map[string]interface{}) ([]byte, error)
 	Curl(ctx context.Context, request platform.CurlRequest) ([]byte, error)
+	GetFieldCapabilities(ctx context.Context, index string, fields []string) ([]byte, error)
+	Bulk(ctx context.Context, payload []byte) ([]byte, error)
+	IndexExists(ctx context.Context, index string) (bool, error)
+	CreateIndex(ctx context.Context, index string, payload interface{}) ([]byte, error)
+	Search(ctx context.Context, index string, payload interface{}) ([]byte, error)
+	CreatePointInTime(ctx context.Context, index string, keepAlive string) ([]byte, error)
+	DeletePointInTime(ctx context.Context, pitID string) error
 }
 
 type gateway struct {
@@ -46,13 +70,23 @@ func New(c *client.Client, p *entity.Profile) (Gateway, error) {
 	}
 	return &gateway{*g}, nil
 }
-func buildPayload(field string) *platform.SearchRequest {
+func buildPayload(field string, after map[string]interface{}) *platform.SearchRequest {
 	return &platform.SearchRequest{
 		Size: 0, // This will skip data in the response
 		Agg: platform.Aggregate{
-			Group: platform.DistinctGroups{
-				Term: platform.Terms{
-					Field: field,
+			Group: platform.CompositeGroups{
+				Composite: platform.Composite{
+					Size: DistinctValuesPageSize,
+					Sources: []map[string]platform.DistinctGroups{
+						{
+							DistinctGroupName: {
+								Term: platform.Terms{
+									Field: field,
+								},
+							},
+						},
+					},
+					After: after,
 				},
 			},
 		},
@@ -68,13 +102,14 @@ func (g *gateway) buildSearchURL(index string) (*url.URL, error) {
 	return endpoint, nil
 }
 
-// SearchDistinctValues gets distinct values on index for given field
-func (g *gateway) SearchDistinctValues(ctx context.Context, index string, field string) ([]byte, error) {
+// SearchDistinctValues gets page of distinct values on index for given field using composite aggregation,
+// after is the after_key from previous page, nil for first page
+func (g *gateway) SearchDistinctValues(ctx context.Context, index string, field string, after map[string]interface{}) ([]byte, error) {
 	searchURL, err := g.buildSearchURL(index)
 	if err != nil {
 		return nil, err
 	}
-	searchRequest, err := g.BuildRequest(ctx, http.MethodGet, buildPayload(field), searchURL.String(), gw.GetDefaultHeaders())
+	searchRequest, err := g.BuildRequest(ctx, http.MethodGet, buildPayload(field, after), searchURL.String(), gw.GetDefaultHeaders())
 	if err != nil {
 		return nil, err
 	}
@@ -127,3 +162,140 @@ func (g *gateway) buildURL(request platform.CurlRequest) (*url.URL, error) {
 	endpoint.RawQuery = request.QueryParams
 	return endpoint, nil
 }
+
+func (g *gateway) buildFieldCapabilitiesURL(index string, fields []string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf("%s/%s", index, fieldCapabilities)
+	endpoint.RawQuery = fmt.Sprintf("fields=%s&%s", url.QueryEscape(strings.Join(fields, ",")), fieldCapabilitiesQuery)
+	return endpoint, nil
+}
+
+// GetFieldCapabilities gets type and capabilities of fields on indices matched by index, indices which
+// do not exist are ignored
+func (g *gateway) GetFieldCapabilities(ctx context.Context, index string, fields []string) ([]byte, error) {
+	fieldCapsURL, err := g.buildFieldCapabilitiesURL(index, fields)
+	if err != nil {
+		return nil, err
+	}
+	fieldCapsRequest, err := g.BuildRequest(ctx, http.MethodGet, nil, fieldCapsURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(fieldCapsRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (g *gateway) buildPathURL(path string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	return endpoint, nil
+}
+
+// Bulk sends newline delimited actions and documents to bulk API, request rejected with status 429 or 503
+// fails with RequestError so that it can be retried
+func (g *gateway) Bulk(ctx context.Context, payload []byte) ([]byte, error) {
+	bulkURL, err := g.buildPathURL(bulk)
+	if err != nil {
+		return nil, err
+	}
+	bulkRequest, err := g.BuildCurlRequest(ctx, http.MethodPost, payload, bulkURL.String(), map[string]string{
+		"content-type": bulkContentType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Execute(bulkRequest)
+	if r, ok := err.(*platform.RequestError); ok && r.StatusCode() != http.StatusTooManyRequests && r.StatusCode() != http.StatusServiceUnavailable {
+		return nil, errors.New(r.GetResponse())
+	}
+	return response, err
+}
+
+// IndexExists checks whether index exists
+func (g *gateway) IndexExists(ctx context.Context, index string) (bool, error) {
+	indexURL, err := g.buildPathURL(index)
+	if err != nil {
+		return false, err
+	}
+	indexRequest, err := g.BuildRequest(ctx, http.MethodHead, nil, indexURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return false, err
+	}
+	_, err = g.Execute(indexRequest)
+	if err == nil {
+		return true, nil
+	}
+	if r, ok := err.(*platform.RequestError); ok && r.StatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
+
+// CreateIndex creates index with settings and mappings given by payload
+func (g *gateway) CreateIndex(ctx context.Context, index string, payload interface{}) ([]byte, error) {
+	indexURL, err := g.buildPathURL(index)
+	if err != nil {
+		return nil, err
+	}
+	indexRequest, err := g.BuildRequest(ctx, http.MethodPut, payload, indexURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(indexRequest, http.StatusOK)
+}
+
+// Search searches documents of index with query DSL given by payload, every index is searched if index is empty
+func (g *gateway) Search(ctx context.Context, index string, payload interface{}) ([]byte, error) {
+	path := search
+	if len(index) > 0 {
+		path = fmt.Sprintf("%s/%s", index, search)
+	}
+	searchURL, err := g.buildPathURL(path)
+	if err != nil {
+		return nil, err
+	}
+	searchRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, searchURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(searchRequest, http.StatusOK)
+}
+
+// CreatePointInTime creates point in time of index which is kept alive for keepAlive duration
+func (g *gateway) CreatePointInTime(ctx context.Context, index string, keepAlive string) ([]byte, error) {
+	pitURL, err := g.buildPathURL(fmt.Sprintf("%s/%s", index, pointInTimeURL))
+	if err != nil {
+		return nil, err
+	}
+	pitURL.RawQuery = fmt.Sprintf(pointInTimeQuery, url.QueryEscape(keepAlive))
+	pitRequest, err := g.BuildRequest(ctx, http.MethodPost, nil, pitURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(pitRequest, http.StatusOK)
+}
+
+// DeletePointInTime deletes point in time before it expires
+func (g *gateway) DeletePointInTime(ctx context.Context, pitID string) error {
+	pitURL, err := g.buildPathURL(pointInTimeURL)
+	if err != nil {
+		return err
+	}
+	pitRequest, err := g.BuildRequest(ctx, http.MethodDelete, platform.DeletePointInTimeRequest{
+		PITIDs: []string{pitID},
+	}, pitURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return err
+	}
+	_, err = g.Call(pitRequest, http.StatusOK)
+	return err
+}
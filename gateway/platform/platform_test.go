@@ -46,7 +46,10 @@ func getTestClient(t *testing.T, responseData string, code int) *client.Client {
 		err := json.Unmarshal(resBytes, &body)
 		assert.NoError(t, err)
 		assert.EqualValues(t, body.Size, 0)
-		assert.EqualValues(t, body.Agg.Group.Term.Field, "day_of_week")
+		composite := body.Agg.Group.Composite
+		assert.EqualValues(t, DistinctValuesPageSize, composite.Size)
+		assert.EqualValues(t, "day_of_week", composite.Sources[0][DistinctGroupName].Term.Field)
+		assert.EqualValues(t, map[string]interface{}{DistinctGroupName: "Dairy"}, composite.After)
 		assert.EqualValues(t, len(req.Header), 2)
 		return &http.Response{
 			StatusCode: code,
@@ -94,7 +97,7 @@ func TestGateway_SearchDistinctValues(t *testing.T) {
 			Password: "admin",
 		})
 		assert.NoError(t, err)
-		actual, err := testGateway.SearchDistinctValues(ctx, "test_index", "day_of_week")
+		actual, err := testGateway.SearchDistinctValues(ctx, "test_index", "day_of_week", map[string]interface{}{DistinctGroupName: "Dairy"})
 		assert.NoError(t, err)
 		assert.EqualValues(t, actual, responseData)
 	})
@@ -106,11 +109,49 @@ func TestGateway_SearchDistinctValues(t *testing.T) {
 			Password: "admin",
 		})
 		assert.NoError(t, err)
-		_, err = testGateway.SearchDistinctValues(ctx, "test_index", "day_of_week")
+		_, err = testGateway.SearchDistinctValues(ctx, "test_index", "day_of_week", map[string]interface{}{DistinctGroupName: "Dairy"})
 		assert.EqualError(t, err, "No connection found")
 	})
 }
 
+func TestGateway_GetFieldCapabilities(t *testing.T) {
+	ctx := context.Background()
+	getFieldCapsClient := func(t *testing.T, response []byte, code int) *client.Client {
+		return mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, "http://localhost:9200/order%2A/_field_caps?fields=timestamp%2Cvalue&ignore_unavailable=true&allow_no_indices=true", req.URL.String())
+			assert.EqualValues(t, http.MethodGet, req.Method)
+			return &http.Response{
+				StatusCode: code,
+				Body:       io.NopCloser(bytes.NewBuffer(response)),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+	}
+	t.Run("get field capabilities succeeded", func(t *testing.T) {
+		responseData := helperLoadBytes(t, "field_caps_result.json")
+		testGateway, err := New(getFieldCapsClient(t, responseData, 200), &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		actual, err := testGateway.GetFieldCapabilities(ctx, "order*", []string{"timestamp", "value"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, responseData, actual)
+	})
+	t.Run("get field capabilities failed", func(t *testing.T) {
+		testGateway, err := New(getFieldCapsClient(t, []byte("connection failed"), 400), &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.GetFieldCapabilities(ctx, "order*", []string{"timestamp", "value"})
+		assert.EqualError(t, err, "connection failed")
+	})
+}
+
 func getErrorResponse() []byte {
 	return []byte(`{
   "error" : {
@@ -241,3 +282,131 @@ func TestGatewayCurl(t *testing.T) {
 		assert.EqualValues(t, 501, requestError.StatusCode())
 	})
 }
+
+func TestGateway_Bulk(t *testing.T) {
+	ctx := context.Background()
+	p := &entity.Profile{
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	}
+	payload := []byte("{\"index\":{\"_index\":\"index1\"}}\n{\"a\":1}\n")
+	t.Run("bulk succeeded", func(t *testing.T) {
+		testClient := getCurlTestClient(t, "http://localhost:9200/_bulk", payload, map[string]string{"content-type": "application/x-ndjson"}, `{"errors":false}`, 200)
+		testGateway, err := New(testClient, p)
+		assert.NoError(t, err)
+		actual, err := testGateway.Bulk(ctx, payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"errors":false}`, string(actual))
+	})
+	t.Run("bulk failed", func(t *testing.T) {
+		testClient := getCurlTestClient(t, "http://localhost:9200/_bulk", payload, nil, "bad request", 400)
+		testGateway, err := New(testClient, p)
+		assert.NoError(t, err)
+		_, err = testGateway.Bulk(ctx, payload)
+		assert.EqualError(t, err, "bad request")
+	})
+	t.Run("bulk throttled", func(t *testing.T) {
+		testClient := getCurlTestClient(t, "http://localhost:9200/_bulk", payload, nil, "rejected", 429)
+		testClient.HTTPClient.RetryMax = 0
+		testGateway, err := New(testClient, p)
+		assert.NoError(t, err)
+		_, err = testGateway.Bulk(ctx, payload)
+		requestError, ok := err.(*platform.RequestError)
+		assert.True(t, ok)
+		assert.EqualValues(t, 429, requestError.StatusCode())
+	})
+}
+
+func TestGateway_IndexExists(t *testing.T) {
+	ctx := context.Background()
+	p := &entity.Profile{
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	}
+	t.Run("index exists", func(t *testing.T) {
+		testGateway, err := New(getCurlTestClient(t, "http://localhost:9200/index1", []byte{}, nil, "", 200), p)
+		assert.NoError(t, err)
+		exists, err := testGateway.IndexExists(ctx, "index1")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+	t.Run("index missing", func(t *testing.T) {
+		testGateway, err := New(getCurlTestClient(t, "http://localhost:9200/index1", []byte{}, nil, "", 404), p)
+		assert.NoError(t, err)
+		exists, err := testGateway.IndexExists(ctx, "index1")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+	t.Run("request failed", func(t *testing.T) {
+		testGateway, err := New(getCurlTestClient(t, "http://localhost:9200/index1", []byte{}, nil, "", 401), p)
+		assert.NoError(t, err)
+		_, err = testGateway.IndexExists(ctx, "index1")
+		assert.Error(t, err)
+	})
+}
+
+func TestGateway_CreateIndex(t *testing.T) {
+	ctx := context.Background()
+	testClient := getCurlTestClient(t, "http://localhost:9200/index1", []byte(`{"settings":{}}`), nil, `{"acknowledged":true}`, 200)
+	testGateway, err := New(testClient, &entity.Profile{
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	})
+	assert.NoError(t, err)
+	actual, err := testGateway.CreateIndex(ctx, "index1", map[string]interface{}{"settings": map[string]interface{}{}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, `{"acknowledged":true}`, string(actual))
+}
+
+func TestGateway_Search(t *testing.T) {
+	ctx := context.Background()
+	p := &entity.Profile{
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	}
+	payload := map[string]interface{}{"size": 1}
+	t.Run("search index", func(t *testing.T) {
+		testGateway, err := New(getCurlTestClient(t, "http://localhost:9200/index1/_search", []byte(`{"size":1}`), nil, `{"hits":{}}`, 200), p)
+		assert.NoError(t, err)
+		actual, err := testGateway.Search(ctx, "index1", payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"hits":{}}`, string(actual))
+	})
+	t.Run("search point in time", func(t *testing.T) {
+		testGateway, err := New(getCurlTestClient(t, "http://localhost:9200/_search", []byte(`{"size":1}`), nil, `{"hits":{}}`, 200), p)
+		assert.NoError(t, err)
+		_, err = testGateway.Search(ctx, "", payload)
+		assert.NoError(t, err)
+	})
+	t.Run("search failed", func(t *testing.T) {
+		testGateway, err := New(getCurlTestClient(t, "http://localhost:9200/index1/_search", []byte(`{"size":1}`), nil, "no such index", 404), p)
+		assert.NoError(t, err)
+		_, err = testGateway.Search(ctx, "index1", payload)
+		assert.EqualError(t, err, "no such index")
+	})
+}
+
+func TestGateway_PointInTime(t *testing.T) {
+	ctx := context.Background()
+	p := &entity.Profile{
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	}
+	t.Run("create point in time", func(t *testing.T) {
+		testGateway, err := New(getCurlTestClient(t, "http://localhost:9200/index1/_search/point_in_time?keep_alive=1m", []byte{}, nil, `{"pit_id":"abc"}`, 200), p)
+		assert.NoError(t, err)
+		actual, err := testGateway.CreatePointInTime(ctx, "index1", "1m")
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"pit_id":"abc"}`, string(actual))
+	})
+	t.Run("delete point in time", func(t *testing.T) {
+		testGateway, err := New(getCurlTestClient(t, "http://localhost:9200/_search/point_in_time", []byte(`{"pit_id":["abc"]}`), nil, `{"pits":[]}`, 200), p)
+		assert.NoError(t, err)
+		assert.NoError(t, testGateway.DeletePointInTime(ctx, "abc"))
+	})
+}
@@ -0,0 +1,174 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"opensearch-cli/entity"
+	"os"
+	"strings"
+)
+
+const (
+	//pinPrefix is optional prefix of pin, same as the one used by curl --pinnedpubkey
+	pinPrefix = "sha256//"
+)
+
+//tlsVersions maps minimum TLS versions allowed in profile
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// GetTLSConfig builds TLS configuration of profile. Certificate of cluster is verified against CA certificates of
+// system and CA bundles of profile unless verification is disabled, TLS 1.2 is required unless minimum version is set.
+// Pins are checked even if verification is disabled
+func GetTLSConfig(p *entity.Profile) (*tls.Config, error) {
+	settings := entity.TLS{}
+	if p.TLS != nil {
+		settings = *p.TLS
+	}
+	config := &tls.Config{
+		ServerName:         settings.ServerName,
+		InsecureSkipVerify: settings.Verify != nil && !*settings.Verify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if len(settings.MinVersion) > 0 {
+		version, ok := tlsVersions[settings.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("invalid minimum TLS version %s, allowed versions are 1.0, 1.1, 1.2 and 1.3", settings.MinVersion)
+		}
+		config.MinVersion = version
+	}
+	trust := p.Certificate
+	if trust == nil {
+		trust = &entity.Trust{}
+	}
+	if trust.ClientCertificateFilePath != nil && trust.ClientKeyFilePath != nil {
+		cert, err := tls.LoadX509KeyPair(*trust.ClientCertificateFilePath, *trust.ClientKeyFilePath)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error creating x509 keypair from client cert file %s and client key file %s",
+				*trust.ClientCertificateFilePath, *trust.ClientKeyFilePath)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	bundles := settings.CABundles
+	if trust.CAFilePath != nil {
+		bundles = append([]string{*trust.CAFilePath}, bundles...)
+	}
+	useSystemCA := settings.SystemCA == nil || *settings.SystemCA
+	if len(bundles) > 0 || !useSystemCA {
+		pool, err := getCertPool(bundles, useSystemCA)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if len(settings.Pins) > 0 {
+		verify, err := verifyPins(settings.Pins, !config.InsecureSkipVerify)
+		if err != nil {
+			return nil, err
+		}
+		config.VerifyConnection = verify
+	}
+	return config, nil
+}
+
+//getCertPool returns pool of CA certificates from bundles, CA certificates of system are added if useSystemCA is true
+func getCertPool(bundles []string, useSystemCA bool) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if useSystemCA {
+		//system pool is not available on every platform, bundles are trusted anyway
+		if systemPool, err := x509.SystemCertPool(); err == nil && systemPool != nil {
+			pool = systemPool
+		}
+	}
+	for _, bundle := range bundles {
+		caCert, err := os.ReadFile(bundle)
+		if err != nil {
+			return nil, fmt.Errorf("error opening certificate file %s, error: %s", bundle, err)
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in certificate file %s", bundle)
+		}
+	}
+	return pool, nil
+}
+
+//verifyPins returns function which checks that public key of certificate presented by cluster matches any pin.
+//If certificates are verified, public keys of verified chains are checked, otherwise only public key of leaf
+//certificate is checked since other certificates presented by cluster are not trusted
+func verifyPins(pins []string, verified bool) (func(tls.ConnectionState) error, error) {
+	allowed := map[string]bool{}
+	for _, pin := range pins {
+		pin = strings.TrimPrefix(pin, pinPrefix)
+		if hash, err := base64.StdEncoding.DecodeString(pin); err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("invalid pin %s, pin must be base64 encoded SHA-256 hash of public key", pin)
+		}
+		allowed[pin] = true
+	}
+	return func(state tls.ConnectionState) error {
+		chains := state.VerifiedChains
+		if !verified && len(state.PeerCertificates) > 0 {
+			chains = [][]*x509.Certificate{state.PeerCertificates[:1]}
+		}
+		for _, chain := range chains {
+			for _, cert := range chain {
+				hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+				if allowed[base64.StdEncoding.EncodeToString(hash[:])] {
+					return nil
+				}
+			}
+		}
+		return errors.New("certificate of cluster does not match any pinned public key")
+	}, nil
+}
+
+//TLSTransport is transport other than *http.Transport which can connect to cluster using TLS configuration of profile
+type TLSTransport interface {
+	http.RoundTripper
+	//WithTLSConfig returns transport which uses TLS configuration
+	WithTLSConfig(config *tls.Config) http.RoundTripper
+}
+
+// BuildTransport returns transport which connects to cluster using TLS configuration of profile.
+// Base transport is cloned so that its proxy and keep-alive settings are kept, base transport which is neither
+// *http.Transport nor TLSTransport is rejected since TLS configuration cannot be applied to it.
+// Default transport is used if base is nil
+func BuildTransport(base http.RoundTripper, p *entity.Profile) (http.RoundTripper, error) {
+	tlsConfig, err := GetTLSConfig(p)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	switch transport := base.(type) {
+	case *http.Transport:
+		transport = transport.Clone()
+		transport.TLSClientConfig = tlsConfig
+		return transport, nil
+	case TLSTransport:
+		return transport.WithTLSConfig(tlsConfig), nil
+	default:
+		return nil, fmt.Errorf("transport %T does not support TLS configuration of profile", base)
+	}
+}
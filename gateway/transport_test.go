@@ -0,0 +1,210 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/entity"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//roundTripper is transport which cannot use TLS configuration
+type roundTripper struct{}
+
+func (roundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, http.ErrNotSupported
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+//writeServerCA writes certificate of server as PEM file which can be used as CA bundle
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func getPin(server *httptest.Server) string {
+	hash := sha256.Sum256(server.Certificate().RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+//startServerWithAppendedCert starts server whose leaf certificate has its own key and which presents certificate of
+//other server after its leaf certificate
+func startServerWithAppendedCert(t *testing.T, other *httptest.Server) *httptest.Server {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "leaf"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	leaf, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{
+		Certificate: [][]byte{leaf, other.Certificate().Raw},
+		PrivateKey:  key,
+	}}}
+	server.StartTLS()
+	return server
+}
+
+//connect sends request to server with transport built for TLS settings
+func connect(t *testing.T, server *httptest.Server, settings *entity.TLS) error {
+	transport, err := BuildTransport(http.DefaultTransport, &entity.Profile{TLS: settings})
+	assert.NoError(t, err)
+	response, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
+
+func TestBuildTransportConnection(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caBundle := writeServerCA(t, server)
+
+	t.Run("certificate is verified by default", func(t *testing.T) {
+		err := connect(t, server, nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+	t.Run("certificate signed by CA bundle", func(t *testing.T) {
+		assert.NoError(t, connect(t, server, &entity.TLS{CABundles: []string{caBundle}}))
+	})
+	t.Run("CA bundle without system CA", func(t *testing.T) {
+		assert.NoError(t, connect(t, server, &entity.TLS{CABundles: []string{caBundle}, SystemCA: boolPtr(false)}))
+	})
+	t.Run("server name", func(t *testing.T) {
+		assert.NoError(t, connect(t, server, &entity.TLS{CABundles: []string{caBundle}, ServerName: "example.com"}))
+		assert.Error(t, connect(t, server, &entity.TLS{CABundles: []string{caBundle}, ServerName: "opensearch.example.org"}))
+	})
+	t.Run("verification disabled", func(t *testing.T) {
+		assert.NoError(t, connect(t, server, &entity.TLS{Verify: boolPtr(false)}))
+	})
+	t.Run("matching pin", func(t *testing.T) {
+		assert.NoError(t, connect(t, server, &entity.TLS{CABundles: []string{caBundle}, Pins: []string{"sha256//" + getPin(server)}}))
+	})
+	t.Run("mismatching pin is rejected even if verification is disabled", func(t *testing.T) {
+		hash := sha256.Sum256([]byte("other key"))
+		err := connect(t, server, &entity.TLS{Verify: boolPtr(false), Pins: []string{base64.StdEncoding.EncodeToString(hash[:])}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate of cluster does not match any pinned public key")
+	})
+	t.Run("pinned certificate appended to chain is rejected", func(t *testing.T) {
+		attacker := startServerWithAppendedCert(t, server)
+		defer attacker.Close()
+		pins := []string{getPin(server)}
+		err := connect(t, attacker, &entity.TLS{Verify: boolPtr(false), Pins: pins})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate of cluster does not match any pinned public key")
+		err = connect(t, attacker, &entity.TLS{CABundles: []string{writeServerCA(t, attacker)}, Pins: pins})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate of cluster does not match any pinned public key")
+	})
+}
+
+func TestGetTLSConfig(t *testing.T) {
+	t.Run("secure defaults", func(t *testing.T) {
+		config, err := GetTLSConfig(&entity.Profile{})
+		assert.NoError(t, err)
+		assert.False(t, config.InsecureSkipVerify)
+		assert.EqualValues(t, tls.VersionTLS12, config.MinVersion)
+		assert.Nil(t, config.RootCAs)
+		assert.Nil(t, config.VerifyConnection)
+	})
+	t.Run("minimum version", func(t *testing.T) {
+		config, err := GetTLSConfig(&entity.Profile{TLS: &entity.TLS{MinVersion: "1.3", ServerName: "node-1"}})
+		assert.NoError(t, err)
+		assert.EqualValues(t, tls.VersionTLS13, config.MinVersion)
+		assert.EqualValues(t, "node-1", config.ServerName)
+	})
+	t.Run("invalid minimum version", func(t *testing.T) {
+		_, err := GetTLSConfig(&entity.Profile{TLS: &entity.TLS{MinVersion: "1.4"}})
+		assert.EqualError(t, err, "invalid minimum TLS version 1.4, allowed versions are 1.0, 1.1, 1.2 and 1.3")
+	})
+	t.Run("invalid pin", func(t *testing.T) {
+		_, err := GetTLSConfig(&entity.Profile{TLS: &entity.TLS{Pins: []string{"sha256//abc"}}})
+		assert.EqualError(t, err, "invalid pin abc, pin must be base64 encoded SHA-256 hash of public key")
+	})
+	t.Run("CA bundle without certificates", func(t *testing.T) {
+		_, err := GetTLSConfig(&entity.Profile{TLS: &entity.TLS{CABundles: []string{"testdata/client.key"}}})
+		assert.EqualError(t, err, "no certificates found in certificate file testdata/client.key")
+	})
+	t.Run("missing CA bundle", func(t *testing.T) {
+		_, err := GetTLSConfig(&entity.Profile{TLS: &entity.TLS{CABundles: []string{"testdata/ca1.cert"}}})
+		assert.EqualError(t, err, "error opening certificate file testdata/ca1.cert, error: open testdata/ca1.cert: no such file or directory")
+	})
+}
+
+func TestBuildTransport(t *testing.T) {
+	t.Run("proxy and keep-alive settings of base transport are kept", func(t *testing.T) {
+		base := &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			IdleConnTimeout: 90 * time.Second,
+			MaxIdleConns:    100,
+		}
+		transport, err := BuildTransport(base, &entity.Profile{TLS: &entity.TLS{Verify: boolPtr(false)}})
+		assert.NoError(t, err)
+		result, ok := transport.(*http.Transport)
+		assert.True(t, ok)
+		assert.NotSame(t, base, result)
+		assert.NotNil(t, result.Proxy)
+		assert.EqualValues(t, base.IdleConnTimeout, result.IdleConnTimeout)
+		assert.EqualValues(t, base.MaxIdleConns, result.MaxIdleConns)
+		assert.True(t, result.TLSClientConfig.InsecureSkipVerify)
+		assert.True(t, base.TLSClientConfig == nil || !base.TLSClientConfig.InsecureSkipVerify)
+	})
+	t.Run("transport of tests is kept", func(t *testing.T) {
+		testClient := mocks.NewTestClient(nil)
+		transport, err := BuildTransport(testClient.HTTPClient.HTTPClient.Transport, &entity.Profile{})
+		assert.NoError(t, err)
+		assert.IsType(t, mocks.RoundTripFunc(nil), transport)
+	})
+	t.Run("transport without TLS configuration is rejected", func(t *testing.T) {
+		_, err := BuildTransport(roundTripper{}, &entity.Profile{TLS: &entity.TLS{Verify: boolPtr(false)}})
+		assert.EqualError(t, err, "transport gateway.roundTripper does not support TLS configuration of profile")
+	})
+	t.Run("invalid settings", func(t *testing.T) {
+		_, err := BuildTransport(http.DefaultTransport, &entity.Profile{TLS: &entity.TLS{MinVersion: "2"}})
+		assert.EqualError(t, err, "invalid minimum TLS version 2, allowed versions are 1.0, 1.1, 1.2 and 1.3")
+	})
+}
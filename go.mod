@@ -9,6 +9,7 @@ require (
 	github.com/hashicorp/go-retryablehttp v0.6.7
 	github.com/spf13/cobra v1.5.0
 	github.com/stretchr/testify v1.6.1
+	golang.org/x/crypto v0.0.0-20220525230936-793ad666bf5e
 	golang.org/x/term v0.0.0-20201210144234-2321bbc49cbf
 	gopkg.in/yaml.v2 v2.4.0
 	gopkg.in/yaml.v3 v3.0.0
@@ -14,12 +14,15 @@ package ad
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"opensearch-cli/controller/ad"
 	entity "opensearch-cli/entity/ad"
-	"opensearch-cli/mapper"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
 // Handler is facade for controller
@@ -34,9 +37,10 @@ func New(controller ad.Controller) *Handler {
 	}
 }
 
-// CreateAnomalyDetector creates detector based on file configurations
-func CreateAnomalyDetector(h *Handler, fileName string) error {
-	return h.CreateAnomalyDetector(fileName)
+// CreateAnomalyDetector creates detector based on file configurations, if fanOut is true,
+// one detector is created per value of partition field
+func CreateAnomalyDetector(h *Handler, fileName string, fanOut bool) error {
+	return h.CreateAnomalyDetector(fileName, fanOut)
 }
 
 // GenerateAnomalyDetector generate sample detector to provide skeleton for users
@@ -53,23 +57,23 @@ func GenerateAnomalyDetector() ([]byte, error) {
 				Field:           []string{},
 			},
 		},
-		Filter:         []byte("{}"),
-		Interval:       "10m",
-		Delay:          "1m",
-		Start:          false,
-		PartitionField: mapper.StringToStringPtr(""),
+		Filter:        []byte("{}"),
+		Interval:      "10m",
+		Delay:         "1m",
+		Start:         false,
+		CategoryField: []string{},
 	}, "", "  ")
 }
 
-// CreateAnomalyDetector creates detector based on file configurations
-func (h *Handler) CreateAnomalyDetector(fileName string) error {
+//readCreateDetectorRequest reads detector configuration from file
+func readCreateDetectorRequest(fileName string) (*entity.CreateDetectorRequest, error) {
 	if len(fileName) < 1 {
-		return fmt.Errorf("file name cannot be empty")
+		return nil, fmt.Errorf("file name cannot be empty")
 	}
 
 	jsonFile, err := os.Open(fileName)
 	if err != nil {
-		return fmt.Errorf("failed to open file %s due to %v", fileName, err)
+		return nil, fmt.Errorf("failed to open file %s due to %v", fileName, err)
 	}
 	defer func() {
 		err := jsonFile.Close()
@@ -81,10 +85,21 @@ func (h *Handler) CreateAnomalyDetector(fileName string) error {
 	var request entity.CreateDetectorRequest
 	err = json.Unmarshal(byteValue, &request)
 	if err != nil {
-		return fmt.Errorf("file %s cannot be accepted due to %v", fileName, err)
+		return nil, fmt.Errorf("file %s cannot be accepted due to %v", fileName, err)
 	}
+	return &request, nil
+}
+
+// CreateAnomalyDetector creates detector based on file configurations, if fanOut is true,
+// one detector is created per value of partition field
+func (h *Handler) CreateAnomalyDetector(fileName string, fanOut bool) error {
+	request, err := readCreateDetectorRequest(fileName)
+	if err != nil {
+		return err
+	}
+	request.FanOut = fanOut
 	ctx := context.Background()
-	names, err := h.CreateMultiEntityAnomalyDetector(ctx, request, true, true)
+	names, err := h.CreateMultiEntityAnomalyDetector(ctx, *request, true, true)
 	if err != nil {
 		return err
 	}
@@ -259,3 +274,347 @@ func (h *Handler) UpdateDetector(fileName string, force bool, start bool) error
 func UpdateAnomalyDetector(h *Handler, fileName string, force bool, start bool) error {
 	return h.UpdateDetector(fileName, force, start)
 }
+
+// GetAnomalyDetectorStatusByNamePattern gets detector status based on detector name pattern
+func GetAnomalyDetectorStatusByNamePattern(h *Handler, detector string) ([]*entity.DetectorStatus, error) {
+	return h.GetAnomalyDetectorStatusByNamePattern(detector)
+}
+
+// GetAnomalyDetectorStatusByNamePattern gets detector status based on detector name pattern
+func (h *Handler) GetAnomalyDetectorStatusByNamePattern(name string) ([]*entity.DetectorStatus, error) {
+
+	ctx := context.Background()
+	return h.GetDetectorStatusByName(ctx, name, false)
+}
+
+// GetAnomalyDetectorStatusByID gets detector status based on detector id
+func GetAnomalyDetectorStatusByID(h *Handler, detector string) ([]*entity.DetectorStatus, error) {
+	return h.GetAnomalyDetectorStatusByID(detector)
+}
+
+// GetAnomalyDetectorStatusByID gets detector status based on detector id
+func (h *Handler) GetAnomalyDetectorStatusByID(ID string) ([]*entity.DetectorStatus, error) {
+
+	ctx := context.Background()
+	status, err := h.GetDetectorStatus(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	return []*entity.DetectorStatus{status}, nil
+}
+
+// SearchAnomalyResultsByNamePattern searches anomaly results of detectors matched by name patterns
+func SearchAnomalyResultsByNamePattern(h *Handler, detectors []string, filter entity.AnomalyResultFilter, process func([]*entity.AnomalyResultOutput) error) error {
+	return h.SearchAnomalyResultsByNamePattern(detectors, filter, process)
+}
+
+// SearchAnomalyResultsByNamePattern searches anomaly results of detectors matched by name patterns
+func (h *Handler) SearchAnomalyResultsByNamePattern(names []string, filter entity.AnomalyResultFilter, process func([]*entity.AnomalyResultOutput) error) error {
+
+	ctx := context.Background()
+	return h.SearchAnomalyResultsByName(ctx, names, filter, process)
+}
+
+// SearchAnomalyResultsByID searches anomaly results of detectors based on detector ids
+func SearchAnomalyResultsByID(h *Handler, detectors []string, filter entity.AnomalyResultFilter, process func([]*entity.AnomalyResultOutput) error) error {
+	return h.SearchAnomalyResultsByID(detectors, filter, process)
+}
+
+// SearchAnomalyResultsByID searches anomaly results of detectors based on detector ids
+func (h *Handler) SearchAnomalyResultsByID(IDs []string, filter entity.AnomalyResultFilter, process func([]*entity.AnomalyResultOutput) error) error {
+
+	ctx := context.Background()
+	return h.SearchAnomalyResults(ctx, IDs, filter, process)
+}
+
+// StartHistoricalAnalysisByNamePattern starts historical analysis of detectors based on detector name pattern,
+// IDs of detectors whose analysis is started are returned
+func StartHistoricalAnalysisByNamePattern(h *Handler, detector string, from string, to string) ([]string, error) {
+	return h.StartHistoricalAnalysisByNamePattern(detector, from, to)
+}
+
+// StartHistoricalAnalysisByNamePattern starts historical analysis of detectors based on detector name pattern
+func (h *Handler) StartHistoricalAnalysisByNamePattern(detector string, from string, to string) ([]string, error) {
+
+	ctx := context.Background()
+	return h.StartHistoricalAnalysisByName(ctx, detector, from, to, true)
+}
+
+// StartHistoricalAnalysisByID starts historical analysis of detector based on detector id
+func StartHistoricalAnalysisByID(h *Handler, detector string, from string, to string) ([]string, error) {
+	return h.StartHistoricalAnalysisByID(detector, from, to)
+}
+
+// StartHistoricalAnalysisByID starts historical analysis of detector based on detector id
+func (h *Handler) StartHistoricalAnalysisByID(detector string, from string, to string) ([]string, error) {
+
+	ctx := context.Background()
+	if err := h.StartHistoricalAnalysis(ctx, detector, from, to); err != nil {
+		return nil, err
+	}
+	return []string{detector}, nil
+}
+
+// StopHistoricalAnalysisByNamePattern stops historical analysis of detectors based on detector name pattern
+func StopHistoricalAnalysisByNamePattern(h *Handler, detector string) error {
+	return h.StopHistoricalAnalysisByNamePattern(detector)
+}
+
+// StopHistoricalAnalysisByNamePattern stops historical analysis of detectors based on detector name pattern
+func (h *Handler) StopHistoricalAnalysisByNamePattern(detector string) error {
+
+	ctx := context.Background()
+	return h.StopHistoricalAnalysisByName(ctx, detector, true)
+}
+
+// StopHistoricalAnalysisByID stops historical analysis of detector based on detector id
+func StopHistoricalAnalysisByID(h *Handler, detector string) error {
+	return h.StopHistoricalAnalysisByID(detector)
+}
+
+// StopHistoricalAnalysisByID stops historical analysis of detector based on detector id
+func (h *Handler) StopHistoricalAnalysisByID(detector string) error {
+
+	ctx := context.Background()
+	return h.StopHistoricalAnalysis(ctx, detector)
+}
+
+// GetHistoricalAnalysisStatusByNamePattern gets historical analysis status based on detector name pattern
+func GetHistoricalAnalysisStatusByNamePattern(h *Handler, detector string) ([]*entity.HistoricalAnalysisStatus, error) {
+	return h.GetHistoricalAnalysisStatusByNamePattern(detector)
+}
+
+// GetHistoricalAnalysisStatusByNamePattern gets historical analysis status based on detector name pattern
+func (h *Handler) GetHistoricalAnalysisStatusByNamePattern(detector string) ([]*entity.HistoricalAnalysisStatus, error) {
+
+	ctx := context.Background()
+	return h.GetHistoricalAnalysisStatusByName(ctx, detector)
+}
+
+// GetHistoricalAnalysisStatusByID gets historical analysis status based on detector id
+func GetHistoricalAnalysisStatusByID(h *Handler, detector string) ([]*entity.HistoricalAnalysisStatus, error) {
+	return h.GetHistoricalAnalysisStatusByID(detector)
+}
+
+// GetHistoricalAnalysisStatusByID gets historical analysis status based on detector id
+func (h *Handler) GetHistoricalAnalysisStatusByID(detector string) ([]*entity.HistoricalAnalysisStatus, error) {
+
+	ctx := context.Background()
+	status, err := h.GetHistoricalAnalysisStatus(ctx, detector)
+	if err != nil {
+		return nil, err
+	}
+	return []*entity.HistoricalAnalysisStatus{status}, nil
+}
+
+// WaitForHistoricalAnalysis displays progress of historical analysis of detectors until every task is completed
+// or timeout is reached, timeout 0 waits without limit
+func WaitForHistoricalAnalysis(h *Handler, detectors []string, interval time.Duration, timeout time.Duration) ([]*entity.HistoricalAnalysisStatus, error) {
+	return h.WaitForHistoricalAnalysisByID(detectors, interval, timeout)
+}
+
+// WaitForHistoricalAnalysisByID displays progress of historical analysis of detectors until every task is completed
+// or timeout is reached, timeout 0 waits without limit
+func (h *Handler) WaitForHistoricalAnalysisByID(detectors []string, interval time.Duration, timeout time.Duration) ([]*entity.HistoricalAnalysisStatus, error) {
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	result, err := h.WaitForHistoricalAnalysis(ctx, detectors, interval, true)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %s waiting for historical analysis to complete", timeout)
+	}
+	return result, err
+}
+
+// PreviewAnomalyDetector previews detector based on file configurations without creating it
+func PreviewAnomalyDetector(h *Handler, fileName string, from string, to string) ([]*entity.AnomalyResultOutput, error) {
+	return h.PreviewAnomalyDetector(fileName, from, to)
+}
+
+// PreviewAnomalyDetector previews detector based on file configurations without creating it
+func (h *Handler) PreviewAnomalyDetector(fileName string, from string, to string) ([]*entity.AnomalyResultOutput, error) {
+	request, err := readCreateDetectorRequest(fileName)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	return h.Controller.PreviewAnomalyDetector(ctx, *request, from, to)
+}
+
+// PreviewAnomalyDetectorByNamePattern previews existing detectors based on name pattern
+func PreviewAnomalyDetectorByNamePattern(h *Handler, detector string, from string, to string) ([]*entity.AnomalyResultOutput, error) {
+	return h.PreviewAnomalyDetectorByNamePattern(detector, from, to)
+}
+
+// PreviewAnomalyDetectorByNamePattern previews existing detectors based on name pattern
+func (h *Handler) PreviewAnomalyDetectorByNamePattern(detector string, from string, to string) ([]*entity.AnomalyResultOutput, error) {
+
+	ctx := context.Background()
+	return h.PreviewDetectorByName(ctx, detector, from, to)
+}
+
+// PreviewAnomalyDetectorByID previews existing detector based on detectorId
+func PreviewAnomalyDetectorByID(h *Handler, detector string, from string, to string) ([]*entity.AnomalyResultOutput, error) {
+	return h.PreviewAnomalyDetectorByID(detector, from, to)
+}
+
+// PreviewAnomalyDetectorByID previews existing detector based on detectorId
+func (h *Handler) PreviewAnomalyDetectorByID(ID string, from string, to string) ([]*entity.AnomalyResultOutput, error) {
+
+	ctx := context.Background()
+	return h.PreviewDetector(ctx, ID, from, to)
+}
+
+// ValidateAnomalyDetector validates detector based on file configurations without creating it
+func ValidateAnomalyDetector(h *Handler, fileName string) ([]*entity.ValidationProblem, error) {
+	return h.ValidateAnomalyDetector(fileName)
+}
+
+// ValidateAnomalyDetector validates detector based on file configurations without creating it
+func (h *Handler) ValidateAnomalyDetector(fileName string) ([]*entity.ValidationProblem, error) {
+	request, err := readCreateDetectorRequest(fileName)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	return h.Controller.ValidateAnomalyDetector(ctx, *request)
+}
+
+//readCreateDetectorRequests reads detector configuration from file, or from every json file if path is a directory
+func readCreateDetectorRequests(path string) (map[string]entity.CreateDetectorRequest, error) {
+	if len(path) < 1 {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	fileNames := []string{path}
+	if info.IsDir() {
+		if fileNames, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return nil, err
+		}
+	}
+	if len(fileNames) < 1 {
+		return nil, fmt.Errorf("no detector configuration file found in %s", path)
+	}
+	requests := map[string]entity.CreateDetectorRequest{}
+	for _, fileName := range fileNames {
+		request, err := readCreateDetectorRequest(fileName)
+		if err != nil {
+			return nil, err
+		}
+		requests[fileName] = *request
+	}
+	return requests, nil
+}
+
+// PlanAnomalyDetectors compares detectors configuration from file or directory with live detectors, if prunePattern
+// is not empty, live detectors matched by the pattern without configuration file are planned to be deleted
+func PlanAnomalyDetectors(h *Handler, path string, prunePattern string) ([]*entity.DetectorPlan, error) {
+	return h.PlanAnomalyDetectors(path, prunePattern)
+}
+
+// PlanAnomalyDetectors compares detectors configuration from file or directory with live detectors, if prunePattern
+// is not empty, live detectors matched by the pattern without configuration file are planned to be deleted
+func (h *Handler) PlanAnomalyDetectors(path string, prunePattern string) ([]*entity.DetectorPlan, error) {
+	requests, err := readCreateDetectorRequests(path)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	return h.PlanDetectors(ctx, requests, prunePattern)
+}
+
+// ApplyAnomalyDetectors creates, updates or deletes detectors as planned, user is asked to confirm changes if interactive is true
+func ApplyAnomalyDetectors(h *Handler, plans []*entity.DetectorPlan, interactive bool) error {
+	return h.ApplyAnomalyDetectors(plans, interactive)
+}
+
+// ApplyAnomalyDetectors creates, updates or deletes detectors as planned, user is asked to confirm changes if interactive is true
+func (h *Handler) ApplyAnomalyDetectors(plans []*entity.DetectorPlan, interactive bool) error {
+
+	ctx := context.Background()
+	return h.ApplyDetectorPlan(ctx, plans, interactive, true)
+}
+
+//detectorFileNameReplacer replaces path separators in detector name to build file name
+var detectorFileNameReplacer = strings.NewReplacer("/", "_", "\\", "_")
+
+// ExportAnomalyDetectors writes one file per detector matched by name pattern into dir, in the format accepted
+// by CreateAnomalyDetector. Existing files are replaced only if overwrite is true. Detectors which cannot be
+// exported are skipped and returned
+func ExportAnomalyDetectors(h *Handler, pattern string, dir string, overwrite bool) ([]string, []*entity.SkippedDetector, error) {
+	return h.ExportAnomalyDetectors(pattern, dir, overwrite)
+}
+
+// ExportAnomalyDetectors writes one file per detector matched by name pattern into dir, in the format accepted
+// by CreateAnomalyDetector. Existing files are replaced only if overwrite is true. Detectors which cannot be
+// exported are skipped and returned
+func (h *Handler) ExportAnomalyDetectors(pattern string, dir string, overwrite bool) ([]string, []*entity.SkippedDetector, error) {
+	ctx := context.Background()
+	requests, skipped, err := h.ExportDetectorsByName(ctx, pattern, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	fileNames, err := writeDetectorFiles(requests, dir, overwrite)
+	return fileNames, skipped, err
+}
+
+//writeDetectorFiles writes one file per request into dir, existing files are replaced only if overwrite is true
+func writeDetectorFiles(requests []*entity.CreateDetectorRequest, dir string, overwrite bool) ([]string, error) {
+	if len(requests) < 1 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	var fileNames []string
+	for _, request := range requests {
+		fileName := filepath.Join(dir, detectorFileNameReplacer.Replace(request.Name)+".json")
+		if _, err := os.Stat(fileName); err == nil && !overwrite {
+			return fileNames, fmt.Errorf("file %s already exists", fileName)
+		}
+		content, err := json.MarshalIndent(request, "", "  ")
+		if err != nil {
+			return fileNames, err
+		}
+		if err = os.WriteFile(fileName, append(content, '\n'), 0644); err != nil {
+			return fileNames, err
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	return fileNames, nil
+}
+
+// CopyAnomalyDetectors creates detectors matched by name pattern on source into target, index names found in
+// indexRewrites are replaced by their new name. Detectors whose name already exists on target are reported as conflict
+func CopyAnomalyDetectors(source *Handler, target *Handler, pattern string, indexRewrites map[string]string) ([]*entity.CopyResult, error) {
+	return source.CopyAnomalyDetectors(target, pattern, indexRewrites)
+}
+
+// CopyAnomalyDetectors creates detectors matched by name pattern into target, index names found in
+// indexRewrites are replaced by their new name. Detectors whose name already exists on target are reported as conflict
+func (h *Handler) CopyAnomalyDetectors(target *Handler, pattern string, indexRewrites map[string]string) ([]*entity.CopyResult, error) {
+	ctx := context.Background()
+	requests, skipped, err := h.ExportDetectorsByName(ctx, pattern, false)
+	if err != nil {
+		return nil, err
+	}
+	var results []*entity.CopyResult
+	for _, detector := range skipped {
+		results = append(results, &entity.CopyResult{Name: detector.Name, Status: entity.CopyStatusFailed, Reason: detector.Reason})
+	}
+	if len(requests) < 1 {
+		return results, nil
+	}
+	copied, err := target.CopyDetectors(ctx, requests, indexRewrites, true)
+	if err != nil {
+		return nil, err
+	}
+	return append(results, copied...), nil
+}
@@ -15,10 +15,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"opensearch-cli/controller/ad/mocks"
 	"opensearch-cli/entity/ad"
 	"opensearch-cli/mapper"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -61,32 +65,41 @@ func TestHandlerCreateAnomalyDetector(t *testing.T) {
 		mockedController := mocks.NewMockController(mockCtrl)
 		mockedController.EXPECT().CreateMultiEntityAnomalyDetector(ctx, getCreateDetectorRequest(), true, true).Return([]string{"test-detector-ecommerce0-one"}, nil)
 		instance := New(mockedController)
-		err := CreateAnomalyDetector(instance, "testdata/create.json")
+		err := CreateAnomalyDetector(instance, "testdata/create.json", false)
 		assert.NoError(t, err)
 	})
 	t.Run("test create failure", func(t *testing.T) {
 		mockedController := mocks.NewMockController(mockCtrl)
 		mockedController.EXPECT().CreateMultiEntityAnomalyDetector(ctx, getCreateDetectorRequest(), true, true).Return(nil, errors.New("failed to create"))
 		instance := New(mockedController)
-		err := CreateAnomalyDetector(instance, "testdata/create.json")
+		err := CreateAnomalyDetector(instance, "testdata/create.json", false)
 		assert.EqualError(t, err, "failed to create")
 	})
+	t.Run("test create one detector per partition value", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		request := getCreateDetectorRequest()
+		request.FanOut = true
+		mockedController.EXPECT().CreateMultiEntityAnomalyDetector(ctx, request, true, true).Return([]string{"test-detector-ecommerce0-one"}, nil)
+		instance := New(mockedController)
+		err := CreateAnomalyDetector(instance, "testdata/create.json", true)
+		assert.NoError(t, err)
+	})
 	t.Run("test create failure due to invalid file", func(t *testing.T) {
 		mockedController := mocks.NewMockController(mockCtrl)
 		instance := New(mockedController)
-		err := CreateAnomalyDetector(instance, "testdata/create1.json")
+		err := CreateAnomalyDetector(instance, "testdata/create1.json", false)
 		assert.EqualError(t, err, "failed to open file testdata/create1.json due to open testdata/create1.json: no such file or directory")
 	})
 	t.Run("test create failure due to empty file", func(t *testing.T) {
 		mockedController := mocks.NewMockController(mockCtrl)
 		instance := New(mockedController)
-		err := CreateAnomalyDetector(instance, "")
+		err := CreateAnomalyDetector(instance, "", false)
 		assert.EqualError(t, err, "file name cannot be empty")
 	})
 	t.Run("test create failure due to invalid file", func(t *testing.T) {
 		mockedController := mocks.NewMockController(mockCtrl)
 		instance := New(mockedController)
-		err := CreateAnomalyDetector(instance, "testdata/invalid.txt")
+		err := CreateAnomalyDetector(instance, "testdata/invalid.txt", false)
 		assert.EqualError(t, err, "file testdata/invalid.txt cannot be accepted due to invalid character 'i' looking for beginning of value")
 	})
 }
@@ -203,11 +216,11 @@ func TestGenerateAnomalyDetector(t *testing.T) {
 					Field:           []string{},
 				},
 			},
-			Filter:         []byte("{}"),
-			Interval:       "10m",
-			Delay:          "1m",
-			Start:          false,
-			PartitionField: mapper.StringToStringPtr(""),
+			Filter:        []byte("{}"),
+			Interval:      "10m",
+			Delay:         "1m",
+			Start:         false,
+			CategoryField: []string{},
 		}
 		res, err := GenerateAnomalyDetector()
 		assert.NoError(t, err)
@@ -350,3 +363,334 @@ func TestHandlerUpdateDetector(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestHandlerGetAnomalyDetectorStatus(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	status := &ad.DetectorStatus{
+		ID:    "detectorID",
+		Name:  "detector",
+		State: "RUNNING",
+		Nodes: []string{"node1"},
+	}
+	t.Run("test get status by name success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().GetDetectorStatusByName(ctx, "detector", false).Return([]*ad.DetectorStatus{status}, nil)
+		instance := New(mockedController)
+		result, err := GetAnomalyDetectorStatusByNamePattern(instance, "detector")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*ad.DetectorStatus{status}, result)
+	})
+	t.Run("test get status by id success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().GetDetectorStatus(ctx, "detectorID").Return(status, nil)
+		instance := New(mockedController)
+		result, err := GetAnomalyDetectorStatusByID(instance, "detectorID")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*ad.DetectorStatus{status}, result)
+	})
+	t.Run("test get status by id failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().GetDetectorStatus(ctx, "detectorID").Return(nil, errors.New("failed to get status"))
+		instance := New(mockedController)
+		_, err := instance.GetAnomalyDetectorStatusByID("detectorID")
+		assert.EqualError(t, err, "failed to get status")
+	})
+}
+
+func TestHandlerSearchAnomalyResults(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	filter := ad.AnomalyResultFilter{MinAnomalyGrade: 0.5, Limit: 10}
+	process := func([]*ad.AnomalyResultOutput) error { return nil }
+	t.Run("test search results by name success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().SearchAnomalyResultsByName(ctx, []string{"detector"}, filter, gomock.Any()).Return(nil)
+		instance := New(mockedController)
+		err := SearchAnomalyResultsByNamePattern(instance, []string{"detector"}, filter, process)
+		assert.NoError(t, err)
+	})
+	t.Run("test search results by id failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().SearchAnomalyResults(ctx, []string{"detectorID"}, filter, gomock.Any()).Return(errors.New("failed to search results"))
+		instance := New(mockedController)
+		err := SearchAnomalyResultsByID(instance, []string{"detectorID"}, filter, process)
+		assert.EqualError(t, err, "failed to search results")
+	})
+}
+
+func TestHandlerHistoricalAnalysis(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	status := &ad.HistoricalAnalysisStatus{
+		ID:       "detectorID",
+		Name:     "detector",
+		TaskID:   "taskID",
+		State:    "FINISHED",
+		Progress: "100%",
+	}
+	t.Run("test start by name success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().StartHistoricalAnalysisByName(ctx, "detector", "24h", "", true).Return([]string{"detectorID"}, nil)
+		instance := New(mockedController)
+		started, err := StartHistoricalAnalysisByNamePattern(instance, "detector", "24h", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"detectorID"}, started)
+	})
+	t.Run("test start by id failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().StartHistoricalAnalysis(ctx, "detectorID", "24h", "").Return(errors.New("failed to start"))
+		instance := New(mockedController)
+		_, err := StartHistoricalAnalysisByID(instance, "detectorID", "24h", "")
+		assert.EqualError(t, err, "failed to start")
+	})
+	t.Run("test stop by name success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().StopHistoricalAnalysisByName(ctx, "detector", true).Return(nil)
+		instance := New(mockedController)
+		err := StopHistoricalAnalysisByNamePattern(instance, "detector")
+		assert.NoError(t, err)
+	})
+	t.Run("test stop by id success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().StopHistoricalAnalysis(ctx, "detectorID").Return(nil)
+		instance := New(mockedController)
+		err := StopHistoricalAnalysisByID(instance, "detectorID")
+		assert.NoError(t, err)
+	})
+	t.Run("test get status by id success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().GetHistoricalAnalysisStatus(ctx, "detectorID").Return(status, nil)
+		instance := New(mockedController)
+		result, err := GetHistoricalAnalysisStatusByID(instance, "detectorID")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*ad.HistoricalAnalysisStatus{status}, result)
+	})
+	t.Run("test get status by name success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().GetHistoricalAnalysisStatusByName(ctx, "detector").Return([]*ad.HistoricalAnalysisStatus{status}, nil)
+		instance := New(mockedController)
+		result, err := GetHistoricalAnalysisStatusByNamePattern(instance, "detector")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*ad.HistoricalAnalysisStatus{status}, result)
+	})
+	t.Run("test wait success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().WaitForHistoricalAnalysis(ctx, []string{"detectorID"}, time.Second, true).Return([]*ad.HistoricalAnalysisStatus{status}, nil)
+		instance := New(mockedController)
+		result, err := WaitForHistoricalAnalysis(instance, []string{"detectorID"}, time.Second, 0)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*ad.HistoricalAnalysisStatus{status}, result)
+	})
+	t.Run("test wait timed out", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().WaitForHistoricalAnalysis(gomock.Any(), []string{"detectorID"}, time.Second, true).DoAndReturn(
+			func(ctx context.Context, _ []string, _ time.Duration, _ bool) ([]*ad.HistoricalAnalysisStatus, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		instance := New(mockedController)
+		_, err := WaitForHistoricalAnalysis(instance, []string{"detectorID"}, time.Second, time.Millisecond)
+		assert.EqualError(t, err, "timed out after 1ms waiting for historical analysis to complete")
+	})
+}
+
+func TestHandlerPreviewAnomalyDetector(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	results := []*ad.AnomalyResultOutput{{DetectorName: "detector", AnomalyGrade: 0.5}}
+	t.Run("test preview file success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().PreviewAnomalyDetector(ctx, getCreateDetectorRequest(), "24h", "").Return(results, nil)
+		instance := New(mockedController)
+		actual, err := PreviewAnomalyDetector(instance, "testdata/create.json", "24h", "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, results, actual)
+	})
+	t.Run("test preview failure due to invalid file", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		instance := New(mockedController)
+		_, err := PreviewAnomalyDetector(instance, "testdata/invalid.txt", "24h", "")
+		assert.EqualError(t, err, "file testdata/invalid.txt cannot be accepted due to invalid character 'i' looking for beginning of value")
+	})
+	t.Run("test preview by name success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().PreviewDetectorByName(ctx, "detector", "24h", "1h").Return(results, nil)
+		instance := New(mockedController)
+		actual, err := PreviewAnomalyDetectorByNamePattern(instance, "detector", "24h", "1h")
+		assert.NoError(t, err)
+		assert.EqualValues(t, results, actual)
+	})
+	t.Run("test preview by id failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().PreviewDetector(ctx, "detectorID", "24h", "").Return(nil, errors.New("failed to preview"))
+		instance := New(mockedController)
+		_, err := PreviewAnomalyDetectorByID(instance, "detectorID", "24h", "")
+		assert.EqualError(t, err, "failed to preview")
+	})
+}
+
+func TestHandlerValidateAnomalyDetector(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("test validate success", func(t *testing.T) {
+		problems := []*ad.ValidationProblem{{Source: "mapping", Field: "time_field", Message: "time field utc_time does not exist in index kibana_sample_data_ecommerce*"}}
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ValidateAnomalyDetector(ctx, getCreateDetectorRequest()).Return(problems, nil)
+		instance := New(mockedController)
+		actual, err := ValidateAnomalyDetector(instance, "testdata/create.json")
+		assert.NoError(t, err)
+		assert.EqualValues(t, problems, actual)
+	})
+	t.Run("test validate failure due to empty file", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		instance := New(mockedController)
+		_, err := ValidateAnomalyDetector(instance, "")
+		assert.EqualError(t, err, "file name cannot be empty")
+	})
+}
+
+func TestHandlerPlanAnomalyDetectors(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	plans := []*ad.DetectorPlan{{Action: ad.PlanActionCreate, Name: "test-detector-ecommerce0"}}
+	t.Run("test plan directory success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().PlanDetectors(ctx, map[string]ad.CreateDetectorRequest{
+			"testdata/apply/create.json": getCreateDetectorRequest(),
+		}, "test-*").Return(plans, nil)
+		instance := New(mockedController)
+		actual, err := PlanAnomalyDetectors(instance, "testdata/apply", "test-*")
+		assert.NoError(t, err)
+		assert.EqualValues(t, plans, actual)
+	})
+	t.Run("test plan file success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().PlanDetectors(ctx, map[string]ad.CreateDetectorRequest{
+			"testdata/create.json": getCreateDetectorRequest(),
+		}, "").Return(plans, nil)
+		instance := New(mockedController)
+		actual, err := PlanAnomalyDetectors(instance, "testdata/create.json", "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, plans, actual)
+	})
+	t.Run("test plan failure due to missing path", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		instance := New(mockedController)
+		_, err := PlanAnomalyDetectors(instance, "testdata/missing", "")
+		assert.EqualError(t, err, "stat testdata/missing: no such file or directory")
+	})
+	t.Run("test apply success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ApplyDetectorPlan(ctx, plans, true, true).Return(nil)
+		instance := New(mockedController)
+		err := ApplyAnomalyDetectors(instance, plans, true)
+		assert.NoError(t, err)
+	})
+	t.Run("test apply without confirmation", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ApplyDetectorPlan(ctx, plans, false, true).Return(nil)
+		instance := New(mockedController)
+		err := ApplyAnomalyDetectors(instance, plans, false)
+		assert.NoError(t, err)
+	})
+}
+
+func TestHandlerExportAnomalyDetectors(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	request := getCreateDetectorRequest()
+	t.Run("test export success", func(t *testing.T) {
+		dir := t.TempDir()
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ExportDetectorsByName(ctx, "test-*", false).Return([]*ad.CreateDetectorRequest{&request}, nil, nil)
+		instance := New(mockedController)
+		fileNames, skipped, err := ExportAnomalyDetectors(instance, "test-*", dir, false)
+		assert.NoError(t, err)
+		assert.Empty(t, skipped)
+		assert.EqualValues(t, []string{filepath.Join(dir, "test-detector-ecommerce0.json")}, fileNames)
+		exported, err := readCreateDetectorRequest(fileNames[0])
+		assert.NoError(t, err)
+		assert.EqualValues(t, request.Name, exported.Name)
+		assert.EqualValues(t, request.Features, exported.Features)
+		assert.JSONEq(t, string(request.Filter), string(exported.Filter))
+	})
+	t.Run("test export failure due to existing file", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "test-detector-ecommerce0.json"), []byte("{}"), 0644))
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ExportDetectorsByName(ctx, "test-*", false).Return([]*ad.CreateDetectorRequest{&request}, nil, nil)
+		instance := New(mockedController)
+		_, _, err := ExportAnomalyDetectors(instance, "test-*", dir, false)
+		assert.EqualError(t, err, fmt.Sprintf("file %s already exists", filepath.Join(dir, "test-detector-ecommerce0.json")))
+	})
+	t.Run("test export overwrite existing file", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "test-detector-ecommerce0.json"), []byte("{}"), 0644))
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ExportDetectorsByName(ctx, "test-*", false).Return([]*ad.CreateDetectorRequest{&request}, nil, nil)
+		instance := New(mockedController)
+		fileNames, _, err := ExportAnomalyDetectors(instance, "test-*", dir, true)
+		assert.NoError(t, err)
+		assert.Len(t, fileNames, 1)
+	})
+	t.Run("test export skips detectors which cannot be exported", func(t *testing.T) {
+		dir := t.TempDir()
+		skipped := []*ad.SkippedDetector{{Name: "scripted", Reason: "detector scripted: unsupported aggregation"}}
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ExportDetectorsByName(ctx, "test-*", false).Return([]*ad.CreateDetectorRequest{&request}, skipped, nil)
+		instance := New(mockedController)
+		fileNames, actual, err := ExportAnomalyDetectors(instance, "test-*", dir, false)
+		assert.NoError(t, err)
+		assert.Len(t, fileNames, 1)
+		assert.EqualValues(t, skipped, actual)
+	})
+}
+
+func TestHandlerCopyAnomalyDetectors(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	request := getCreateDetectorRequest()
+	t.Run("test copy success", func(t *testing.T) {
+		sourceController := mocks.NewMockController(mockCtrl)
+		sourceController.EXPECT().ExportDetectorsByName(ctx, "test-*", false).Return([]*ad.CreateDetectorRequest{&request}, nil, nil)
+		targetController := mocks.NewMockController(mockCtrl)
+		expected := []*ad.CopyResult{{Name: request.Name, Status: ad.CopyStatusCreated, ID: "id"}}
+		targetController.EXPECT().CopyDetectors(ctx, []*ad.CreateDetectorRequest{&request}, map[string]string{"a": "b"}, true).Return(expected, nil)
+		results, err := CopyAnomalyDetectors(New(sourceController), New(targetController), "test-*", map[string]string{"a": "b"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, results)
+	})
+	t.Run("test copy reports skipped detectors as failed", func(t *testing.T) {
+		sourceController := mocks.NewMockController(mockCtrl)
+		sourceController.EXPECT().ExportDetectorsByName(ctx, "test-*", false).Return(nil,
+			[]*ad.SkippedDetector{{Name: "scripted", Reason: "unsupported aggregation"}}, nil)
+		targetController := mocks.NewMockController(mockCtrl)
+		results, err := CopyAnomalyDetectors(New(sourceController), New(targetController), "test-*", nil)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*ad.CopyResult{{Name: "scripted", Status: ad.CopyStatusFailed, Reason: "unsupported aggregation"}}, results)
+	})
+	t.Run("test copy nothing matched", func(t *testing.T) {
+		sourceController := mocks.NewMockController(mockCtrl)
+		sourceController.EXPECT().ExportDetectorsByName(ctx, "test-*", false).Return(nil, nil, nil)
+		targetController := mocks.NewMockController(mockCtrl)
+		results, err := CopyAnomalyDetectors(New(sourceController), New(targetController), "test-*", nil)
+		assert.NoError(t, err)
+		assert.Nil(t, results)
+	})
+	t.Run("test copy failure", func(t *testing.T) {
+		sourceController := mocks.NewMockController(mockCtrl)
+		sourceController.EXPECT().ExportDetectorsByName(ctx, "test-*", false).Return(nil, nil, errors.New("failed to export"))
+		targetController := mocks.NewMockController(mockCtrl)
+		_, err := CopyAnomalyDetectors(New(sourceController), New(targetController), "test-*", nil)
+		assert.EqualError(t, err, "failed to export")
+	})
+}
@@ -0,0 +1,97 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"context"
+	"opensearch-cli/controller/cluster"
+	entity "opensearch-cli/entity/cluster"
+	"time"
+)
+
+//Handler is facade for controller
+type Handler struct {
+	cluster.Controller
+}
+
+// New returns new Handler instance
+func New(controller cluster.Controller) *Handler {
+	return &Handler{
+		controller,
+	}
+}
+
+//GetHealth gets health of cluster
+func GetHealth(h *Handler) (*entity.Health, error) {
+	return h.GetHealth()
+}
+
+//GetHealth gets health of cluster
+func (h *Handler) GetHealth() (*entity.Health, error) {
+	ctx := context.Background()
+	return h.Controller.GetHealth(ctx)
+}
+
+//WaitForHealth waits until status of cluster is at least as good as status or timeout is reached
+func WaitForHealth(h *Handler, status string, interval time.Duration, timeout time.Duration) (*entity.Health, error) {
+	return h.WaitForHealth(status, interval, timeout)
+}
+
+//WaitForHealth waits until status of cluster is at least as good as status or timeout is reached, health is checked every interval
+func (h *Handler) WaitForHealth(status string, interval time.Duration, timeout time.Duration) (*entity.Health, error) {
+	ctx := context.Background()
+	return h.Controller.WaitForHealth(ctx, status, interval, timeout)
+}
+
+//ListNodes lists nodes of cluster
+func ListNodes(h *Handler) ([]entity.NodeOutput, error) {
+	return h.ListNodes()
+}
+
+//ListNodes lists nodes of cluster with their roles and resource usage
+func (h *Handler) ListNodes() ([]entity.NodeOutput, error) {
+	ctx := context.Background()
+	return h.Controller.ListNodes(ctx)
+}
+
+//ExplainAllocation explains allocation of shard
+func ExplainAllocation(h *Handler, request entity.AllocationExplainRequest) (*entity.AllocationExplanation, error) {
+	return h.ExplainAllocation(request)
+}
+
+//ExplainAllocation explains allocation of shard, first unassigned shard is explained if no index is given
+func (h *Handler) ExplainAllocation(request entity.AllocationExplainRequest) (*entity.AllocationExplanation, error) {
+	ctx := context.Background()
+	return h.Controller.ExplainAllocation(ctx, request)
+}
+
+//GetSettings gets cluster settings
+func GetSettings(h *Handler, request entity.SettingsRequest) ([]entity.SettingOutput, error) {
+	return h.GetSettings(request)
+}
+
+//GetSettings gets cluster settings which match names, optionally with defaults
+func (h *Handler) GetSettings(request entity.SettingsRequest) ([]entity.SettingOutput, error) {
+	ctx := context.Background()
+	return h.Controller.GetSettings(ctx, request)
+}
+
+//PutSettings updates cluster settings
+func PutSettings(h *Handler, values []string, transient bool) ([]entity.SettingOutput, error) {
+	return h.PutSettings(values, transient)
+}
+
+//PutSettings updates cluster settings from name=value pairs, settings are persistent unless transient is enabled
+func (h *Handler) PutSettings(values []string, transient bool) ([]entity.SettingOutput, error) {
+	ctx := context.Background()
+	return h.Controller.PutSettings(ctx, values, transient)
+}
@@ -0,0 +1,91 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"opensearch-cli/controller/cluster/mocks"
+	entity "opensearch-cli/entity/cluster"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerHealth(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("get health", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		health := &entity.Health{Status: "green"}
+		mockedController.EXPECT().GetHealth(ctx).Return(health, nil)
+		response, err := GetHealth(New(mockedController))
+		assert.NoError(t, err)
+		assert.EqualValues(t, health, response)
+	})
+	t.Run("wait for health failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().WaitForHealth(ctx, "green", time.Second, time.Minute).Return(nil, errors.New("failed"))
+		_, err := WaitForHealth(New(mockedController), "green", time.Second, time.Minute)
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestHandlerListNodes(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockedController := mocks.NewMockController(mockCtrl)
+	nodes := []entity.NodeOutput{{Name: "node1"}}
+	mockedController.EXPECT().ListNodes(ctx).Return(nodes, nil)
+	response, err := ListNodes(New(mockedController))
+	assert.NoError(t, err)
+	assert.EqualValues(t, nodes, response)
+}
+
+func TestHandlerExplainAllocation(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockedController := mocks.NewMockController(mockCtrl)
+	request := entity.AllocationExplainRequest{Index: "index1"}
+	explanation := &entity.AllocationExplanation{Index: "index1", CurrentState: "started"}
+	mockedController.EXPECT().ExplainAllocation(ctx, request).Return(explanation, nil)
+	response, err := ExplainAllocation(New(mockedController), request)
+	assert.NoError(t, err)
+	assert.EqualValues(t, explanation, response)
+}
+
+func TestHandlerSettings(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	settings := []entity.SettingOutput{{Scope: "persistent", Name: "action.auto_create_index", Value: "false"}}
+	t.Run("get settings", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		request := entity.SettingsRequest{Names: []string{"action"}}
+		mockedController.EXPECT().GetSettings(ctx, request).Return(settings, nil)
+		response, err := GetSettings(New(mockedController), request)
+		assert.NoError(t, err)
+		assert.EqualValues(t, settings, response)
+	})
+	t.Run("put settings", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().PutSettings(ctx, []string{"action.auto_create_index=false"}, false).Return(settings, nil)
+		response, err := PutSettings(New(mockedController), []string{"action.auto_create_index=false"}, false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, settings, response)
+	})
+}
@@ -0,0 +1,41 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package connection
+
+import (
+	"context"
+	"opensearch-cli/controller/connection"
+	entity "opensearch-cli/entity/connection"
+)
+
+//Handler is facade for controller
+type Handler struct {
+	connection.Controller
+}
+
+// New returns new Handler instance
+func New(controller connection.Controller) *Handler {
+	return &Handler{
+		controller,
+	}
+}
+
+//TestConnection tests connection to cluster
+func TestConnection(h *Handler) entity.Result {
+	return h.TestConnection()
+}
+
+//TestConnection tests connection to cluster and reports its details
+func (h *Handler) TestConnection() entity.Result {
+	ctx := context.Background()
+	return h.Controller.TestConnection(ctx)
+}
@@ -0,0 +1,32 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package connection
+
+import (
+	"context"
+	"opensearch-cli/controller/connection/mocks"
+	entity "opensearch-cli/entity/connection"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerTestConnection(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockedController := mocks.NewMockController(mockCtrl)
+	result := entity.Result{Reachable: true, ClusterName: "docker-cluster"}
+	mockedController.EXPECT().TestConnection(ctx).Return(result)
+	assert.Equal(t, result, TestConnection(New(mockedController)))
+}
@@ -0,0 +1,158 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"opensearch-cli/controller/index"
+	entity "opensearch-cli/entity/index"
+	mapper "opensearch-cli/mapper/index"
+	"os"
+)
+
+//Handler is facade for controller
+type Handler struct {
+	index.Controller
+}
+
+// New returns new Handler instance
+func New(controller index.Controller) *Handler {
+	return &Handler{
+		controller,
+	}
+}
+
+//ListIndices lists indices matched by request
+func ListIndices(h *Handler, request entity.ListRequest) ([]entity.CatIndex, error) {
+	return h.ListIndices(request)
+}
+
+//ListIndices lists indices matched by pattern, filtered by health and status and sorted by column
+func (h *Handler) ListIndices(request entity.ListRequest) ([]entity.CatIndex, error) {
+	ctx := context.Background()
+	return h.Controller.ListIndices(ctx, request)
+}
+
+//CreateIndex creates index with settings, mappings and aliases from file
+func CreateIndex(h *Handler, index string, fileName string) error {
+	return h.CreateIndex(index, fileName)
+}
+
+//CreateIndex creates index with settings, mappings and aliases from file, index is created with defaults if file name is empty
+func (h *Handler) CreateIndex(index string, fileName string) error {
+	ctx := context.Background()
+	var body map[string]interface{}
+	if len(fileName) > 0 {
+		content, err := os.ReadFile(fileName)
+		if err != nil {
+			return fmt.Errorf("failed to read file %s due to %v", fileName, err)
+		}
+		if body, err = mapper.MapToIndexBody(content); err != nil {
+			return fmt.Errorf("file %s cannot be accepted due to %v", fileName, err)
+		}
+	}
+	return h.Controller.CreateIndex(ctx, index, body)
+}
+
+//DeleteIndex deletes index
+func DeleteIndex(h *Handler, index string, interactive bool) error {
+	return h.DeleteIndex(index, interactive)
+}
+
+//DeleteIndex deletes index, if interactive is enabled, user is asked for confirmation
+func (h *Handler) DeleteIndex(index string, interactive bool) error {
+	ctx := context.Background()
+	return h.Controller.DeleteIndex(ctx, index, interactive)
+}
+
+//GetMapping gets mappings of index
+func GetMapping(h *Handler, index string) (json.RawMessage, error) {
+	return h.GetMapping(index)
+}
+
+//GetMapping gets mappings of indices matched by index
+func (h *Handler) GetMapping(index string) (json.RawMessage, error) {
+	ctx := context.Background()
+	return h.Controller.GetMapping(ctx, index)
+}
+
+//PutSettings updates settings of index
+func PutSettings(h *Handler, index string, settings string) error {
+	return h.PutSettings(index, settings)
+}
+
+//PutSettings updates dynamic settings of index, settings are either JSON or file name with prefix '@'
+func (h *Handler) PutSettings(index string, settings string) error {
+	ctx := context.Background()
+	body, err := mapper.MapToSettings(settings)
+	if err != nil {
+		return err
+	}
+	return h.Controller.PutSettings(ctx, index, body)
+}
+
+//OpenIndex opens index
+func OpenIndex(h *Handler, index string) error {
+	return h.OpenIndex(index)
+}
+
+//OpenIndex opens closed index
+func (h *Handler) OpenIndex(index string) error {
+	ctx := context.Background()
+	return h.Controller.OpenIndex(ctx, index)
+}
+
+//CloseIndex closes index
+func CloseIndex(h *Handler, index string) error {
+	return h.CloseIndex(index)
+}
+
+//CloseIndex closes index
+func (h *Handler) CloseIndex(index string) error {
+	ctx := context.Background()
+	return h.Controller.CloseIndex(ctx, index)
+}
+
+//ListAliases lists aliases of index
+func ListAliases(h *Handler, index string) ([]entity.AliasOutput, error) {
+	return h.ListAliases(index)
+}
+
+//ListAliases lists aliases of indices matched by index, aliases of every index are listed if index is empty
+func (h *Handler) ListAliases(index string) ([]entity.AliasOutput, error) {
+	ctx := context.Background()
+	return h.Controller.ListAliases(ctx, index)
+}
+
+//AddAlias adds alias to index
+func AddAlias(h *Handler, index string, alias string, isWriteIndex bool) error {
+	return h.AddAlias(index, alias, isWriteIndex)
+}
+
+//AddAlias adds alias to index, if isWriteIndex is enabled, index becomes write index of alias
+func (h *Handler) AddAlias(index string, alias string, isWriteIndex bool) error {
+	ctx := context.Background()
+	return h.Controller.AddAlias(ctx, index, alias, isWriteIndex)
+}
+
+//RemoveAlias removes alias from index
+func RemoveAlias(h *Handler, index string, alias string) error {
+	return h.RemoveAlias(index, alias)
+}
+
+//RemoveAlias removes alias from index
+func (h *Handler) RemoveAlias(index string, alias string) error {
+	ctx := context.Background()
+	return h.Controller.RemoveAlias(ctx, index, alias)
+}
@@ -0,0 +1,122 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"context"
+	"errors"
+	"opensearch-cli/controller/index/mocks"
+	entity "opensearch-cli/entity/index"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerListIndices(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("list success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		request := entity.ListRequest{Pattern: "logs-*", Sort: "store.size:desc"}
+		indices := []entity.CatIndex{{Index: "logs-1"}}
+		mockedController.EXPECT().ListIndices(ctx, request).Return(indices, nil)
+		instance := New(mockedController)
+		response, err := ListIndices(instance, request)
+		assert.NoError(t, err)
+		assert.EqualValues(t, indices, response)
+	})
+	t.Run("list failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ListIndices(ctx, entity.ListRequest{}).Return(nil, errors.New("failed"))
+		instance := New(mockedController)
+		_, err := instance.ListIndices(entity.ListRequest{})
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestHandlerCreateIndex(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("create from file", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().CreateIndex(ctx, "index1", map[string]interface{}{
+			"settings": map[string]interface{}{
+				"number_of_shards":   float64(1),
+				"number_of_replicas": float64(0),
+			},
+			"mappings": map[string]interface{}{
+				"properties": map[string]interface{}{
+					"title": map[string]interface{}{"type": "text"},
+				},
+			},
+		}).Return(nil)
+		instance := New(mockedController)
+		assert.NoError(t, CreateIndex(instance, "index1", "testdata/index.json"))
+	})
+	t.Run("create with defaults", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().CreateIndex(ctx, "index1", nil).Return(nil)
+		instance := New(mockedController)
+		assert.NoError(t, instance.CreateIndex("index1", ""))
+	})
+	t.Run("missing file", func(t *testing.T) {
+		instance := New(mocks.NewMockController(mockCtrl))
+		assert.Error(t, instance.CreateIndex("index1", "testdata/missing.json"))
+	})
+}
+
+func TestHandlerDeleteIndex(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockedController := mocks.NewMockController(mockCtrl)
+	mockedController.EXPECT().DeleteIndex(ctx, "index1", true).Return(nil)
+	instance := New(mockedController)
+	assert.NoError(t, DeleteIndex(instance, "index1", true))
+}
+
+func TestHandlerPutSettings(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("put success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().PutSettings(ctx, "index1", map[string]interface{}{"index.refresh_interval": "1s"}).Return(nil)
+		instance := New(mockedController)
+		assert.NoError(t, PutSettings(instance, "index1", `{"index.refresh_interval":"1s"}`))
+	})
+	t.Run("invalid settings", func(t *testing.T) {
+		instance := New(mocks.NewMockController(mockCtrl))
+		assert.EqualError(t, instance.PutSettings("index1", ""), "settings cannot be empty")
+	})
+}
+
+func TestHandlerAliases(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("add alias", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().AddAlias(ctx, "logs-1", "logs", true).Return(nil)
+		instance := New(mockedController)
+		assert.NoError(t, AddAlias(instance, "logs-1", "logs", true))
+	})
+	t.Run("remove alias failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().RemoveAlias(ctx, "logs-1", "logs").Return(errors.New("aliases_not_found_exception"))
+		instance := New(mockedController)
+		assert.EqualError(t, RemoveAlias(instance, "logs-1", "logs"), "aliases_not_found_exception")
+	})
+}
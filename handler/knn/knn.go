@@ -14,8 +14,13 @@ package knn
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"opensearch-cli/controller/knn"
 	entity "opensearch-cli/entity/knn"
+	mapper "opensearch-cli/mapper/knn"
+	"os"
+	"time"
 )
 
 //Handler is facade for controller
@@ -31,23 +36,14 @@ func New(controller knn.Controller) *Handler {
 }
 
 //GetStatistics gets stats data based on nodes and stat names
-func GetStatistics(h *Handler, nodes string, names string) ([]byte, error) {
+func GetStatistics(h *Handler, nodes string, names string) (*entity.Stats, error) {
 	return h.GetStatistics(nodes, names)
 }
 
 //GetStatistics gets stats data based on nodes and stat names
-func (h *Handler) GetStatistics(nodes string, names string) ([]byte, error) {
+func (h *Handler) GetStatistics(nodes string, names string) (*entity.Stats, error) {
 	ctx := context.Background()
-	response, err := h.Controller.GetStatistics(ctx, nodes, names)
-	if err != nil {
-		return nil, err
-	}
-	var data map[string]interface{}
-
-	if err := json.Unmarshal(response, &data); err != nil {
-		return nil, err
-	}
-	return json.MarshalIndent(data, "", "  ")
+	return h.Controller.GetStatistics(ctx, nodes, names)
 }
 
 //WarmupIndices warmups knn index
@@ -60,3 +56,132 @@ func (h *Handler) WarmupIndices(index []string) (*entity.Shards, error) {
 	ctx := context.Background()
 	return h.Controller.WarmupIndices(ctx, index)
 }
+
+//ClearCache clears cache of knn index
+func ClearCache(h *Handler, index []string) (*entity.Shards, error) {
+	return h.ClearCache(index)
+}
+
+//ClearCache evicts graphs of knn index from native memory and returns status of shards
+func (h *Handler) ClearCache(index []string) (*entity.Shards, error) {
+	ctx := context.Background()
+	return h.Controller.ClearCache(ctx, index)
+}
+
+//TrainModel starts training of model based on request from file and returns model id
+func TrainModel(h *Handler, fileName string, modelID string) (string, error) {
+	return h.TrainModel(fileName, modelID)
+}
+
+//TrainModel starts training of model based on request from file and returns model id
+func (h *Handler) TrainModel(fileName string, modelID string) (string, error) {
+	ctx := context.Background()
+	if len(fileName) < 1 {
+		return "", fmt.Errorf("file name cannot be empty")
+	}
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s due to %v", fileName, err)
+	}
+	var request entity.TrainModelRequest
+	if err = json.Unmarshal(content, &request); err != nil {
+		return "", fmt.Errorf("file %s cannot be accepted due to %v", fileName, err)
+	}
+	return h.Controller.TrainModel(ctx, modelID, request)
+}
+
+//GetModel gets model metadata by model id
+func GetModel(h *Handler, modelID string) (*entity.Model, error) {
+	return h.GetModel(modelID)
+}
+
+//GetModel gets model metadata by model id
+func (h *Handler) GetModel(modelID string) (*entity.Model, error) {
+	ctx := context.Background()
+	return h.Controller.GetModel(ctx, modelID)
+}
+
+//ListModels gets metadata of every model
+func ListModels(h *Handler) ([]*entity.Model, error) {
+	return h.ListModels()
+}
+
+//ListModels gets metadata of every model
+func (h *Handler) ListModels() ([]*entity.Model, error) {
+	ctx := context.Background()
+	return h.Controller.ListModels(ctx)
+}
+
+//DeleteModel deletes model by model id
+func DeleteModel(h *Handler, modelID string) error {
+	return h.DeleteModel(modelID)
+}
+
+//DeleteModel deletes model by model id
+func (h *Handler) DeleteModel(modelID string) error {
+	ctx := context.Background()
+	return h.Controller.DeleteModel(ctx, modelID)
+}
+
+//WaitForModel waits until training of model is finished or timeout is reached, model state is checked every interval,
+//timeout 0 waits without limit
+func WaitForModel(h *Handler, modelID string, interval time.Duration, timeout time.Duration) (*entity.Model, error) {
+	return h.WaitForModel(modelID, interval, timeout)
+}
+
+//WaitForModel waits until training of model is finished or timeout is reached, model state is checked every interval,
+//timeout 0 waits without limit
+func (h *Handler) WaitForModel(modelID string, interval time.Duration, timeout time.Duration) (*entity.Model, error) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	model, err := h.Controller.WaitForModel(ctx, modelID, interval)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %s waiting for training of model %s to finish", timeout, modelID)
+	}
+	return model, err
+}
+
+//SearchVector searches k nearest neighbors of vector given by user
+func SearchVector(h *Handler, request entity.SearchCommandRequest) ([]*entity.SearchResult, error) {
+	return h.SearchVector(request)
+}
+
+//SearchVector searches k nearest neighbors of vector given by user
+func (h *Handler) SearchVector(request entity.SearchCommandRequest) ([]*entity.SearchResult, error) {
+	ctx := context.Background()
+	return h.Controller.SearchVector(ctx, request)
+}
+
+//IngestVectors indexes vectors and metadata from file, format is detected from file extension unless provided
+func IngestVectors(h *Handler, fileName string, request entity.IngestRequest) (*entity.IngestResult, error) {
+	return h.IngestVectors(fileName, request)
+}
+
+//IngestVectors indexes vectors and metadata from file, format is detected from file extension unless provided
+func (h *Handler) IngestVectors(fileName string, request entity.IngestRequest) (*entity.IngestResult, error) {
+	ctx := context.Background()
+	if len(request.Format) < 1 || request.Format == entity.FileFormatAuto {
+		format, err := mapper.GetVectorFileFormat(fileName)
+		if err != nil {
+			return nil, err
+		}
+		request.Format = format
+	}
+	if len(request.VectorColumn) < 1 {
+		request.VectorColumn = request.Field
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return h.Controller.IngestVectors(ctx, request, file, info.Size(), true)
+}
@@ -17,6 +17,7 @@ import (
 	"opensearch-cli/controller/knn/mocks"
 	entity "opensearch-cli/entity/knn"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -28,11 +29,12 @@ func TestHandlerGetStatistics(t *testing.T) {
 	defer mockCtrl.Finish()
 	t.Run("get stats success", func(t *testing.T) {
 		mockedController := mocks.NewMockController(mockCtrl)
-		mockedController.EXPECT().GetStatistics(ctx, "node1", "stats-name").Return([]byte("{}"), nil)
+		stats := &entity.Stats{ClusterName: "cluster"}
+		mockedController.EXPECT().GetStatistics(ctx, "node1", "stats-name").Return(stats, nil)
 		instance := New(mockedController)
 		response, err := GetStatistics(instance, "node1", "stats-name")
 		assert.NoError(t, err)
-		assert.EqualValues(t, "{}", string(response))
+		assert.EqualValues(t, stats, response)
 	})
 	t.Run("get stats failure", func(t *testing.T) {
 		mockedController := mocks.NewMockController(mockCtrl)
@@ -68,3 +70,152 @@ func TestHandlerWarmupIndices(t *testing.T) {
 		assert.EqualError(t, err, "failed")
 	})
 }
+
+func TestHandlerClearCache(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("clear cache success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		result := &entity.Shards{
+			Total:      10,
+			Successful: 10,
+		}
+		mockedController.EXPECT().ClearCache(ctx, []string{"index1"}).Return(result, nil)
+		instance := New(mockedController)
+		response, err := ClearCache(instance, []string{"index1"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, *result, *response)
+	})
+	t.Run("clear cache failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ClearCache(ctx, []string{"index1"}).Return(nil, errors.New("failed"))
+		instance := New(mockedController)
+		_, err := instance.ClearCache([]string{"index1"})
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestHandlerTrainModel(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("train success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().TrainModel(ctx, "model1", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, request entity.TrainModelRequest) (string, error) {
+				assert.EqualValues(t, "train-index", request.TrainingIndex)
+				assert.EqualValues(t, "train-field", request.TrainingField)
+				assert.EqualValues(t, 4, request.Dimension)
+				assert.NotEmpty(t, request.Method)
+				return "model1", nil
+			})
+		instance := New(mockedController)
+		modelID, err := TrainModel(instance, "testdata/train.json", "model1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, "model1", modelID)
+	})
+	t.Run("train failure due to missing file", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		instance := New(mockedController)
+		_, err := instance.TrainModel("testdata/missing.json", "model1")
+		assert.Error(t, err)
+	})
+}
+
+func TestHandlerModels(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	model := &entity.Model{ModelID: "model1", State: entity.ModelStateCreated}
+	t.Run("get model", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().GetModel(ctx, "model1").Return(model, nil)
+		result, err := GetModel(New(mockedController), "model1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, model, result)
+	})
+	t.Run("list models", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().ListModels(ctx).Return([]*entity.Model{model}, nil)
+		result, err := ListModels(New(mockedController))
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*entity.Model{model}, result)
+	})
+	t.Run("delete model", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().DeleteModel(ctx, "model1").Return(errors.New("failed"))
+		err := DeleteModel(New(mockedController), "model1")
+		assert.EqualError(t, err, "failed")
+	})
+	t.Run("wait for model", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().WaitForModel(ctx, "model1", time.Second).Return(model, nil)
+		result, err := WaitForModel(New(mockedController), "model1", time.Second, 0)
+		assert.NoError(t, err)
+		assert.EqualValues(t, model, result)
+	})
+	t.Run("wait for model timed out", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().WaitForModel(gomock.Any(), "model1", time.Second).DoAndReturn(
+			func(ctx context.Context, _ string, _ time.Duration) (*entity.Model, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		_, err := WaitForModel(New(mockedController), "model1", time.Second, time.Millisecond)
+		assert.EqualError(t, err, "timed out after 1ms waiting for training of model model1 to finish")
+	})
+}
+
+func TestHandlerSearchVector(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	request := entity.SearchCommandRequest{Index: "index1", Field: "my_vector", K: 1, Vector: []byte("[1]")}
+	t.Run("search success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		expected := []*entity.SearchResult{{Index: "index1", ID: "1", Score: 1}}
+		mockedController.EXPECT().SearchVector(ctx, request).Return(expected, nil)
+		results, err := SearchVector(New(mockedController), request)
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, results)
+	})
+	t.Run("search failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().SearchVector(ctx, request).Return(nil, errors.New("failed"))
+		_, err := New(mockedController).SearchVector(request)
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestHandlerIngestVectors(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("ingest success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		expected := &entity.IngestResult{Indexed: 1}
+		mockedController.EXPECT().IngestVectors(ctx, entity.IngestRequest{
+			Index:        "index1",
+			Field:        "embedding",
+			Format:       entity.FileFormatCSV,
+			VectorColumn: "embedding",
+			BatchSize:    10,
+			Concurrency:  1,
+		}, gomock.Any(), int64(33), true).Return(expected, nil)
+		result, err := IngestVectors(New(mockedController), "testdata/vectors.csv", entity.IngestRequest{
+			Index:       "index1",
+			Field:       "embedding",
+			Format:      entity.FileFormatAuto,
+			BatchSize:   10,
+			Concurrency: 1,
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, result)
+	})
+	t.Run("ingest failure due to unknown format", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		_, err := New(mockedController).IngestVectors("testdata/train.txt", entity.IngestRequest{Index: "index1", Field: "embedding"})
+		assert.EqualError(t, err, "cannot detect format of file testdata/train.txt, supported formats are csv, jsonl, npy, fvecs")
+	})
+}
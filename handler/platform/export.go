@@ -0,0 +1,214 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	entity "opensearch-cli/entity/platform"
+	mapper "opensearch-cli/mapper/platform"
+	"os"
+	"strings"
+)
+
+//exportWriter writes pages to output file and saves checkpoint after every page if checkpoint file is provided.
+//Compressed output is written as separate gzip member per page when checkpoint is saved, so that output can be
+//truncated to size stored in checkpoint
+type exportWriter struct {
+	file           *os.File
+	compress       bool
+	gzip           *gzip.Writer
+	checkpointFile string
+	checkpoint     entity.ExportCheckpoint
+}
+
+func (e *exportWriter) write(page entity.ExportPage) error {
+	var w io.Writer = e.file
+	if e.compress {
+		if e.gzip == nil {
+			e.gzip = gzip.NewWriter(e.file)
+		}
+		w = e.gzip
+	}
+	if _, err := w.Write(mapper.MapToBulkBody(page.Items)); err != nil {
+		return err
+	}
+	state := &e.checkpoint.Slices[page.Slice]
+	state.SearchAfter = page.SearchAfter
+	state.Exported += len(page.Items)
+	state.Done = page.Done
+	if len(e.checkpointFile) < 1 {
+		return nil
+	}
+	if err := e.closeGzip(); err != nil {
+		return err
+	}
+	if err := e.file.Sync(); err != nil {
+		return err
+	}
+	offset, err := e.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	e.checkpoint.Offset = offset
+	return saveExportCheckpoint(e.checkpointFile, e.checkpoint)
+}
+
+func (e *exportWriter) closeGzip() error {
+	if e.gzip == nil {
+		return nil
+	}
+	err := e.gzip.Close()
+	e.gzip = nil
+	return err
+}
+
+func (e *exportWriter) close() error {
+	gzipErr := e.closeGzip()
+	if err := e.file.Close(); err != nil {
+		return err
+	}
+	return gzipErr
+}
+
+func (e *exportWriter) exported() int {
+	exported := 0
+	for _, slice := range e.checkpoint.Slices {
+		exported += slice.Exported
+	}
+	return exported
+}
+
+//saveExportCheckpoint replaces checkpoint file with checkpoint, file is replaced by rename to survive crash
+func saveExportCheckpoint(fileName string, checkpoint entity.ExportCheckpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	temporary := fileName + ".tmp"
+	if err = ioutil.WriteFile(temporary, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(temporary, fileName)
+}
+
+//loadExportCheckpoint reads checkpoint file, nil is returned if file doesn't exist
+func loadExportCheckpoint(fileName string) (*entity.ExportCheckpoint, error) {
+	if len(fileName) < 1 {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var checkpoint entity.ExportCheckpoint
+	if err = json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %s: %v", fileName, err)
+	}
+	return &checkpoint, nil
+}
+
+//openExportWriter creates output file, or truncates existing output to size stored in checkpoint if export
+//is resumed
+func openExportWriter(request entity.ExportRequest, outFile string, checkpointFile string) (*exportWriter, bool, error) {
+	checkpoint, err := loadExportCheckpoint(checkpointFile)
+	if err != nil {
+		return nil, false, err
+	}
+	writer := &exportWriter{
+		compress:       strings.HasSuffix(strings.ToLower(outFile), mapper.GzipExtension),
+		checkpointFile: checkpointFile,
+	}
+	if checkpoint == nil {
+		writer.checkpoint = entity.ExportCheckpoint{
+			Index:      request.Index,
+			Query:      request.Query,
+			Tiebreaker: request.Tiebreaker,
+		}
+		for i := 0; i < request.Slices; i++ {
+			writer.checkpoint.Slices = append(writer.checkpoint.Slices, entity.ExportSliceState{ID: i})
+		}
+		if writer.file, err = os.Create(outFile); err != nil {
+			return nil, false, err
+		}
+		return writer, false, nil
+	}
+	if checkpoint.Index != request.Index || checkpoint.Query != request.Query || checkpoint.Tiebreaker != request.Tiebreaker ||
+		len(checkpoint.Slices) != request.Slices {
+		return nil, false, fmt.Errorf("checkpoint file %s belongs to export of different index, query, tiebreaker or number of slices", checkpointFile)
+	}
+	writer.checkpoint = *checkpoint
+	if writer.file, err = os.OpenFile(outFile, os.O_RDWR, 0644); err != nil {
+		return nil, false, err
+	}
+	if err = writer.file.Truncate(checkpoint.Offset); err != nil {
+		writer.file.Close()
+		return nil, false, err
+	}
+	if _, err = writer.file.Seek(checkpoint.Offset, io.SeekStart); err != nil {
+		writer.file.Close()
+		return nil, false, err
+	}
+	return writer, true, nil
+}
+
+//ExportIndex exports documents of index into outFile in bulk format, output is compressed if file name ends
+//with .gz. If checkpointFile is provided, progress is saved after every page and export resumes from checkpoint
+//if it exists. Checkpoint file is deleted after export is completed
+func ExportIndex(h *Handler, request entity.ExportRequest, outFile string, checkpointFile string) (*entity.ExportResult, error) {
+	return h.ExportIndex(request, outFile, checkpointFile)
+}
+
+//ExportIndex exports documents of index into outFile in bulk format, output is compressed if file name ends
+//with .gz. If checkpointFile is provided, progress is saved after every page and export resumes from checkpoint
+//if it exists, in point in time of checkpoint if it is still alive. Checkpoint file is deleted after export is completed
+func (h *Handler) ExportIndex(request entity.ExportRequest, outFile string, checkpointFile string) (*entity.ExportResult, error) {
+	ctx := context.Background()
+	writer, resumed, err := openExportWriter(request, outFile, checkpointFile)
+	if err != nil {
+		return nil, err
+	}
+	request.PITID = writer.checkpoint.PITID
+	pitID, consistent, err := h.Controller.OpenExportPointInTime(ctx, request, writer.checkpoint.Slices)
+	if err != nil {
+		_ = writer.close()
+		return nil, err
+	}
+	request.PITID = pitID
+	writer.checkpoint.PITID = pitID
+	err = h.Controller.ExportDocuments(ctx, request, writer.checkpoint.Slices, writer.write)
+	closeErr := writer.close()
+	result := &entity.ExportResult{
+		Exported:   writer.exported(),
+		Resumed:    resumed,
+		Consistent: consistent,
+	}
+	if err != nil {
+		return result, err
+	}
+	if closeErr != nil {
+		return result, closeErr
+	}
+	if len(checkpointFile) > 0 {
+		if err = os.Remove(checkpointFile); err != nil && !os.IsNotExist(err) {
+			return result, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,143 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"opensearch-cli/controller/platform/mocks"
+	entity "opensearch-cli/entity/platform"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func getExportPage(slice int, id string, done bool) entity.ExportPage {
+	return entity.ExportPage{
+		Slice:       slice,
+		Items:       []*entity.BulkItem{{Action: []byte(`{"index":{"_id":"` + id + `"}}`), Source: []byte(`{}`)}},
+		SearchAfter: []interface{}{id},
+		Done:        done,
+	}
+}
+
+func TestHandlerExportIndex(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	request := entity.ExportRequest{Index: "index1", Slices: 1, PageSize: 1, Tiebreaker: "_shard_doc"}
+	withPIT := func(r entity.ExportRequest, pitID string) entity.ExportRequest {
+		r.PITID = pitID
+		return r
+	}
+	t.Run("export into file", func(t *testing.T) {
+		outFile := filepath.Join(t.TempDir(), "out.jsonl")
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().OpenExportPointInTime(gomock.Any(), request, []entity.ExportSliceState{{ID: 0}}).Return("pit1", true, nil)
+		mockedController.EXPECT().ExportDocuments(gomock.Any(), withPIT(request, "pit1"), []entity.ExportSliceState{{ID: 0}}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ entity.ExportRequest, _ []entity.ExportSliceState, process func(entity.ExportPage) error) error {
+				assert.NoError(t, process(getExportPage(0, "1", false)))
+				return process(getExportPage(0, "2", true))
+			})
+		result, err := ExportIndex(New(mockedController), request, outFile, "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, &entity.ExportResult{Exported: 2, Consistent: true}, result)
+		contents, err := ioutil.ReadFile(outFile)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "{\"index\":{\"_id\":\"1\"}}\n{}\n{\"index\":{\"_id\":\"2\"}}\n{}\n", string(contents))
+	})
+	t.Run("resume compressed export from checkpoint", func(t *testing.T) {
+		directory := t.TempDir()
+		outFile := filepath.Join(directory, "out.jsonl.gz")
+		checkpointFile := filepath.Join(directory, "export.checkpoint")
+		sliced := request
+		sliced.Slices = 2
+		mockedController := mocks.NewMockController(mockCtrl)
+		resumedSlices := []entity.ExportSliceState{
+			{ID: 0, SearchAfter: []interface{}{"1"}, Exported: 1, Done: true},
+			{ID: 1, SearchAfter: []interface{}{"2"}, Exported: 1},
+		}
+		gomock.InOrder(
+			mockedController.EXPECT().OpenExportPointInTime(gomock.Any(), sliced, []entity.ExportSliceState{{ID: 0}, {ID: 1}}).Return("pit1", true, nil),
+			mockedController.EXPECT().ExportDocuments(gomock.Any(), withPIT(sliced, "pit1"), []entity.ExportSliceState{{ID: 0}, {ID: 1}}, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ entity.ExportRequest, _ []entity.ExportSliceState, process func(entity.ExportPage) error) error {
+					assert.NoError(t, process(getExportPage(0, "1", true)))
+					assert.NoError(t, process(getExportPage(1, "2", false)))
+					return errors.New("connection reset")
+				}),
+			mockedController.EXPECT().OpenExportPointInTime(gomock.Any(), withPIT(sliced, "pit1"), resumedSlices).Return("pit1", true, nil),
+			mockedController.EXPECT().ExportDocuments(gomock.Any(), withPIT(sliced, "pit1"), resumedSlices, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ entity.ExportRequest, _ []entity.ExportSliceState, process func(entity.ExportPage) error) error {
+					return process(getExportPage(1, "3", true))
+				}),
+		)
+		instance := New(mockedController)
+		result, err := instance.ExportIndex(sliced, outFile, checkpointFile)
+		assert.EqualError(t, err, "connection reset")
+		assert.EqualValues(t, 2, result.Exported)
+		// simulate partially written page after last checkpoint
+		file, err := os.OpenFile(outFile, os.O_APPEND|os.O_WRONLY, 0644)
+		assert.NoError(t, err)
+		_, err = file.Write([]byte("partial"))
+		assert.NoError(t, err)
+		assert.NoError(t, file.Close())
+
+		result, err = instance.ExportIndex(sliced, outFile, checkpointFile)
+		assert.NoError(t, err)
+		assert.EqualValues(t, &entity.ExportResult{Exported: 3, Resumed: true, Consistent: true}, result)
+		_, err = os.Stat(checkpointFile)
+		assert.True(t, os.IsNotExist(err))
+		file, err = os.Open(outFile)
+		assert.NoError(t, err)
+		defer file.Close()
+		reader, err := gzip.NewReader(file)
+		assert.NoError(t, err)
+		contents, err := ioutil.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "{\"index\":{\"_id\":\"1\"}}\n{}\n{\"index\":{\"_id\":\"2\"}}\n{}\n{\"index\":{\"_id\":\"3\"}}\n{}\n", string(contents))
+	})
+	t.Run("resume in new point in time", func(t *testing.T) {
+		directory := t.TempDir()
+		outFile := filepath.Join(directory, "out.jsonl")
+		checkpointFile := filepath.Join(directory, "export.checkpoint")
+		custom := request
+		custom.Tiebreaker = "doc_id"
+		assert.NoError(t, ioutil.WriteFile(outFile, []byte("{\"index\":{\"_id\":\"1\"}}\n{}\n"), 0600))
+		assert.NoError(t, ioutil.WriteFile(checkpointFile, []byte(`{"index":"index1","tiebreaker":"doc_id","pit_id":"expired",
+			"offset":25,"slices":[{"id":0,"search_after":["1"],"exported":1}]}`), 0600))
+		slices := []entity.ExportSliceState{{ID: 0, SearchAfter: []interface{}{"1"}, Exported: 1}}
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().OpenExportPointInTime(gomock.Any(), withPIT(custom, "expired"), slices).Return("pit2", false, nil)
+		mockedController.EXPECT().ExportDocuments(gomock.Any(), withPIT(custom, "pit2"), slices, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ entity.ExportRequest, _ []entity.ExportSliceState, process func(entity.ExportPage) error) error {
+				return process(getExportPage(0, "2", true))
+			})
+		result, err := New(mockedController).ExportIndex(custom, outFile, checkpointFile)
+		assert.NoError(t, err)
+		assert.EqualValues(t, &entity.ExportResult{Exported: 2, Resumed: true, Consistent: false}, result)
+		contents, err := ioutil.ReadFile(outFile)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "{\"index\":{\"_id\":\"1\"}}\n{}\n{\"index\":{\"_id\":\"2\"}}\n{}\n", string(contents))
+	})
+	t.Run("checkpoint of different export", func(t *testing.T) {
+		directory := t.TempDir()
+		checkpointFile := filepath.Join(directory, "export.checkpoint")
+		assert.NoError(t, ioutil.WriteFile(checkpointFile, []byte(`{"index":"index2","tiebreaker":"_shard_doc","slices":[{"id":0}]}`), 0600))
+		mockedController := mocks.NewMockController(mockCtrl)
+		_, err := New(mockedController).ExportIndex(request, filepath.Join(directory, "out.jsonl"), checkpointFile)
+		assert.EqualError(t, err, "checkpoint file "+checkpointFile+" belongs to export of different index, query, tiebreaker or number of slices")
+	})
+}
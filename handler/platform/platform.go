@@ -12,11 +12,21 @@
 package platform
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"opensearch-cli/controller/platform"
 	entity "opensearch-cli/entity/platform"
+	mapper "opensearch-cli/mapper/platform"
+	"os"
+	"strings"
 )
 
+//StdinFileName is file name used to read from standard input
+const StdinFileName = "-"
+
 //Handler is facade for controller
 type Handler struct {
 	platform.Controller
@@ -39,3 +49,98 @@ func (h *Handler) Curl(request entity.CurlCommandRequest) ([]byte, error) {
 	ctx := context.Background()
 	return h.Controller.Curl(ctx, request)
 }
+
+//deadLetterFile creates file on first write so that file is created only if any item failed
+type deadLetterFile struct {
+	name string
+	file *os.File
+}
+
+func (d *deadLetterFile) Write(p []byte) (int, error) {
+	if d.file == nil {
+		file, err := os.Create(d.name)
+		if err != nil {
+			return 0, err
+		}
+		d.file = file
+	}
+	return d.file.Write(p)
+}
+
+func (d *deadLetterFile) Close() error {
+	if d.file == nil {
+		return nil
+	}
+	return d.file.Close()
+}
+
+//LoadBulk loads items from every file into OpenSearch using bulk API, stdin is read if file name is "-".
+//Format is detected from file extension unless provided, failed items are written to deadLetter file if provided
+func LoadBulk(h *Handler, fileNames []string, request entity.BulkLoadRequest, deadLetter string) (*entity.BulkLoadResult, error) {
+	return h.LoadBulk(fileNames, request, deadLetter)
+}
+
+//LoadBulk loads items from every file into OpenSearch using bulk API, stdin is read if file name is "-".
+//Format is detected from file extension unless provided, failed items are written to deadLetter file if provided
+func (h *Handler) LoadBulk(fileNames []string, request entity.BulkLoadRequest, deadLetter string) (*entity.BulkLoadResult, error) {
+	var writer io.Writer
+	if len(deadLetter) > 0 {
+		file := &deadLetterFile{name: deadLetter}
+		defer file.Close()
+		writer = file
+	}
+	var results []*entity.BulkLoadResult
+	for _, fileName := range fileNames {
+		result, err := h.loadBulkFile(fileName, request, writer)
+		if result != nil {
+			results = append(results, result)
+		}
+		if err != nil && len(results) < 1 {
+			return nil, fmt.Errorf("%s: %v", fileName, err)
+		}
+		if err != nil {
+			return mapper.MergeBulkLoadResults(results...), fmt.Errorf("%s: %v", fileName, err)
+		}
+	}
+	return mapper.MergeBulkLoadResults(results...), nil
+}
+
+func (h *Handler) loadBulkFile(fileName string, request entity.BulkLoadRequest, deadLetter io.Writer) (*entity.BulkLoadResult, error) {
+	ctx := context.Background()
+	if fileName == StdinFileName {
+		return h.Controller.LoadBulk(ctx, request, os.Stdin, 0, deadLetter, false)
+	}
+	if len(request.Format) < 1 || request.Format == entity.BulkFormatAuto {
+		request.Format = mapper.GetBulkFileFormat(fileName)
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(strings.ToLower(fileName), mapper.GzipExtension) {
+		return h.Controller.LoadBulk(ctx, request, file, info.Size(), deadLetter, true)
+	}
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	// size of uncompressed contents is unknown, progress is not displayed
+	return h.Controller.LoadBulk(ctx, request, reader, 0, deadLetter, true)
+}
+
+//SearchDocuments searches documents of index, hits are passed to process page by page
+func SearchDocuments(h *Handler, request entity.SearchCommandRequest, process func([]json.RawMessage) error) error {
+	return h.SearchDocuments(request, process)
+}
+
+//SearchDocuments searches documents of index, hits are passed to process page by page
+func (h *Handler) SearchDocuments(request entity.SearchCommandRequest, process func([]json.RawMessage) error) error {
+	ctx := context.Background()
+	return h.Controller.SearchDocuments(ctx, request, process)
+}
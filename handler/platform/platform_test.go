@@ -12,10 +12,16 @@
 package platform
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
 	"opensearch-cli/controller/platform/mocks"
 	entity "opensearch-cli/entity/platform"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -43,3 +49,119 @@ func TestHandlerCurl(t *testing.T) {
 		assert.EqualError(t, err, "failed to execute")
 	})
 }
+
+func TestHandlerLoadBulk(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	request := entity.BulkLoadRequest{Index: "docs", Format: entity.BulkFormatAuto}
+	t.Run("load files", func(t *testing.T) {
+		deadLetter := filepath.Join(t.TempDir(), "failed.ndjson")
+		mockedController := mocks.NewMockController(mockCtrl)
+		ndjsonRequest := request
+		ndjsonRequest.Format = entity.BulkFormatAuto
+		csvRequest := request
+		csvRequest.Format = entity.BulkFormatCSV
+		gomock.InOrder(
+			mockedController.EXPECT().LoadBulk(gomock.Any(), ndjsonRequest, gomock.Any(), int64(37), gomock.Any(), true).Return(
+				&entity.BulkLoadResult{Loaded: 2}, nil),
+			mockedController.EXPECT().LoadBulk(gomock.Any(), csvRequest, gomock.Any(), int64(12), gomock.Any(), true).DoAndReturn(
+				func(_ context.Context, _ entity.BulkLoadRequest, _ io.Reader, _ int64, w io.Writer, _ bool) (*entity.BulkLoadResult, error) {
+					_, err := w.Write([]byte("failed\n"))
+					return &entity.BulkLoadResult{
+						Failed:   1,
+						Failures: []*entity.BulkFailure{{Type: "mapper_parsing_exception", Count: 1, Reason: "failed to parse"}},
+					}, err
+				}),
+		)
+		instance := New(mockedController)
+		result, err := LoadBulk(instance, []string{"testdata/docs.ndjson", "testdata/docs.csv"}, request, deadLetter)
+		assert.NoError(t, err)
+		assert.EqualValues(t, &entity.BulkLoadResult{
+			Loaded:   2,
+			Failed:   1,
+			Failures: []*entity.BulkFailure{{Type: "mapper_parsing_exception", Count: 1, Reason: "failed to parse"}},
+		}, result)
+		contents, err := ioutil.ReadFile(deadLetter)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "failed\n", string(contents))
+	})
+	t.Run("dead letter is not created without failures", func(t *testing.T) {
+		deadLetter := filepath.Join(t.TempDir(), "failed.ndjson")
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().LoadBulk(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), true).Return(
+			&entity.BulkLoadResult{Loaded: 2}, nil)
+		instance := New(mockedController)
+		_, err := instance.LoadBulk([]string{"testdata/docs.ndjson"}, request, deadLetter)
+		assert.NoError(t, err)
+		_, err = os.Stat(deadLetter)
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("load compressed file", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "docs.csv.gz")
+		file, err := os.Create(fileName)
+		assert.NoError(t, err)
+		writer := gzip.NewWriter(file)
+		_, err = writer.Write([]byte("title\nthird\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+		assert.NoError(t, file.Close())
+		csvRequest := request
+		csvRequest.Format = entity.BulkFormatCSV
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().LoadBulk(gomock.Any(), csvRequest, gomock.Any(), int64(0), gomock.Any(), true).DoAndReturn(
+			func(_ context.Context, _ entity.BulkLoadRequest, r io.Reader, _ int64, _ io.Writer, _ bool) (*entity.BulkLoadResult, error) {
+				contents, err := ioutil.ReadAll(r)
+				assert.NoError(t, err)
+				assert.EqualValues(t, "title\nthird\n", string(contents))
+				return &entity.BulkLoadResult{Loaded: 1}, nil
+			})
+		result, err := New(mockedController).LoadBulk([]string{fileName}, request, "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, result.Loaded)
+	})
+	t.Run("file not found", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		instance := New(mockedController)
+		result, err := instance.LoadBulk([]string{"testdata/missing.ndjson"}, request, "")
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("load failed", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().LoadBulk(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), true).Return(
+			&entity.BulkLoadResult{Loaded: 1}, errors.New("gateway failed"))
+		instance := New(mockedController)
+		result, err := instance.LoadBulk([]string{"testdata/docs.ndjson"}, request, "")
+		assert.EqualError(t, err, "testdata/docs.ndjson: gateway failed")
+		assert.EqualValues(t, 1, result.Loaded)
+	})
+}
+
+func TestHandlerSearchDocuments(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	request := entity.SearchCommandRequest{Index: "index1", Query: "a:1"}
+	t.Run("success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().SearchDocuments(ctx, request, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ entity.SearchCommandRequest, process func([]json.RawMessage) error) error {
+				return process([]json.RawMessage{json.RawMessage(`{"_id":"1"}`)})
+			})
+		instance := New(mockedController)
+		var hits []json.RawMessage
+		err := SearchDocuments(instance, request, func(page []json.RawMessage) error {
+			hits = append(hits, page...)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, []json.RawMessage{json.RawMessage(`{"_id":"1"}`)}, hits)
+	})
+	t.Run("failed", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().SearchDocuments(ctx, request, gomock.Any()).Return(errors.New("search failed"))
+		instance := New(mockedController)
+		err := instance.SearchDocuments(request, nil)
+		assert.EqualError(t, err, "search failed")
+	})
+}
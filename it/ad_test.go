@@ -20,12 +20,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"opensearch-cli/client"
 	adctrl "opensearch-cli/controller/ad"
 	"opensearch-cli/controller/platform"
-	"opensearch-cli/entity"
 	adentity "opensearch-cli/entity/ad"
-	"opensearch-cli/environment"
 	adgateway "opensearch-cli/gateway/ad"
 	esg "opensearch-cli/gateway/platform"
 	"os"
@@ -66,17 +63,12 @@ func getRawFeatureAggregation() []byte {
 func (a *ADTestSuite) SetupSuite() {
 	var err error
 	a.Plugins = append(a.Plugins, "opensearch-anomaly-detection")
-	a.Client, err = client.New(nil)
+	a.Profile = NewTestProfile()
+	a.Client, err = NewTestClient(a.Profile)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
-	a.Profile = &entity.Profile{
-		Name:     "test",
-		Endpoint: os.Getenv(environment.OPENSEARCH_ENDPOINT),
-		UserName: os.Getenv(environment.OPENSEARCH_USER),
-		Password: os.Getenv(environment.OPENSEARCH_PASSWORD),
-	}
 	if err = a.ValidateProfile(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -20,6 +20,7 @@ import (
 	"opensearch-cli/client"
 	"opensearch-cli/entity"
 	"opensearch-cli/environment"
+	"opensearch-cli/gateway"
 	"os"
 	"path/filepath"
 	"strings"
@@ -51,6 +52,28 @@ func HelperLoadBytes(name string) []byte {
 	return contents
 }
 
+// NewTestProfile returns profile of test cluster from environment, certificate is not verified
+// since test cluster uses self-signed demo certificates
+func NewTestProfile() *entity.Profile {
+	verify := false
+	return &entity.Profile{
+		Name:     "test",
+		Endpoint: os.Getenv(environment.OPENSEARCH_ENDPOINT),
+		UserName: os.Getenv(environment.OPENSEARCH_USER),
+		Password: os.Getenv(environment.OPENSEARCH_PASSWORD),
+		TLS:      &entity.TLS{Verify: &verify},
+	}
+}
+
+// NewTestClient returns client which connects to cluster of profile using TLS settings of profile
+func NewTestClient(p *entity.Profile) (*client.Client, error) {
+	transport, err := gateway.BuildTransport(http.DefaultTransport, p)
+	if err != nil {
+		return nil, err
+	}
+	return client.New(transport)
+}
+
 // DeleteIndex deletes index by name
 func (a *CLISuite) DeleteIndex(indexName string) {
 	_, err := a.callRequest(http.MethodDelete, []byte(""), fmt.Sprintf("%s/%s", a.Profile.Endpoint, indexName))
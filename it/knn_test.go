@@ -16,14 +16,12 @@ package it
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"opensearch-cli/client"
 	ctrl "opensearch-cli/controller/knn"
-	"opensearch-cli/entity"
-	"opensearch-cli/environment"
+	"opensearch-cli/controller/platform"
 	gateway "opensearch-cli/gateway/knn"
+	esg "opensearch-cli/gateway/platform"
 	"os"
 	"strings"
 	"testing"
@@ -47,24 +45,20 @@ type KNNTestSuite struct {
 //SetupSuite runs once for every test suite
 func (a *KNNTestSuite) SetupSuite() {
 	var err error
-	a.Client, err = client.New(nil)
+	a.Profile = NewTestProfile()
+	a.Client, err = NewTestClient(a.Profile)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
-	a.Profile = &entity.Profile{
-		Name:     "test",
-		Endpoint: os.Getenv(environment.OPENSEARCH_ENDPOINT),
-		UserName: os.Getenv(environment.OPENSEARCH_USER),
-		Password: os.Getenv(environment.OPENSEARCH_PASSWORD),
-	}
 	if err = a.ValidateProfile(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 	a.Plugins = append(a.Plugins, "opensearch-knn")
 	a.Gateway, _ = gateway.New(a.Client, a.Profile)
-	a.Controller = ctrl.New(a.Gateway)
+	g, _ := esg.New(a.Client, a.Profile)
+	a.Controller = ctrl.New(platform.New(g), a.Gateway)
 	a.CreateIndex(KNNSampleIndexFileName, KnnSampleIndexMappingFileName)
 }
 func (a *KNNTestSuite) TearDownSuite() {
@@ -89,79 +83,49 @@ func (a *KNNTestSuite) TestGetStatistics() {
 		ctx := context.Background()
 		response, err := a.Controller.GetStatistics(ctx, "", "")
 		assert.NoError(t, err, "failed to get stats")
-		assert.NotNil(t, string(response))
+		assert.NotNil(t, response)
+		assert.NotEmpty(t, response.NodeStats)
 	})
 	a.T().Run("test filtered full stats", func(t *testing.T) {
 		ctx := context.Background()
 		nodeID := a.GetNodesIDUsingRESTAPI(t)
 		response, err := a.Controller.GetStatistics(ctx, nodeID, "graph_index_errors,knn_query_requests")
 		assert.NoError(t, err, "failed to get stats")
-		assert.NotNil(t, string(response))
-		var data map[string]interface{}
-		if err := json.Unmarshal(response, &data); err != nil {
-			t.Fatal(err)
-		}
-		assert.NotNil(t, data)
-		assert.NotNil(t, data["nodes"])
-		nodes := data["nodes"].(map[string]interface{})
-		if _, ok := nodes[nodeID]; !ok {
+		assert.NotNil(t, response)
+		stats, ok := response.NodeStats[nodeID]
+		if !ok {
 			t.Fatal("Node id is not found")
 		}
-		stats := nodes[nodeID].(map[string]interface{})
-		if _, ok := stats["graph_index_errors"]; !ok {
-			t.Fatal("graph_index_errors is not found")
-		}
-		if _, ok := stats["knn_query_requests"]; !ok {
-			t.Fatal("knn_query_requests is not found")
-		}
+		assert.NotNil(t, stats.GraphIndexErrors, "graph_index_errors is not found")
+		assert.NotNil(t, stats.KNNQueryRequests, "knn_query_requests is not found")
+		assert.Nil(t, stats.GraphMemoryUsage, "graph_memory_usage is not filtered")
 	})
 	a.T().Run("test filtered nodes", func(t *testing.T) {
 		ctx := context.Background()
 		nodeID := a.GetNodesIDUsingRESTAPI(t)
 		response, err := a.Controller.GetStatistics(ctx, nodeID, "")
 		assert.NoError(t, err, "failed to get stats")
-		assert.NotNil(t, string(response))
-		var data map[string]interface{}
-		if err := json.Unmarshal(response, &data); err != nil {
-			t.Fatal(err)
-		}
-		assert.NotNil(t, data)
-		assert.NotNil(t, data["nodes"])
-		nodes := data["nodes"].(map[string]interface{})
-		if _, ok := nodes[nodeID]; !ok {
+		assert.NotNil(t, response)
+		assert.Len(t, response.NodeStats, 1)
+		stats, ok := response.NodeStats[nodeID]
+		if !ok {
 			t.Fatal("Node id is not found")
 		}
-		stats := nodes[nodeID].(map[string]interface{})
-		if _, ok := stats["graph_index_errors"]; !ok {
-			t.Fatal("graph_index_errors is not found")
-		}
-		if _, ok := stats["knn_query_requests"]; !ok {
-			t.Fatal("knn_query_requests is not found")
-		}
+		assert.NotNil(t, stats.GraphIndexErrors, "graph_index_errors is not found")
+		assert.NotNil(t, stats.KNNQueryRequests, "knn_query_requests is not found")
 	})
 	a.T().Run("test filtered only stats", func(t *testing.T) {
 		ctx := context.Background()
 		response, err := a.Controller.GetStatistics(ctx, "", "graph_index_errors,knn_query_requests")
 		assert.NoError(t, err, "failed to get stats")
-		assert.NotNil(t, string(response))
-		var data map[string]interface{}
-		if err := json.Unmarshal(response, &data); err != nil {
-			t.Fatal(err)
-		}
-		assert.NotNil(t, data)
-		assert.NotNil(t, data["nodes"])
-		nodes := data["nodes"].(map[string]interface{})
+		assert.NotNil(t, response)
 		nodeID := a.GetNodesIDUsingRESTAPI(t)
-		if _, ok := nodes[nodeID]; !ok {
+		stats, ok := response.NodeStats[nodeID]
+		if !ok {
 			t.Fatal("Node id is not found")
 		}
-		stats := nodes[nodeID].(map[string]interface{})
-		if _, ok := stats["graph_index_errors"]; !ok {
-			t.Fatal("graph_index_errors is not found")
-		}
-		if _, ok := stats["knn_query_requests"]; !ok {
-			t.Fatal("knn_query_requests is not found")
-		}
+		assert.NotNil(t, stats.GraphIndexErrors, "graph_index_errors is not found")
+		assert.NotNil(t, stats.KNNQueryRequests, "knn_query_requests is not found")
 	})
 }
 
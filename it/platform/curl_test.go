@@ -18,11 +18,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"opensearch-cli/client"
 	ctrl "opensearch-cli/controller/platform"
-	"opensearch-cli/entity"
 	"opensearch-cli/entity/platform"
-	"opensearch-cli/environment"
 	gateway "opensearch-cli/gateway/platform"
 	"opensearch-cli/it"
 	"os"
@@ -51,17 +48,12 @@ type result struct {
 //SetupSuite runs once for every test suite
 func (a *OpenSearchTestSuite) SetupSuite() {
 	var err error
-	a.Client, err = client.New(nil)
+	a.Profile = it.NewTestProfile()
+	a.Client, err = it.NewTestClient(a.Profile)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
-	a.Profile = &entity.Profile{
-		Name:     "test",
-		Endpoint: os.Getenv(environment.OPENSEARCH_ENDPOINT),
-		UserName: os.Getenv(environment.OPENSEARCH_USER),
-		Password: os.Getenv(environment.OPENSEARCH_PASSWORD),
-	}
 	if err = a.ValidateProfile(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -17,24 +17,56 @@ import (
 	"opensearch-cli/entity/ad"
 	"opensearch-cli/mapper"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
 	featureCountLimit = 5
+	categoryLimit     = 2
 	minutesKey        = "m"
 	minutes           = "Minutes"
+	resultTimeField   = "data_end_time"
+	sortDescending    = "desc"
+	sortAscending     = "asc"
+	entityPath        = "entity"
+	entitySeparator   = "="
+	matchAllQuery     = `{"match_all":{}}`
 )
 
+//boolClauses are clauses of bool query which are stored as array by OpenSearch
+var boolClauses = map[string]bool{
+	"must":     true,
+	"filter":   true,
+	"should":   true,
+	"must_not": true,
+}
+
+//shorthandQueries are leaf queries whose short form is expanded to object with given key by OpenSearch
+var shorthandQueries = map[string]string{
+	"term":         "value",
+	"prefix":       "value",
+	"wildcard":     "value",
+	"match":        "query",
+	"match_phrase": "query",
+}
+
+//timeNow returns current time, it is a variable to be replaced in tests
+var timeNow = time.Now
+
+//userTypeToESType maps aggregation type accepted in detector file to OpenSearch aggregation
+var userTypeToESType = map[string]string{
+	"average": "avg",
+	"count":   "value_count",
+	"sum":     "sum",
+	"min":     "min",
+	"max":     "max",
+}
+
 func getFeatureAggregationQuery(name string, agg string, field string) ([]byte, error) {
 
-	userTypeToESType := make(map[string]string)
-	userTypeToESType["average"] = "avg"
-	userTypeToESType["count"] = "value_count"
-	userTypeToESType["sum"] = "sum"
-	userTypeToESType["min"] = "min"
-	userTypeToESType["max"] = "max"
 	val, ok := userTypeToESType[strings.ToLower(agg)]
 	if !ok {
 		var allowedTypes []string
@@ -130,6 +162,31 @@ func mapIntervalToStringPtr(request ad.Interval) (*string, error) {
 	return mapper.StringToStringPtr(fmt.Sprintf("%d%s", duration, *unit)), nil
 }
 
+//MapToCategoryField returns distinct category fields of request, partition field is treated as category field
+func MapToCategoryField(request ad.CreateDetectorRequest) []string {
+	fields := append([]string{}, request.CategoryField...)
+	if request.PartitionField != nil {
+		fields = append(fields, *request.PartitionField)
+	}
+	var result []string
+	seen := map[string]bool{}
+	for _, f := range fields {
+		if len(f) < 1 || seen[f] {
+			continue
+		}
+		seen[f] = true
+		result = append(result, f)
+	}
+	return result
+}
+
+func validateCategoryLimit(fields []string) error {
+	if len(fields) > categoryLimit {
+		return fmt.Errorf("trying to create detector with %d category fields, only upto %d category fields are allowed", len(fields), categoryLimit)
+	}
+	return nil
+}
+
 //MapToCreateDetector maps to CreateDetector
 func MapToCreateDetector(request ad.CreateDetectorRequest) (*ad.CreateDetector, error) {
 
@@ -138,6 +195,10 @@ func MapToCreateDetector(request ad.CreateDetectorRequest) (*ad.CreateDetector,
 	if err != nil {
 		return nil, err
 	}
+	categoryField := MapToCategoryField(request)
+	if err = validateCategoryLimit(categoryField); err != nil {
+		return nil, err
+	}
 	for _, f := range request.Features {
 		ftr, err := mapToFeature(f)
 		if err != nil {
@@ -155,14 +216,15 @@ func MapToCreateDetector(request ad.CreateDetectorRequest) (*ad.CreateDetector,
 		return nil, err
 	}
 	return &ad.CreateDetector{
-		Name:        request.Name,
-		Description: request.Description,
-		TimeField:   request.TimeField,
-		Index:       request.Index,
-		Features:    features,
-		Filter:      request.Filter,
-		Interval:    *interval,
-		Delay:       *delay,
+		Name:          request.Name,
+		Description:   request.Description,
+		TimeField:     request.TimeField,
+		Index:         request.Index,
+		Features:      features,
+		Filter:        request.Filter,
+		Interval:      *interval,
+		Delay:         *delay,
+		CategoryField: categoryField,
 	}, nil
 }
 
@@ -184,12 +246,18 @@ func MapToDetectors(searchResponse []byte, name string) ([]ad.Detector, error) {
 	if err != nil {
 		return nil, err
 	}
+	return MapHitsToDetectors(data.Hits.Hits, name), nil
+}
+
+//MapHitsToDetectors maps hits whose name matches name pattern to detectors, * matches any characters and +
+//matches at least one character, every other character is matched literally
+func MapHitsToDetectors(hits []ad.Hit, name string) []ad.Detector {
 	var result []ad.Detector
-	processedNameAnyCharacter := strings.ReplaceAll(name, "*", "(.*)")
-	processedName := strings.ReplaceAll(processedNameAnyCharacter, "+", "(.+)")
+	processedNameAnyCharacter := strings.ReplaceAll(regexp.QuoteMeta(name), `\*`, "(.*)")
+	processedName := strings.ReplaceAll(processedNameAnyCharacter, `\+`, "(.+)")
 
 	r, _ := regexp.Compile(fmt.Sprintf("^%s$", processedName))
-	for _, detector := range data.Hits.Hits {
+	for _, detector := range hits {
 		if !r.MatchString(detector.Source.Name) {
 			continue
 		}
@@ -198,7 +266,7 @@ func MapToDetectors(searchResponse []byte, name string) ([]ad.Detector, error) {
 			ID:   detector.ID,
 		})
 	}
-	return result, nil
+	return result
 }
 
 func MapToDetectorOutput(response ad.DetectorResponse) (*ad.DetectorOutput, error) {
@@ -220,6 +288,7 @@ func MapToDetectorOutput(response ad.DetectorResponse) (*ad.DetectorOutput, erro
 		Filter:        response.AnomalyDetector.Filter,
 		Interval:      mapper.StringPtrToString(interval),
 		Delay:         mapper.StringPtrToString(delay),
+		CategoryField: response.AnomalyDetector.CategoryField,
 		LastUpdatedAt: response.AnomalyDetector.LastUpdateTime,
 		SchemaVersion: response.AnomalyDetector.SchemaVersion,
 	}, nil
@@ -229,6 +298,9 @@ func MapToUpdateDetector(request ad.UpdateDetectorUserInput) (*ad.UpdateDetector
 	if err := validateFeatures(request.Features); err != nil {
 		return nil, err
 	}
+	if err := validateCategoryLimit(request.CategoryField); err != nil {
+		return nil, err
+	}
 	delay, err := mapToInterval(request.Delay)
 	if err != nil {
 		return nil, err
@@ -238,14 +310,15 @@ func MapToUpdateDetector(request ad.UpdateDetectorUserInput) (*ad.UpdateDetector
 		return nil, err
 	}
 	return &ad.UpdateDetector{
-		Name:        request.Name,
-		Description: request.Description,
-		TimeField:   request.TimeField,
-		Index:       request.Index,
-		Features:    request.Features,
-		Filter:      request.Filter,
-		Interval:    *interval,
-		Delay:       *delay,
+		Name:          request.Name,
+		Description:   request.Description,
+		TimeField:     request.TimeField,
+		Index:         request.Index,
+		Features:      request.Features,
+		Filter:        request.Filter,
+		Interval:      *interval,
+		Delay:         *delay,
+		CategoryField: request.CategoryField,
 	}, nil
 }
 
@@ -263,3 +336,507 @@ func validateFeatures(features []ad.Feature) error {
 	}
 	return nil
 }
+
+//MapToDetectorStatus maps detector and its profile to DetectorStatus
+func MapToDetectorStatus(detector ad.Detector, response []byte) (*ad.DetectorStatus, error) {
+	var profile ad.DetectorProfile
+	if err := json.Unmarshal(response, &profile); err != nil {
+		return nil, err
+	}
+	status := &ad.DetectorStatus{
+		ID:               detector.ID,
+		Name:             detector.Name,
+		State:            profile.State,
+		Error:            profile.Error,
+		CoordinatingNode: profile.CoordinatingNode,
+		Nodes:            []string{},
+	}
+	if profile.InitProgress != nil {
+		status.InitProgress = profile.InitProgress.Percentage
+		status.EstimatedMinutesLeft = &profile.InitProgress.EstimatedMinutesLeft
+	}
+	nodes := map[string]bool{}
+	for _, m := range profile.Models {
+		if len(m.NodeID) < 1 || nodes[m.NodeID] {
+			continue
+		}
+		nodes[m.NodeID] = true
+		status.Nodes = append(status.Nodes, m.NodeID)
+	}
+	return status, nil
+}
+
+//mapToEpochMillis maps time as duration relative to now like 24h, RFC3339, date or epoch milliseconds to epoch milliseconds
+func mapToEpochMillis(value string) (int64, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return timeNow().Add(-d).UnixNano() / int64(time.Millisecond), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UnixNano() / int64(time.Millisecond), nil
+		}
+	}
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return millis, nil
+	}
+	return 0, fmt.Errorf("invalid time: %s, expected duration like 24h, RFC3339 time, date like 2006-01-02 or epoch milliseconds", value)
+}
+
+//mapToEntityQuery maps entity filter either as value or name=value to nested query
+func mapToEntityQuery(filter string) interface{} {
+	terms := []interface{}{}
+	value := filter
+	//only first separator splits name from value, value may contain separator as well
+	if parts := strings.SplitN(filter, entitySeparator, 2); len(parts) == 2 {
+		terms = append(terms, map[string]interface{}{
+			"term": map[string]string{entityPath + ".name": parts[0]},
+		})
+		value = parts[1]
+	}
+	terms = append(terms, map[string]interface{}{
+		"term": map[string]string{entityPath + ".value": value},
+	})
+	return map[string]interface{}{
+		"nested": map[string]interface{}{
+			"path": entityPath,
+			"query": map[string]interface{}{
+				"bool": map[string]interface{}{
+					"filter": terms,
+				},
+			},
+		},
+	}
+}
+
+//MapToAnomalyResultSearchRequest maps detector ids and user's filter to anomaly results search request.
+// Only anomalies are returned, i.e, results with anomaly grade greater than zero
+func MapToAnomalyResultSearchRequest(detectorIDs []string, filter ad.AnomalyResultFilter, size int, searchAfter []interface{}) (*ad.AnomalyResultSearchRequest, error) {
+	if len(detectorIDs) < 1 {
+		return nil, fmt.Errorf("detector ids cannot be empty")
+	}
+	filters := []interface{}{
+		map[string]interface{}{
+			"terms": map[string][]string{"detector_id": detectorIDs},
+		},
+	}
+	timeRange := map[string]int64{}
+	if len(filter.From) > 0 {
+		from, err := mapToEpochMillis(filter.From)
+		if err != nil {
+			return nil, err
+		}
+		timeRange["gte"] = from
+	}
+	if len(filter.To) > 0 {
+		to, err := mapToEpochMillis(filter.To)
+		if err != nil {
+			return nil, err
+		}
+		timeRange["lte"] = to
+	}
+	if len(timeRange) > 0 {
+		filters = append(filters, map[string]interface{}{
+			"range": map[string]interface{}{resultTimeField: timeRange},
+		})
+	}
+	grade := map[string]float64{"gt": 0}
+	if filter.MinAnomalyGrade > 0 {
+		grade = map[string]float64{"gte": filter.MinAnomalyGrade}
+	}
+	filters = append(filters, map[string]interface{}{
+		"range": map[string]interface{}{"anomaly_grade": grade},
+	})
+	if filter.MinConfidence > 0 {
+		filters = append(filters, map[string]interface{}{
+			"range": map[string]interface{}{"confidence": map[string]float64{"gte": filter.MinConfidence}},
+		})
+	}
+	if len(filter.Entity) > 0 {
+		filters = append(filters, mapToEntityQuery(filter.Entity))
+	}
+	return &ad.AnomalyResultSearchRequest{
+		Query: ad.ResultQuery{
+			Bool: ad.ResultFilter{
+				Filter: filters,
+			},
+		},
+		Sort: []map[string]ad.SortOrder{
+			{resultTimeField: {Order: sortDescending}},
+			{"detector_id": {Order: sortAscending}},
+			{"model_id": {Order: sortAscending, UnmappedType: "keyword"}},
+		},
+		Size:        size,
+		SearchAfter: searchAfter,
+	}, nil
+}
+
+//mapEpochMillisToTime maps epoch milliseconds to RFC3339 time in UTC
+func mapEpochMillisToTime(millis int64) string {
+	if millis < 1 {
+		return ""
+	}
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+//MapToAnomalyResultOutput maps anomaly result to output displayed to user
+func MapToAnomalyResultOutput(result ad.AnomalyResult, detectorName string) *ad.AnomalyResultOutput {
+	var entities []string
+	for _, e := range result.Entity {
+		entities = append(entities, e.Name+entitySeparator+e.Value)
+	}
+	features := map[string]float64{}
+	for _, f := range result.FeatureData {
+		name := f.FeatureName
+		if len(name) < 1 {
+			name = f.FeatureID
+		}
+		features[name] = f.Data
+	}
+	return &ad.AnomalyResultOutput{
+		DetectorName: detectorName,
+		DetectorID:   result.DetectorID,
+		StartTime:    mapEpochMillisToTime(result.DataStartTime),
+		EndTime:      mapEpochMillisToTime(result.DataEndTime),
+		AnomalyGrade: result.AnomalyGrade,
+		Confidence:   result.Confidence,
+		Entity:       strings.Join(entities, ","),
+		Features:     features,
+		Error:        result.Error,
+	}
+}
+
+//mapToTimeRange maps user's time range to epoch milliseconds, if to is empty, current time is used
+// as end of the range
+func mapToTimeRange(from string, to string) (int64, int64, error) {
+	if len(from) < 1 {
+		return 0, 0, fmt.Errorf("start of time range cannot be empty")
+	}
+	start, err := mapToEpochMillis(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	end := timeNow().UnixNano() / int64(time.Millisecond)
+	if len(to) > 0 {
+		if end, err = mapToEpochMillis(to); err != nil {
+			return 0, 0, err
+		}
+	}
+	if start >= end {
+		return 0, 0, fmt.Errorf("start of time range %s must be before end of time range", mapEpochMillisToTime(start))
+	}
+	return start, end, nil
+}
+
+//MapToHistoricalAnalysisRequest maps user's time range to historical analysis request, if to is empty,
+// current time is used as end of the range
+func MapToHistoricalAnalysisRequest(from string, to string) (*ad.HistoricalAnalysisRequest, error) {
+	start, end, err := mapToTimeRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &ad.HistoricalAnalysisRequest{
+		StartTime: start,
+		EndTime:   end,
+	}, nil
+}
+
+//MapToHistoricalAnalysisStatus maps detector and its latest historical task to HistoricalAnalysisStatus
+func MapToHistoricalAnalysisStatus(response ad.DetectorTaskResponse) *ad.HistoricalAnalysisStatus {
+	status := &ad.HistoricalAnalysisStatus{
+		ID:   response.ID,
+		Name: response.AnomalyDetector.Name,
+	}
+	task := response.HistoricalTask
+	if task == nil {
+		return status
+	}
+	status.TaskID = task.TaskID
+	status.State = task.State
+	status.Progress = fmt.Sprintf("%.0f%%", task.TaskProgress*100)
+	status.From = mapEpochMillisToTime(task.DetectionDateRange.StartTime)
+	status.To = mapEpochMillisToTime(task.DetectionDateRange.EndTime)
+	status.Error = task.Error
+	return status
+}
+
+//MapToPreviewRequest maps user's time range to preview request, if to is empty, current time is used
+// as end of the range. Detector or detector id has to be set by caller
+func MapToPreviewRequest(from string, to string) (*ad.PreviewRequest, error) {
+	start, end, err := mapToTimeRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &ad.PreviewRequest{
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}, nil
+}
+
+//MapToValidationProblems maps issues found by validate API to problems displayed to user, sorted by field.
+// Issue with sub issues is mapped to one problem per sub issue
+func MapToValidationProblems(source string, issues map[string]ad.ValidationIssue) []*ad.ValidationProblem {
+	var fields []string
+	for field := range issues {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	var problems []*ad.ValidationProblem
+	for _, field := range fields {
+		issue := issues[field]
+		message := issue.Message
+		if len(issue.SuggestedValue) > 0 {
+			message = fmt.Sprintf("%s, suggested value: %s", message, string(issue.SuggestedValue))
+		}
+		if len(issue.SubIssues) < 1 {
+			problems = append(problems, &ad.ValidationProblem{
+				Source:  source,
+				Field:   field,
+				Message: message,
+			})
+			continue
+		}
+		var names []string
+		for name := range issue.SubIssues {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			problems = append(problems, &ad.ValidationProblem{
+				Source:  source,
+				Field:   fmt.Sprintf("%s.%s", field, name),
+				Message: issue.SubIssues[name],
+			})
+		}
+	}
+	return problems
+}
+
+//normalizeQuery removes defaults added by OpenSearch to stored queries, so that user query can be compared
+// with stored one
+func normalizeQuery(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for key, item := range v {
+			if key == "boost" && item == float64(1) {
+				continue
+			}
+			if key == "adjust_pure_negative" && item == true {
+				continue
+			}
+			if _, ok := item.(map[string]interface{}); ok && boolClauses[key] {
+				item = []interface{}{item}
+			}
+			if valueKey, ok := shorthandQueries[key]; ok {
+				item = expandShorthandQuery(item, valueKey)
+			}
+			result[key] = normalizeQuery(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, normalizeQuery(item))
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+//expandShorthandQuery expands {"field": value} to {"field": {valueKey: value}}
+func expandShorthandQuery(query interface{}, valueKey string) interface{} {
+	fields, ok := query.(map[string]interface{})
+	if !ok {
+		return query
+	}
+	result := map[string]interface{}{}
+	for field, value := range fields {
+		if _, ok := value.(map[string]interface{}); ok {
+			result[field] = value
+			continue
+		}
+		result[field] = map[string]interface{}{valueKey: value}
+	}
+	return result
+}
+
+//mapToNormalizedJSON returns compact JSON of query without defaults added by OpenSearch, empty query is
+// treated as match_all
+func mapToNormalizedJSON(query json.RawMessage) (string, error) {
+	trimmed := strings.TrimSpace(string(query))
+	if len(trimmed) < 1 || trimmed == "null" || trimmed == "{}" {
+		trimmed = matchAllQuery
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		return "", err
+	}
+	normalized, err := json.Marshal(normalizeQuery(value))
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+//mapToFeatureValues maps features to their setting by feature name
+func mapToFeatureValues(features []ad.Feature) (map[string]string, error) {
+	values := map[string]string{}
+	for _, feature := range features {
+		query, err := mapToNormalizedJSON(feature.AggregationQuery)
+		if err != nil {
+			return nil, err
+		}
+		values[feature.Name] = fmt.Sprintf("enabled=%t %s", feature.Enabled, query)
+	}
+	return values, nil
+}
+
+//mapFeaturesDiff compares features by name
+func mapFeaturesDiff(live []ad.Feature, desired []ad.Feature) ([]ad.FieldDiff, error) {
+	liveValues, err := mapToFeatureValues(live)
+	if err != nil {
+		return nil, err
+	}
+	desiredValues, err := mapToFeatureValues(desired)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range liveValues {
+		names = append(names, name)
+	}
+	for name := range desiredValues {
+		if _, ok := liveValues[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var diffs []ad.FieldDiff
+	for _, name := range names {
+		if liveValues[name] != desiredValues[name] {
+			diffs = append(diffs, ad.FieldDiff{
+				Field:   "features." + name,
+				Live:    liveValues[name],
+				Desired: desiredValues[name],
+			})
+		}
+	}
+	return diffs, nil
+}
+
+//MapToDetectorDiff returns field level differences between live detector and desired configuration
+func MapToDetectorDiff(live ad.DetectorOutput, desired ad.CreateDetector) ([]ad.FieldDiff, error) {
+	interval, err := mapIntervalToStringPtr(desired.Interval)
+	if err != nil {
+		return nil, err
+	}
+	delay, err := mapIntervalToStringPtr(desired.Delay)
+	if err != nil {
+		return nil, err
+	}
+	liveFilter, err := mapToNormalizedJSON(live.Filter)
+	if err != nil {
+		return nil, err
+	}
+	desiredFilter, err := mapToNormalizedJSON(desired.Filter)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []ad.FieldDiff
+	for _, field := range []ad.FieldDiff{
+		{Field: "description", Live: live.Description, Desired: desired.Description},
+		{Field: "time_field", Live: live.TimeField, Desired: desired.TimeField},
+		{Field: "indices", Live: strings.Join(live.Index, ","), Desired: strings.Join(desired.Index, ",")},
+		{Field: "filter_query", Live: liveFilter, Desired: desiredFilter},
+		{Field: "detection_interval", Live: live.Interval, Desired: mapper.StringPtrToString(interval)},
+		{Field: "window_delay", Live: live.Delay, Desired: mapper.StringPtrToString(delay)},
+		{Field: "category_field", Live: strings.Join(live.CategoryField, ","), Desired: strings.Join(desired.CategoryField, ",")},
+	} {
+		if field.Live != field.Desired {
+			diffs = append(diffs, field)
+		}
+	}
+	featureDiffs, err := mapFeaturesDiff(live.Features, desired.Features)
+	if err != nil {
+		return nil, err
+	}
+	return append(diffs, featureDiffs...), nil
+}
+
+//mapToFeatureRequest maps feature of live detector to feature accepted in detector file, only single field
+// aggregations supported by detector file can be mapped
+func mapToFeatureRequest(feature ad.Feature) (*ad.FeatureRequest, error) {
+	unsupported := fmt.Errorf("feature %s cannot be exported, only %s aggregation on single field is supported",
+		feature.Name, strings.Join(getSupportedAggregationTypes(), ", "))
+	var query map[string]map[string]map[string]interface{}
+	if err := json.Unmarshal(feature.AggregationQuery, &query); err != nil || len(query) != 1 {
+		return nil, unsupported
+	}
+	for _, aggregation := range query {
+		if len(aggregation) != 1 {
+			return nil, unsupported
+		}
+		for esType, body := range aggregation {
+			field, ok := body["field"].(string)
+			userType, found := getUserAggregationType(esType)
+			if !ok || !found || len(body) != 1 {
+				return nil, unsupported
+			}
+			return &ad.FeatureRequest{
+				AggregationType: []string{userType},
+				Enabled:         feature.Enabled,
+				Field:           []string{field},
+			}, nil
+		}
+	}
+	return nil, unsupported
+}
+
+//getUserAggregationType returns aggregation type accepted in detector file for OpenSearch aggregation
+func getUserAggregationType(esType string) (string, bool) {
+	for userType, t := range userTypeToESType {
+		if t == esType {
+			return userType, true
+		}
+	}
+	return "", false
+}
+
+//getSupportedAggregationTypes returns sorted aggregation types accepted in detector file
+func getSupportedAggregationTypes() []string {
+	var types []string
+	for userType := range userTypeToESType {
+		types = append(types, userType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+//MapToCreateDetectorRequest maps live detector to detector file accepted by create, features are named
+// after their aggregation type and field once created from the file
+func MapToCreateDetectorRequest(output ad.DetectorOutput) (*ad.CreateDetectorRequest, error) {
+	features := []ad.FeatureRequest{}
+	for _, feature := range output.Features {
+		request, err := mapToFeatureRequest(feature)
+		if err != nil {
+			return nil, fmt.Errorf("detector %s: %v", output.Name, err)
+		}
+		features = append(features, *request)
+	}
+	categoryField := output.CategoryField
+	if categoryField == nil {
+		categoryField = []string{}
+	}
+	return &ad.CreateDetectorRequest{
+		Name:          output.Name,
+		Description:   output.Description,
+		TimeField:     output.TimeField,
+		Index:         output.Index,
+		Features:      features,
+		Filter:        output.Filter,
+		Interval:      output.Interval,
+		Delay:         output.Delay,
+		CategoryField: categoryField,
+	}, nil
+}
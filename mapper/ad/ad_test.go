@@ -12,11 +12,13 @@
 package ad
 
 import (
+	"encoding/json"
 	"opensearch-cli/entity/ad"
 	"opensearch-cli/mapper"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -107,9 +109,30 @@ func TestMapToCreateDetector(t *testing.T) {
 		r := getCreateDetectorRequest("1m", "1m")
 		actual, err := MapToCreateDetector(r)
 		expected := getCreateDetector()
+		expected.CategoryField = []string{"ip"}
 		assert.NoError(t, err)
 		assert.EqualValues(t, expected, *actual)
 	})
+	t.Run("Success: category fields", func(t *testing.T) {
+		r := getCreateDetectorRequest("1m", "1m")
+		r.CategoryField = []string{"host", "ip"}
+		actual, err := MapToCreateDetector(r)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{"host", "ip"}, actual.CategoryField)
+	})
+	t.Run("Success: single entity", func(t *testing.T) {
+		r := getCreateDetectorRequest("1m", "1m")
+		r.PartitionField = nil
+		actual, err := MapToCreateDetector(r)
+		assert.NoError(t, err)
+		assert.EqualValues(t, getCreateDetector(), *actual)
+	})
+	t.Run("Failure: too many category fields", func(t *testing.T) {
+		r := getCreateDetectorRequest("1m", "1m")
+		r.CategoryField = []string{"host", "service"}
+		_, err := MapToCreateDetector(r)
+		assert.EqualError(t, err, "trying to create detector with 3 category fields, only upto 2 category fields are allowed")
+	})
 	t.Run("Failure: interval val", func(t *testing.T) {
 		r := getCreateDetectorRequest("m1", "1m")
 		_, err := MapToCreateDetector(r)
@@ -196,6 +219,23 @@ func TestMapToDetectors(t *testing.T) {
 	})
 }
 
+func TestMapHitsToDetectors(t *testing.T) {
+	hits := []ad.Hit{
+		{ID: "1", Source: ad.Source{Name: "cpu.usage(p99)"}},
+		{ID: "2", Source: ad.Source{Name: "cpuXusage(p99)"}},
+		{ID: "3", Source: ad.Source{Name: "cpu.usage(p99)-eu"}},
+	}
+	t.Run("regular expression characters are matched literally", func(t *testing.T) {
+		actual := MapHitsToDetectors(hits, "cpu.usage(p99)")
+		assert.EqualValues(t, []ad.Detector{{Name: "cpu.usage(p99)", ID: "1"}}, actual)
+	})
+	t.Run("wildcards are expanded", func(t *testing.T) {
+		actual := MapHitsToDetectors(hits, "cpu.usage(p99)+")
+		assert.EqualValues(t, []ad.Detector{{Name: "cpu.usage(p99)-eu", ID: "3"}}, actual)
+		assert.Len(t, MapHitsToDetectors(hits, "cpu*"), 3)
+	})
+}
+
 func TestMapToDetectorOutput(t *testing.T) {
 	expected := ad.DetectorOutput{
 		ID:          "m4ccEnIBTXsGi3mvMt9p",
@@ -369,3 +409,438 @@ func TestMapToUpdateDetector(t *testing.T) {
 		assert.EqualError(t, err, "feature avg_order is defined more than once")
 	})
 }
+
+func TestMapToDetectorStatus(t *testing.T) {
+	detector := ad.Detector{
+		Name: "detector",
+		ID:   "detectorID",
+	}
+	t.Run("maps initializing detector", func(t *testing.T) {
+		minutesLeft := 77
+		actual, err := MapToDetectorStatus(detector, []byte(`{
+			"state": "INIT",
+			"init_progress": {"percentage": "70%", "estimated_minutes_left": 77, "needed_shingles": 77},
+			"coordinating_node": "node1",
+			"models": [
+				{"model_id": "model_rcf_0", "model_size_in_bytes": 1608616, "node_id": "node1"},
+				{"model_id": "model_rcf_1", "model_size_in_bytes": 1608616, "node_id": "node2"},
+				{"model_id": "model_threshold", "node_id": "node1"}
+			]
+		}`))
+		assert.NoError(t, err)
+		assert.EqualValues(t, ad.DetectorStatus{
+			ID:                   "detectorID",
+			Name:                 "detector",
+			State:                "INIT",
+			InitProgress:         "70%",
+			EstimatedMinutesLeft: &minutesLeft,
+			CoordinatingNode:     "node1",
+			Nodes:                []string{"node1", "node2"},
+		}, *actual)
+	})
+	t.Run("maps failed detector", func(t *testing.T) {
+		actual, err := MapToDetectorStatus(detector, []byte(`{"state": "DISABLED", "error": "No data in the index"}`))
+		assert.NoError(t, err)
+		assert.EqualValues(t, ad.DetectorStatus{
+			ID:    "detectorID",
+			Name:  "detector",
+			State: "DISABLED",
+			Error: "No data in the index",
+			Nodes: []string{},
+		}, *actual)
+	})
+	t.Run("invalid response", func(t *testing.T) {
+		_, err := MapToDetectorStatus(detector, []byte(`invalid`))
+		assert.Error(t, err)
+	})
+}
+
+func TestMapToAnomalyResultSearchRequest(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	}
+	defer func() { timeNow = time.Now }()
+	t.Run("maps all filters", func(t *testing.T) {
+		actual, err := MapToAnomalyResultSearchRequest([]string{"detectorID"}, ad.AnomalyResultFilter{
+			From:            "24h",
+			To:              "2021-03-09T12:00:00Z",
+			MinAnomalyGrade: 0.5,
+			MinConfidence:   0.9,
+			Entity:          "host=host-1",
+		}, 10, []interface{}{1615305660000, "detectorID", "model"})
+		assert.NoError(t, err)
+		payload, err := json.Marshal(actual)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"query": {"bool": {"filter": [
+				{"terms": {"detector_id": ["detectorID"]}},
+				{"range": {"data_end_time": {"gte": 1615248000000, "lte": 1615291200000}}},
+				{"range": {"anomaly_grade": {"gte": 0.5}}},
+				{"range": {"confidence": {"gte": 0.9}}},
+				{"nested": {"path": "entity", "query": {"bool": {"filter": [
+					{"term": {"entity.name": "host"}},
+					{"term": {"entity.value": "host-1"}}
+				]}}}}
+			]}},
+			"sort": [
+				{"data_end_time": {"order": "desc"}},
+				{"detector_id": {"order": "asc"}},
+				{"model_id": {"order": "asc", "unmapped_type": "keyword"}}
+			],
+			"size": 10,
+			"search_after": [1615305660000, "detectorID", "model"]
+		}`, string(payload))
+	})
+	t.Run("entity value contains separator", func(t *testing.T) {
+		actual, err := MapToAnomalyResultSearchRequest([]string{"detectorID"}, ad.AnomalyResultFilter{
+			MinAnomalyGrade: 0.5,
+			Entity:          "token=YWJj=ZA==",
+		}, 10, nil)
+		assert.NoError(t, err)
+		payload, err := json.Marshal(actual.Query.Bool.Filter[2])
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"nested": {"path": "entity", "query": {"bool": {"filter": [
+			{"term": {"entity.name": "token"}},
+			{"term": {"entity.value": "YWJj=ZA=="}}
+		]}}}}`, string(payload))
+	})
+	t.Run("returns only anomalies by default", func(t *testing.T) {
+		actual, err := MapToAnomalyResultSearchRequest([]string{"detectorID"}, ad.AnomalyResultFilter{}, 10, nil)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []interface{}{
+			map[string]interface{}{"terms": map[string][]string{"detector_id": {"detectorID"}}},
+			map[string]interface{}{"range": map[string]interface{}{"anomaly_grade": map[string]float64{"gt": 0}}},
+		}, actual.Query.Bool.Filter)
+		assert.Nil(t, actual.SearchAfter)
+	})
+	t.Run("invalid time", func(t *testing.T) {
+		_, err := MapToAnomalyResultSearchRequest([]string{"detectorID"}, ad.AnomalyResultFilter{From: "yesterday"}, 10, nil)
+		assert.Error(t, err)
+	})
+	t.Run("empty detectors", func(t *testing.T) {
+		_, err := MapToAnomalyResultSearchRequest(nil, ad.AnomalyResultFilter{}, 10, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestMapToAnomalyResultOutput(t *testing.T) {
+	actual := MapToAnomalyResultOutput(ad.AnomalyResult{
+		DetectorID:    "detectorID",
+		AnomalyGrade:  0.82,
+		Confidence:    0.96,
+		DataStartTime: 1615305600000,
+		DataEndTime:   1615305660000,
+		Entity: []ad.ResultEntity{
+			{Name: "host", Value: "host-1"},
+			{Name: "service", Value: "app"},
+		},
+		FeatureData: []ad.FeatureData{
+			{FeatureID: "cpu-id", FeatureName: "total_cpu", Data: 98.5},
+			{FeatureID: "memory-id", Data: 10},
+		},
+	}, "detector")
+	assert.EqualValues(t, ad.AnomalyResultOutput{
+		DetectorName: "detector",
+		DetectorID:   "detectorID",
+		StartTime:    "2021-03-09T16:00:00Z",
+		EndTime:      "2021-03-09T16:01:00Z",
+		AnomalyGrade: 0.82,
+		Confidence:   0.96,
+		Entity:       "host=host-1,service=app",
+		Features:     map[string]float64{"total_cpu": 98.5, "memory-id": 10},
+	}, *actual)
+}
+
+func TestMapToHistoricalAnalysisRequest(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	}
+	defer func() { timeNow = time.Now }()
+	t.Run("to defaults to now", func(t *testing.T) {
+		actual, err := MapToHistoricalAnalysisRequest("24h", "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, ad.HistoricalAnalysisRequest{
+			StartTime: 1615248000000,
+			EndTime:   1615334400000,
+		}, *actual)
+	})
+	t.Run("date range", func(t *testing.T) {
+		actual, err := MapToHistoricalAnalysisRequest("2021-03-01", "2021-03-02T00:00:00Z")
+		assert.NoError(t, err)
+		assert.EqualValues(t, ad.HistoricalAnalysisRequest{
+			StartTime: 1614556800000,
+			EndTime:   1614643200000,
+		}, *actual)
+	})
+	t.Run("empty from", func(t *testing.T) {
+		_, err := MapToHistoricalAnalysisRequest("", "")
+		assert.EqualError(t, err, "start of time range cannot be empty")
+	})
+	t.Run("from after to", func(t *testing.T) {
+		_, err := MapToHistoricalAnalysisRequest("2021-03-02", "2021-03-01")
+		assert.EqualError(t, err, "start of time range 2021-03-02T00:00:00Z must be before end of time range")
+	})
+}
+
+func TestMapToHistoricalAnalysisStatus(t *testing.T) {
+	t.Run("running task", func(t *testing.T) {
+		actual := MapToHistoricalAnalysisStatus(ad.DetectorTaskResponse{
+			ID: "detectorID",
+			AnomalyDetector: ad.AnomalyDetector{
+				Metadata: ad.Metadata{Name: "detector"},
+			},
+			HistoricalTask: &ad.HistoricalTask{
+				TaskID:       "taskID",
+				State:        "RUNNING",
+				TaskProgress: 0.456,
+				DetectionDateRange: ad.HistoricalAnalysisRequest{
+					StartTime: 1614556800000,
+					EndTime:   1614643200000,
+				},
+			},
+		})
+		assert.EqualValues(t, ad.HistoricalAnalysisStatus{
+			ID:       "detectorID",
+			Name:     "detector",
+			TaskID:   "taskID",
+			State:    "RUNNING",
+			Progress: "46%",
+			From:     "2021-03-01T00:00:00Z",
+			To:       "2021-03-02T00:00:00Z",
+		}, *actual)
+	})
+	t.Run("no task", func(t *testing.T) {
+		actual := MapToHistoricalAnalysisStatus(ad.DetectorTaskResponse{ID: "detectorID"})
+		assert.EqualValues(t, ad.HistoricalAnalysisStatus{ID: "detectorID"}, *actual)
+	})
+}
+
+func TestMapToPreviewRequest(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	}
+	defer func() { timeNow = time.Now }()
+	t.Run("to defaults to now", func(t *testing.T) {
+		actual, err := MapToPreviewRequest("24h", "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, ad.PreviewRequest{
+			PeriodStart: 1615248000000,
+			PeriodEnd:   1615334400000,
+		}, *actual)
+	})
+	t.Run("empty from", func(t *testing.T) {
+		_, err := MapToPreviewRequest("", "")
+		assert.EqualError(t, err, "start of time range cannot be empty")
+	})
+	t.Run("invalid to", func(t *testing.T) {
+		_, err := MapToPreviewRequest("24h", "yesterday")
+		assert.Error(t, err)
+	})
+}
+
+func TestMapToValidationProblems(t *testing.T) {
+	t.Run("no issues", func(t *testing.T) {
+		assert.Nil(t, MapToValidationProblems("detector", nil))
+	})
+	t.Run("issues sorted by field", func(t *testing.T) {
+		actual := MapToValidationProblems("model", map[string]ad.ValidationIssue{
+			"name": {Message: "name is duplicated"},
+			"feature_attributes": {
+				Message: "Feature has invalid query",
+				SubIssues: map[string]string{
+					"sum_value": "Feature has invalid query returning empty aggregated data",
+					"max_value": "Feature has invalid query returning empty aggregated data",
+				},
+			},
+			"detection_interval": {
+				Message:        "Detection interval is too small",
+				SuggestedValue: []byte(`{"period":{"interval":10,"unit":"Minutes"}}`),
+			},
+		})
+		assert.EqualValues(t, []*ad.ValidationProblem{
+			{
+				Source:  "model",
+				Field:   "detection_interval",
+				Message: `Detection interval is too small, suggested value: {"period":{"interval":10,"unit":"Minutes"}}`,
+			},
+			{
+				Source:  "model",
+				Field:   "feature_attributes.max_value",
+				Message: "Feature has invalid query returning empty aggregated data",
+			},
+			{
+				Source:  "model",
+				Field:   "feature_attributes.sum_value",
+				Message: "Feature has invalid query returning empty aggregated data",
+			},
+			{
+				Source:  "model",
+				Field:   "name",
+				Message: "name is duplicated",
+			},
+		}, actual)
+	})
+}
+
+func TestMapToDetectorDiff(t *testing.T) {
+	live := ad.DetectorOutput{
+		ID:          "detectorID",
+		Name:        "detector",
+		Description: "Test detector",
+		TimeField:   "timestamp",
+		Index:       []string{"order*"},
+		Features: []ad.Feature{
+			{
+				Name:             "sum_value",
+				Enabled:          true,
+				AggregationQuery: []byte(`{"sum_value":{"sum":{"field":"value"}}}`),
+			},
+			{
+				Name:             "max_value",
+				Enabled:          true,
+				AggregationQuery: []byte(`{"max_value":{"max":{"field":"value"}}}`),
+			},
+		},
+		Filter:   []byte(`{"bool":{"filter":[{"term":{"currency":{"value":"EUR","boost":1.0}}}],"adjust_pure_negative":true,"boost":1.0}}`),
+		Interval: "1m",
+		Delay:    "1m",
+	}
+	desired := ad.CreateDetector{
+		Name:        "detector",
+		Description: "Test detector",
+		TimeField:   "timestamp",
+		Index:       []string{"order*"},
+		Features: []ad.Feature{
+			{
+				Name:             "sum_value",
+				Enabled:          true,
+				AggregationQuery: []byte(`{"sum_value": {"sum": {"field": "value"}}}`),
+			},
+			{
+				Name:             "max_value",
+				Enabled:          true,
+				AggregationQuery: []byte(`{"max_value": {"max": {"field": "value"}}}`),
+			},
+		},
+		Filter: []byte(`{"bool": {"filter": {"term": {"currency": "EUR"}}}}`),
+		Interval: ad.Interval{Period: ad.Period{
+			Duration: 1,
+			Unit:     "Minutes",
+		}},
+		Delay: ad.Interval{Period: ad.Period{
+			Duration: 1,
+			Unit:     "Minutes",
+		}},
+	}
+	t.Run("equivalent configuration", func(t *testing.T) {
+		actual, err := MapToDetectorDiff(live, desired)
+		assert.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+	t.Run("empty filter is match all", func(t *testing.T) {
+		l := live
+		l.Filter = []byte(`{"match_all":{"boost":1.0}}`)
+		d := desired
+		d.Filter = nil
+		actual, err := MapToDetectorDiff(l, d)
+		assert.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+	t.Run("changed fields", func(t *testing.T) {
+		d := desired
+		d.Description = "new description"
+		d.Interval = ad.Interval{Period: ad.Period{Duration: 10, Unit: "Minutes"}}
+		d.CategoryField = []string{"host"}
+		d.Features = []ad.Feature{
+			{
+				Name:             "sum_value",
+				Enabled:          false,
+				AggregationQuery: []byte(`{"sum_value":{"sum":{"field":"value"}}}`),
+			},
+			{
+				Name:             "min_value",
+				Enabled:          true,
+				AggregationQuery: []byte(`{"min_value":{"min":{"field":"value"}}}`),
+			},
+		}
+		actual, err := MapToDetectorDiff(live, d)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []ad.FieldDiff{
+			{Field: "description", Live: "Test detector", Desired: "new description"},
+			{Field: "detection_interval", Live: "1m", Desired: "10m"},
+			{Field: "category_field", Live: "", Desired: "host"},
+			{Field: "features.max_value", Live: `enabled=true {"max_value":{"max":{"field":"value"}}}`, Desired: ""},
+			{Field: "features.min_value", Live: "", Desired: `enabled=true {"min_value":{"min":{"field":"value"}}}`},
+			{Field: "features.sum_value", Live: `enabled=true {"sum_value":{"sum":{"field":"value"}}}`, Desired: `enabled=false {"sum_value":{"sum":{"field":"value"}}}`},
+		}, actual)
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		d := desired
+		d.Filter = []byte(`{`)
+		_, err := MapToDetectorDiff(live, d)
+		assert.Error(t, err)
+	})
+}
+
+func TestMapToCreateDetectorRequest(t *testing.T) {
+	output := ad.DetectorOutput{
+		ID:          "detectorID",
+		Name:        "detector",
+		Description: "Test detector",
+		TimeField:   "timestamp",
+		Index:       []string{"order*"},
+		Features: []ad.Feature{
+			{
+				Name:             "total_order",
+				Enabled:          true,
+				AggregationQuery: []byte(`{"total_order":{"sum":{"field":"value"}}}`),
+			},
+			{
+				Name:             "avg_order",
+				Enabled:          false,
+				AggregationQuery: []byte(`{"avg_order":{"avg":{"field":"value"}}}`),
+			},
+		},
+		Filter:        []byte(`{"match_all":{"boost":1.0}}`),
+		Interval:      "5m",
+		Delay:         "1m",
+		LastUpdatedAt: 1589441737319,
+		SchemaVersion: 2,
+	}
+	t.Run("valid detector", func(t *testing.T) {
+		actual, err := MapToCreateDetectorRequest(output)
+		assert.NoError(t, err)
+		assert.EqualValues(t, ad.CreateDetectorRequest{
+			Name:        "detector",
+			Description: "Test detector",
+			TimeField:   "timestamp",
+			Index:       []string{"order*"},
+			Features: []ad.FeatureRequest{
+				{AggregationType: []string{"sum"}, Enabled: true, Field: []string{"value"}},
+				{AggregationType: []string{"average"}, Enabled: false, Field: []string{"value"}},
+			},
+			Filter:        []byte(`{"match_all":{"boost":1.0}}`),
+			Interval:      "5m",
+			Delay:         "1m",
+			CategoryField: []string{},
+		}, *actual)
+	})
+	t.Run("unsupported aggregation", func(t *testing.T) {
+		o := output
+		o.Features = []ad.Feature{{
+			Name:             "unique_users",
+			AggregationQuery: []byte(`{"unique_users":{"cardinality":{"field":"user"}}}`),
+		}}
+		_, err := MapToCreateDetectorRequest(o)
+		assert.EqualError(t, err, "detector detector: feature unique_users cannot be exported, only average, count, max, min, sum aggregation on single field is supported")
+	})
+	t.Run("scripted aggregation", func(t *testing.T) {
+		o := output
+		o.Features = []ad.Feature{{
+			Name:             "total",
+			AggregationQuery: []byte(`{"total":{"sum":{"script":{"source":"doc['value'].value * 2"}}}}`),
+		}}
+		_, err := MapToCreateDetectorRequest(o)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,202 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"opensearch-cli/entity/cluster"
+	"sort"
+	"strings"
+)
+
+const (
+	//settingSeparator separates name of setting from its value
+	settingSeparator = "="
+	//resetSettingValue resets setting to its default value
+	resetSettingValue = "null"
+	//electedClusterManager marks elected cluster manager in cat nodes API
+	electedClusterManager = "*"
+)
+
+//healthStatusRanks orders health statuses from worst to best
+var healthStatusRanks = map[string]int{
+	cluster.HealthStatusRed:    0,
+	cluster.HealthStatusYellow: 1,
+	cluster.HealthStatusGreen:  2,
+}
+
+//settingsScopes are scopes of settings in order of precedence
+var settingsScopes = []string{
+	cluster.SettingsScopeTransient,
+	cluster.SettingsScopePersistent,
+	cluster.SettingsScopeDefaults,
+}
+
+//HasReachedStatus checks whether current health status is at least as good as expected status
+func HasReachedStatus(current string, expected string) (bool, error) {
+	expectedRank, ok := healthStatusRanks[strings.ToLower(expected)]
+	if !ok {
+		return false, fmt.Errorf("invalid status %s, allowed statuses are %s, %s and %s",
+			expected, cluster.HealthStatusGreen, cluster.HealthStatusYellow, cluster.HealthStatusRed)
+	}
+	currentRank, ok := healthStatusRanks[strings.ToLower(current)]
+	return ok && currentRank >= expectedRank, nil
+}
+
+//MapToNodeOutput maps nodes of cat nodes API to rows sorted by name
+func MapToNodeOutput(nodes []cluster.CatNode) []cluster.NodeOutput {
+	result := []cluster.NodeOutput{}
+	for _, node := range nodes {
+		clusterManager := node.ClusterManager
+		if len(clusterManager) < 1 {
+			clusterManager = node.Master
+		}
+		result = append(result, cluster.NodeOutput{
+			Name:           node.Name,
+			IP:             node.IP,
+			Roles:          node.NodeRole,
+			ClusterManager: clusterManager == electedClusterManager,
+			HeapPercent:    node.HeapPercent,
+			RAMPercent:     node.RAMPercent,
+			CPU:            node.CPU,
+			Load1m:         node.Load1m,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+//MapToAllocationExplainPayload returns body of allocation explain API, body is nil if no shard is given
+func MapToAllocationExplainPayload(r cluster.AllocationExplainRequest) (interface{}, error) {
+	if len(r.Index) < 1 {
+		if r.Shard != 0 || r.Primary {
+			return nil, fmt.Errorf("index is required when shard or primary is given")
+		}
+		return nil, nil
+	}
+	if r.Shard < 0 {
+		return nil, fmt.Errorf("shard cannot be negative")
+	}
+	return r, nil
+}
+
+//MapToDeciderOutput flattens decisions of every node into rows, nodes are in order of weight ranking.
+//Node without deciders is displayed as single row
+func MapToDeciderOutput(explanation cluster.AllocationExplanation) []cluster.DeciderOutput {
+	decisions := append([]cluster.NodeAllocationDecision{}, explanation.NodeAllocationDecisions...)
+	sort.SliceStable(decisions, func(i, j int) bool {
+		return decisions[i].WeightRanking < decisions[j].WeightRanking
+	})
+	result := []cluster.DeciderOutput{}
+	for _, decision := range decisions {
+		if len(decision.Deciders) < 1 {
+			result = append(result, cluster.DeciderOutput{
+				Node:     decision.NodeName,
+				Decision: decision.NodeDecision,
+			})
+			continue
+		}
+		for _, decider := range decision.Deciders {
+			result = append(result, cluster.DeciderOutput{
+				Node:        decision.NodeName,
+				Decision:    decider.Decision,
+				Decider:     decider.Decider,
+				Explanation: decider.Explanation,
+			})
+		}
+	}
+	return result
+}
+
+//mapToSettingValue returns value of setting as displayed to user, values which are not strings are displayed as JSON
+func mapToSettingValue(value interface{}) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+//matchesSetting checks whether setting name is one of names or starts with one of them followed by '.'
+func matchesSetting(name string, names []string) bool {
+	if len(names) < 1 {
+		return true
+	}
+	for _, prefix := range names {
+		if name == prefix || strings.HasPrefix(name, strings.TrimSuffix(prefix, ".")+".") {
+			return true
+		}
+	}
+	return false
+}
+
+//MapToSettingOutput flattens settings of every scope into rows filtered by names, rows are ordered by scope
+//precedence and name
+func MapToSettingOutput(settings cluster.Settings, names []string) []cluster.SettingOutput {
+	scopes := map[string]map[string]interface{}{
+		cluster.SettingsScopeTransient:  settings.Transient,
+		cluster.SettingsScopePersistent: settings.Persistent,
+		cluster.SettingsScopeDefaults:   settings.Defaults,
+	}
+	result := []cluster.SettingOutput{}
+	for _, scope := range settingsScopes {
+		var rows []cluster.SettingOutput
+		for name, value := range scopes[scope] {
+			if !matchesSetting(name, names) {
+				continue
+			}
+			rows = append(rows, cluster.SettingOutput{
+				Scope: scope,
+				Name:  name,
+				Value: mapToSettingValue(value),
+			})
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			return rows[i].Name < rows[j].Name
+		})
+		result = append(result, rows...)
+	}
+	return result
+}
+
+//MapToSettingsUpdate returns body of cluster settings API from name=value pairs, value null resets setting to default
+func MapToSettingsUpdate(values []string, transient bool) (map[string]interface{}, error) {
+	if len(values) < 1 {
+		return nil, fmt.Errorf("settings cannot be empty")
+	}
+	settings := map[string]interface{}{}
+	for _, value := range values {
+		separator := strings.Index(value, settingSeparator)
+		if separator < 1 {
+			return nil, fmt.Errorf("invalid setting %s, settings must be in format name=value", value)
+		}
+		name := strings.TrimSpace(value[:separator])
+		if value[separator+1:] == resetSettingValue {
+			settings[name] = nil
+			continue
+		}
+		settings[name] = value[separator+1:]
+	}
+	scope := cluster.SettingsScopePersistent
+	if transient {
+		scope = cluster.SettingsScopeTransient
+	}
+	return map[string]interface{}{
+		scope: settings,
+	}, nil
+}
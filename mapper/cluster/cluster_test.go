@@ -0,0 +1,119 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"opensearch-cli/entity/cluster"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasReachedStatus(t *testing.T) {
+	tests := []struct {
+		current  string
+		expected string
+		reached  bool
+	}{
+		{current: "green", expected: "yellow", reached: true},
+		{current: "yellow", expected: "yellow", reached: true},
+		{current: "red", expected: "yellow", reached: false},
+		{current: "yellow", expected: "GREEN", reached: false},
+		{current: "", expected: "red", reached: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current+" "+tt.expected, func(t *testing.T) {
+			reached, err := HasReachedStatus(tt.current, tt.expected)
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.reached, reached)
+		})
+	}
+	t.Run("invalid status", func(t *testing.T) {
+		_, err := HasReachedStatus("green", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestMapToNodeOutput(t *testing.T) {
+	t.Run("cluster manager reported as master", func(t *testing.T) {
+		nodes := MapToNodeOutput([]cluster.CatNode{
+			{Name: "node2", Master: "-"},
+			{Name: "node1", Master: "*"},
+		})
+		assert.EqualValues(t, []cluster.NodeOutput{
+			{Name: "node1", ClusterManager: true},
+			{Name: "node2"},
+		}, nodes)
+	})
+}
+
+func TestMapToDeciderOutput(t *testing.T) {
+	t.Run("nodes in order of weight ranking", func(t *testing.T) {
+		rows := MapToDeciderOutput(cluster.AllocationExplanation{
+			NodeAllocationDecisions: []cluster.NodeAllocationDecision{
+				{NodeName: "node2", NodeDecision: "yes", WeightRanking: 2},
+				{NodeName: "node1", NodeDecision: "no", WeightRanking: 1, Deciders: []cluster.Decider{
+					{Decider: "same_shard", Decision: "NO", Explanation: "copy is allocated"},
+					{Decider: "disk_threshold", Decision: "NO", Explanation: "above watermark"},
+				}},
+			},
+		})
+		assert.EqualValues(t, []cluster.DeciderOutput{
+			{Node: "node1", Decision: "NO", Decider: "same_shard", Explanation: "copy is allocated"},
+			{Node: "node1", Decision: "NO", Decider: "disk_threshold", Explanation: "above watermark"},
+			{Node: "node2", Decision: "yes"},
+		}, rows)
+	})
+}
+
+func TestMapToSettingOutput(t *testing.T) {
+	settings := cluster.Settings{
+		Persistent: map[string]interface{}{"cluster.max_shards_per_node": "2000"},
+		Defaults: map[string]interface{}{
+			"discovery.seed_hosts":        []interface{}{"node1", "node2"},
+			"cluster.max_shards_per_node": "1000",
+		},
+	}
+	t.Run("every setting", func(t *testing.T) {
+		assert.EqualValues(t, []cluster.SettingOutput{
+			{Scope: "persistent", Name: "cluster.max_shards_per_node", Value: "2000"},
+			{Scope: "defaults", Name: "cluster.max_shards_per_node", Value: "1000"},
+			{Scope: "defaults", Name: "discovery.seed_hosts", Value: `["node1","node2"]`},
+		}, MapToSettingOutput(settings, nil))
+	})
+	t.Run("prefix matches whole name parts only", func(t *testing.T) {
+		assert.Empty(t, MapToSettingOutput(settings, []string{"discovery.seed"}))
+		assert.Len(t, MapToSettingOutput(settings, []string{"discovery."}), 1)
+	})
+}
+
+func TestMapToSettingsUpdate(t *testing.T) {
+	t.Run("persistent with reset", func(t *testing.T) {
+		body, err := MapToSettingsUpdate([]string{"cluster.routing.allocation.enable=all", "action.auto_create_index=null", "a=b=c"}, false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, map[string]interface{}{
+			"persistent": map[string]interface{}{
+				"cluster.routing.allocation.enable": "all",
+				"action.auto_create_index":          nil,
+				"a":                                 "b=c",
+			},
+		}, body)
+	})
+	t.Run("missing value", func(t *testing.T) {
+		_, err := MapToSettingsUpdate([]string{"cluster.routing.allocation.enable"}, true)
+		assert.EqualError(t, err, "invalid setting cluster.routing.allocation.enable, settings must be in format name=value")
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := MapToSettingsUpdate(nil, true)
+		assert.EqualError(t, err, "settings cannot be empty")
+	})
+}
@@ -0,0 +1,74 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package connection
+
+import (
+	"crypto/tls"
+	"opensearch-cli/entity/connection"
+	"sort"
+	"time"
+)
+
+const (
+	//SecurityPluginName is name of plugin which provides authentication
+	SecurityPluginName = "opensearch-security"
+	hoursPerDay        = 24
+)
+
+//MapToCertificates maps certificate chain presented by cluster, starting from its own certificate,
+//nil is returned if connection does not use TLS
+func MapToCertificates(state *tls.ConnectionState, now time.Time) []connection.Certificate {
+	if state == nil {
+		return nil
+	}
+	var result []connection.Certificate
+	for _, cert := range state.PeerCertificates {
+		result = append(result, connection.Certificate{
+			Subject:       cert.Subject.String(),
+			Issuer:        cert.Issuer.String(),
+			NotAfter:      cert.NotAfter,
+			ExpiresInDays: int(cert.NotAfter.Sub(now).Hours() / hoursPerDay),
+		})
+	}
+	return result
+}
+
+//MapToPlugins maps plugins installed on nodes to distinct plugins sorted by name
+func MapToPlugins(plugins []connection.CatPlugin) []connection.Plugin {
+	result := []connection.Plugin{}
+	found := map[connection.Plugin]bool{}
+	for _, p := range plugins {
+		plugin := connection.Plugin{Name: p.Component, Version: p.Version}
+		if found[plugin] {
+			continue
+		}
+		found[plugin] = true
+		result = append(result, plugin)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Name == result[j].Name {
+			return result[i].Version < result[j].Version
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+//HasPlugin checks whether plugin is installed
+func HasPlugin(plugins []connection.Plugin, name string) bool {
+	for _, p := range plugins {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package connection
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"opensearch-cli/entity/connection"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapToCertificates(t *testing.T) {
+	t.Run("no tls", func(t *testing.T) {
+		assert.Nil(t, MapToCertificates(nil, time.Now()))
+	})
+	t.Run("chain", func(t *testing.T) {
+		now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+		state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{
+			{
+				Subject:  pkix.Name{CommonName: "node-0"},
+				Issuer:   pkix.Name{CommonName: "root-ca"},
+				NotAfter: now.Add(30*24*time.Hour + time.Hour),
+			},
+			{
+				Subject:  pkix.Name{CommonName: "root-ca"},
+				Issuer:   pkix.Name{CommonName: "root-ca"},
+				NotAfter: now.Add(-48 * time.Hour),
+			},
+		}}
+		assert.Equal(t, []connection.Certificate{
+			{Subject: "CN=node-0", Issuer: "CN=root-ca", NotAfter: now.Add(30*24*time.Hour + time.Hour), ExpiresInDays: 30},
+			{Subject: "CN=root-ca", Issuer: "CN=root-ca", NotAfter: now.Add(-48 * time.Hour), ExpiresInDays: -2},
+		}, MapToCertificates(state, now))
+	})
+}
+
+func TestMapToPlugins(t *testing.T) {
+	plugins := MapToPlugins([]connection.CatPlugin{
+		{Name: "node-1", Component: "opensearch-security", Version: "2.11.0.0"},
+		{Name: "node-1", Component: "opensearch-knn", Version: "2.11.0.0"},
+		{Name: "node-2", Component: "opensearch-security", Version: "2.11.0.0"},
+		{Name: "node-2", Component: "opensearch-knn", Version: "2.11.0.0"},
+	})
+	assert.Equal(t, []connection.Plugin{
+		{Name: "opensearch-knn", Version: "2.11.0.0"},
+		{Name: "opensearch-security", Version: "2.11.0.0"},
+	}, plugins)
+	assert.True(t, HasPlugin(plugins, SecurityPluginName))
+	assert.False(t, HasPlugin(plugins, "opensearch-sql"))
+	assert.Empty(t, MapToPlugins(nil))
+}
@@ -0,0 +1,228 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"opensearch-cli/entity/index"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	//FileNameIdentifier identifies settings given as file name instead of JSON
+	FileNameIdentifier = "@"
+	//AliasActionAdd adds alias to index
+	AliasActionAdd = "add"
+	//AliasActionRemove removes alias from index
+	AliasActionRemove = "remove"
+	//sortSeparator separates sort column from order
+	sortSeparator  = ":"
+	sortAscending  = "asc"
+	sortDescending = "desc"
+)
+
+//indexBodyKeys are top level keys allowed in body of create index API
+var indexBodyKeys = map[string]bool{
+	"settings": true,
+	"mappings": true,
+	"aliases":  true,
+}
+
+//numericColumns are columns of cat indices which are compared as numbers
+var numericColumns = map[string]bool{
+	"pri":            true,
+	"rep":            true,
+	"docs.count":     true,
+	"docs.deleted":   true,
+	"store.size":     true,
+	"pri.store.size": true,
+}
+
+//getColumn returns value of column of cat indices by its name
+func getColumn(i index.CatIndex, column string) (string, bool) {
+	switch column {
+	case "health":
+		return i.Health, true
+	case "status":
+		return i.Status, true
+	case "index":
+		return i.Index, true
+	case "uuid":
+		return i.UUID, true
+	case "pri":
+		return i.Primaries, true
+	case "rep":
+		return i.Replicas, true
+	case "docs.count":
+		return i.DocsCount, true
+	case "docs.deleted":
+		return i.DocsDeleted, true
+	case "store.size":
+		return i.StoreSize, true
+	case "pri.store.size":
+		return i.PriStoreSize, true
+	}
+	return "", false
+}
+
+//MapToIndexBody parses body of create index API and checks that it contains only settings, mappings and aliases
+func MapToIndexBody(data []byte) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("invalid index body: %v", err)
+	}
+	for key := range body {
+		if !indexBodyKeys[key] {
+			return nil, fmt.Errorf("invalid key %s in index body, only settings, mappings and aliases are allowed", key)
+		}
+	}
+	return body, nil
+}
+
+//MapToSettings parses settings given either as JSON or as file name with prefix '@'
+func MapToSettings(data string) (map[string]interface{}, error) {
+	if len(strings.TrimSpace(data)) < 1 {
+		return nil, fmt.Errorf("settings cannot be empty")
+	}
+	content := []byte(data)
+	if strings.HasPrefix(data, FileNameIdentifier) {
+		var err error
+		if content, err = os.ReadFile(data[len(FileNameIdentifier):]); err != nil {
+			return nil, err
+		}
+	}
+	settings := map[string]interface{}{}
+	if err := json.Unmarshal(content, &settings); err != nil {
+		return nil, fmt.Errorf("invalid settings, settings can be either JSON object or file name with prefix '@': %v", err)
+	}
+	if len(settings) < 1 {
+		return nil, fmt.Errorf("settings cannot be empty")
+	}
+	return settings, nil
+}
+
+//FilterIndices returns indices which match health and status, empty filter matches every index
+func FilterIndices(indices []index.CatIndex, health string, status string) []index.CatIndex {
+	var result []index.CatIndex
+	for _, i := range indices {
+		if len(health) > 0 && !strings.EqualFold(i.Health, health) {
+			continue
+		}
+		if len(status) > 0 && !strings.EqualFold(i.Status, status) {
+			continue
+		}
+		result = append(result, i)
+	}
+	return result
+}
+
+//SortIndices sorts indices by column optionally followed by :asc or :desc, sizes and counts are compared as numbers.
+//Indices are sorted by name if column is empty, ties are broken by name
+func SortIndices(indices []index.CatIndex, column string) error {
+	descending := false
+	if separator := strings.LastIndex(column, sortSeparator); separator >= 0 {
+		switch strings.ToLower(column[separator+1:]) {
+		case sortAscending:
+		case sortDescending:
+			descending = true
+		default:
+			return fmt.Errorf("invalid sort order %s, only %s and %s are allowed", column[separator+1:], sortAscending, sortDescending)
+		}
+		column = column[:separator]
+	}
+	if len(column) < 1 {
+		column = "index"
+	}
+	if _, ok := getColumn(index.CatIndex{}, column); !ok {
+		return fmt.Errorf("invalid sort column %s", column)
+	}
+	less := func(a, b index.CatIndex) int {
+		left, _ := getColumn(a, column)
+		right, _ := getColumn(b, column)
+		if numericColumns[column] {
+			l, _ := strconv.ParseInt(left, 10, 64)
+			r, _ := strconv.ParseInt(right, 10, 64)
+			switch {
+			case l < r:
+				return -1
+			case l > r:
+				return 1
+			}
+			return 0
+		}
+		return strings.Compare(left, right)
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		result := less(indices[i], indices[j])
+		if descending {
+			result = -result
+		}
+		if result == 0 {
+			return indices[i].Index < indices[j].Index
+		}
+		return result < 0
+	})
+	return nil
+}
+
+//MapToAliasOutput flattens aliases of every index into rows sorted by alias and index
+func MapToAliasOutput(aliases map[string]index.IndexAliases) []index.AliasOutput {
+	result := []index.AliasOutput{}
+	for name, i := range aliases {
+		for alias, metadata := range i.Aliases {
+			result = append(result, index.AliasOutput{
+				Alias:        alias,
+				Index:        name,
+				IsWriteIndex: metadata.IsWriteIndex != nil && *metadata.IsWriteIndex,
+				Filtered:     len(metadata.Filter) > 0,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Alias != result[j].Alias {
+			return result[i].Alias < result[j].Alias
+		}
+		return result[i].Index < result[j].Index
+	})
+	return result
+}
+
+//MapToUpdateAliasesRequest returns request of aliases API with single add or remove action
+func MapToUpdateAliasesRequest(action string, i string, alias string, isWriteIndex *bool) (index.UpdateAliasesRequest, error) {
+	if len(i) < 1 {
+		return index.UpdateAliasesRequest{}, fmt.Errorf("index cannot be empty")
+	}
+	if len(alias) < 1 {
+		return index.UpdateAliasesRequest{}, fmt.Errorf("alias cannot be empty")
+	}
+	if action != AliasActionAdd && action != AliasActionRemove {
+		return index.UpdateAliasesRequest{}, fmt.Errorf("invalid alias action %s", action)
+	}
+	if action == AliasActionRemove {
+		isWriteIndex = nil
+	}
+	return index.UpdateAliasesRequest{
+		Actions: []map[string]index.AliasAction{
+			{
+				action: {
+					Index:        i,
+					Alias:        alias,
+					IsWriteIndex: isWriteIndex,
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,135 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"opensearch-cli/entity/index"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getIndexNames(indices []index.CatIndex) []string {
+	var names []string
+	for _, i := range indices {
+		names = append(names, i.Index)
+	}
+	return names
+}
+
+func getTestIndices() []index.CatIndex {
+	return []index.CatIndex{
+		{Health: "green", Status: "open", Index: "b", DocsCount: "9", StoreSize: "100"},
+		{Health: "yellow", Status: "open", Index: "a", DocsCount: "10", StoreSize: "100"},
+		{Health: "red", Status: "close", Index: "c", DocsCount: "", StoreSize: "2000"},
+	}
+}
+
+func TestSortIndices(t *testing.T) {
+	t.Run("by name by default", func(t *testing.T) {
+		indices := getTestIndices()
+		assert.NoError(t, SortIndices(indices, ""))
+		assert.EqualValues(t, []string{"a", "b", "c"}, getIndexNames(indices))
+	})
+	t.Run("counts are compared as numbers", func(t *testing.T) {
+		indices := getTestIndices()
+		assert.NoError(t, SortIndices(indices, "docs.count:desc"))
+		assert.EqualValues(t, []string{"a", "b", "c"}, getIndexNames(indices))
+	})
+	t.Run("ties are broken by name", func(t *testing.T) {
+		indices := getTestIndices()
+		assert.NoError(t, SortIndices(indices, "store.size:ASC"))
+		assert.EqualValues(t, []string{"a", "b", "c"}, getIndexNames(indices))
+	})
+	t.Run("text column descending", func(t *testing.T) {
+		indices := getTestIndices()
+		assert.NoError(t, SortIndices(indices, "health:desc"))
+		assert.EqualValues(t, []string{"a", "c", "b"}, getIndexNames(indices))
+	})
+	t.Run("invalid order", func(t *testing.T) {
+		assert.EqualError(t, SortIndices(getTestIndices(), "index:up"), "invalid sort order up, only asc and desc are allowed")
+	})
+	t.Run("invalid column", func(t *testing.T) {
+		assert.EqualError(t, SortIndices(getTestIndices(), "name"), "invalid sort column name")
+	})
+}
+
+func TestFilterIndices(t *testing.T) {
+	t.Run("no filter", func(t *testing.T) {
+		assert.Len(t, FilterIndices(getTestIndices(), "", ""), 3)
+	})
+	t.Run("by health ignoring case", func(t *testing.T) {
+		assert.EqualValues(t, []string{"a"}, getIndexNames(FilterIndices(getTestIndices(), "YELLOW", "")))
+	})
+	t.Run("by health and status", func(t *testing.T) {
+		assert.Empty(t, FilterIndices(getTestIndices(), "red", "open"))
+	})
+}
+
+func TestMapToIndexBody(t *testing.T) {
+	t.Run("valid body", func(t *testing.T) {
+		body, err := MapToIndexBody([]byte(`{"settings":{"number_of_shards":1},"aliases":{"logs":{}}}`))
+		assert.NoError(t, err)
+		assert.Contains(t, body, "settings")
+		assert.Contains(t, body, "aliases")
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := MapToIndexBody([]byte(`{"number_of_shards":1}`))
+		assert.EqualError(t, err, "invalid key number_of_shards in index body, only settings, mappings and aliases are allowed")
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := MapToIndexBody([]byte(`{`))
+		assert.Error(t, err)
+	})
+}
+
+func TestMapToSettings(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		settings, err := MapToSettings(`{"index.number_of_replicas":2}`)
+		assert.NoError(t, err)
+		assert.EqualValues(t, map[string]interface{}{"index.number_of_replicas": float64(2)}, settings)
+	})
+	t.Run("file", func(t *testing.T) {
+		settings, err := MapToSettings("@testdata/settings.json")
+		assert.NoError(t, err)
+		assert.EqualValues(t, map[string]interface{}{
+			"index": map[string]interface{}{"refresh_interval": "30s"},
+		}, settings)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		_, err := MapToSettings("@testdata/missing.json")
+		assert.Error(t, err)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := MapToSettings("{}")
+		assert.EqualError(t, err, "settings cannot be empty")
+	})
+	t.Run("not json", func(t *testing.T) {
+		_, err := MapToSettings("number_of_replicas=2")
+		assert.Error(t, err)
+	})
+}
+
+func TestMapToUpdateAliasesRequest(t *testing.T) {
+	isWriteIndex := true
+	t.Run("write index is dropped from remove", func(t *testing.T) {
+		request, err := MapToUpdateAliasesRequest(AliasActionRemove, "logs-1", "logs", &isWriteIndex)
+		assert.NoError(t, err)
+		assert.EqualValues(t, index.UpdateAliasesRequest{
+			Actions: []map[string]index.AliasAction{{"remove": {Index: "logs-1", Alias: "logs"}}},
+		}, request)
+	})
+	t.Run("empty index", func(t *testing.T) {
+		_, err := MapToUpdateAliasesRequest(AliasActionAdd, "", "logs", nil)
+		assert.EqualError(t, err, "index cannot be empty")
+	})
+}
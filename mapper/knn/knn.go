@@ -0,0 +1,290 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package knn
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"opensearch-cli/entity/knn"
+	"opensearch-cli/entity/platform"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	//FileNameIdentifier identifies filter given as file name instead of JSON
+	FileNameIdentifier = "@"
+	//float32Size is number of bytes of float32 in binary vector
+	float32Size = 4
+	//knnVectorType is mapping type of vector field
+	knnVectorType = "knn_vector"
+)
+
+//MapToVector parses vector from JSON array, CSV row or float32 little-endian bytes. If format is
+//auto, JSON is used for input starting with '[', then CSV, and binary if input is not text
+func MapToVector(data []byte, format string) ([]float32, error) {
+	switch format {
+	case knn.VectorFormatJSON:
+		return jsonToVector(data)
+	case knn.VectorFormatCSV:
+		return csvToVector(data)
+	case knn.VectorFormatBinary:
+		return binaryToVector(data)
+	case knn.VectorFormatAuto, "":
+		return autoToVector(data)
+	}
+	return nil, fmt.Errorf("invalid vector format %s, allowed formats are %s", format, strings.Join([]string{
+		knn.VectorFormatAuto, knn.VectorFormatJSON, knn.VectorFormatCSV, knn.VectorFormatBinary,
+	}, ", "))
+}
+
+func autoToVector(data []byte) ([]float32, error) {
+	trimmed := bytes.TrimSpace(data)
+	if !utf8.Valid(trimmed) {
+		return binaryToVector(data)
+	}
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		return jsonToVector(trimmed)
+	}
+	vector, err := csvToVector(trimmed)
+	if err == nil {
+		return vector, nil
+	}
+	if len(data) > 0 && len(data)%float32Size == 0 {
+		return binaryToVector(data)
+	}
+	return nil, err
+}
+
+func jsonToVector(data []byte) ([]float32, error) {
+	var vector []float32
+	if err := json.Unmarshal(data, &vector); err != nil {
+		return nil, fmt.Errorf("invalid JSON vector: %v", err)
+	}
+	return validateVector(vector)
+}
+
+func csvToVector(data []byte) ([]float32, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	record, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("vector cannot be empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV vector: %v", err)
+	}
+	var vector []float32
+	for _, value := range record {
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV vector: %v", err)
+		}
+		vector = append(vector, float32(f))
+	}
+	return validateVector(vector)
+}
+
+func binaryToVector(data []byte) ([]float32, error) {
+	if len(data)%float32Size != 0 {
+		return nil, fmt.Errorf("invalid binary vector: size %d is not multiple of %d", len(data), float32Size)
+	}
+	vector := make([]float32, len(data)/float32Size)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*float32Size:]))
+	}
+	return validateVector(vector)
+}
+
+func validateVector(vector []float32) ([]float32, error) {
+	if len(vector) < 1 {
+		return nil, fmt.Errorf("vector cannot be empty")
+	}
+	return vector, nil
+}
+
+//mapToFilter returns filter query from JSON or from file if filter starts with FileNameIdentifier
+func mapToFilter(filter string) (json.RawMessage, error) {
+	filter = strings.TrimSpace(filter)
+	if len(filter) < 1 {
+		return nil, nil
+	}
+	contents := []byte(filter)
+	if strings.HasPrefix(filter, FileNameIdentifier) {
+		fileName := strings.TrimPrefix(filter, FileNameIdentifier)
+		var err error
+		if contents, err = os.ReadFile(fileName); err != nil {
+			return nil, fmt.Errorf("failed to read filter from file %s due to %v", fileName, err)
+		}
+	}
+	if !json.Valid(contents) {
+		return nil, fmt.Errorf("filter is not a valid JSON query")
+	}
+	return contents, nil
+}
+
+//CommandToSearchRequest maps user input to k-NN search request, vector field is excluded from source of hits
+func CommandToSearchRequest(request knn.SearchCommandRequest) (*knn.SearchRequest, error) {
+	if len(request.Index) < 1 {
+		return nil, fmt.Errorf("index cannot be empty")
+	}
+	if len(request.Field) < 1 {
+		return nil, fmt.Errorf("field cannot be empty")
+	}
+	if request.K < 1 {
+		return nil, fmt.Errorf("k must be positive")
+	}
+	vector, err := MapToVector(request.Vector, request.VectorFormat)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := mapToFilter(request.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return &knn.SearchRequest{
+		Size: request.K,
+		Query: knn.KNNQuery{
+			KNN: map[string]knn.KNNQueryField{
+				request.Field: {
+					Vector: vector,
+					K:      request.K,
+					Filter: filter,
+				},
+			},
+		},
+		Source: knn.SourceFilter{
+			Excludes: []string{request.Field},
+		},
+	}, nil
+}
+
+//MapToSearchResults maps hits of search response to results displayed to user
+func MapToSearchResults(response knn.SearchResponse) []*knn.SearchResult {
+	results := []*knn.SearchResult{}
+	for _, hit := range response.Hits.Hits {
+		results = append(results, &knn.SearchResult{
+			Index:  hit.Index,
+			ID:     hit.ID,
+			Score:  hit.Score,
+			Source: hit.Source,
+		})
+	}
+	return results
+}
+
+//MapToBulkPayload maps documents to newline delimited index actions of bulk API, vector is stored in field
+func MapToBulkPayload(index string, field string, documents []*knn.VectorDocument) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	for _, document := range documents {
+		action := map[string]string{"_index": index}
+		if len(document.ID) > 0 {
+			action["_id"] = document.ID
+		}
+		if err := encoder.Encode(map[string]interface{}{"index": action}); err != nil {
+			return nil, err
+		}
+		source := make(map[string]interface{}, len(document.Metadata)+1)
+		for key, value := range document.Metadata {
+			source[key] = value
+		}
+		source[field] = document.Vector
+		if err := encoder.Encode(source); err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+//MapToIngestResult maps result of bulk load to ingest result with count and example reason of every failure type
+func MapToIngestResult(result platform.BulkLoadResult) *knn.IngestResult {
+	ingest := &knn.IngestResult{
+		Indexed: result.Loaded,
+		Failed:  result.Failed,
+	}
+	for _, failure := range result.Failures {
+		ingest.Errors = append(ingest.Errors, fmt.Sprintf("%d document(s) failed with %s: %s", failure.Count, failure.Type, failure.Reason))
+	}
+	return ingest
+}
+
+//MapToCreateIndexRequest maps vector field and its dimension to request creating k-NN index
+func MapToCreateIndexRequest(field string, dimension int) knn.CreateIndexRequest {
+	return knn.CreateIndexRequest{
+		Settings: knn.IndexSettings{
+			KNN: true,
+		},
+		Mappings: knn.IndexMappings{
+			Properties: map[string]knn.FieldMapping{
+				field: {
+					Type:      knnVectorType,
+					Dimension: dimension,
+				},
+			},
+		},
+	}
+}
+
+//deltaOf returns difference between current and previous counter, nil if counter is not available
+func deltaOf(previous *int64, current *int64) *int64 {
+	if previous == nil || current == nil {
+		return current
+	}
+	delta := *current - *previous
+	return &delta
+}
+
+//MapToNodeStatsOutput maps stats of every node to rows sorted by node id
+func MapToNodeStatsOutput(stats knn.Stats) []*knn.NodeStatsOutput {
+	return MapToNodeStatsDelta(nil, stats)
+}
+
+//MapToNodeStatsDelta maps stats of every node to rows sorted by node id, counters are replaced by difference
+//from previous stats of same node. Gauges like memory usage are kept as is
+func MapToNodeStatsDelta(previous *knn.Stats, current knn.Stats) []*knn.NodeStatsOutput {
+	var nodes []string
+	for node := range current.NodeStats {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	output := []*knn.NodeStatsOutput{}
+	for _, node := range nodes {
+		c := current.NodeStats[node]
+		var p knn.NodeStats
+		if previous != nil {
+			p = previous.NodeStats[node]
+		}
+		output = append(output, &knn.NodeStatsOutput{
+			Node:                       node,
+			GraphMemoryUsage:           c.GraphMemoryUsage,
+			GraphMemoryUsagePercentage: c.GraphMemoryUsagePercentage,
+			CacheCapacityReached:       c.CacheCapacityReached,
+			HitCount:                   deltaOf(p.HitCount, c.HitCount),
+			MissCount:                  deltaOf(p.MissCount, c.MissCount),
+			EvictionCount:              deltaOf(p.EvictionCount, c.EvictionCount),
+			LoadSuccessCount:           deltaOf(p.LoadSuccessCount, c.LoadSuccessCount),
+			LoadExceptionCount:         deltaOf(p.LoadExceptionCount, c.LoadExceptionCount),
+			KNNQueryRequests:           deltaOf(p.KNNQueryRequests, c.KNNQueryRequests),
+			GraphQueryErrors:           deltaOf(p.GraphQueryErrors, c.GraphQueryErrors),
+		})
+	}
+	return output
+}
@@ -0,0 +1,205 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package knn
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"opensearch-cli/entity/knn"
+	"opensearch-cli/entity/platform"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func toBinaryVector(vector []float32) []byte {
+	data := make([]byte, len(vector)*float32Size)
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(data[i*float32Size:], math.Float32bits(v))
+	}
+	return data
+}
+
+func TestMapToVector(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		format  string
+		want    []float32
+		wantErr string
+	}{
+		{name: "json", data: []byte(" [1.5, 2, -3]\n"), format: knn.VectorFormatAuto, want: []float32{1.5, 2, -3}},
+		{name: "csv", data: []byte("1.5, 2,-3\n4,5,6\n"), format: knn.VectorFormatAuto, want: []float32{1.5, 2, -3}},
+		{name: "binary", data: toBinaryVector([]float32{1.5, 2, -3}), format: knn.VectorFormatAuto, want: []float32{1.5, 2, -3}},
+		{name: "explicit binary", data: toBinaryVector([]float32{0.25}), format: knn.VectorFormatBinary, want: []float32{0.25}},
+		{name: "explicit csv", data: []byte("0.25"), format: knn.VectorFormatCSV, want: []float32{0.25}},
+		{name: "invalid binary size", data: []byte{0, 0, 0}, format: knn.VectorFormatBinary, wantErr: "invalid binary vector: size 3 is not multiple of 4"},
+		{name: "invalid json", data: []byte(`["a"]`), format: knn.VectorFormatJSON, wantErr: "invalid JSON vector"},
+		{name: "empty", data: []byte("  "), format: knn.VectorFormatAuto, wantErr: "vector cannot be empty"},
+		{name: "invalid format", data: []byte("1"), format: "xml", wantErr: "invalid vector format xml, allowed formats are auto, json, csv, binary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MapToVector(tt.data, tt.format)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommandToSearchRequest(t *testing.T) {
+	t.Run("search request with filter from file", func(t *testing.T) {
+		request, err := CommandToSearchRequest(knn.SearchCommandRequest{
+			Index:  "index1",
+			Field:  "my_vector",
+			K:      2,
+			Vector: []byte("[1, 2]"),
+			Filter: "@testdata/filter.json",
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, &knn.SearchRequest{
+			Size: 2,
+			Query: knn.KNNQuery{
+				KNN: map[string]knn.KNNQueryField{
+					"my_vector": {
+						Vector: []float32{1, 2},
+						K:      2,
+						Filter: []byte("{\"term\": {\"color\": \"red\"}}\n"),
+					},
+				},
+			},
+			Source: knn.SourceFilter{Excludes: []string{"my_vector"}},
+		}, request)
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := CommandToSearchRequest(knn.SearchCommandRequest{
+			Index:  "index1",
+			Field:  "my_vector",
+			K:      2,
+			Vector: []byte("[1, 2]"),
+			Filter: "{invalid",
+		})
+		assert.EqualError(t, err, "filter is not a valid JSON query")
+	})
+	t.Run("invalid k", func(t *testing.T) {
+		_, err := CommandToSearchRequest(knn.SearchCommandRequest{
+			Index:  "index1",
+			Field:  "my_vector",
+			Vector: []byte("[1, 2]"),
+		})
+		assert.EqualError(t, err, "k must be positive")
+	})
+}
+
+func TestMapToSearchResults(t *testing.T) {
+	results := MapToSearchResults(knn.SearchResponse{
+		Hits: knn.SearchContainer{
+			Hits: []knn.SearchHit{{Index: "index1", ID: "1", Score: 0.5, Source: []byte(`{"color":"red"}`)}},
+		},
+	})
+	assert.EqualValues(t, []*knn.SearchResult{{Index: "index1", ID: "1", Score: 0.5, Source: []byte(`{"color":"red"}`)}}, results)
+}
+
+func TestMapToBulkPayload(t *testing.T) {
+	payload, err := MapToBulkPayload("index1", "my_vector", []*knn.VectorDocument{
+		{ID: "1", Vector: []float32{1, 2}, Metadata: map[string]interface{}{"color": "red"}},
+		{Vector: []float32{3, 4}},
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, `{"index":{"_id":"1","_index":"index1"}}
+{"color":"red","my_vector":[1,2]}
+{"index":{"_index":"index1"}}
+{"my_vector":[3,4]}
+`, string(payload))
+}
+
+func TestMapToIngestResult(t *testing.T) {
+	result := MapToIngestResult(platform.BulkLoadResult{
+		Loaded: 2,
+		Failed: 3,
+		Failures: []*platform.BulkFailure{
+			{Type: "mapper_parsing_exception", Count: 3, Reason: "failed to parse field [my_vector]"},
+		},
+	})
+	assert.EqualValues(t, &knn.IngestResult{
+		Indexed: 2,
+		Failed:  3,
+		Errors:  []string{"3 document(s) failed with mapper_parsing_exception: failed to parse field [my_vector]"},
+	}, result)
+}
+
+func TestMapToCreateIndexRequest(t *testing.T) {
+	request, err := json.Marshal(MapToCreateIndexRequest("my_vector", 3))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"settings": {"index.knn": true},
+		"mappings": {"properties": {"my_vector": {"type": "knn_vector", "dimension": 3}}}
+	}`, string(request))
+}
+
+func TestMapToNodeStatsDelta(t *testing.T) {
+	count := func(value int64) *int64 { return &value }
+	usage := 12.5
+	reached := true
+	previous := knn.Stats{
+		NodeStats: map[string]knn.NodeStats{
+			"node2": {HitCount: count(10), MissCount: count(4), EvictionCount: count(1), GraphMemoryUsage: count(100)},
+			"node1": {HitCount: count(5)},
+		},
+	}
+	current := knn.Stats{
+		NodeStats: map[string]knn.NodeStats{
+			"node2": {
+				HitCount:                   count(25),
+				MissCount:                  count(4),
+				EvictionCount:              count(3),
+				GraphMemoryUsage:           count(80),
+				GraphMemoryUsagePercentage: &usage,
+				CacheCapacityReached:       &reached,
+			},
+			"node1": {HitCount: count(9)},
+			"node3": {HitCount: count(2)},
+		},
+	}
+	t.Run("absolute values", func(t *testing.T) {
+		result := MapToNodeStatsOutput(current)
+		assert.Len(t, result, 3)
+		assert.EqualValues(t, []string{"node1", "node2", "node3"}, []string{result[0].Node, result[1].Node, result[2].Node})
+		assert.EqualValues(t, 25, *result[1].HitCount)
+		assert.EqualValues(t, 80, *result[1].GraphMemoryUsage)
+		assert.Nil(t, result[0].MissCount)
+	})
+	t.Run("deltas", func(t *testing.T) {
+		result := MapToNodeStatsDelta(&previous, current)
+		assert.Len(t, result, 3)
+		assert.EqualValues(t, 4, *result[0].HitCount)
+		assert.EqualValues(t, &knn.NodeStatsOutput{
+			Node:                       "node2",
+			GraphMemoryUsage:           count(80),
+			GraphMemoryUsagePercentage: &usage,
+			CacheCapacityReached:       &reached,
+			HitCount:                   count(15),
+			MissCount:                  count(0),
+			EvictionCount:              count(2),
+		}, result[1])
+		assert.EqualValues(t, 2, *result[2].HitCount)
+	})
+	t.Run("no nodes", func(t *testing.T) {
+		assert.Empty(t, MapToNodeStatsOutput(knn.Stats{}))
+	})
+}
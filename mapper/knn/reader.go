@@ -0,0 +1,335 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package knn
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"opensearch-cli/entity/knn"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	npyMagic       = "\x93NUMPY"
+	float64Size    = 8
+	maxJSONLineLen = 64 * 1024 * 1024
+)
+
+var (
+	npyDescrPattern   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortranPattern = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShapePattern   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+	fileFormats       = map[string]string{
+		".csv":    knn.FileFormatCSV,
+		".jsonl":  knn.FileFormatJSONL,
+		".ndjson": knn.FileFormatJSONL,
+		".json":   knn.FileFormatJSONL,
+		".npy":    knn.FileFormatNPY,
+		".fvecs":  knn.FileFormatFVECS,
+	}
+)
+
+//VectorReader reads documents from file one by one, io.EOF is returned after last document
+type VectorReader interface {
+	Read() (*knn.VectorDocument, error)
+}
+
+//GetVectorFileFormat returns format of vector file based on its extension
+func GetVectorFileFormat(fileName string) (string, error) {
+	if format, ok := fileFormats[strings.ToLower(filepath.Ext(fileName))]; ok {
+		return format, nil
+	}
+	return "", fmt.Errorf("cannot detect format of file %s, supported formats are %s", fileName, strings.Join([]string{
+		knn.FileFormatCSV, knn.FileFormatJSONL, knn.FileFormatNPY, knn.FileFormatFVECS,
+	}, ", "))
+}
+
+//NewVectorReader returns reader of documents in given format. vectorColumn and idColumn are names of
+//columns or keys of CSV and JSONL files, other columns are read as metadata
+func NewVectorReader(r io.Reader, format string, vectorColumn string, idColumn string) (VectorReader, error) {
+	switch format {
+	case knn.FileFormatCSV:
+		return newCSVReader(r, vectorColumn, idColumn)
+	case knn.FileFormatJSONL:
+		return newJSONLReader(r, vectorColumn, idColumn), nil
+	case knn.FileFormatNPY:
+		return newNPYReader(r)
+	case knn.FileFormatFVECS:
+		return &fvecsReader{reader: bufio.NewReader(r)}, nil
+	}
+	return nil, fmt.Errorf("invalid file format %s", format)
+}
+
+//csvReader reads documents from CSV file with header, vector column contains JSON array or comma separated values
+type csvReader struct {
+	reader       *csv.Reader
+	header       []string
+	vectorColumn string
+	idColumn     string
+	line         int
+}
+
+func newCSVReader(r io.Reader, vectorColumn string, idColumn string) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, name := range header {
+		if name == vectorColumn {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("vector column %s not found in CSV header", vectorColumn)
+	}
+	return &csvReader{
+		reader:       reader,
+		header:       header,
+		vectorColumn: vectorColumn,
+		idColumn:     idColumn,
+		line:         1,
+	}, nil
+}
+
+func (c *csvReader) Read() (*knn.VectorDocument, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	c.line++
+	document := &knn.VectorDocument{
+		Metadata: map[string]interface{}{},
+	}
+	for i, value := range record {
+		switch c.header[i] {
+		case c.vectorColumn:
+			if document.Vector, err = MapToVector([]byte(value), knn.VectorFormatAuto); err != nil {
+				return nil, fmt.Errorf("line %d: %v", c.line, err)
+			}
+		case c.idColumn:
+			document.ID = value
+		default:
+			document.Metadata[c.header[i]] = value
+		}
+	}
+	return document, nil
+}
+
+//jsonlReader reads documents from file with one JSON object per line
+type jsonlReader struct {
+	scanner      *bufio.Scanner
+	vectorColumn string
+	idColumn     string
+	line         int
+}
+
+func newJSONLReader(r io.Reader, vectorColumn string, idColumn string) *jsonlReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxJSONLineLen)
+	return &jsonlReader{
+		scanner:      scanner,
+		vectorColumn: vectorColumn,
+		idColumn:     idColumn,
+	}
+}
+
+func (j *jsonlReader) Read() (*knn.VectorDocument, error) {
+	for j.scanner.Scan() {
+		j.line++
+		line := bytes.TrimSpace(j.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		return j.toDocument(line)
+	}
+	if err := j.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (j *jsonlReader) toDocument(line []byte) (*knn.VectorDocument, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(line, &object); err != nil {
+		return nil, fmt.Errorf("line %d: %v", j.line, err)
+	}
+	vector, ok := object[j.vectorColumn]
+	if !ok {
+		return nil, fmt.Errorf("line %d: vector key %s not found", j.line, j.vectorColumn)
+	}
+	document := &knn.VectorDocument{
+		Metadata: map[string]interface{}{},
+	}
+	var err error
+	if document.Vector, err = MapToVector(vector, knn.VectorFormatJSON); err != nil {
+		return nil, fmt.Errorf("line %d: %v", j.line, err)
+	}
+	for key, value := range object {
+		switch key {
+		case j.vectorColumn:
+		case j.idColumn:
+			var id interface{}
+			if err = json.Unmarshal(value, &id); err != nil {
+				return nil, fmt.Errorf("line %d: %v", j.line, err)
+			}
+			document.ID = fmt.Sprintf("%v", id)
+		default:
+			document.Metadata[key] = value
+		}
+	}
+	return document, nil
+}
+
+//npyReader reads rows of two dimensional float32 or float64 numpy array
+type npyReader struct {
+	reader    *bufio.Reader
+	dimension int
+	rows      int
+	read      int
+	valueSize int
+}
+
+func newNPYReader(r io.Reader) (*npyReader, error) {
+	reader := bufio.NewReader(r)
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(reader, prefix); err != nil {
+		return nil, fmt.Errorf("invalid npy file: %v", err)
+	}
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return nil, fmt.Errorf("invalid npy file: magic string not found")
+	}
+	var headerLen int
+	if major := prefix[len(npyMagic)]; major == 1 {
+		var size uint16
+		if err := binary.Read(reader, binary.LittleEndian, &size); err != nil {
+			return nil, fmt.Errorf("invalid npy file: %v", err)
+		}
+		headerLen = int(size)
+	} else {
+		var size uint32
+		if err := binary.Read(reader, binary.LittleEndian, &size); err != nil {
+			return nil, fmt.Errorf("invalid npy file: %v", err)
+		}
+		headerLen = int(size)
+	}
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, fmt.Errorf("invalid npy file: %v", err)
+	}
+	return parseNPYHeader(reader, string(header))
+}
+
+func parseNPYHeader(reader *bufio.Reader, header string) (*npyReader, error) {
+	descr := npyDescrPattern.FindStringSubmatch(header)
+	fortran := npyFortranPattern.FindStringSubmatch(header)
+	shape := npyShapePattern.FindStringSubmatch(header)
+	if descr == nil || fortran == nil || shape == nil {
+		return nil, fmt.Errorf("invalid npy file: cannot parse header %s", header)
+	}
+	result := &npyReader{reader: reader}
+	switch descr[1] {
+	case "<f4":
+		result.valueSize = float32Size
+	case "<f8":
+		result.valueSize = float64Size
+	default:
+		return nil, fmt.Errorf("unsupported npy data type %s, only <f4 and <f8 are supported", descr[1])
+	}
+	if fortran[1] == "True" {
+		return nil, fmt.Errorf("unsupported npy file: fortran order is not supported")
+	}
+	var dimensions []int
+	for _, value := range strings.Split(shape[1], ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		dimension, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid npy shape %s", shape[1])
+		}
+		dimensions = append(dimensions, dimension)
+	}
+	switch len(dimensions) {
+	case 1:
+		result.rows, result.dimension = 1, dimensions[0]
+	case 2:
+		result.rows, result.dimension = dimensions[0], dimensions[1]
+	default:
+		return nil, fmt.Errorf("unsupported npy shape %s, only one or two dimensional arrays are supported", shape[1])
+	}
+	return result, nil
+}
+
+func (n *npyReader) Read() (*knn.VectorDocument, error) {
+	if n.read >= n.rows {
+		return nil, io.EOF
+	}
+	row := make([]byte, n.dimension*n.valueSize)
+	if _, err := io.ReadFull(n.reader, row); err != nil {
+		return nil, fmt.Errorf("invalid npy file: row %d: %v", n.read, err)
+	}
+	n.read++
+	vector := make([]float32, n.dimension)
+	for i := range vector {
+		if n.valueSize == float64Size {
+			vector[i] = float32(math.Float64frombits(binary.LittleEndian.Uint64(row[i*float64Size:])))
+			continue
+		}
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(row[i*float32Size:]))
+	}
+	return &knn.VectorDocument{Vector: vector}, nil
+}
+
+//fvecsReader reads vectors stored as little-endian int32 dimension followed by float32 values
+type fvecsReader struct {
+	reader *bufio.Reader
+	read   int
+}
+
+func (f *fvecsReader) Read() (*knn.VectorDocument, error) {
+	var dimension int32
+	if err := binary.Read(f.reader, binary.LittleEndian, &dimension); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("invalid fvecs file: vector %d: %v", f.read, err)
+	}
+	if dimension < 1 {
+		return nil, fmt.Errorf("invalid fvecs file: vector %d has dimension %d", f.read, dimension)
+	}
+	data := make([]byte, int(dimension)*float32Size)
+	if _, err := io.ReadFull(f.reader, data); err != nil {
+		return nil, fmt.Errorf("invalid fvecs file: vector %d: %v", f.read, err)
+	}
+	f.read++
+	vector, err := binaryToVector(data)
+	if err != nil {
+		return nil, err
+	}
+	return &knn.VectorDocument{Vector: vector}, nil
+}
@@ -0,0 +1,127 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package knn
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"opensearch-cli/entity/knn"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readAll(t *testing.T, reader VectorReader) []*knn.VectorDocument {
+	var documents []*knn.VectorDocument
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			return documents
+		}
+		assert.NoError(t, err)
+		documents = append(documents, document)
+	}
+}
+
+func TestGetVectorFileFormat(t *testing.T) {
+	format, err := GetVectorFileFormat("vectors.NPY")
+	assert.NoError(t, err)
+	assert.EqualValues(t, knn.FileFormatNPY, format)
+	_, err = GetVectorFileFormat("vectors.txt")
+	assert.EqualError(t, err, "cannot detect format of file vectors.txt, supported formats are csv, jsonl, npy, fvecs")
+}
+
+func TestCSVReader(t *testing.T) {
+	t.Run("read documents", func(t *testing.T) {
+		reader, err := NewVectorReader(strings.NewReader("id,embedding,color\n1,\"[1,2]\",red\n2,\"3,4\",blue\n"), knn.FileFormatCSV, "embedding", "id")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*knn.VectorDocument{
+			{ID: "1", Vector: []float32{1, 2}, Metadata: map[string]interface{}{"color": "red"}},
+			{ID: "2", Vector: []float32{3, 4}, Metadata: map[string]interface{}{"color": "blue"}},
+		}, readAll(t, reader))
+	})
+	t.Run("missing vector column", func(t *testing.T) {
+		_, err := NewVectorReader(strings.NewReader("id,color\n"), knn.FileFormatCSV, "embedding", "id")
+		assert.EqualError(t, err, "vector column embedding not found in CSV header")
+	})
+	t.Run("invalid vector", func(t *testing.T) {
+		reader, err := NewVectorReader(strings.NewReader("embedding\n\"[a]\"\n"), knn.FileFormatCSV, "embedding", "")
+		assert.NoError(t, err)
+		_, err = reader.Read()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2: invalid JSON vector")
+	})
+}
+
+func TestJSONLReader(t *testing.T) {
+	reader, err := NewVectorReader(strings.NewReader(`{"id": 7, "embedding": [1, 2], "color": "red"}
+
+{"embedding": [3, 4]}
+`), knn.FileFormatJSONL, "embedding", "id")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*knn.VectorDocument{
+		{ID: "7", Vector: []float32{1, 2}, Metadata: map[string]interface{}{"color": json.RawMessage(`"red"`)}},
+		{Vector: []float32{3, 4}, Metadata: map[string]interface{}{}},
+	}, readAll(t, reader))
+}
+
+func TestNPYReader(t *testing.T) {
+	header := "{'descr': '<f4', 'fortran_order': False, 'shape': (2, 3), }"
+	var data bytes.Buffer
+	data.WriteString(npyMagic)
+	data.Write([]byte{1, 0})
+	_ = binary.Write(&data, binary.LittleEndian, uint16(len(header)))
+	data.WriteString(header)
+	data.Write(toBinaryVector([]float32{1, 2, 3, 4, 5, 6}))
+	t.Run("read rows", func(t *testing.T) {
+		reader, err := NewVectorReader(bytes.NewReader(data.Bytes()), knn.FileFormatNPY, "", "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*knn.VectorDocument{
+			{Vector: []float32{1, 2, 3}},
+			{Vector: []float32{4, 5, 6}},
+		}, readAll(t, reader))
+	})
+	t.Run("unsupported data type", func(t *testing.T) {
+		invalid := bytes.Replace(data.Bytes(), []byte("<f4"), []byte("<i4"), 1)
+		_, err := NewVectorReader(bytes.NewReader(invalid), knn.FileFormatNPY, "", "")
+		assert.EqualError(t, err, "unsupported npy data type <i4, only <f4 and <f8 are supported")
+	})
+	t.Run("invalid magic", func(t *testing.T) {
+		_, err := NewVectorReader(strings.NewReader("not a numpy file"), knn.FileFormatNPY, "", "")
+		assert.EqualError(t, err, "invalid npy file: magic string not found")
+	})
+}
+
+func TestFVECSReader(t *testing.T) {
+	var data bytes.Buffer
+	for _, vector := range [][]float32{{1, 2}, {3, 4}} {
+		_ = binary.Write(&data, binary.LittleEndian, int32(len(vector)))
+		data.Write(toBinaryVector(vector))
+	}
+	t.Run("read vectors", func(t *testing.T) {
+		reader, err := NewVectorReader(bytes.NewReader(data.Bytes()), knn.FileFormatFVECS, "", "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []*knn.VectorDocument{
+			{Vector: []float32{1, 2}},
+			{Vector: []float32{3, 4}},
+		}, readAll(t, reader))
+	})
+	t.Run("truncated file", func(t *testing.T) {
+		reader, err := NewVectorReader(bytes.NewReader(data.Bytes()[:10]), knn.FileFormatFVECS, "", "")
+		assert.NoError(t, err)
+		_, err = reader.Read()
+		assert.EqualError(t, err, "invalid fvecs file: vector 0: unexpected EOF")
+	})
+}
@@ -0,0 +1,470 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"opensearch-cli/entity/platform"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxBulkLineLen = 64 * 1024 * 1024
+	//maxDetectedLineLen is maximum length of first line which is checked whether it is bulk action
+	maxDetectedLineLen = 64 * 1024
+	//GzipExtension is extension of gzip compressed files
+	GzipExtension = ".gz"
+)
+
+var (
+	bulkFileFormats = map[string]string{
+		".csv": platform.BulkFormatCSV,
+	}
+	bulkActions = map[string]bool{
+		"index":  true,
+		"create": true,
+		"update": true,
+		"delete": true,
+	}
+)
+
+//BulkReader reads items of bulk request one by one, io.EOF is returned after last item
+type BulkReader interface {
+	Read() (*platform.BulkItem, error)
+}
+
+//GetBulkFileFormat returns format of file based on its extension ignoring gzip extension, auto is returned
+//if format can only be detected from content
+func GetBulkFileFormat(fileName string) string {
+	fileName = strings.TrimSuffix(strings.ToLower(fileName), GzipExtension)
+	if format, ok := bulkFileFormats[strings.ToLower(filepath.Ext(fileName))]; ok {
+		return format
+	}
+	return platform.BulkFormatAuto
+}
+
+//NewBulkReader returns reader of items in given format. Documents of ndjson, json and csv formats are sent
+//with action to index, document ID is taken from idField if provided. Items of bulk format are sent as they are,
+//index is added to actions without one. Auto format reads JSON array if source starts with '[', bulk format if
+//first line is bulk action, otherwise ndjson
+func NewBulkReader(r io.Reader, format string, index string, action string, idField string) (BulkReader, error) {
+	reader := bufio.NewReaderSize(r, maxDetectedLineLen)
+	if format == platform.BulkFormatAuto || len(format) == 0 {
+		format = detectBulkFormat(reader)
+	}
+	switch format {
+	case platform.BulkFormatNDJSON:
+		return &documentReader{source: newNDJSONSource(reader), index: index, action: action, idField: idField}, nil
+	case platform.BulkFormatJSON:
+		source, err := newJSONArraySource(reader)
+		if err != nil {
+			return nil, err
+		}
+		return &documentReader{source: source, index: index, action: action, idField: idField}, nil
+	case platform.BulkFormatCSV:
+		source, err := newCSVSource(reader, idField)
+		if err != nil {
+			return nil, err
+		}
+		return &documentReader{source: source, index: index, action: action, idField: idField}, nil
+	case platform.BulkFormatBulk:
+		return &bulkReader{scanner: newLineScanner(reader), index: index}, nil
+	}
+	return nil, fmt.Errorf("invalid format %s", format)
+}
+
+//MapToBulkAction returns action line for document with given index and optional ID
+func MapToBulkAction(action string, index string, id string) ([]byte, error) {
+	metadata := map[string]string{
+		"_index": index,
+	}
+	if len(id) > 0 {
+		metadata["_id"] = id
+	}
+	return json.Marshal(map[string]interface{}{
+		action: metadata,
+	})
+}
+
+//MapToExportItem returns hit as bulk item with index action without index name, so that documents can be
+//loaded into any index. Routing is added to action if routing is set and hit has custom routing
+func MapToExportItem(hit platform.SearchHit, routing bool) (*platform.BulkItem, error) {
+	metadata := map[string]string{
+		"_id": hit.ID,
+	}
+	if routing && len(hit.Routing) > 0 {
+		metadata["routing"] = hit.Routing
+	}
+	action, err := json.Marshal(map[string]interface{}{
+		platform.BulkActionIndex: metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	source := &bytes.Buffer{}
+	if err = json.Compact(source, hit.Source); err != nil {
+		return nil, fmt.Errorf("invalid source of document %s: %v", hit.ID, err)
+	}
+	return &platform.BulkItem{Action: action, Source: source.Bytes()}, nil
+}
+
+//MapToBulkBody returns newline delimited body of bulk request for items
+func MapToBulkBody(items []*platform.BulkItem) []byte {
+	var body bytes.Buffer
+	for _, item := range items {
+		body.Write(item.Action)
+		body.WriteByte('\n')
+		if len(item.Source) > 0 {
+			body.Write(item.Source)
+			body.WriteByte('\n')
+		}
+	}
+	return body.Bytes()
+}
+
+//MergeBulkLoadResults adds up loaded and failed items of results, failures are merged by type and sorted by count
+func MergeBulkLoadResults(results ...*platform.BulkLoadResult) *platform.BulkLoadResult {
+	merged := &platform.BulkLoadResult{}
+	failures := map[string]*platform.BulkFailure{}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		merged.Loaded += result.Loaded
+		merged.Failed += result.Failed
+		for _, failure := range result.Failures {
+			if existing, ok := failures[failure.Type]; ok {
+				existing.Count += failure.Count
+				continue
+			}
+			copied := *failure
+			failures[failure.Type] = &copied
+		}
+	}
+	merged.Failures = SortBulkFailures(failures)
+	return merged
+}
+
+//SortBulkFailures returns failures sorted by count in descending order, then by type
+func SortBulkFailures(failures map[string]*platform.BulkFailure) []*platform.BulkFailure {
+	var result []*platform.BulkFailure
+	for _, failure := range failures {
+		result = append(result, failure)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+//detectBulkFormat peeks first line of source without consuming it
+func detectBulkFormat(reader *bufio.Reader) string {
+	data, _ := reader.Peek(maxDetectedLineLen)
+	data = bytes.TrimLeftFunc(data, unicode.IsSpace)
+	if len(data) < 1 {
+		return platform.BulkFormatNDJSON
+	}
+	if data[0] == '[' {
+		return platform.BulkFormatJSON
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		data = data[:i]
+	}
+	if isBulkAction(data) {
+		return platform.BulkFormatBulk
+	}
+	return platform.BulkFormatNDJSON
+}
+
+//isBulkAction checks whether line is object with single action key and object value
+func isBulkAction(line []byte) bool {
+	var action map[string]json.RawMessage
+	if err := json.Unmarshal(line, &action); err != nil || len(action) != 1 {
+		return false
+	}
+	for name, metadata := range action {
+		metadata = bytes.TrimSpace(metadata)
+		return bulkActions[name] && len(metadata) > 0 && metadata[0] == '{'
+	}
+	return false
+}
+
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxBulkLineLen)
+	return scanner
+}
+
+//documentSource returns JSON object of next document and its position in source used in errors
+type documentSource interface {
+	next() ([]byte, string, error)
+}
+
+//documentReader creates bulk item with action line for every document read from source
+type documentReader struct {
+	source  documentSource
+	index   string
+	action  string
+	idField string
+}
+
+func (d *documentReader) Read() (*platform.BulkItem, error) {
+	document, position, err := d.source.next()
+	if err != nil {
+		return nil, err
+	}
+	id, err := getDocumentID(document, d.idField)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", position, err)
+	}
+	action, err := MapToBulkAction(d.action, d.index, id)
+	if err != nil {
+		return nil, err
+	}
+	return &platform.BulkItem{Action: action, Source: document}, nil
+}
+
+//getDocumentID returns value of idField as string, empty string is returned if idField is not provided
+func getDocumentID(document []byte, idField string) (string, error) {
+	if len(idField) < 1 {
+		return "", nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(document, &object); err != nil {
+		return "", err
+	}
+	value, ok := object[idField]
+	if !ok {
+		return "", fmt.Errorf("id field %s not found", idField)
+	}
+	var id string
+	if err := json.Unmarshal(value, &id); err == nil {
+		return id, nil
+	}
+	return string(bytes.TrimSpace(value)), nil
+}
+
+//validateDocument checks whether document is JSON object
+func validateDocument(document []byte) error {
+	if len(document) < 1 || document[0] != '{' || !json.Valid(document) {
+		return fmt.Errorf("invalid JSON object")
+	}
+	return nil
+}
+
+//ndjsonSource reads one document per line, empty lines are skipped
+type ndjsonSource struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newNDJSONSource(r io.Reader) *ndjsonSource {
+	return &ndjsonSource{scanner: newLineScanner(r)}
+}
+
+func (n *ndjsonSource) next() ([]byte, string, error) {
+	for n.scanner.Scan() {
+		n.line++
+		line := bytes.TrimSpace(n.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		position := fmt.Sprintf("line %d", n.line)
+		if err := validateDocument(line); err != nil {
+			return nil, position, fmt.Errorf("%s: %v", position, err)
+		}
+		document := make([]byte, len(line))
+		copy(document, line)
+		return document, position, nil
+	}
+	if err := n.scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	return nil, "", io.EOF
+}
+
+//jsonArraySource reads documents from JSON array one by one without loading whole array
+type jsonArraySource struct {
+	decoder *json.Decoder
+	element int
+	done    bool
+}
+
+func newJSONArraySource(r io.Reader) (*jsonArraySource, error) {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON array: %v", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("invalid JSON array: expected '[' but found %v", token)
+	}
+	return &jsonArraySource{decoder: decoder}, nil
+}
+
+func (j *jsonArraySource) next() ([]byte, string, error) {
+	if j.done || !j.decoder.More() {
+		j.done = true
+		return nil, "", io.EOF
+	}
+	position := fmt.Sprintf("element %d", j.element)
+	j.element++
+	var document json.RawMessage
+	if err := j.decoder.Decode(&document); err != nil {
+		return nil, position, fmt.Errorf("%s: %v", position, err)
+	}
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, document); err != nil {
+		return nil, position, fmt.Errorf("%s: %v", position, err)
+	}
+	if err := validateDocument(compacted.Bytes()); err != nil {
+		return nil, position, fmt.Errorf("%s: %v", position, err)
+	}
+	return compacted.Bytes(), position, nil
+}
+
+//csvSource reads documents from CSV file with header, every column is indexed as string field
+type csvSource struct {
+	reader *csv.Reader
+	header []string
+	line   int
+}
+
+func newCSVSource(r io.Reader, idField string) (*csvSource, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(idField) > 0 {
+		found := false
+		for _, name := range header {
+			if name == idField {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("id field %s not found in CSV header", idField)
+		}
+	}
+	return &csvSource{reader: reader, header: header, line: 1}, nil
+}
+
+func (c *csvSource) next() ([]byte, string, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		return nil, "", err
+	}
+	c.line++
+	var document bytes.Buffer
+	document.WriteByte('{')
+	for i, value := range record {
+		if i > 0 {
+			document.WriteByte(',')
+		}
+		key, _ := json.Marshal(c.header[i])
+		encoded, _ := json.Marshal(value)
+		document.Write(key)
+		document.WriteByte(':')
+		document.Write(encoded)
+	}
+	document.WriteByte('}')
+	return document.Bytes(), fmt.Sprintf("line %d", c.line), nil
+}
+
+//bulkReader reads action and source lines of bulk API body
+type bulkReader struct {
+	scanner *bufio.Scanner
+	index   string
+	line    int
+}
+
+func (b *bulkReader) nextLine() ([]byte, error) {
+	for b.scanner.Scan() {
+		b.line++
+		line := bytes.TrimSpace(b.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		result := make([]byte, len(line))
+		copy(result, line)
+		return result, nil
+	}
+	if err := b.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (b *bulkReader) Read() (*platform.BulkItem, error) {
+	line, err := b.nextLine()
+	if err != nil {
+		return nil, err
+	}
+	action, name, err := b.toAction(line)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %v", b.line, err)
+	}
+	item := &platform.BulkItem{Action: action}
+	if name == "delete" {
+		return item, nil
+	}
+	source, err := b.nextLine()
+	if err == io.EOF {
+		return nil, fmt.Errorf("line %d: source of %s action is missing", b.line, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = validateDocument(source); err != nil {
+		return nil, fmt.Errorf("line %d: %v", b.line, err)
+	}
+	item.Source = source
+	return item, nil
+}
+
+//toAction validates action line and adds index to action metadata if it is missing
+func (b *bulkReader) toAction(line []byte) ([]byte, string, error) {
+	var action map[string]map[string]interface{}
+	if err := json.Unmarshal(line, &action); err != nil || len(action) != 1 {
+		return nil, "", fmt.Errorf("invalid action, expected one of index, create, update or delete")
+	}
+	for name, metadata := range action {
+		if !bulkActions[name] {
+			return nil, "", fmt.Errorf("invalid action %s, expected one of index, create, update or delete", name)
+		}
+		if _, ok := metadata["_index"]; ok || len(b.index) < 1 {
+			return line, name, nil
+		}
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+		metadata["_index"] = b.index
+		result, err := json.Marshal(map[string]interface{}{name: metadata})
+		return result, name, err
+	}
+	return line, "", nil
+}
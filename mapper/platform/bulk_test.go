@@ -0,0 +1,190 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"io"
+	"opensearch-cli/entity/platform"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readAllBulkItems(t *testing.T, reader BulkReader) []string {
+	var result []string
+	for {
+		item, err := reader.Read()
+		if err == io.EOF {
+			return result
+		}
+		assert.NoError(t, err)
+		if err != nil {
+			return result
+		}
+		result = append(result, string(MapToBulkBody([]*platform.BulkItem{item})))
+	}
+}
+
+func TestGetBulkFileFormat(t *testing.T) {
+	assert.EqualValues(t, platform.BulkFormatAuto, GetBulkFileFormat("docs.NDJSON"))
+	assert.EqualValues(t, platform.BulkFormatAuto, GetBulkFileFormat("docs.jsonl.gz"))
+	assert.EqualValues(t, platform.BulkFormatCSV, GetBulkFileFormat("docs.CSV"))
+	assert.EqualValues(t, platform.BulkFormatCSV, GetBulkFileFormat("docs.csv.gz"))
+	assert.EqualValues(t, platform.BulkFormatAuto, GetBulkFileFormat("docs.json"))
+	assert.EqualValues(t, platform.BulkFormatAuto, GetBulkFileFormat("docs"))
+}
+
+func TestNewBulkReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		format  string
+		idField string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "ndjson",
+			input:  "{\"a\":1}\n\n  {\"a\":2}\n",
+			format: platform.BulkFormatNDJSON,
+			want: []string{
+				"{\"index\":{\"_index\":\"docs\"}}\n{\"a\":1}\n",
+				"{\"index\":{\"_index\":\"docs\"}}\n{\"a\":2}\n",
+			},
+		},
+		{
+			name:    "auto detects ndjson with id field",
+			input:   "{\"id\":\"x\",\"a\":1}\n{\"id\":7}\n",
+			format:  platform.BulkFormatAuto,
+			idField: "id",
+			want: []string{
+				"{\"index\":{\"_id\":\"x\",\"_index\":\"docs\"}}\n{\"id\":\"x\",\"a\":1}\n",
+				"{\"index\":{\"_id\":\"7\",\"_index\":\"docs\"}}\n{\"id\":7}\n",
+			},
+		},
+		{
+			name:   "auto detects bulk",
+			input:  "\n{\"index\":{\"_id\":\"1\"}}\n{\"a\":1}\n",
+			format: platform.BulkFormatAuto,
+			want: []string{
+				"{\"index\":{\"_id\":\"1\",\"_index\":\"docs\"}}\n{\"a\":1}\n",
+			},
+		},
+		{
+			name:   "auto detects ndjson with index field",
+			input:  "{\"index\":\"x\"}\n",
+			format: platform.BulkFormatAuto,
+			want: []string{
+				"{\"index\":{\"_index\":\"docs\"}}\n{\"index\":\"x\"}\n",
+			},
+		},
+		{
+			name:   "auto detects json array",
+			input:  " [\n {\"a\": 1},\n {\"b\": [1, 2]}\n]",
+			format: platform.BulkFormatAuto,
+			want: []string{
+				"{\"index\":{\"_index\":\"docs\"}}\n{\"a\":1}\n",
+				"{\"index\":{\"_index\":\"docs\"}}\n{\"b\":[1,2]}\n",
+			},
+		},
+		{
+			name:    "csv",
+			input:   "id,name\n1,\"a \"\"quoted\"\" name\"\n",
+			format:  platform.BulkFormatCSV,
+			idField: "id",
+			want: []string{
+				"{\"index\":{\"_id\":\"1\",\"_index\":\"docs\"}}\n{\"id\":\"1\",\"name\":\"a \\\"quoted\\\" name\"}\n",
+			},
+		},
+		{
+			name:   "bulk",
+			input:  "{\"delete\":{\"_index\":\"other\",\"_id\":\"1\"}}\n{\"create\":{\"_id\":\"2\"}}\n{\"a\":1}\n",
+			format: platform.BulkFormatBulk,
+			want: []string{
+				"{\"delete\":{\"_index\":\"other\",\"_id\":\"1\"}}\n",
+				"{\"create\":{\"_id\":\"2\",\"_index\":\"docs\"}}\n{\"a\":1}\n",
+			},
+		},
+		{name: "invalid ndjson", input: "{\"a\":1}\n[1]\n", format: platform.BulkFormatNDJSON, wantErr: "line 2: invalid JSON object"},
+		{name: "missing id", input: "{\"a\":1}\n", format: platform.BulkFormatNDJSON, idField: "id", wantErr: "line 1: id field id not found"},
+		{name: "invalid array element", input: "[{\"a\":1}, 2]", format: platform.BulkFormatJSON, wantErr: "element 1: invalid JSON object"},
+		{name: "csv missing id column", input: "name\na\n", format: platform.BulkFormatCSV, idField: "id", wantErr: "id field id not found in CSV header"},
+		{name: "invalid bulk action", input: "{\"upsert\":{}}\n{}\n", format: platform.BulkFormatBulk, wantErr: "line 1: invalid action upsert, expected one of index, create, update or delete"},
+		{name: "missing bulk source", input: "{\"index\":{}}\n", format: platform.BulkFormatBulk, wantErr: "line 1: source of index action is missing"},
+		{name: "invalid format", input: "", format: "xml", wantErr: "invalid format xml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewBulkReader(strings.NewReader(tt.input), tt.format, "docs", platform.BulkActionIndex, tt.idField)
+			if err == nil && len(tt.wantErr) > 0 {
+				for err == nil {
+					_, err = reader.Read()
+				}
+			}
+			if len(tt.wantErr) > 0 {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.want, readAllBulkItems(t, reader))
+		})
+	}
+}
+
+func TestMergeBulkLoadResults(t *testing.T) {
+	result := MergeBulkLoadResults(
+		&platform.BulkLoadResult{
+			Loaded: 3,
+			Failed: 2,
+			Failures: []*platform.BulkFailure{
+				{Type: "mapper_parsing_exception", Count: 2, Reason: "failed to parse"},
+			},
+		},
+		nil,
+		&platform.BulkLoadResult{
+			Loaded: 1,
+			Failed: 3,
+			Failures: []*platform.BulkFailure{
+				{Type: "version_conflict_engine_exception", Count: 2, Reason: "conflict"},
+				{Type: "mapper_parsing_exception", Count: 1, Reason: "other reason"},
+			},
+		},
+	)
+	assert.EqualValues(t, &platform.BulkLoadResult{
+		Loaded: 4,
+		Failed: 5,
+		Failures: []*platform.BulkFailure{
+			{Type: "mapper_parsing_exception", Count: 3, Reason: "failed to parse"},
+			{Type: "version_conflict_engine_exception", Count: 2, Reason: "conflict"},
+		},
+	}, result)
+}
+
+func TestMapToExportItem(t *testing.T) {
+	hit := platform.SearchHit{
+		Index:   "index1",
+		ID:      "1",
+		Routing: "user1",
+		Source:  []byte(`{ "a": 1 }`),
+	}
+	t.Run("with routing", func(t *testing.T) {
+		item, err := MapToExportItem(hit, true)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "{\"index\":{\"_id\":\"1\",\"routing\":\"user1\"}}\n{\"a\":1}\n", string(MapToBulkBody([]*platform.BulkItem{item})))
+	})
+	t.Run("without routing", func(t *testing.T) {
+		item, err := MapToExportItem(hit, false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"index":{"_id":"1"}}`, string(item.Action))
+	})
+}
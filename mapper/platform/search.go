@@ -0,0 +1,167 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"opensearch-cli/entity/platform"
+	"strings"
+)
+
+const (
+	sortSeparator = ":"
+	//TiebreakerSortField is added to sort of paginated search in point in time so that every document has unique
+	//sort values, unlike _id it doesn't load field data. Its sort values are only valid in point in time they
+	//were returned by
+	TiebreakerSortField = "_shard_doc"
+)
+
+//MapToSearchBody returns query DSL from body, which is either JSON or file name with prefix '@', with
+//shorthand flags query, fields, sort and size applied on top of it
+func MapToSearchBody(request platform.SearchCommandRequest) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	data, err := toCurlPayload(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &body); err != nil {
+			return nil, fmt.Errorf("invalid query body: %v", err)
+		}
+	}
+	if len(request.Query) > 0 {
+		body["query"] = map[string]interface{}{
+			"query_string": map[string]interface{}{
+				"query": request.Query,
+			},
+		}
+	}
+	if len(request.Fields) > 0 {
+		body["_source"] = request.Fields
+	}
+	if len(request.Sort) > 0 {
+		sort, err := mapToSort(request.Sort)
+		if err != nil {
+			return nil, err
+		}
+		body["sort"] = sort
+	}
+	if request.Size > 0 {
+		body["size"] = request.Size
+	}
+	return body, nil
+}
+
+//mapToSort maps sort in format field or field:order to sort of query DSL
+func mapToSort(sort []string) ([]interface{}, error) {
+	var result []interface{}
+	for _, value := range sort {
+		field, order := value, ""
+		if i := strings.LastIndex(value, sortSeparator); i >= 0 {
+			field, order = value[:i], strings.ToLower(value[i+1:])
+		}
+		if len(field) < 1 {
+			return nil, fmt.Errorf("invalid sort %s, sort must be in format field or field:order", value)
+		}
+		if len(order) < 1 {
+			result = append(result, field)
+			continue
+		}
+		if order != "asc" && order != "desc" {
+			return nil, fmt.Errorf("invalid sort order %s, order must be asc or desc", order)
+		}
+		result = append(result, map[string]interface{}{
+			field: map[string]interface{}{
+				"order": order,
+			},
+		})
+	}
+	return result, nil
+}
+
+//AddTiebreakerSort appends tiebreaker field to sort of body unless body is already sorted by it
+func AddTiebreakerSort(body map[string]interface{}, tiebreaker string) {
+	var sort []interface{}
+	switch value := body["sort"].(type) {
+	case nil:
+	case []interface{}:
+		sort = value
+	default:
+		sort = []interface{}{value}
+	}
+	for _, item := range sort {
+		switch field := item.(type) {
+		case string:
+			if field == tiebreaker {
+				return
+			}
+		case map[string]interface{}:
+			if _, ok := field[tiebreaker]; ok {
+				return
+			}
+		}
+	}
+	body["sort"] = append(sort, map[string]interface{}{
+		tiebreaker: map[string]interface{}{
+			"order": "asc",
+		},
+	})
+}
+
+//MapToSearchHitOutput returns hit as single JSON object with index, ID and score followed by fields of source
+//in their original order, so that table and csv have one column per field
+func MapToSearchHitOutput(hit platform.SearchHit) (json.RawMessage, error) {
+	var output bytes.Buffer
+	output.WriteByte('{')
+	write := func(name string, value interface{}) error {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if output.Len() > 1 {
+			output.WriteByte(',')
+		}
+		fmt.Fprintf(&output, "%q:%s", name, encoded)
+		return nil
+	}
+	if err := write("_index", hit.Index); err != nil {
+		return nil, err
+	}
+	if err := write("_id", hit.ID); err != nil {
+		return nil, err
+	}
+	if hit.Score != nil {
+		if err := write("_score", *hit.Score); err != nil {
+			return nil, err
+		}
+	}
+	for _, object := range []json.RawMessage{hit.Source, hit.Fields} {
+		fields := bytes.TrimSpace(object)
+		if len(fields) < 2 || fields[0] != '{' {
+			continue
+		}
+		fields = bytes.TrimSpace(fields[1 : len(fields)-1])
+		if len(fields) < 1 {
+			continue
+		}
+		output.WriteByte(',')
+		output.Write(fields)
+	}
+	output.WriteByte('}')
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, output.Bytes()); err != nil {
+		return nil, err
+	}
+	return compacted.Bytes(), nil
+}
@@ -0,0 +1,108 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package platform
+
+import (
+	"encoding/json"
+	"opensearch-cli/entity/platform"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapToSearchBody(t *testing.T) {
+	t.Run("body from file", func(t *testing.T) {
+		body, err := MapToSearchBody(platform.SearchCommandRequest{Body: "@testdata/search.json"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, map[string]interface{}{
+			"query": map[string]interface{}{"match_all": map[string]interface{}{}},
+			"size":  float64(5),
+		}, body)
+	})
+	t.Run("flags override body", func(t *testing.T) {
+		body, err := MapToSearchBody(platform.SearchCommandRequest{
+			Body:   `{"query":{"match_all":{}},"aggs":{}}`,
+			Query:  "status:error",
+			Fields: []string{"status", "message"},
+			Sort:   []string{"@timestamp:desc", "host", "a:b:ASC"},
+			Size:   20,
+		})
+		assert.NoError(t, err)
+		actual, _ := json.Marshal(body)
+		assert.JSONEq(t, `{
+			"aggs": {},
+			"query": {"query_string": {"query": "status:error"}},
+			"_source": ["status", "message"],
+			"sort": [{"@timestamp": {"order": "desc"}}, "host", {"a:b": {"order": "asc"}}],
+			"size": 20
+		}`, string(actual))
+	})
+	t.Run("invalid body", func(t *testing.T) {
+		_, err := MapToSearchBody(platform.SearchCommandRequest{Body: `[1]`})
+		assert.Error(t, err)
+	})
+	t.Run("invalid sort order", func(t *testing.T) {
+		_, err := MapToSearchBody(platform.SearchCommandRequest{Sort: []string{"field:up"}})
+		assert.EqualError(t, err, "invalid sort order up, order must be asc or desc")
+	})
+}
+
+func TestAddTiebreakerSort(t *testing.T) {
+	t.Run("no sort", func(t *testing.T) {
+		body := map[string]interface{}{}
+		AddTiebreakerSort(body, TiebreakerSortField)
+		actual, _ := json.Marshal(body)
+		assert.JSONEq(t, `{"sort":[{"_shard_doc":{"order":"asc"}}]}`, string(actual))
+	})
+	t.Run("single sort", func(t *testing.T) {
+		body := map[string]interface{}{"sort": "timestamp"}
+		AddTiebreakerSort(body, TiebreakerSortField)
+		actual, _ := json.Marshal(body)
+		assert.JSONEq(t, `{"sort":["timestamp",{"_shard_doc":{"order":"asc"}}]}`, string(actual))
+	})
+	t.Run("already sorted by tiebreaker", func(t *testing.T) {
+		body := map[string]interface{}{"sort": []interface{}{map[string]interface{}{"_shard_doc": "desc"}}}
+		AddTiebreakerSort(body, TiebreakerSortField)
+		actual, _ := json.Marshal(body)
+		assert.JSONEq(t, `{"sort":[{"_shard_doc":"desc"}]}`, string(actual))
+	})
+	t.Run("custom tiebreaker", func(t *testing.T) {
+		body := map[string]interface{}{"sort": "timestamp"}
+		AddTiebreakerSort(body, "doc_id")
+		actual, _ := json.Marshal(body)
+		assert.JSONEq(t, `{"sort":["timestamp",{"doc_id":{"order":"asc"}}]}`, string(actual))
+	})
+}
+
+func TestMapToSearchHitOutput(t *testing.T) {
+	score := 1.5
+	t.Run("source fields keep order", func(t *testing.T) {
+		actual, err := MapToSearchHitOutput(platform.SearchHit{
+			Index:  "index1",
+			ID:     "1",
+			Score:  &score,
+			Source: json.RawMessage(`{"z": 1, "a": {"b": true}}`),
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"_index":"index1","_id":"1","_score":1.5,"z":1,"a":{"b":true}}`, string(actual))
+	})
+	t.Run("without score and source", func(t *testing.T) {
+		actual, err := MapToSearchHitOutput(platform.SearchHit{
+			Index:  "index1",
+			ID:     "1",
+			Source: json.RawMessage(`{}`),
+			Fields: json.RawMessage(`{"x":[1]}`),
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"_index":"index1","_id":"1","x":[1]}`, string(actual))
+	})
+}
@@ -0,0 +1,38 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+// Package prompt asks user to confirm operations in interactive mode.
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//Confirm prints message and reads answers from reader until user types yes or no,
+//error is returned if answer cannot be read, for example when reader reached end of input
+func Confirm(reader io.Reader, message string) (bool, error) {
+	fmt.Print(message)
+	for {
+		var response string
+		if _, err := fmt.Fscanln(reader, &response); err != nil {
+			return false, fmt.Errorf("failed to accept value from user due to %v", err)
+		}
+		switch strings.ToLower(response) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Print("please type (y)es or (n)o and then press enter:")
+	}
+}
@@ -0,0 +1,36 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	t.Run("agreed", func(t *testing.T) {
+		proceed, err := Confirm(strings.NewReader("Yes\n"), "")
+		assert.NoError(t, err)
+		assert.True(t, proceed)
+	})
+	t.Run("declined after invalid answer", func(t *testing.T) {
+		proceed, err := Confirm(strings.NewReader("maybe\nn\n"), "")
+		assert.NoError(t, err)
+		assert.False(t, proceed)
+	})
+	t.Run("end of input", func(t *testing.T) {
+		_, err := Confirm(strings.NewReader(""), "")
+		assert.EqualError(t, err, "failed to accept value from user due to EOF")
+	})
+}